@@ -0,0 +1,244 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/sirupsen/logrus"
+)
+
+// AnchorService links memories to source files and symbols and checks whether
+// the anchored code has changed since
+type AnchorService struct {
+	memoryService *MemoryService
+	memoryRepo    ports.MemoryRepository
+	projectRepo   ports.ProjectRepository
+	sourceControl ports.SourceControl
+	logger        *logrus.Logger
+}
+
+// NewAnchorService creates a new anchor service. Anchor changes are stored through
+// memoryService. sourceControl may be nil, in which case anchors are stored without
+// a commit and staleness is not checked.
+func NewAnchorService(
+	memoryService *MemoryService,
+	memoryRepo ports.MemoryRepository,
+	projectRepo ports.ProjectRepository,
+	sourceControl ports.SourceControl,
+	logger *logrus.Logger,
+) *AnchorService {
+	return &AnchorService{
+		memoryService: memoryService,
+		memoryRepo:    memoryRepo,
+		projectRepo:   projectRepo,
+		sourceControl: sourceControl,
+		logger:        logger,
+	}
+}
+
+// AddAnchors links a memory to source locations. Anchors without a commit are
+// stamped with the current commit of the project's repository.
+func (s *AnchorService) AddAnchors(ctx context.Context, id domain.MemoryID, anchors []domain.CodeAnchor) (*domain.Memory, error) {
+	s.logger.WithFields(logrus.Fields{
+		"memory_id": id,
+		"anchors":   len(anchors),
+	}).Info("Adding memory anchors")
+
+	memory, err := s.memoryRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get memory: %w", err)
+	}
+
+	commit := s.headCommit(ctx, memory.ProjectID)
+	for _, anchor := range anchors {
+		anchor.Path = domain.NormalizeAnchorPath(anchor.Path)
+		if err := anchor.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid anchor %q: %w", anchor.String(), err)
+		}
+		if anchor.Commit == "" {
+			anchor.Commit = commit
+		}
+		memory.AddAnchor(anchor)
+	}
+
+	if err := s.memoryService.UpdateMemoryMetadata(ctx, memory); err != nil {
+		return nil, err
+	}
+
+	return memory, nil
+}
+
+// RemoveAnchors removes all anchors of a memory that point at one of the given paths
+func (s *AnchorService) RemoveAnchors(ctx context.Context, id domain.MemoryID, paths []string) (*domain.Memory, error) {
+	memory, err := s.memoryRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get memory: %w", err)
+	}
+
+	remove := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		remove[domain.NormalizeAnchorPath(path)] = true
+	}
+
+	kept := make([]domain.CodeAnchor, 0, len(memory.Anchors))
+	for _, anchor := range memory.Anchors {
+		if !remove[anchor.Path] {
+			kept = append(kept, anchor)
+		}
+	}
+	if len(kept) == len(memory.Anchors) {
+		return memory, nil
+	}
+
+	memory.Anchors = kept
+	if err := s.memoryService.UpdateMemoryMetadata(ctx, memory); err != nil {
+		return nil, err
+	}
+
+	return memory, nil
+}
+
+// MemoriesForFile returns the memories anchored to a file or to a directory containing it.
+// path may be absolute or relative to the project root.
+func (s *AnchorService) MemoriesForFile(ctx context.Context, projectID domain.ProjectID, path string, checkStaleness bool) ([]ports.AnchoredMemory, error) {
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	relPath, err := projectRelativePath(project, path)
+	if err != nil {
+		return nil, err
+	}
+
+	memories, err := s.memoryRepo.ListAnchored(ctx, &projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list anchored memories: %w", err)
+	}
+
+	checker := s.newAnchorChecker(project, checkStaleness)
+
+	var results []ports.AnchoredMemory
+	for _, memory := range memories {
+		anchors := memory.AnchorsFor(relPath)
+		if len(anchors) == 0 {
+			continue
+		}
+		results = append(results, checker.check(ctx, memory, anchors))
+	}
+
+	return results, nil
+}
+
+// CheckAnchors checks every anchored memory of a project against the source tree
+func (s *AnchorService) CheckAnchors(ctx context.Context, projectID domain.ProjectID) ([]ports.AnchoredMemory, error) {
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	if s.sourceControl == nil {
+		return nil, fmt.Errorf("source control is not available")
+	}
+
+	memories, err := s.memoryRepo.ListAnchored(ctx, &projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list anchored memories: %w", err)
+	}
+
+	checker := s.newAnchorChecker(project, true)
+
+	results := make([]ports.AnchoredMemory, 0, len(memories))
+	for _, memory := range memories {
+		results = append(results, checker.check(ctx, memory, memory.Anchors))
+	}
+
+	return results, nil
+}
+
+// headCommit returns the current commit of a project's repository, or "" if unknown
+func (s *AnchorService) headCommit(ctx context.Context, projectID domain.ProjectID) string {
+	if s.sourceControl == nil {
+		return ""
+	}
+
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		s.logger.WithError(err).WithField("project_id", projectID).Debug("Project not found, anchoring without commit")
+		return ""
+	}
+
+	commit, err := s.sourceControl.HeadCommit(ctx, project.Path)
+	if err != nil {
+		s.logger.WithError(err).WithField("path", project.Path).Debug("No git commit available, anchoring without commit")
+		return ""
+	}
+	return commit
+}
+
+// anchorChecker checks anchors of one project, checking each location only once
+type anchorChecker struct {
+	service *AnchorService
+	project *domain.Project
+	enabled bool
+	cache   map[domain.CodeAnchor]domain.AnchorCheck
+}
+
+func (s *AnchorService) newAnchorChecker(project *domain.Project, enabled bool) *anchorChecker {
+	return &anchorChecker{
+		service: s,
+		project: project,
+		enabled: enabled && s.sourceControl != nil,
+		cache:   make(map[domain.CodeAnchor]domain.AnchorCheck),
+	}
+}
+
+func (c *anchorChecker) check(ctx context.Context, memory *domain.Memory, anchors []domain.CodeAnchor) ports.AnchoredMemory {
+	result := ports.AnchoredMemory{Memory: memory}
+	if !c.enabled {
+		return result
+	}
+
+	for _, anchor := range anchors {
+		// Line ranges and symbols share the file-level result
+		key := domain.CodeAnchor{Path: anchor.Path, Commit: anchor.Commit}
+		fileCheck, ok := c.cache[key]
+		if !ok {
+			var err error
+			fileCheck, err = c.service.sourceControl.CheckAnchor(ctx, c.project.Path, key)
+			if err != nil {
+				c.service.logger.WithError(err).WithField("anchor", anchor.String()).Warn("Failed to check anchor")
+				fileCheck = domain.AnchorCheck{Status: domain.AnchorStatusUnknown, Detail: err.Error()}
+			}
+			c.cache[key] = fileCheck
+		}
+
+		fileCheck.Anchor = anchor
+		result.Checks = append(result.Checks, fileCheck)
+		if fileCheck.PossiblyStale() {
+			result.PossiblyStale = true
+		}
+	}
+
+	return result
+}
+
+// projectRelativePath converts a path to the slash separated form relative to the project root
+func projectRelativePath(project *domain.Project, path string) (string, error) {
+	if filepath.IsAbs(path) {
+		rel, err := filepath.Rel(project.Path, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return "", fmt.Errorf("path %s is outside the project %s", path, project.Path)
+		}
+		path = rel
+	}
+
+	relPath := domain.NormalizeAnchorPath(path)
+	if relPath == "" || relPath == "." {
+		return "", fmt.Errorf("path is required")
+	}
+	return relPath, nil
+}
@@ -0,0 +1,173 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/sirupsen/logrus"
+)
+
+// stubSourceControl reports a fixed head commit, branch and changes and per-path
+// anchor statuses
+type stubSourceControl struct {
+	head     string
+	branch   string
+	changes  domain.GitChanges
+	statuses map[string]domain.AnchorStatus
+	checks   int
+}
+
+func (s *stubSourceControl) HeadCommit(ctx context.Context, root string) (string, error) {
+	return s.head, nil
+}
+
+func (s *stubSourceControl) CurrentBranch(ctx context.Context, root string) (string, error) {
+	return s.branch, nil
+}
+
+func (s *stubSourceControl) Changes(ctx context.Context, root, from, to string) (domain.GitChanges, error) {
+	if from == to {
+		return domain.GitChanges{}, nil
+	}
+	return s.changes, nil
+}
+
+func (s *stubSourceControl) CheckAnchor(ctx context.Context, root string, anchor domain.CodeAnchor) (domain.AnchorCheck, error) {
+	s.checks++
+	status, ok := s.statuses[anchor.Path]
+	if !ok {
+		status = domain.AnchorStatusCurrent
+	}
+	return domain.AnchorCheck{Anchor: anchor, Status: status}, nil
+}
+
+func setupAnchorServiceTest(t *testing.T) (*AnchorService, *MockMemoryRepository, *stubSourceControl, *domain.Project) {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	memoryRepo := NewMockMemoryRepository()
+	projectRepo := NewMockProjectRepository()
+	sourceControl := &stubSourceControl{head: "abc123", statuses: map[string]domain.AnchorStatus{}}
+
+	project := domain.NewProject("Test", "/repo", "")
+	if err := projectRepo.Store(context.Background(), project); err != nil {
+		t.Fatalf("Failed to store project: %v", err)
+	}
+
+	memoryService := NewMemoryService(memoryRepo, NewMockEmbeddingProvider(), NewMockVectorStore(), logger)
+	memoryService.SetProvenance(domain.Provenance{Author: "alice", Source: domain.ProvenanceSourceCLI})
+
+	return NewAnchorService(memoryService, memoryRepo, projectRepo, sourceControl, logger), memoryRepo, sourceControl, project
+}
+
+func TestAnchorService_AddAndRemoveAnchors(t *testing.T) {
+	service, memoryRepo, _, project := setupAnchorServiceTest(t)
+	ctx := context.Background()
+
+	memory := domain.NewMemory(project.ID, domain.MemoryTypeDecision, "Cosine distance", "content", "")
+	if err := memoryRepo.Store(ctx, memory); err != nil {
+		t.Fatalf("Failed to store memory: %v", err)
+	}
+
+	updated, err := service.AddAnchors(ctx, memory.ID, []domain.CodeAnchor{
+		{Path: "./internal/infra/vector/chromadb.go", Symbol: "ChromaDBVectorStore"},
+		{Path: "docs", Commit: "pinned"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to add anchors: %v", err)
+	}
+	if len(updated.Anchors) != 2 {
+		t.Fatalf("Expected 2 anchors, got %d", len(updated.Anchors))
+	}
+	if updated.Anchors[0].Path != "internal/infra/vector/chromadb.go" || updated.Anchors[0].Commit != "abc123" {
+		t.Errorf("Expected normalised path stamped with head commit, got %+v", updated.Anchors[0])
+	}
+	if updated.Anchors[1].Commit != "pinned" {
+		t.Errorf("Expected explicit commit to be kept, got %q", updated.Anchors[1].Commit)
+	}
+	if stored, _ := memoryRepo.GetByID(ctx, memory.ID); stored.LastModifiedBy == nil || stored.LastModifiedBy.Author != "alice" {
+		t.Errorf("Expected anchor change to record who modified the memory, got %+v", stored.LastModifiedBy)
+	}
+
+	if _, err := service.AddAnchors(ctx, memory.ID, []domain.CodeAnchor{{Path: "../outside.go"}}); err == nil {
+		t.Error("Expected error for anchor outside the project")
+	}
+
+	updated, err = service.RemoveAnchors(ctx, memory.ID, []string{"docs"})
+	if err != nil {
+		t.Fatalf("Failed to remove anchors: %v", err)
+	}
+	if len(updated.Anchors) != 1 {
+		t.Errorf("Expected 1 anchor after removal, got %d", len(updated.Anchors))
+	}
+}
+
+func TestAnchorService_MemoriesForFile(t *testing.T) {
+	service, memoryRepo, sourceControl, project := setupAnchorServiceTest(t)
+	ctx := context.Background()
+
+	fileMemory := domain.NewMemory(project.ID, domain.MemoryTypeDecision, "File", "content", "")
+	fileMemory.AddAnchor(domain.CodeAnchor{Path: "internal/infra/vector/chromadb.go", StartLine: 10, EndLine: 20, Commit: "abc123"})
+	fileMemory.AddAnchor(domain.CodeAnchor{Path: "internal/infra/vector/chromadb.go", Symbol: "Search", Commit: "abc123"})
+	dirMemory := domain.NewMemory(project.ID, domain.MemoryTypePattern, "Directory", "content", "")
+	dirMemory.AddAnchor(domain.CodeAnchor{Path: "internal/infra", Commit: "abc123"})
+	otherMemory := domain.NewMemory(project.ID, domain.MemoryTypePattern, "Other", "content", "")
+	otherMemory.AddAnchor(domain.CodeAnchor{Path: "cmd/main.go", Commit: "abc123"})
+
+	for _, m := range []*domain.Memory{fileMemory, dirMemory, otherMemory} {
+		if err := memoryRepo.Store(ctx, m); err != nil {
+			t.Fatalf("Failed to store memory: %v", err)
+		}
+	}
+
+	sourceControl.statuses["internal/infra/vector/chromadb.go"] = domain.AnchorStatusModified
+
+	results, err := service.MemoriesForFile(ctx, project.ID, "/repo/internal/infra/vector/chromadb.go", true)
+	if err != nil {
+		t.Fatalf("Failed to get memories for file: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected file and directory memories, got %d", len(results))
+	}
+
+	stale := map[domain.MemoryID]bool{}
+	for _, result := range results {
+		stale[result.Memory.ID] = result.PossiblyStale
+	}
+	if !stale[fileMemory.ID] || stale[dirMemory.ID] {
+		t.Errorf("Expected only the file memory to be possibly stale, got %v", stale)
+	}
+	if sourceControl.checks != 2 {
+		t.Errorf("Expected one check per anchored path, got %d", sourceControl.checks)
+	}
+
+	if _, err := service.MemoriesForFile(ctx, project.ID, "/elsewhere/main.go", false); err == nil {
+		t.Error("Expected error for a path outside the project")
+	}
+}
+
+func TestAnchorService_CheckAnchors(t *testing.T) {
+	service, memoryRepo, sourceControl, project := setupAnchorServiceTest(t)
+	ctx := context.Background()
+
+	memory := domain.NewMemory(project.ID, domain.MemoryTypeDecision, "Renamed", "content", "")
+	memory.AddAnchor(domain.CodeAnchor{Path: "old.go", Commit: "abc123"})
+	if err := memoryRepo.Store(ctx, memory); err != nil {
+		t.Fatalf("Failed to store memory: %v", err)
+	}
+	sourceControl.statuses["old.go"] = domain.AnchorStatusRenamed
+
+	results, err := service.CheckAnchors(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("Failed to check anchors: %v", err)
+	}
+	if len(results) != 1 || !results[0].PossiblyStale || results[0].Checks[0].Status != domain.AnchorStatusRenamed {
+		t.Errorf("Expected the renamed anchor to be flagged, got %+v", results)
+	}
+
+	var _ ports.AnchorService = service
+}
@@ -0,0 +1,142 @@
+package app
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+)
+
+// minDependencyTermLength keeps short names such as "go" or "os" from matching
+// unrelated memories
+const minDependencyTermLength = 3
+
+// majorVersionSuffix matches the major version suffix of Go module paths, e.g. "v2"
+var majorVersionSuffix = regexp.MustCompile(`^v[0-9]+$`)
+
+// setAppliesTo validates explicit dependency constraints of a new memory or, if there
+// are none, derives them from the project dependencies the memory mentions
+func (s *MemoryService) setAppliesTo(ctx context.Context, memory *domain.Memory, constraints []domain.DependencyConstraint) error {
+	if len(constraints) > 0 {
+		for _, constraint := range constraints {
+			if err := constraint.Validate(); err != nil {
+				return err
+			}
+		}
+		memory.AppliesTo = constraints
+		return nil
+	}
+
+	// Tasks and sessions are tracked by status, not by topic
+	if memory.Type == domain.MemoryTypeTask || memory.Type == domain.MemoryTypeSession {
+		return nil
+	}
+	project := s.projectFor(ctx, memory.ProjectID, nil)
+	if project == nil {
+		return nil
+	}
+	memory.AppliesTo = mentionedDependencies(memory, project.Dependencies)
+	return nil
+}
+
+// mentionedDependencies returns constraints on the current version range of the
+// dependencies named in a memory's text or tags
+func mentionedDependencies(memory *domain.Memory, dependencies []domain.Dependency) []domain.DependencyConstraint {
+	text := strings.ToLower(strings.Join([]string{memory.Title, memory.Content, memory.Context, strings.Join(memory.Tags, " ")}, "\n"))
+
+	var constraints []domain.DependencyConstraint
+	for _, dependency := range dependencies {
+		for _, term := range dependencyTerms(dependency) {
+			if mentions(text, term) {
+				constraints = append(constraints, domain.ConstraintFor(dependency))
+				break
+			}
+		}
+	}
+	return constraints
+}
+
+// dependencyTerms returns the names a dependency is referred to by: its full name and
+// its last path segment, e.g. "github.com/gin-gonic/gin" and "gin"
+func dependencyTerms(dependency domain.Dependency) []string {
+	name := strings.ToLower(dependency.Name)
+	terms := []string{name}
+
+	segments := strings.FieldsFunc(name, func(r rune) bool { return r == '/' || r == ':' })
+	if dependency.Ecosystem == "go" && len(segments) > 1 && majorVersionSuffix.MatchString(segments[len(segments)-1]) {
+		segments = segments[:len(segments)-1]
+	}
+	if len(segments) > 0 && segments[len(segments)-1] != name {
+		terms = append(terms, segments[len(segments)-1])
+	}
+
+	result := terms[:0]
+	for _, term := range terms {
+		if len(term) >= minDependencyTermLength {
+			result = append(result, term)
+		}
+	}
+	return result
+}
+
+// mentions reports whether text contains term as a whole word; '-' and '_' count as
+// part of a word so that "react" does not match "react-dom"
+func mentions(text, term string) bool {
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], term)
+		if i < 0 {
+			return false
+		}
+		start, end := offset+i, offset+i+len(term)
+		if (start == 0 || !isWordByte(text[start-1])) && (end == len(text) || !isWordByte(text[end])) {
+			return true
+		}
+		offset = start + 1
+	}
+}
+
+func isWordByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= '0' && b <= '9' || b == '-' || b == '_'
+}
+
+// applicability checks a memory's dependency constraints against the searched project,
+// or the memory's own project if the search is not limited to one. It returns nil if
+// the memory has no constraints or the project's dependencies are unknown.
+func (s *MemoryService) applicability(ctx context.Context, memory *domain.Memory, projectID *domain.ProjectID, projects map[domain.ProjectID]*domain.Project) *domain.Applicability {
+	if len(memory.AppliesTo) == 0 || s.projectRepo == nil {
+		return nil
+	}
+	id := memory.ProjectID
+	if projectID != nil {
+		id = *projectID
+	}
+
+	project := s.projectFor(ctx, id, projects)
+	if project == nil || len(project.Dependencies) == 0 {
+		return nil
+	}
+	applicability := domain.CheckApplicability(memory.AppliesTo, project.Dependencies)
+	return &applicability
+}
+
+// projectFor loads a project, caching the result in projects if given. It returns nil
+// for shared scopes and unknown projects.
+func (s *MemoryService) projectFor(ctx context.Context, id domain.ProjectID, projects map[domain.ProjectID]*domain.Project) *domain.Project {
+	if s.projectRepo == nil || id.IsScope() {
+		return nil
+	}
+	if project, cached := projects[id]; cached {
+		return project
+	}
+
+	project, err := s.projectRepo.GetByID(ctx, id)
+	if err != nil {
+		s.logger.WithError(err).WithField("project_id", id).Debug("Project not found, skipping dependency constraints")
+		project = nil
+	}
+	if projects != nil {
+		projects[id] = project
+	}
+	return project
+}
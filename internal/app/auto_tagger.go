@@ -0,0 +1,479 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/sirupsen/logrus"
+)
+
+// AutoTagOptions configures automatic tag extraction
+type AutoTagOptions struct {
+	Mode     domain.AutoTagMode
+	MaxTags  int     // maximum number of tags extracted per memory
+	MinScore float64 // suggestions scoring lower are dropped
+}
+
+// DefaultAutoTagOptions returns the auto-tag options used when none are configured.
+// Auto-tagging is opt-in, so the default mode is off.
+func DefaultAutoTagOptions() AutoTagOptions {
+	return AutoTagOptions{
+		Mode:     domain.AutoTagModeOff,
+		MaxTags:  5,
+		MinScore: 0.3,
+	}
+}
+
+// Scores of the different suggestion sources. Known technologies are the most
+// reliable signal; keyword scores are scaled relative to the best keyword.
+const (
+	technologyTagScore = 1.0
+	codeTagBaseScore   = 0.5
+	codeTagMaxScore    = 0.8
+	keywordTagMaxScore = 0.6
+
+	maxCodeTags    = 2
+	maxKeywordTags = 3
+
+	// corpusTTL is how long the document frequencies of a project are kept before they
+	// are rebuilt, which picks up memories written by other processes
+	corpusTTL = 10 * time.Minute
+)
+
+// AutoTagger extracts tags from memory content: known language and framework
+// names, identifiers from code blocks and terms that are distinctive within the
+// project by TF-IDF
+type AutoTagger struct {
+	memoryRepo ports.MemoryRepository
+	normalizer domain.TagNormalizer
+	options    AutoTagOptions
+	logger     *logrus.Logger
+
+	mu      sync.Mutex
+	corpora map[domain.ProjectID]*termCorpus
+}
+
+// NewAutoTagger creates a new auto-tagger
+func NewAutoTagger(memoryRepo ports.MemoryRepository, normalizer domain.TagNormalizer, options AutoTagOptions, logger *logrus.Logger) *AutoTagger {
+	if options.MaxTags <= 0 {
+		options.MaxTags = DefaultAutoTagOptions().MaxTags
+	}
+	return &AutoTagger{
+		memoryRepo: memoryRepo,
+		normalizer: normalizer,
+		options:    options,
+		logger:     logger,
+		corpora:    make(map[domain.ProjectID]*termCorpus),
+	}
+}
+
+// Mode returns what the auto-tagger does with extracted tags when a memory is created
+func (t *AutoTagger) Mode() domain.AutoTagMode {
+	return t.options.Mode
+}
+
+// Suggest returns up to MaxTags tag suggestions for a memory, best first.
+// Tags the memory already has are not suggested.
+func (t *AutoTagger) Suggest(ctx context.Context, memory *domain.Memory) ([]domain.TagSuggestion, error) {
+	text, code := splitCodeBlocks(memory.Title + "\n" + memory.Content + "\n" + memory.Context)
+
+	candidates := make(map[string]domain.TagSuggestion)
+	add := func(tag string, score float64, source string) {
+		tag = t.normalizer.Normalize(tag)
+		if tag == "" || memory.Tags.Contains(tag) {
+			return
+		}
+		if existing, ok := candidates[tag]; !ok || score > existing.Score {
+			candidates[tag] = domain.TagSuggestion{Tag: tag, Score: score, Source: source}
+		}
+	}
+
+	for _, tech := range technologyTags(text, code.languages) {
+		add(tech, technologyTagScore, domain.TagSourceTechnology)
+	}
+	for _, suggestion := range codeIdentifierTags(code.identifiers) {
+		add(suggestion.Tag, suggestion.Score, domain.TagSourceCode)
+	}
+	keywords, err := t.keywordTags(ctx, memory, text)
+	if err != nil {
+		return nil, err
+	}
+	for _, suggestion := range keywords {
+		add(suggestion.Tag, suggestion.Score, domain.TagSourceKeyword)
+	}
+
+	suggestions := make([]domain.TagSuggestion, 0, len(candidates))
+	for _, suggestion := range candidates {
+		if suggestion.Score >= t.options.MinScore {
+			suggestions = append(suggestions, suggestion)
+		}
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return suggestions[i].Tag < suggestions[j].Tag
+	})
+	if len(suggestions) > t.options.MaxTags {
+		suggestions = suggestions[:t.options.MaxTags]
+	}
+
+	t.logger.WithFields(logrus.Fields{
+		"memory_id":   memory.ID,
+		"suggestions": len(suggestions),
+	}).Debug("Extracted tag suggestions")
+	return suggestions, nil
+}
+
+// Index updates the document frequencies with a stored memory, replacing its earlier
+// text. Projects whose frequencies have not been loaded yet are left alone.
+func (t *AutoTagger) Index(memory *domain.Memory) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// The memory may have moved to another project
+	for _, corpus := range t.corpora {
+		corpus.remove(memory.ID)
+	}
+	if corpus, ok := t.corpora[memory.ProjectID]; ok {
+		corpus.add(memory)
+	}
+}
+
+// Forget removes a deleted memory from the document frequencies
+func (t *AutoTagger) Forget(id domain.MemoryID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, corpus := range t.corpora {
+		corpus.remove(id)
+	}
+}
+
+// keywordTags scores the terms of a memory against the document frequencies of its
+// project, loading them on first use
+func (t *AutoTagger) keywordTags(ctx context.Context, memory *domain.Memory, text string) ([]domain.TagSuggestion, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	corpus, ok := t.corpora[memory.ProjectID]
+	if !ok || time.Since(corpus.loadedAt) > corpusTTL {
+		memories, err := t.memoryRepo.ListByProject(ctx, memory.ProjectID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load project corpus: %w", err)
+		}
+		corpus = newTermCorpus(memories)
+		t.corpora[memory.ProjectID] = corpus
+	}
+	return keywordTags(memory, text, corpus), nil
+}
+
+// termCorpus holds the document frequencies of keyword terms across the memories of
+// a project
+type termCorpus struct {
+	terms     map[domain.MemoryID][]string // distinct terms of each memory
+	frequency map[string]int               // number of memories containing each term
+	loadedAt  time.Time
+}
+
+func newTermCorpus(memories []*domain.Memory) *termCorpus {
+	corpus := &termCorpus{
+		terms:     make(map[domain.MemoryID][]string, len(memories)),
+		frequency: make(map[string]int),
+		loadedAt:  time.Now(),
+	}
+	for _, memory := range memories {
+		corpus.add(memory)
+	}
+	return corpus
+}
+
+func (c *termCorpus) add(memory *domain.Memory) {
+	text, _ := splitCodeBlocks(memory.Title + "\n" + memory.Content + "\n" + memory.Context)
+	seen := make(map[string]bool)
+	var terms []string
+	for _, term := range keywordTerms(text) {
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+			c.frequency[term]++
+		}
+	}
+	c.terms[memory.ID] = terms
+}
+
+func (c *termCorpus) remove(id domain.MemoryID) {
+	terms, ok := c.terms[id]
+	if !ok {
+		return
+	}
+	for _, term := range terms {
+		if c.frequency[term]--; c.frequency[term] <= 0 {
+			delete(c.frequency, term)
+		}
+	}
+	delete(c.terms, id)
+}
+
+// technologyNames maps lower-case spellings of languages, frameworks and tools to their tag
+var technologyNames = map[string]string{
+	"golang": "go", "python": "python", "typescript": "typescript", "javascript": "javascript",
+	"java": "java", "kotlin": "kotlin", "rust": "rust", "ruby": "ruby", "php": "php", "scala": "scala",
+	"elixir": "elixir", "haskell": "haskell", "c++": "cpp", "nodejs": "nodejs", "node.js": "nodejs",
+	"deno": "deno", "django": "django", "fastapi": "fastapi", "nextjs": "nextjs", "next.js": "nextjs",
+	"nuxt": "nuxt", "svelte": "svelte", "angular": "angular", "vue": "vue", "vuejs": "vue", "vue.js": "vue",
+	"tailwind": "tailwind", "sqlite": "sqlite", "postgresql": "postgresql", "postgres": "postgresql",
+	"mysql": "mysql", "mongodb": "mongodb", "redis": "redis", "elasticsearch": "elasticsearch",
+	"kafka": "kafka", "rabbitmq": "rabbitmq", "docker": "docker", "kubernetes": "kubernetes",
+	"k8s": "kubernetes", "terraform": "terraform", "ansible": "ansible", "graphql": "graphql",
+	"grpc": "grpc", "protobuf": "protobuf", "jwt": "jwt", "oauth": "oauth", "oauth2": "oauth",
+	"cobra": "cobra", "viper": "viper", "logrus": "logrus", "chromadb": "chromadb", "ollama": "ollama",
+	"sqlalchemy": "sqlalchemy", "pytest": "pytest", "jest": "jest", "webpack": "webpack", "vite": "vite",
+	"nginx": "nginx", "aws": "aws", "gcp": "gcp", "azure": "azure",
+}
+
+// ambiguousTechnologyNames are only recognised with this exact spelling because the
+// lower-case forms are common English words
+var ambiguousTechnologyNames = map[string]string{
+	"Go": "go", "React": "react", "Express": "express", "Echo": "echo", "Gin": "gin",
+	"Flask": "flask", "Spring": "spring", "Rails": "rails", "Swift": "swift",
+}
+
+// codeLanguageNames maps code fence info strings to tags
+var codeLanguageNames = map[string]string{
+	"go": "go", "golang": "go", "py": "python", "python": "python", "ts": "typescript",
+	"tsx": "typescript", "typescript": "typescript", "js": "javascript", "jsx": "javascript",
+	"javascript": "javascript", "rs": "rust", "rust": "rust", "rb": "ruby", "ruby": "ruby",
+	"java": "java", "kt": "kotlin", "kotlin": "kotlin", "php": "php", "sql": "sql",
+	"graphql": "graphql", "proto": "protobuf", "dockerfile": "docker", "hcl": "terraform",
+}
+
+var technologyTokenPattern = regexp.MustCompile(`[A-Za-z][A-Za-z0-9.+#]*`)
+
+// technologyTags returns the known technologies mentioned in text or used as code block languages
+func technologyTags(text string, languages []string) []string {
+	var tags []string
+	seen := make(map[string]bool)
+	add := func(tag string) {
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+
+	for _, language := range languages {
+		if tag, ok := codeLanguageNames[strings.ToLower(language)]; ok {
+			add(tag)
+		}
+	}
+	for _, token := range technologyTokenPattern.FindAllString(text, -1) {
+		token = strings.TrimRight(token, ".")
+		if tag, ok := technologyNames[strings.ToLower(token)]; ok {
+			add(tag)
+		} else if tag, ok := ambiguousTechnologyNames[token]; ok {
+			add(tag)
+		}
+	}
+	return tags
+}
+
+// codeBlocks holds what was found in fenced and inline code
+type codeBlocks struct {
+	languages   []string
+	identifiers []string
+}
+
+var (
+	fencedCodePattern = regexp.MustCompile("(?s)```([A-Za-z0-9+#-]*)[^\n]*\n(.*?)```")
+	inlineCodePattern = regexp.MustCompile("`([^`\n]+)`")
+	identifierPattern = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*`)
+)
+
+// splitCodeBlocks separates prose from fenced and inline code
+func splitCodeBlocks(text string) (string, codeBlocks) {
+	var code codeBlocks
+
+	text = fencedCodePattern.ReplaceAllStringFunc(text, func(block string) string {
+		match := fencedCodePattern.FindStringSubmatch(block)
+		if match[1] != "" {
+			code.languages = append(code.languages, match[1])
+		}
+		code.identifiers = append(code.identifiers, identifierPattern.FindAllString(match[2], -1)...)
+		return "\n"
+	})
+	text = inlineCodePattern.ReplaceAllStringFunc(text, func(span string) string {
+		inner := inlineCodePattern.FindStringSubmatch(span)[1]
+		code.identifiers = append(code.identifiers, identifierPattern.FindAllString(inner, -1)...)
+		return " "
+	})
+
+	return text, code
+}
+
+// codeIdentifierTags scores compound identifiers such as ListByTags or max_retries by frequency
+func codeIdentifierTags(identifiers []string) []domain.TagSuggestion {
+	counts := make(map[string]int)
+	var order []string
+	for _, identifier := range identifiers {
+		if !isCompoundIdentifier(identifier) {
+			continue
+		}
+		if counts[identifier] == 0 {
+			order = append(order, identifier)
+		}
+		counts[identifier]++
+	}
+
+	suggestions := make([]domain.TagSuggestion, 0, len(order))
+	for _, identifier := range order {
+		score := math.Min(codeTagBaseScore+0.1*float64(counts[identifier]-1), codeTagMaxScore)
+		suggestions = append(suggestions, domain.TagSuggestion{Tag: identifier, Score: score})
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Score > suggestions[j].Score
+	})
+	if len(suggestions) > maxCodeTags {
+		suggestions = suggestions[:maxCodeTags]
+	}
+	return suggestions
+}
+
+// isCompoundIdentifier reports whether an identifier is made of several words, in
+// camel case or snake case. Single words are usually keywords or local variables.
+func isCompoundIdentifier(identifier string) bool {
+	if len(identifier) < 4 {
+		return false
+	}
+	trimmed := strings.Trim(identifier, "_")
+	if strings.Contains(trimmed, "_") {
+		return true
+	}
+	runes := []rune(trimmed)
+	for i := 1; i < len(runes); i++ {
+		if unicode.IsLower(runes[i-1]) && unicode.IsUpper(runes[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+var keywordPattern = regexp.MustCompile(`[A-Za-z][A-Za-z0-9-]{2,}`)
+
+// keywordStopWords are common English and technical filler words never used as tags
+var keywordStopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "are": true, "but": true, "not": true, "you": true,
+	"all": true, "any": true, "can": true, "had": true, "her": true, "was": true, "one": true,
+	"our": true, "out": true, "has": true, "have": true, "this": true, "that": true, "with": true,
+	"from": true, "they": true, "will": true, "would": true, "there": true, "their": true,
+	"what": true, "when": true, "which": true, "while": true, "where": true, "who": true,
+	"why": true, "how": true, "into": true, "than": true, "then": true, "them": true, "these": true,
+	"those": true, "been": true, "being": true, "were": true, "does": true, "did": true,
+	"doing": true, "because": true, "should": true, "could": true, "also": true, "only": true,
+	"other": true, "some": true, "such": true, "more": true, "most": true, "very": true,
+	"just": true, "over": true, "after": true, "before": true, "about": true, "each": true,
+	"same": true, "both": true, "between": true, "through": true, "during": true, "without": true,
+	"within": true, "use": true, "used": true, "using": true, "uses": true, "make": true,
+	"makes": true, "need": true, "needs": true, "new": true, "now": true, "way": true, "get": true,
+	"set": true, "via": true, "per": true, "its": true, "it's": true, "don't": true, "instead": true,
+	"like": true, "well": true, "still": true, "always": true, "never": true, "every": true,
+	"value": true, "values": true, "data": true, "code": true, "file": true, "files": true,
+	"function": true, "functions": true, "method": true, "methods": true, "return": true,
+	"returns": true, "error": true, "errors": true, "true": true, "false": true, "null": true,
+	"nil": true, "task": true, "status": true, "priority": true, "todo": true,
+}
+
+// keywordTags returns the terms of a memory that are frequent in it but rare in the
+// rest of the project, scored by TF-IDF relative to the best term
+func keywordTags(memory *domain.Memory, text string, corpus *termCorpus) []domain.TagSuggestion {
+	termCounts := make(map[string]int)
+	for _, term := range keywordTerms(text) {
+		termCounts[term]++
+	}
+	// Title terms count double
+	titleText, _ := splitCodeBlocks(memory.Title)
+	titleTerms := make(map[string]bool)
+	for _, term := range keywordTerms(titleText) {
+		termCounts[term]++
+		titleTerms[term] = true
+	}
+	if len(termCounts) == 0 {
+		return nil
+	}
+
+	// Document frequency across the other memories of the project
+	documents := len(corpus.terms)
+	own := make(map[string]bool)
+	if terms, ok := corpus.terms[memory.ID]; ok {
+		documents--
+		for _, term := range terms {
+			own[term] = true
+		}
+	}
+	documentFrequency := func(term string) int {
+		if own[term] {
+			return corpus.frequency[term] - 1
+		}
+		return corpus.frequency[term]
+	}
+
+	total := 0
+	for _, count := range termCounts {
+		total += count
+	}
+
+	type scoredTerm struct {
+		term  string
+		score float64
+	}
+	var scored []scoredTerm
+	best := 0.0
+	for term, count := range termCounts {
+		// A single mention outside the title is not a topic
+		if count < 2 && !titleTerms[term] {
+			continue
+		}
+		tf := float64(count) / float64(total)
+		idf := math.Log(float64(documents+1)/float64(documentFrequency(term)+1)) + 1
+		score := tf * idf
+		scored = append(scored, scoredTerm{term, score})
+		best = math.Max(best, score)
+	}
+
+	sort.Slice(scored, func(i, j int) bool {
+		if scored[i].score != scored[j].score {
+			return scored[i].score > scored[j].score
+		}
+		return scored[i].term < scored[j].term
+	})
+	if len(scored) > maxKeywordTags {
+		scored = scored[:maxKeywordTags]
+	}
+
+	suggestions := make([]domain.TagSuggestion, 0, len(scored))
+	for _, s := range scored {
+		suggestions = append(suggestions, domain.TagSuggestion{Tag: s.term, Score: keywordTagMaxScore * s.score / best})
+	}
+	return suggestions
+}
+
+// keywordTerms returns the lower-case terms of text, without stop words and numbers
+func keywordTerms(text string) []string {
+	var terms []string
+	for _, token := range keywordPattern.FindAllString(text, -1) {
+		term := strings.Trim(strings.ToLower(token), "-")
+		if len(term) < 3 || keywordStopWords[term] {
+			continue
+		}
+		terms = append(terms, term)
+	}
+	return terms
+}
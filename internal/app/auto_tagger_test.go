@@ -0,0 +1,231 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/sirupsen/logrus"
+)
+
+func newTestAutoTagger(memoryRepo *MockMemoryRepository, mode domain.AutoTagMode) *AutoTagger {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	options := DefaultAutoTagOptions()
+	options.Mode = mode
+	return NewAutoTagger(memoryRepo, domain.DefaultTagNormalizer(), options, logger)
+}
+
+func suggestionsByTag(suggestions []domain.TagSuggestion) map[string]domain.TagSuggestion {
+	byTag := make(map[string]domain.TagSuggestion)
+	for _, suggestion := range suggestions {
+		byTag[suggestion.Tag] = suggestion
+	}
+	return byTag
+}
+
+func TestAutoTagger_Technologies(t *testing.T) {
+	tagger := newTestAutoTagger(NewMockMemoryRepository(), domain.AutoTagModeSuggest)
+
+	memory := domain.NewMemory("proj", domain.MemoryTypeDecision, "HTTP layer",
+		"The API is written in Go with Gin and stores data in Postgres. We go to great lengths to keep handlers small.", "")
+	memory.Tags = domain.Tags{"gin"}
+
+	suggestions, err := tagger.Suggest(context.Background(), memory)
+	if err != nil {
+		t.Fatalf("Failed to suggest tags: %v", err)
+	}
+	byTag := suggestionsByTag(suggestions)
+
+	for _, tag := range []string{"go", "postgresql"} {
+		if byTag[tag].Source != domain.TagSourceTechnology {
+			t.Errorf("Expected technology tag %s, got %+v", tag, suggestions)
+		}
+	}
+	if _, ok := byTag["gin"]; ok {
+		t.Error("Expected existing tags not to be suggested")
+	}
+}
+
+func TestAutoTagger_CodeBlocks(t *testing.T) {
+	tagger := newTestAutoTagger(NewMockMemoryRepository(), domain.AutoTagModeSuggest)
+
+	content := "Profiles are cached:\n\n```py\nprofile = fetch_user_profile(user_id)\nif not profile:\n    profile = fetch_user_profile(user_id, refresh=True)\n```\n\nSee `ProfileCache` for eviction."
+	memory := domain.NewMemory("proj", domain.MemoryTypePattern, "Profile caching", content, "")
+
+	suggestions, err := tagger.Suggest(context.Background(), memory)
+	if err != nil {
+		t.Fatalf("Failed to suggest tags: %v", err)
+	}
+	byTag := suggestionsByTag(suggestions)
+
+	if byTag["python"].Source != domain.TagSourceTechnology {
+		t.Errorf("Expected the code fence language as a tag, got %+v", suggestions)
+	}
+	if byTag["fetch_user_profile"].Source != domain.TagSourceCode {
+		t.Errorf("Expected the repeated identifier as a tag, got %+v", suggestions)
+	}
+	if byTag["fetch_user_profile"].Score <= byTag["profilecache"].Score {
+		t.Errorf("Expected repeated identifiers to score higher, got %+v", suggestions)
+	}
+}
+
+func TestAutoTagger_Keywords(t *testing.T) {
+	memoryRepo := NewMockMemoryRepository()
+	ctx := context.Background()
+	for _, content := range []string{
+		"The database schema is migrated on startup.",
+		"Database connections are pooled.",
+		"Backups of the database run nightly.",
+	} {
+		if err := memoryRepo.Store(ctx, domain.NewMemory("proj", domain.MemoryTypeDocumentation, "Doc", content, "")); err != nil {
+			t.Fatalf("Failed to store memory: %v", err)
+		}
+	}
+	tagger := newTestAutoTagger(memoryRepo, domain.AutoTagModeSuggest)
+
+	memory := domain.NewMemory("proj", domain.MemoryTypeDecision, "Idempotent writes",
+		"Every database write carries an idempotency key. The idempotency key is stored next to the database row.", "")
+
+	suggestions, err := tagger.Suggest(ctx, memory)
+	if err != nil {
+		t.Fatalf("Failed to suggest tags: %v", err)
+	}
+	byTag := suggestionsByTag(suggestions)
+
+	idempotency, ok := byTag["idempotency"]
+	if !ok || idempotency.Source != domain.TagSourceKeyword {
+		t.Fatalf("Expected idempotency as a keyword, got %+v", suggestions)
+	}
+	if database, ok := byTag["database"]; ok && database.Score >= idempotency.Score {
+		t.Errorf("Expected terms common in the project to score lower, got %+v", suggestions)
+	}
+	if _, ok := byTag["every"]; ok {
+		t.Error("Expected single mentions not to become keywords")
+	}
+}
+
+// listCountingMemoryRepository counts how often a project's memories are listed
+type listCountingMemoryRepository struct {
+	*MockMemoryRepository
+	lists int
+}
+
+func (r *listCountingMemoryRepository) ListByProject(ctx context.Context, projectID domain.ProjectID) ([]*domain.Memory, error) {
+	r.lists++
+	return r.MockMemoryRepository.ListByProject(ctx, projectID)
+}
+
+func TestAutoTagger_CorpusIsCachedAndUpdated(t *testing.T) {
+	memoryRepo := &listCountingMemoryRepository{MockMemoryRepository: NewMockMemoryRepository()}
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	options := DefaultAutoTagOptions()
+	options.Mode = domain.AutoTagModeSuggest
+	tagger := NewAutoTagger(memoryRepo, domain.DefaultTagNormalizer(), options, logger)
+	ctx := context.Background()
+
+	memory := domain.NewMemory("proj", domain.MemoryTypeDecision, "Idempotent writes",
+		"Every write carries an idempotency key. The idempotency key is stored next to the row.", "")
+	suggest := func() map[string]domain.TagSuggestion {
+		t.Helper()
+		suggestions, err := tagger.Suggest(ctx, memory)
+		if err != nil {
+			t.Fatalf("Failed to suggest tags: %v", err)
+		}
+		return suggestionsByTag(suggestions)
+	}
+
+	if _, ok := suggest()["idempotency"]; !ok {
+		t.Fatal("Expected idempotency as a keyword in an empty project")
+	}
+
+	// Memories indexed after the first load count without listing the project again
+	var others []*domain.Memory
+	for i := 0; i < 3; i++ {
+		other := domain.NewMemory("proj", domain.MemoryTypeDocumentation, "Doc", "Idempotency keys expire after a day.", "")
+		tagger.Index(other)
+		others = append(others, other)
+	}
+	tagger.Index(domain.NewMemory("other", domain.MemoryTypeDocumentation, "Doc", "Unrelated", ""))
+	if _, ok := suggest()["idempotency"]; ok {
+		t.Error("Expected a term used across the project not to be suggested")
+	}
+	if memoryRepo.lists != 1 {
+		t.Errorf("Expected the project to be listed once, got %d", memoryRepo.lists)
+	}
+
+	for _, other := range others {
+		tagger.Forget(other.ID)
+	}
+	if _, ok := suggest()["idempotency"]; !ok {
+		t.Error("Expected forgotten memories to be removed from the document frequencies")
+	}
+}
+
+func TestAutoTagger_MaxTags(t *testing.T) {
+	tagger := newTestAutoTagger(NewMockMemoryRepository(), domain.AutoTagModeSuggest)
+
+	memory := domain.NewMemory("proj", domain.MemoryTypeDocumentation, "Stack",
+		"Python, Django, Redis, Kafka, Docker, Kubernetes and Terraform.", "")
+
+	suggestions, err := tagger.Suggest(context.Background(), memory)
+	if err != nil {
+		t.Fatalf("Failed to suggest tags: %v", err)
+	}
+	if len(suggestions) != DefaultAutoTagOptions().MaxTags {
+		t.Errorf("Expected %d suggestions, got %+v", DefaultAutoTagOptions().MaxTags, suggestions)
+	}
+}
+
+func TestMemoryService_CreateMemoryAutoTags(t *testing.T) {
+	ctx := context.Background()
+	req := ports.CreateMemoryRequest{
+		ProjectID: "proj",
+		Type:      domain.MemoryTypeDecision,
+		Title:     "Cache sessions in Redis",
+		Content:   "Sessions are stored in Redis with a TTL.",
+		Tags:      []string{"sessions"},
+	}
+
+	tests := []struct {
+		name          string
+		mode          domain.AutoTagMode
+		memoryType    domain.MemoryType
+		wantAuto      bool
+		wantSuggested bool
+	}{
+		{"off", domain.AutoTagModeOff, domain.MemoryTypeDecision, false, false},
+		{"suggest", domain.AutoTagModeSuggest, domain.MemoryTypeDecision, false, true},
+		{"apply", domain.AutoTagModeApply, domain.MemoryTypeDecision, true, false},
+		{"tasks are skipped", domain.AutoTagModeApply, domain.MemoryTypeTask, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, memoryRepo, _, _ := setupMemoryServiceTest()
+			service.SetAutoTagger(newTestAutoTagger(memoryRepo, tt.mode))
+
+			req.Type = tt.memoryType
+			memory, err := service.CreateMemory(ctx, req)
+			if err != nil {
+				t.Fatalf("Failed to create memory: %v", err)
+			}
+
+			if got := memory.AutoTags.Contains("redis"); got != tt.wantAuto {
+				t.Errorf("Expected redis auto tag %v, got tags %v auto %v", tt.wantAuto, memory.Tags, memory.AutoTags)
+			}
+			if got := memory.Tags.Contains("redis"); got != tt.wantAuto {
+				t.Errorf("Expected redis tag %v, got %v", tt.wantAuto, memory.Tags)
+			}
+			if got := memory.SuggestedTags.Contains("redis"); got != tt.wantSuggested {
+				t.Errorf("Expected redis suggestion %v, got %v", tt.wantSuggested, memory.SuggestedTags)
+			}
+			if memory.TagOrigin("sessions") != domain.TagOriginManual {
+				t.Error("Expected the given tag to stay manual")
+			}
+		})
+	}
+}
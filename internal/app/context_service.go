@@ -0,0 +1,273 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/sirupsen/logrus"
+)
+
+// maxContextFilesInQuery limits how many file paths are added to the search query
+const maxContextFilesInQuery = 20
+
+// contextKnowledgeTypes are the memory types included as knowledge in a context pack
+var contextKnowledgeTypes = []domain.MemoryType{
+	domain.MemoryTypeDecision,
+	domain.MemoryTypePattern,
+	domain.MemoryTypeErrorSolution,
+}
+
+// contextService implements the ContextService interface
+type contextService struct {
+	memoryService  ports.MemoryService
+	taskService    ports.TaskService
+	sessionService ports.SessionService
+	logger         *logrus.Logger
+}
+
+// NewContextService creates a new context service. taskService and sessionService
+// may be nil, in which case open tasks or the last session are left out.
+func NewContextService(memoryService ports.MemoryService, taskService ports.TaskService, sessionService ports.SessionService, logger *logrus.Logger) ports.ContextService {
+	return &contextService{
+		memoryService:  memoryService,
+		taskService:    taskService,
+		sessionService: sessionService,
+		logger:         logger,
+	}
+}
+
+// BuildContextPack collects the decisions, patterns, error solutions, open tasks and
+// last session outcome relevant to a task and renders them within a token budget
+func (s *contextService) BuildContextPack(ctx context.Context, req ports.ContextPackRequest) (*ports.ContextPack, error) {
+	task := strings.TrimSpace(req.Task)
+	if task == "" {
+		return nil, fmt.Errorf("task description is required")
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = ports.DefaultContextLimit
+	}
+	threshold := req.Threshold
+	if threshold <= 0 {
+		threshold = ports.DefaultContextThreshold
+	}
+	budget := req.TokenBudget
+	if budget <= 0 {
+		budget = ports.DefaultContextTokenBudget
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"project_id": req.ProjectID,
+		"files":      len(req.Files),
+		"limit":      limit,
+	}).Info("Building context pack")
+
+	query := contextQuery(task, req.Files)
+	pack := &ports.ContextPack{
+		ProjectID: req.ProjectID,
+		Task:      task,
+	}
+
+	knowledge, err := s.memoryService.SearchMemories(ctx, ports.SemanticSearchRequest{
+		Query:     query,
+		ProjectID: req.ProjectID,
+		// Fetch extra results so that every type can fill its section after deduplication
+		Limit:     limit * len(contextKnowledgeTypes) * 2,
+		Threshold: threshold,
+		Filters:   &ports.SearchFilters{Types: contextKnowledgeTypes},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search memories: %w", err)
+	}
+
+	for _, result := range deduplicateResults(knowledge) {
+		switch result.Memory.Type {
+		case domain.MemoryTypeDecision:
+			if len(pack.Decisions) < limit {
+				pack.Decisions = append(pack.Decisions, result)
+			}
+		case domain.MemoryTypePattern:
+			if len(pack.Patterns) < limit {
+				pack.Patterns = append(pack.Patterns, result)
+			}
+		case domain.MemoryTypeErrorSolution:
+			if len(pack.ErrorSolutions) < limit {
+				pack.ErrorSolutions = append(pack.ErrorSolutions, result)
+			}
+		}
+	}
+
+	pack.OpenTasks = s.matchingOpenTasks(ctx, req.ProjectID, query, limit, threshold)
+	pack.LastSession = s.lastCompletedSession(ctx, req.ProjectID)
+
+	pack.Text = renderContextPack(pack, budget)
+	pack.EstimatedTokens = domain.EstimateTokens(pack.Text)
+
+	return pack, nil
+}
+
+// matchingOpenTasks returns tasks similar to the query that are not done yet
+func (s *contextService) matchingOpenTasks(ctx context.Context, projectID *domain.ProjectID, query string, limit int, threshold float32) []*domain.Task {
+	if s.taskService == nil {
+		return nil
+	}
+
+	taskType := domain.MemoryTypeTask
+	results, err := s.memoryService.SearchMemories(ctx, ports.SemanticSearchRequest{
+		Query:     query,
+		ProjectID: projectID,
+		Type:      &taskType,
+		Limit:     limit * 2,
+		Threshold: threshold,
+	})
+	if err != nil {
+		s.logger.WithError(err).Warn("Failed to search tasks for context pack")
+		return nil
+	}
+
+	tasks := make([]*domain.Task, 0, limit)
+	for _, result := range results {
+		if len(tasks) >= limit {
+			break
+		}
+		task, err := s.taskService.GetTask(ctx, result.Memory.ID)
+		if err != nil {
+			s.logger.WithError(err).WithField("task_id", result.Memory.ID).Debug("Failed to load task for context pack")
+			continue
+		}
+		if task.Status == domain.TaskStatusDone {
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
+// lastCompletedSession returns the most recently started completed session of a project
+func (s *contextService) lastCompletedSession(ctx context.Context, projectID *domain.ProjectID) *domain.Session {
+	if s.sessionService == nil || projectID == nil {
+		return nil
+	}
+
+	status := domain.SessionStatusCompleted
+	sessions, err := s.sessionService.ListSessions(ctx, ports.SessionFilters{
+		ProjectID: projectID,
+		Status:    &status,
+		Limit:     1,
+	})
+	if err != nil {
+		s.logger.WithError(err).Warn("Failed to load last session for context pack")
+		return nil
+	}
+	if len(sessions) == 0 {
+		return nil
+	}
+	return sessions[0]
+}
+
+// contextQuery combines the task description with the touched files. Each file
+// contributes its path and its base name without extension, e.g. "auth/jwt.go jwt".
+func contextQuery(task string, files []string) string {
+	parts := []string{task}
+	for i, file := range files {
+		if i >= maxContextFilesInQuery {
+			break
+		}
+		file = strings.TrimSpace(file)
+		if file == "" {
+			continue
+		}
+		parts = append(parts, filepath.ToSlash(file))
+		if name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)); name != "" && name != "." && name != file {
+			parts = append(parts, name)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// deduplicateResults drops repeated memories and memories whose title and content
+// match an earlier, more similar result
+func deduplicateResults(results []ports.MemorySearchResult) []ports.MemorySearchResult {
+	seenIDs := make(map[domain.MemoryID]bool, len(results))
+	seenContent := make(map[string]bool, len(results))
+
+	unique := make([]ports.MemorySearchResult, 0, len(results))
+	for _, result := range results {
+		key := normalizeForDedup(result.Memory.Title + " " + result.Memory.Content)
+		if seenIDs[result.Memory.ID] || seenContent[key] {
+			continue
+		}
+		seenIDs[result.Memory.ID] = true
+		seenContent[key] = true
+		unique = append(unique, result)
+	}
+	return unique
+}
+
+func normalizeForDedup(text string) string {
+	return strings.Join(strings.Fields(strings.ToLower(text)), " ")
+}
+
+// renderContextPack formats a context pack as markdown within the token budget
+func renderContextPack(pack *ports.ContextPack, budget int) string {
+	header := fmt.Sprintf("# Context for: %s\n\n", pack.Task)
+
+	sections := []domain.PromptSection{
+		{Heading: "## Decisions", Items: memoryItems(pack.Decisions)},
+		{Heading: "## Patterns", Items: memoryItems(pack.Patterns)},
+		{Heading: "## Error Solutions", Items: memoryItems(pack.ErrorSolutions)},
+		{Heading: "## Open Tasks", Items: taskItems(pack.OpenTasks)},
+	}
+	if pack.LastSession != nil {
+		sections = append(sections, domain.PromptSection{Heading: "## Last Session", Items: []string{sessionItem(pack.LastSession)}})
+	}
+
+	body := domain.RenderPromptSections(sections, budget-domain.EstimateTokens(header))
+	if body == "" {
+		body = "_No relevant context found._\n"
+	}
+	return header + body
+}
+
+func memoryItems(results []ports.MemorySearchResult) []string {
+	items := make([]string, 0, len(results))
+	for _, result := range results {
+		item := fmt.Sprintf("### %s\n", result.Memory.Title)
+		if len(result.Memory.Tags) > 0 {
+			item += fmt.Sprintf("Tags: %s\n", strings.Join(result.Memory.Tags, ", "))
+		}
+		items = append(items, item+strings.TrimSpace(result.Memory.Content)+"\n\n")
+	}
+	return items
+}
+
+func taskItems(tasks []*domain.Task) []string {
+	items := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		item := fmt.Sprintf("- [%s] %s (%s priority", task.Status, task.Title, task.Priority)
+		if task.DueDate != nil {
+			item += ", due " + task.DueDate.Format("2006-01-02")
+		}
+		items = append(items, item+")\n")
+	}
+	return items
+}
+
+func sessionItem(session *domain.Session) string {
+	item := fmt.Sprintf("**%s**", session.Name)
+	if session.EndTime != nil {
+		item += fmt.Sprintf(" (completed %s)", session.EndTime.Format("2006-01-02"))
+	}
+	if session.TaskDescription != "" {
+		item += "\nTask: " + session.TaskDescription
+	}
+	if session.Outcome != "" {
+		item += "\nOutcome: " + session.Outcome
+	}
+	return item + "\n"
+}
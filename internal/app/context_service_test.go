@@ -0,0 +1,153 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/sirupsen/logrus"
+)
+
+// constantEmbeddingProvider embeds every text as the same unit vector so that
+// all memories match a search with similarity 1
+type constantEmbeddingProvider struct {
+	*MockEmbeddingProvider
+}
+
+func (p constantEmbeddingProvider) GenerateEmbedding(ctx context.Context, text string) (domain.EmbeddingVector, error) {
+	vector := make(domain.EmbeddingVector, p.GetDimensions())
+	vector[0] = 1
+	return vector, nil
+}
+
+func setupContextServiceTest(t *testing.T) (ports.ContextService, *MemoryService, *MockSessionRepository, domain.ProjectID) {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	memoryService := NewMemoryService(NewMockMemoryRepository(), constantEmbeddingProvider{NewMockEmbeddingProvider()}, NewMockVectorStore(), logger)
+
+	sessionRepo := NewMockSessionRepository()
+	sessionService := NewSessionService(sessionRepo, NewMockProjectRepository(), logger)
+	taskService := NewTaskService(memoryService, logger)
+
+	projectID := domain.ProjectID(generateUniqueTestID("proj"))
+	return NewContextService(memoryService, taskService, sessionService, logger), memoryService, sessionRepo, projectID
+}
+
+func TestContextService_BuildContextPack(t *testing.T) {
+	service, memoryService, sessionRepo, projectID := setupContextServiceTest(t)
+	ctx := context.Background()
+
+	create := func(memoryType domain.MemoryType, title, content string) {
+		t.Helper()
+		if _, err := memoryService.CreateMemory(ctx, ports.CreateMemoryRequest{
+			ProjectID: projectID,
+			Type:      memoryType,
+			Title:     title,
+			Content:   content,
+		}); err != nil {
+			t.Fatalf("Failed to create memory: %v", err)
+		}
+	}
+
+	create(domain.MemoryTypeDecision, "Use JWT", "Tokens are signed with RS256")
+	create(domain.MemoryTypeDecision, "Use  JWT", "Tokens are signed with  RS256") // duplicate after normalisation
+	create(domain.MemoryTypePattern, "Middleware chain", "Auth runs before logging")
+	create(domain.MemoryTypeErrorSolution, "Clock skew", "Allow 30s leeway when validating exp")
+	create(domain.MemoryTypeCode, "Unrelated snippet", "Code memories are not part of the pack")
+	create(domain.MemoryTypeTask, "Rotate signing keys", "Add key rotation")
+
+	session := domain.NewSession(projectID, "Auth refactor", "Refactor token validation")
+	session.Complete("Validation moved into middleware")
+	if err := sessionRepo.Store(ctx, session); err != nil {
+		t.Fatalf("Failed to store session: %v", err)
+	}
+
+	pack, err := service.BuildContextPack(ctx, ports.ContextPackRequest{
+		ProjectID: &projectID,
+		Task:      "Add refresh tokens",
+		Files:     []string{"internal/auth/jwt.go"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to build context pack: %v", err)
+	}
+
+	if len(pack.Decisions) != 1 {
+		t.Errorf("Expected duplicate decisions to be merged, got %d", len(pack.Decisions))
+	}
+	if len(pack.Patterns) != 1 || len(pack.ErrorSolutions) != 1 {
+		t.Errorf("Expected one pattern and one error solution, got %d and %d", len(pack.Patterns), len(pack.ErrorSolutions))
+	}
+	if len(pack.OpenTasks) != 1 || pack.OpenTasks[0].Title != "Rotate signing keys" {
+		t.Errorf("Expected the open task, got %d tasks", len(pack.OpenTasks))
+	}
+	if pack.LastSession == nil || pack.LastSession.ID != session.ID {
+		t.Error("Expected the last completed session")
+	}
+
+	for _, expected := range []string{"# Context for: Add refresh tokens", "## Decisions", "## Error Solutions", "## Open Tasks", "Validation moved into middleware"} {
+		if !strings.Contains(pack.Text, expected) {
+			t.Errorf("Expected %q in rendered pack:\n%s", expected, pack.Text)
+		}
+	}
+	if strings.Contains(pack.Text, "Unrelated snippet") {
+		t.Error("Expected code memories to be excluded")
+	}
+	if len(pack.MemoryIDs()) != 4 {
+		t.Errorf("Expected 4 memory IDs, got %d", len(pack.MemoryIDs()))
+	}
+}
+
+func TestContextService_BuildContextPack_Budget(t *testing.T) {
+	service, memoryService, _, projectID := setupContextServiceTest(t)
+	ctx := context.Background()
+
+	for _, title := range []string{"First decision", "Second decision", "Third decision"} {
+		if _, err := memoryService.CreateMemory(ctx, ports.CreateMemoryRequest{
+			ProjectID: projectID,
+			Type:      domain.MemoryTypeDecision,
+			Title:     title,
+			Content:   strings.Repeat(title+" details. ", 40),
+		}); err != nil {
+			t.Fatalf("Failed to create memory: %v", err)
+		}
+	}
+
+	pack, err := service.BuildContextPack(ctx, ports.ContextPackRequest{
+		ProjectID:   &projectID,
+		Task:        "decide",
+		TokenBudget: 200,
+	})
+	if err != nil {
+		t.Fatalf("Failed to build context pack: %v", err)
+	}
+	if len(pack.Decisions) != 3 {
+		t.Errorf("Expected all decisions in the structured pack, got %d", len(pack.Decisions))
+	}
+	if !strings.Contains(pack.Text, "to fit the token budget") {
+		t.Errorf("Expected rendered text to be cut to the budget:\n%s", pack.Text)
+	}
+	if pack.EstimatedTokens > 230 {
+		t.Errorf("Expected about 200 tokens, got %d", pack.EstimatedTokens)
+	}
+}
+
+func TestContextService_BuildContextPack_RequiresTask(t *testing.T) {
+	service, _, _, projectID := setupContextServiceTest(t)
+
+	if _, err := service.BuildContextPack(context.Background(), ports.ContextPackRequest{ProjectID: &projectID, Task: "  "}); err == nil {
+		t.Error("Expected error for empty task")
+	}
+}
+
+func TestContextQuery(t *testing.T) {
+	query := contextQuery("Add refresh tokens", []string{"internal/auth/jwt.go", "", "Makefile"})
+	expected := "Add refresh tokens internal/auth/jwt.go jwt Makefile"
+	if query != expected {
+		t.Errorf("Expected %q, got %q", expected, query)
+	}
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"math"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+)
+
+// mmrCandidate is a search result prepared for maximal marginal relevance re-ranking
+type mmrCandidate struct {
+	relevance float64
+	vector    domain.EmbeddingVector
+}
+
+// mmrSelection describes a candidate picked by rerankMMR
+type mmrSelection struct {
+	index   int     // index into the candidate slice
+	score   float64 // MMR score at the time of selection
+	penalty float64 // maximum similarity to previously selected candidates
+}
+
+// rerankMMR orders candidates by maximal marginal relevance.
+// Relevance is normalised to [0,1] so it is comparable with cosine similarity.
+// Candidates without a vector are never penalised for redundancy.
+func rerankMMR(candidates []mmrCandidate, lambda float64) []mmrSelection {
+	maxRelevance := 0.0
+	for _, c := range candidates {
+		if c.relevance > maxRelevance {
+			maxRelevance = c.relevance
+		}
+	}
+
+	selected := make([]mmrSelection, 0, len(candidates))
+	used := make([]bool, len(candidates))
+
+	for len(selected) < len(candidates) {
+		best := -1
+		bestScore := math.Inf(-1)
+		bestPenalty := 0.0
+
+		for i, c := range candidates {
+			if used[i] {
+				continue
+			}
+
+			relevance := 0.0
+			if maxRelevance > 0 {
+				relevance = c.relevance / maxRelevance
+			}
+
+			penalty := 0.0
+			for _, sel := range selected {
+				if sim := cosineSimilarity(c.vector, candidates[sel.index].vector); sim > penalty {
+					penalty = sim
+				}
+			}
+
+			score := lambda*relevance - (1-lambda)*penalty
+			if score > bestScore {
+				best = i
+				bestScore = score
+				bestPenalty = penalty
+			}
+		}
+
+		used[best] = true
+		selected = append(selected, mmrSelection{index: best, score: bestScore, penalty: bestPenalty})
+	}
+
+	return selected
+}
+
+// cosineSimilarity returns the cosine similarity of two vectors, or 0 if they are incomparable
+func cosineSimilarity(a, b domain.EmbeddingVector) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -3,20 +3,63 @@ package app
 import (
 	"context"
 	"fmt"
+	"math"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/joern1811/memory-bank/internal/domain"
 	"github.com/joern1811/memory-bank/internal/ports"
 	"github.com/sirupsen/logrus"
 )
 
+// mmrCandidateMultiplier controls how many extra candidates are fetched for diversity re-ranking
+const mmrCandidateMultiplier = 3
+
+// RelevanceOptions configures the usage- and dependency-aware components of the relevance score
+type RelevanceOptions struct {
+	// RecencyWindow is the age after which a memory no longer receives a recency boost.
+	// Age is measured from the later of the last update and the last access.
+	RecencyWindow time.Duration
+	// RecencyMaxBoost is the boost applied to a memory used just now
+	RecencyMaxBoost float64
+	// PopularityMaxBoost is the boost applied to a memory accessed PopularitySaturation times or more
+	PopularityMaxBoost float64
+	// PopularitySaturation is the access count at which the popularity boost stops growing
+	PopularitySaturation int
+	// MismatchWeight scales the similarity of memories whose dependency constraints
+	// the project no longer satisfies
+	MismatchWeight float64
+}
+
+// DefaultRelevanceOptions returns the relevance options used when none are configured
+func DefaultRelevanceOptions() RelevanceOptions {
+	return RelevanceOptions{
+		RecencyWindow:        90 * 24 * time.Hour,
+		RecencyMaxBoost:      0.1,
+		PopularityMaxBoost:   0.1,
+		PopularitySaturation: 20,
+		MismatchWeight:       0.5,
+	}
+}
+
 // MemoryService implements the memory service use cases
 type MemoryService struct {
 	memoryRepo        ports.MemoryRepository
 	embeddingProvider ports.EmbeddingProvider
 	vectorStore       ports.VectorStore
 	logger            *logrus.Logger
+	relevance         RelevanceOptions
+	tagNormalizer     domain.TagNormalizer
+	autoTagger        *AutoTagger
+	memoryTypes       *domain.MemoryTypeRegistry
+	redactor          *domain.Redactor
+	provenance        domain.Provenance
+	sessionRepo       ports.SessionRepository
+	reviewPolicy      domain.ReviewPolicy
+	scopes            domain.ScopeSettings
+	projectRepo       ports.ProjectRepository
+	encrypted         bool
 }
 
 // NewMemoryService creates a new memory service
@@ -31,33 +74,120 @@ func NewMemoryService(
 		embeddingProvider: embeddingProvider,
 		vectorStore:       vectorStore,
 		logger:            logger,
+		relevance:         DefaultRelevanceOptions(),
+		tagNormalizer:     domain.DefaultTagNormalizer(),
+		memoryTypes:       domain.NewMemoryTypeRegistry(),
+		scopes:            domain.DefaultScopeSettings(),
 	}
 }
 
+// SetRelevanceOptions overrides the usage-aware relevance scoring options
+func (s *MemoryService) SetRelevanceOptions(opts RelevanceOptions) {
+	s.relevance = opts
+}
+
+// SetTagNormalizer overrides how tags are normalized on write and in filters
+func (s *MemoryService) SetTagNormalizer(normalizer domain.TagNormalizer) {
+	s.tagNormalizer = normalizer
+}
+
+// TagNormalizer returns the normalizer applied to tags
+func (s *MemoryService) TagNormalizer() domain.TagNormalizer {
+	return s.tagNormalizer
+}
+
+// SetMemoryTypes overrides the registry of memory types, e.g. to add user-defined types
+func (s *MemoryService) SetMemoryTypes(registry *domain.MemoryTypeRegistry) {
+	s.memoryTypes = registry
+}
+
+// ListMemoryTypes returns the memory types available in a project, or in all projects if projectID is nil
+func (s *MemoryService) ListMemoryTypes(ctx context.Context, projectID *domain.ProjectID) []domain.MemoryTypeDefinition {
+	return s.memoryTypes.Types(projectID)
+}
+
+// SetAutoTagger enables automatic tag extraction when memories are created
+func (s *MemoryService) SetAutoTagger(tagger *AutoTagger) {
+	s.autoTagger = tagger
+}
+
+// SetRedactor enables masking of secrets and personal data before memories are
+// stored and embedded
+func (s *MemoryService) SetRedactor(redactor *domain.Redactor) {
+	s.redactor = redactor
+}
+
+// SetEncrypted keeps memory titles out of the vector store metadata when the database
+// encrypts memory text, so that they are only stored encrypted
+func (s *MemoryService) SetEncrypted(encrypted bool) {
+	s.encrypted = encrypted
+}
+
+// SetProvenance sets the provenance recorded on writes whose context carries none,
+// e.g. the local user and the cli source
+func (s *MemoryService) SetProvenance(provenance domain.Provenance) {
+	s.provenance = provenance
+}
+
+// SetSessionRepository enables recording the project's active session as the
+// originating session of writes
+func (s *MemoryService) SetSessionRepository(sessionRepo ports.SessionRepository) {
+	s.sessionRepo = sessionRepo
+}
+
+// SetReviewPolicy sets which new memories are created as drafts that wait in the
+// review queue, e.g. those written through MCP
+func (s *MemoryService) SetReviewPolicy(policy domain.ReviewPolicy) {
+	s.reviewPolicy = policy
+}
+
+// SetScopes configures which shared scopes are searched with each project and how
+// their memories are weighted
+func (s *MemoryService) SetScopes(scopes domain.ScopeSettings) {
+	s.scopes = scopes
+}
+
+// SetProjectRepository enables deriving dependency constraints of new memories from
+// the project's manifests and checking them against the project in searches
+func (s *MemoryService) SetProjectRepository(projectRepo ports.ProjectRepository) {
+	s.projectRepo = projectRepo
+}
+
 // CreateMemory creates a new memory entry with embedding
 func (s *MemoryService) CreateMemory(ctx context.Context, req ports.CreateMemoryRequest) (*domain.Memory, error) {
-	s.logger.WithFields(logrus.Fields{
-		"project_id": req.ProjectID,
-		"type":       req.Type,
-		"title":      req.Title,
-	}).Info("Creating memory")
-
 	// Create memory entity
 	memory := domain.NewMemory(req.ProjectID, req.Type, req.Title, req.Content, req.Context)
 	if req.SessionID != nil {
 		memory.SessionID = req.SessionID
 	}
+	memory.Provenance = s.provenanceFor(ctx, memory.ProjectID, memory.SessionID)
+	s.applyReviewPolicy(memory)
+	if err := s.setFields(memory, req.Fields); err != nil {
+		return nil, err
+	}
+	s.redact(memory)
+
+	s.logger.WithFields(logrus.Fields{
+		"project_id": memory.ProjectID,
+		"type":       memory.Type,
+		"title":      memory.Title,
+	}).Info("Creating memory")
 
 	// Add tags
-	for _, tag := range req.Tags {
+	for _, tag := range s.tagNormalizer.NormalizeTags(req.Tags) {
 		memory.AddTag(tag)
 	}
+	s.autoTag(ctx, memory)
+	if err := s.setAppliesTo(ctx, memory, req.AppliesTo); err != nil {
+		return nil, err
+	}
 
 	// Store in database first
 	if err := s.memoryRepo.Store(ctx, memory); err != nil {
 		s.logger.WithError(err).Error("Failed to store memory")
 		return nil, fmt.Errorf("failed to store memory: %w", err)
 	}
+	s.indexForTagging(memory)
 
 	// Generate and store embedding
 	if err := s.generateAndStoreEmbedding(ctx, memory); err != nil {
@@ -78,10 +208,30 @@ func (s *MemoryService) GetMemory(ctx context.Context, id domain.MemoryID) (*dom
 func (s *MemoryService) UpdateMemory(ctx context.Context, memory *domain.Memory) error {
 	s.logger.WithField("memory_id", memory.ID).Info("Updating memory")
 
+	memory.Tags = s.tagNormalizer.NormalizeTags(memory.Tags)
+	memory.AutoTags = s.tagNormalizer.NormalizeTags(memory.AutoTags)
+	memory.SuggestedTags = s.tagNormalizer.NormalizeTags(memory.SuggestedTags)
+	memory.ReconcileTagOrigins()
+
+	// Fields of memories whose type is no longer defined are kept as they are
+	if _, defined := s.memoryTypes.Lookup(memory.ProjectID, memory.Type); defined {
+		if err := s.setFields(memory, memory.Fields); err != nil {
+			return err
+		}
+	}
+	for _, constraint := range memory.AppliesTo {
+		if err := constraint.Validate(); err != nil {
+			return err
+		}
+	}
+	s.redact(memory)
+	memory.LastModifiedBy = s.provenanceFor(ctx, memory.ProjectID, nil)
+
 	// Update in database
 	if err := s.memoryRepo.Update(ctx, memory); err != nil {
 		return fmt.Errorf("failed to update memory: %w", err)
 	}
+	s.indexForTagging(memory)
 
 	// Regenerate embedding if content changed
 	if err := s.generateAndStoreEmbedding(ctx, memory); err != nil {
@@ -91,6 +241,33 @@ func (s *MemoryService) UpdateMemory(ctx context.Context, memory *domain.Memory)
 	return nil
 }
 
+// UpdateMemoryMetadata stores changes that leave the embedded text alone, such as tags
+// and anchors, without embedding the memory again. The vector metadata is refreshed
+// and the update time is left to the caller.
+func (s *MemoryService) UpdateMemoryMetadata(ctx context.Context, memory *domain.Memory) error {
+	memory.LastModifiedBy = s.provenanceFor(ctx, memory.ProjectID, nil)
+	if err := s.memoryRepo.Update(ctx, memory); err != nil {
+		return fmt.Errorf("failed to update memory: %w", err)
+	}
+
+	if !memory.HasEmbedding {
+		return nil
+	}
+	vectors, err := s.vectorStore.GetVectors(ctx, []string{string(memory.ID)})
+	if err != nil {
+		s.logger.WithError(err).WithField("memory_id", memory.ID).Warn("Failed to get embedding for metadata update")
+		return nil
+	}
+	vector, ok := vectors[string(memory.ID)]
+	if !ok {
+		return nil
+	}
+	if err := s.vectorStore.Update(ctx, string(memory.ID), vector, s.vectorMetadata(memory)); err != nil {
+		s.logger.WithError(err).WithField("memory_id", memory.ID).Warn("Failed to update embedding metadata")
+	}
+	return nil
+}
+
 // DeleteMemory deletes a memory entry
 func (s *MemoryService) DeleteMemory(ctx context.Context, id domain.MemoryID) error {
 	s.logger.WithField("memory_id", id).Info("Deleting memory")
@@ -104,6 +281,9 @@ func (s *MemoryService) DeleteMemory(ctx context.Context, id domain.MemoryID) er
 	if err := s.memoryRepo.Delete(ctx, id); err != nil {
 		return fmt.Errorf("failed to delete memory: %w", err)
 	}
+	if s.autoTagger != nil {
+		s.autoTagger.Forget(id)
+	}
 
 	return nil
 }
@@ -116,6 +296,17 @@ func (s *MemoryService) SearchMemories(ctx context.Context, query ports.Semantic
 		"limit":      query.Limit,
 	}).Info("Searching memories")
 
+	if err := validateTimeFilter(query.TimeFilter); err != nil {
+		return nil, err
+	}
+	if query.Filters != nil {
+		if err := validateTimeFilter(query.Filters.TimeFilter); err != nil {
+			return nil, err
+		}
+	}
+	query.Tags = s.tagNormalizer.NormalizeTags(query.Tags)
+	query.Filters = s.normalizeFilterTags(query.Filters)
+
 	// Generate embedding for query
 	queryVector, err := s.embeddingProvider.GenerateEmbedding(ctx, query.Query)
 	if err != nil {
@@ -147,15 +338,35 @@ func (s *MemoryService) SearchMemories(ctx context.Context, query ports.Semantic
 
 	// Build results maintaining search order
 	var results []ports.MemorySearchResult
+	weighted := false
+	projects := make(map[domain.ProjectID]*domain.Project)
 	for _, searchResult := range searchResults {
 		memoryID := domain.MemoryID(searchResult.ID)
 		if memory, exists := memoryMap[memoryID]; exists && s.matchesFilters(memory, query) {
+			similarity := searchResult.Similarity
+			// Shared memories rank below project memories of the same similarity
+			if s.isSharedResult(memory, query) {
+				similarity = domain.Similarity(float64(similarity) * s.scopes.Weight)
+				weighted = true
+			}
+			// So do memories for dependency versions the project no longer uses
+			applicability := s.applicability(ctx, memory, query.ProjectID, projects)
+			if applicability != nil && applicability.Status == domain.ApplicabilityMismatch {
+				similarity = domain.Similarity(float64(similarity) * s.relevance.MismatchWeight)
+				weighted = true
+			}
 			results = append(results, ports.MemorySearchResult{
-				Memory:     memory,
-				Similarity: searchResult.Similarity,
+				Memory:        memory,
+				Similarity:    similarity,
+				Applicability: applicability,
 			})
 		}
 	}
+	if weighted {
+		sort.SliceStable(results, func(i, j int) bool {
+			return results[i].Similarity > results[j].Similarity
+		})
+	}
 
 	s.logger.WithField("result_count", len(results)).Info("Search completed")
 	return results, nil
@@ -201,6 +412,8 @@ func (s *MemoryService) ListMemories(ctx context.Context, req ports.ListMemories
 		"limit":      req.Limit,
 	}).Info("Listing memories")
 
+	req.Tags = s.tagNormalizer.NormalizeTags(req.Tags)
+
 	// Use repository's ListByProject if project filter is specified
 	if req.ProjectID != nil {
 		memories, err := s.memoryRepo.ListByProject(ctx, *req.ProjectID)
@@ -224,6 +437,31 @@ func (s *MemoryService) ListMemories(ctx context.Context, req ports.ListMemories
 		return filtered, nil
 	}
 
+	// Several projects, e.g. of a workspace, are listed together newest first
+	if len(req.ProjectIDs) > 0 {
+		var filtered []*domain.Memory
+		for _, projectID := range req.ProjectIDs {
+			memories, err := s.memoryRepo.ListByProject(ctx, projectID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get memories by project: %w", err)
+			}
+			for _, memory := range memories {
+				if s.matchesListFilters(memory, req) {
+					filtered = append(filtered, memory)
+				}
+			}
+		}
+
+		sort.SliceStable(filtered, func(i, j int) bool {
+			return filtered[i].CreatedAt.After(filtered[j].CreatedAt)
+		})
+		if req.Limit > 0 && len(filtered) > req.Limit {
+			filtered = filtered[:req.Limit]
+		}
+
+		return filtered, nil
+	}
+
 	// For global listing, we would need to implement a GetAll method in repository
 	// For now, return empty list with informative log
 	s.logger.Info("Global memory listing requested but not yet implemented - requires GetAll repository method")
@@ -232,20 +470,55 @@ func (s *MemoryService) ListMemories(ctx context.Context, req ports.ListMemories
 
 // matchesListFilters checks if a memory matches the list filters
 func (s *MemoryService) matchesListFilters(memory *domain.Memory, req ports.ListMemoriesRequest) bool {
+	// Drafts are only listed on request
+	if memory.IsDraft() && !req.IncludeDrafts {
+		return false
+	}
+
 	// Type filter
 	if req.Type != nil && memory.Type != *req.Type {
 		return false
 	}
 
-	// Tags filter
-	if len(req.Tags) > 0 {
-		for _, requiredTag := range req.Tags {
-			if !memory.Tags.Contains(requiredTag) {
-				return false
-			}
+	// Tags filter, including tags below the required ones
+	if !memory.Tags.MatchesAll(req.Tags) {
+		return false
+	}
+
+	if req.Provenance != nil && !req.Provenance.Matches(memory.Provenance) {
+		return false
+	}
+
+	return matchesFields(memory, req.Fields)
+}
+
+// setFields validates type-specific fields against the memory's type and stores them
+// in canonical form. Types without a definition, such as internal documentation
+// types, are accepted but cannot carry fields.
+func (s *MemoryService) setFields(memory *domain.Memory, fields map[string]interface{}) error {
+	definition, ok := s.memoryTypes.Lookup(memory.ProjectID, memory.Type)
+	if !ok {
+		if len(fields) > 0 {
+			return fmt.Errorf("memory type %s does not define fields", memory.Type)
 		}
+		return nil
+	}
+
+	validated, err := definition.ValidateFields(fields)
+	if err != nil {
+		return fmt.Errorf("invalid fields: %w", err)
 	}
+	memory.Fields = validated
+	return nil
+}
 
+// matchesFields checks that every field filter matches the memory's field value
+func matchesFields(memory *domain.Memory, filters map[string]string) bool {
+	for name, value := range filters {
+		if !domain.FieldMatches(memory.Fields[name], value) {
+			return false
+		}
+	}
 	return true
 }
 
@@ -264,16 +537,27 @@ func (s *MemoryService) CreateDecision(ctx context.Context, req ports.CreateDeci
 	if req.SessionID != nil {
 		decision.Memory.SessionID = req.SessionID
 	}
+	decision.Memory.Provenance = s.provenanceFor(ctx, req.ProjectID, req.SessionID)
+	s.applyReviewPolicy(decision.Memory)
+	if err := s.setFields(decision.Memory, req.Fields); err != nil {
+		return nil, err
+	}
+	s.redact(decision.Memory)
 
 	// Add tags
-	for _, tag := range req.Tags {
+	for _, tag := range s.tagNormalizer.NormalizeTags(req.Tags) {
 		decision.Memory.AddTag(tag)
 	}
+	s.autoTag(ctx, decision.Memory)
+	if err := s.setAppliesTo(ctx, decision.Memory, nil); err != nil {
+		return nil, err
+	}
 
 	// Store the underlying memory
 	if err := s.memoryRepo.Store(ctx, decision.Memory); err != nil {
 		return nil, fmt.Errorf("failed to store decision: %w", err)
 	}
+	s.indexForTagging(decision.Memory)
 
 	// Generate embedding
 	if err := s.generateAndStoreEmbedding(ctx, decision.Memory); err != nil {
@@ -297,16 +581,27 @@ func (s *MemoryService) CreatePattern(ctx context.Context, req ports.CreatePatte
 	if req.SessionID != nil {
 		pattern.Memory.SessionID = req.SessionID
 	}
+	pattern.Memory.Provenance = s.provenanceFor(ctx, req.ProjectID, req.SessionID)
+	s.applyReviewPolicy(pattern.Memory)
+	if err := s.setFields(pattern.Memory, req.Fields); err != nil {
+		return nil, err
+	}
+	s.redact(pattern.Memory)
 
 	// Add tags
-	for _, tag := range req.Tags {
+	for _, tag := range s.tagNormalizer.NormalizeTags(req.Tags) {
 		pattern.Memory.AddTag(tag)
 	}
+	s.autoTag(ctx, pattern.Memory)
+	if err := s.setAppliesTo(ctx, pattern.Memory, nil); err != nil {
+		return nil, err
+	}
 
 	// Store the underlying memory
 	if err := s.memoryRepo.Store(ctx, pattern.Memory); err != nil {
 		return nil, fmt.Errorf("failed to store pattern: %w", err)
 	}
+	s.indexForTagging(pattern.Memory)
 
 	// Generate embedding
 	if err := s.generateAndStoreEmbedding(ctx, pattern.Memory); err != nil {
@@ -331,16 +626,27 @@ func (s *MemoryService) CreateErrorSolution(ctx context.Context, req ports.Creat
 	if req.SessionID != nil {
 		errorSolution.Memory.SessionID = req.SessionID
 	}
+	errorSolution.Memory.Provenance = s.provenanceFor(ctx, req.ProjectID, req.SessionID)
+	s.applyReviewPolicy(errorSolution.Memory)
+	if err := s.setFields(errorSolution.Memory, req.Fields); err != nil {
+		return nil, err
+	}
+	s.redact(errorSolution.Memory)
 
 	// Add tags
-	for _, tag := range req.Tags {
+	for _, tag := range s.tagNormalizer.NormalizeTags(req.Tags) {
 		errorSolution.Memory.AddTag(tag)
 	}
+	s.autoTag(ctx, errorSolution.Memory)
+	if err := s.setAppliesTo(ctx, errorSolution.Memory, nil); err != nil {
+		return nil, err
+	}
 
 	// Store the underlying memory
 	if err := s.memoryRepo.Store(ctx, errorSolution.Memory); err != nil {
 		return nil, fmt.Errorf("failed to store error solution: %w", err)
 	}
+	s.indexForTagging(errorSolution.Memory)
 
 	// Generate embedding
 	if err := s.generateAndStoreEmbedding(ctx, errorSolution.Memory); err != nil {
@@ -350,6 +656,83 @@ func (s *MemoryService) CreateErrorSolution(ctx context.Context, req ports.Creat
 	return errorSolution, nil
 }
 
+// provenanceFor returns the provenance of a write to a project: the service default
+// overridden by the context, and the given session or else the project's only active
+// session. With parallel active sessions the write cannot be attributed to one of them
+// and no session is recorded. It returns nil if nothing is known.
+func (s *MemoryService) provenanceFor(ctx context.Context, projectID domain.ProjectID, sessionID *domain.SessionID) *domain.Provenance {
+	provenance := s.provenance.Merge(domain.ProvenanceFromContext(ctx))
+	if sessionID != nil {
+		provenance.SessionID = sessionID
+	} else if provenance.SessionID == nil && s.sessionRepo != nil {
+		status := domain.SessionStatusActive
+		active, err := s.sessionRepo.ListWithFilters(ctx, ports.SessionFilters{ProjectID: &projectID, Status: &status, Limit: 2})
+		if err == nil && len(active) == 1 {
+			activeID := active[0].ID
+			provenance.SessionID = &activeID
+		}
+	}
+	if provenance.IsEmpty() {
+		return nil
+	}
+	return &provenance
+}
+
+// applyReviewPolicy puts a new memory into the review queue if the policy requires it
+func (s *MemoryService) applyReviewPolicy(memory *domain.Memory) {
+	if s.reviewPolicy.RequiresReview(memory) {
+		memory.MarkDraft()
+	}
+}
+
+// redact masks secrets in a memory before it is stored or embedded
+func (s *MemoryService) redact(memory *domain.Memory) {
+	if s.redactor == nil {
+		return
+	}
+	if redactions := s.redactor.RedactMemory(memory); len(redactions) > 0 {
+		s.logger.WithFields(logrus.Fields{
+			"memory_id":  memory.ID,
+			"redactions": redactions,
+		}).Warn("Masked secrets in memory")
+	}
+}
+
+// autoTag runs the auto-tagger on a new memory and applies or records its suggestions.
+// Extraction failures are logged but never prevent the memory from being stored.
+func (s *MemoryService) autoTag(ctx context.Context, memory *domain.Memory) {
+	if s.autoTagger == nil || s.autoTagger.Mode() == domain.AutoTagModeOff {
+		return
+	}
+	// Tasks and sessions are tracked by status, not by topic
+	if memory.Type == domain.MemoryTypeTask || memory.Type == domain.MemoryTypeSession {
+		return
+	}
+
+	suggestions, err := s.autoTagger.Suggest(ctx, memory)
+	if err != nil {
+		s.logger.WithError(err).Warn("Failed to extract tags")
+		return
+	}
+	tags := make([]string, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		tags = append(tags, suggestion.Tag)
+	}
+
+	if s.autoTagger.Mode() == domain.AutoTagModeApply {
+		memory.ApplyAutoTags(tags)
+	} else {
+		memory.SuggestTags(tags)
+	}
+}
+
+// indexForTagging keeps the auto-tagger's document frequencies in step with stored memories
+func (s *MemoryService) indexForTagging(memory *domain.Memory) {
+	if s.autoTagger != nil {
+		s.autoTagger.Index(memory)
+	}
+}
+
 // generateAndStoreEmbedding generates and stores embedding for a memory
 func (s *MemoryService) generateAndStoreEmbedding(ctx context.Context, memory *domain.Memory) error {
 	// Generate embedding
@@ -359,22 +742,8 @@ func (s *MemoryService) generateAndStoreEmbedding(ctx context.Context, memory *d
 		return fmt.Errorf("failed to generate embedding: %w", err)
 	}
 
-	// Prepare metadata
-	metadata := map[string]interface{}{
-		"memory_id":  memory.ID,
-		"project_id": memory.ProjectID,
-		"type":       memory.Type,
-		"title":      memory.Title,
-		"tags":       memory.Tags,
-		"created_at": memory.CreatedAt,
-	}
-
-	if memory.SessionID != nil {
-		metadata["session_id"] = *memory.SessionID
-	}
-
 	// Store in vector store
-	if err := s.vectorStore.Store(ctx, string(memory.ID), vector, metadata); err != nil {
+	if err := s.vectorStore.Store(ctx, string(memory.ID), vector, s.vectorMetadata(memory)); err != nil {
 		return fmt.Errorf("failed to store embedding: %w", err)
 	}
 
@@ -387,10 +756,39 @@ func (s *MemoryService) generateAndStoreEmbedding(ctx context.Context, memory *d
 	return nil
 }
 
+// vectorMetadata returns the metadata stored with a memory's embedding. Search results
+// are loaded from the database, so the title is only informational and left out when
+// the database is encrypted.
+func (s *MemoryService) vectorMetadata(memory *domain.Memory) map[string]interface{} {
+	metadata := map[string]interface{}{
+		"memory_id":  memory.ID,
+		"project_id": memory.ProjectID,
+		"type":       memory.Type,
+		"tags":       memory.Tags,
+		"created_at": memory.CreatedAt,
+	}
+	if !s.encrypted {
+		metadata["title"] = memory.Title
+	}
+
+	if memory.SessionID != nil {
+		metadata["session_id"] = *memory.SessionID
+	}
+	return metadata
+}
+
 // matchesFilters checks if a memory matches the search filters
 func (s *MemoryService) matchesFilters(memory *domain.Memory, query ports.SemanticSearchRequest) bool {
-	// Project filter
-	if query.ProjectID != nil && memory.ProjectID != *query.ProjectID {
+	// Drafts are only returned on request
+	if memory.IsDraft() && !query.IncludeDrafts {
+		return false
+	}
+
+	// Project filter, including the shared scopes searched with the project
+	if query.ProjectID != nil && !s.scopes.Includes(*query.ProjectID, memory.ProjectID) {
+		return false
+	}
+	if query.ProjectID == nil && len(query.ProjectIDs) > 0 && !s.includedInProjects(query.ProjectIDs, memory.ProjectID) {
 		return false
 	}
 
@@ -399,23 +797,44 @@ func (s *MemoryService) matchesFilters(memory *domain.Memory, query ports.Semant
 		return false
 	}
 
-	// Tags filter
-	if len(query.Tags) > 0 {
-		for _, requiredTag := range query.Tags {
-			if !memory.Tags.Contains(requiredTag) {
-				return false
-			}
-		}
+	// Tags filter, including tags below the required ones
+	if !memory.Tags.MatchesAll(query.Tags) {
+		return false
 	}
 
-	// Time filter implementation would go here
-	// if req.CreatedAfter != nil && memory.CreatedAt.Before(*req.CreatedAfter) {
-	//     return false
-	// }
-	// if req.CreatedBefore != nil && memory.CreatedAt.After(*req.CreatedBefore) {
-	//     return false
-	// }
+	// Time filter
+	if !matchesTimeFilter(memory, query.TimeFilter) {
+		return false
+	}
 
+	return matchesSearchFilters(memory, query.Filters)
+}
+
+// includedInProjects reports whether memories of the given project or scope are part
+// of a search over several projects
+func (s *MemoryService) includedInProjects(projectIDs []domain.ProjectID, memoryProjectID domain.ProjectID) bool {
+	for _, projectID := range projectIDs {
+		if s.scopes.Includes(projectID, memoryProjectID) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSharedResult reports whether a search result comes from a shared scope rather
+// than from the searched projects
+func (s *MemoryService) isSharedResult(memory *domain.Memory, query ports.SemanticSearchRequest) bool {
+	if query.ProjectID != nil {
+		return memory.ProjectID != *query.ProjectID
+	}
+	if len(query.ProjectIDs) == 0 {
+		return false
+	}
+	for _, projectID := range query.ProjectIDs {
+		if memory.ProjectID == projectID {
+			return false
+		}
+	}
 	return true
 }
 
@@ -428,12 +847,22 @@ func (s *MemoryService) FacetedSearch(ctx context.Context, req ports.FacetedSear
 		"limit":          req.Limit,
 	}).Info("Performing faceted search")
 
+	if req.Filters != nil {
+		if err := validateTimeFilter(req.Filters.TimeFilter); err != nil {
+			return nil, err
+		}
+	}
+	req.Filters = s.normalizeFilterTags(req.Filters)
+
 	// Convert to basic search request
 	basicQuery := ports.SemanticSearchRequest{
 		Query:     req.Query,
 		ProjectID: req.ProjectID,
 		Limit:     req.Limit * 2, // Get more results for filtering
 		Threshold: req.Threshold,
+
+		ProjectIDs:    req.ProjectIDs,
+		IncludeDrafts: req.IncludeDrafts,
 	}
 
 	// Perform basic search
@@ -475,10 +904,20 @@ func (s *MemoryService) FacetedSearch(ctx context.Context, req ports.FacetedSear
 
 // SearchWithRelevanceScoring performs search with enhanced relevance scoring
 func (s *MemoryService) SearchWithRelevanceScoring(ctx context.Context, query ports.SemanticSearchRequest) ([]ports.EnhancedMemorySearchResult, error) {
-	s.logger.WithField("query", query.Query).Info("Performing enhanced relevance search")
+	s.logger.WithFields(logrus.Fields{
+		"query":     query.Query,
+		"diversify": query.Diversify,
+		"explain":   query.Explain,
+	}).Info("Performing enhanced relevance search")
+
+	// Fetch a larger candidate pool when re-ranking for diversity
+	basicQuery := query
+	if query.Diversify && query.Limit > 0 {
+		basicQuery.Limit = query.Limit * mmrCandidateMultiplier
+	}
 
 	// Perform basic search
-	basicResults, err := s.SearchMemories(ctx, query)
+	basicResults, err := s.SearchMemories(ctx, basicQuery)
 	if err != nil {
 		return nil, err
 	}
@@ -488,12 +927,17 @@ func (s *MemoryService) SearchWithRelevanceScoring(ctx context.Context, query po
 	queryTerms := strings.Fields(strings.ToLower(query.Query))
 
 	for i, result := range basicResults {
+		breakdown := s.calculateScoreBreakdown(result.Memory, queryTerms, float64(result.Similarity))
 		enhancedResults[i] = ports.EnhancedMemorySearchResult{
 			Memory:         result.Memory,
 			Similarity:     result.Similarity,
-			RelevanceScore: s.calculateRelevanceScore(result.Memory, queryTerms, float64(result.Similarity)),
+			RelevanceScore: breakdown.Total(),
 			MatchReasons:   s.getMatchReasons(result.Memory, queryTerms),
 			Highlights:     s.getHighlights(result.Memory, queryTerms),
+			Applicability:  result.Applicability,
+		}
+		if query.Explain {
+			enhancedResults[i].ScoreBreakdown = &breakdown
 		}
 	}
 
@@ -502,9 +946,69 @@ func (s *MemoryService) SearchWithRelevanceScoring(ctx context.Context, query po
 		return enhancedResults[i].RelevanceScore > enhancedResults[j].RelevanceScore
 	})
 
+	// Re-rank for diversity if requested
+	if query.Diversify {
+		enhancedResults = s.diversifyResults(ctx, enhancedResults, query.DiversityLambda)
+	}
+
+	if query.Limit > 0 && len(enhancedResults) > query.Limit {
+		enhancedResults = enhancedResults[:query.Limit]
+	}
+
 	return enhancedResults, nil
 }
 
+// diversifyResults re-ranks results using maximal marginal relevance over the stored vectors
+func (s *MemoryService) diversifyResults(ctx context.Context, results []ports.EnhancedMemorySearchResult, lambda float64) []ports.EnhancedMemorySearchResult {
+	if len(results) < 2 {
+		return results
+	}
+	if lambda <= 0 || lambda > 1 {
+		lambda = ports.DefaultDiversityLambda
+	}
+
+	ids := make([]string, len(results))
+	for i, result := range results {
+		ids[i] = string(result.Memory.ID)
+	}
+
+	vectors, err := s.vectorStore.GetVectors(ctx, ids)
+	if err != nil {
+		s.logger.WithError(err).Warn("Failed to load vectors for diversity re-ranking, keeping relevance order")
+		return results
+	}
+
+	candidates := make([]mmrCandidate, len(results))
+	for i, result := range results {
+		candidates[i] = mmrCandidate{
+			relevance: result.RelevanceScore,
+			vector:    vectors[ids[i]],
+		}
+	}
+
+	selections := rerankMMR(candidates, lambda)
+
+	reranked := make([]ports.EnhancedMemorySearchResult, 0, len(results))
+	for _, sel := range selections {
+		result := results[sel.index]
+		if result.ScoreBreakdown != nil {
+			breakdown := *result.ScoreBreakdown
+			breakdown.DiversityPenalty = sel.penalty
+			breakdown.MMRScore = sel.score
+			result.ScoreBreakdown = &breakdown
+		}
+		reranked = append(reranked, result)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"candidates":   len(results),
+		"with_vectors": len(vectors),
+		"lambda":       lambda,
+	}).Debug("Applied diversity re-ranking")
+
+	return reranked
+}
+
 // GetSearchSuggestions provides search suggestions based on existing content
 func (s *MemoryService) GetSearchSuggestions(ctx context.Context, partialQuery string, projectID *domain.ProjectID) ([]string, error) {
 	s.logger.WithField("partial_query", partialQuery).Info("Getting search suggestions")
@@ -585,77 +1089,157 @@ func (s *MemoryService) applyAdvancedFilters(results []ports.MemorySearchResult,
 	}
 
 	var filtered []ports.MemorySearchResult
-
 	for _, result := range results {
-		memory := result.Memory
-
-		// Type filter
-		if len(filters.Types) > 0 {
-			found := false
-			for _, t := range filters.Types {
-				if memory.Type == t {
-					found = true
-					break
-				}
-			}
-			if !found {
-				continue
-			}
+		if matchesSearchFilters(result.Memory, filters) {
+			filtered = append(filtered, result)
 		}
+	}
 
-		// Tags filter
-		if len(filters.Tags) > 0 {
-			hasAllTags := true
-			for _, requiredTag := range filters.Tags {
-				if !memory.Tags.Contains(requiredTag) {
-					hasAllTags = false
-					break
-				}
-			}
-			if !hasAllTags {
-				continue
-			}
-		}
+	return filtered
+}
+
+// matchesSearchFilters checks if a memory matches the comprehensive search filters
+func matchesSearchFilters(memory *domain.Memory, filters *ports.SearchFilters) bool {
+	if filters == nil {
+		return true
+	}
 
-		// Content length filters
-		contentLen := len(memory.Content)
-		if filters.MinLength != nil && contentLen < *filters.MinLength {
-			continue
+	// Type filter
+	if len(filters.Types) > 0 {
+		found := false
+		for _, t := range filters.Types {
+			if memory.Type == t {
+				found = true
+				break
+			}
 		}
-		if filters.MaxLength != nil && contentLen > *filters.MaxLength {
-			continue
+		if !found {
+			return false
 		}
+	}
 
-		// Has content filter
-		if filters.HasContent && len(strings.TrimSpace(memory.Content)) == 0 {
-			continue
+	// Tags filter; a tag also matches the tags below it in the hierarchy
+	if !memory.Tags.MatchesAll(filters.Tags) {
+		return false
+	}
+	for _, excludedTag := range filters.ExcludeTags {
+		if memory.Tags.Matches(excludedTag) {
+			return false
 		}
+	}
 
-		// Session ID filter
-		if len(filters.SessionIDs) > 0 && memory.SessionID != nil {
-			found := false
-			for _, sessionID := range filters.SessionIDs {
-				if *memory.SessionID == sessionID {
-					found = true
-					break
-				}
+	// Type-specific field filter
+	if !matchesFields(memory, filters.Fields) {
+		return false
+	}
+
+	// Provenance filter
+	if filters.Provenance != nil && !filters.Provenance.Matches(memory.Provenance) {
+		return false
+	}
+
+	// Phrase filter
+	if len(filters.Phrases) > 0 {
+		text := strings.ToLower(memory.Title + "\n" + memory.Content + "\n" + memory.Context)
+		for _, phrase := range filters.Phrases {
+			if !strings.Contains(text, strings.ToLower(phrase)) {
+				return false
 			}
-			if !found {
-				continue
+		}
+	}
+
+	// Content length filters
+	contentLen := len(memory.Content)
+	if filters.MinLength != nil && contentLen < *filters.MinLength {
+		return false
+	}
+	if filters.MaxLength != nil && contentLen > *filters.MaxLength {
+		return false
+	}
+
+	// Has content filter
+	if filters.HasContent && len(strings.TrimSpace(memory.Content)) == 0 {
+		return false
+	}
+
+	// Session ID filter
+	if len(filters.SessionIDs) > 0 && memory.SessionID != nil {
+		found := false
+		for _, sessionID := range filters.SessionIDs {
+			if *memory.SessionID == sessionID {
+				found = true
+				break
 			}
 		}
+		if !found {
+			return false
+		}
+	}
 
-		// Time filter (basic implementation)
-		// TODO: Implement time filtering when TimeFilter is properly defined
-		// if filters.TimeFilter != nil {
-		//     // Time filtering would be implemented here based on memory timestamps
-		//     // For example: filter by CreatedAt, UpdatedAt ranges
-		// }
+	return matchesTimeFilter(memory, filters.TimeFilter)
+}
 
-		filtered = append(filtered, result)
+// normalizeFilterTags returns a copy of filters with normalized tags
+func (s *MemoryService) normalizeFilterTags(filters *ports.SearchFilters) *ports.SearchFilters {
+	if filters == nil || (len(filters.Tags) == 0 && len(filters.ExcludeTags) == 0) {
+		return filters
 	}
 
-	return filtered
+	normalized := *filters
+	normalized.Tags = s.tagNormalizer.NormalizeTags(filters.Tags)
+	normalized.ExcludeTags = s.tagNormalizer.NormalizeTags(filters.ExcludeTags)
+	return &normalized
+}
+
+// matchesTimeFilter checks the memory's creation time against the filter bounds.
+// After is inclusive, Before is exclusive. Bounds are expected to be validated already.
+func matchesTimeFilter(memory *domain.Memory, filter *ports.TimeFilter) bool {
+	return timeInFilter(memory.CreatedAt, filter)
+}
+
+// timeInFilter checks a time against the filter bounds; After is inclusive, Before is
+// exclusive
+func timeInFilter(t time.Time, filter *ports.TimeFilter) bool {
+	if filter == nil {
+		return true
+	}
+	if filter.After != nil {
+		if after, err := parseTimeBound(*filter.After); err == nil && t.Before(after) {
+			return false
+		}
+	}
+	if filter.Before != nil {
+		if before, err := parseTimeBound(*filter.Before); err == nil && !t.Before(before) {
+			return false
+		}
+	}
+	return true
+}
+
+// validateTimeFilter ensures the time filter bounds can be parsed
+func validateTimeFilter(filter *ports.TimeFilter) error {
+	if filter == nil {
+		return nil
+	}
+	if filter.After != nil {
+		if _, err := parseTimeBound(*filter.After); err != nil {
+			return fmt.Errorf("invalid time filter after %q: %w", *filter.After, err)
+		}
+	}
+	if filter.Before != nil {
+		if _, err := parseTimeBound(*filter.Before); err != nil {
+			return fmt.Errorf("invalid time filter before %q: %w", *filter.Before, err)
+		}
+	}
+	return nil
+}
+
+// parseTimeBound parses an ISO 8601 timestamp or date
+func parseTimeBound(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
 }
 
 func (s *MemoryService) sortResults(results []ports.MemorySearchResult, sortBy ports.SortOption) {
@@ -791,7 +1375,12 @@ func (s *MemoryService) generateTimePeriodFacets(results []ports.MemorySearchRes
 }
 
 func (s *MemoryService) calculateRelevanceScore(memory *domain.Memory, queryTerms []string, similarity float64) float64 {
-	score := similarity
+	return s.calculateScoreBreakdown(memory, queryTerms, similarity).Total()
+}
+
+// calculateScoreBreakdown computes the individual components of the relevance score
+func (s *MemoryService) calculateScoreBreakdown(memory *domain.Memory, queryTerms []string, similarity float64) ports.ScoreBreakdown {
+	breakdown := ports.ScoreBreakdown{VectorSimilarity: similarity}
 
 	titleLower := strings.ToLower(memory.Title)
 	contentLower := strings.ToLower(memory.Content)
@@ -803,15 +1392,15 @@ func (s *MemoryService) calculateRelevanceScore(memory *domain.Memory, queryTerm
 			titleMatches++
 		}
 	}
-	score += float64(titleMatches) * 0.3
+	breakdown.TitleBoost = float64(titleMatches) * 0.3
 
 	// Boost for exact phrase matches
 	queryPhrase := strings.Join(queryTerms, " ")
 	if strings.Contains(titleLower, queryPhrase) {
-		score += 0.5
+		breakdown.PhraseBoost += 0.5
 	}
 	if strings.Contains(contentLower, queryPhrase) {
-		score += 0.2
+		breakdown.PhraseBoost += 0.2
 	}
 
 	// Boost for tag matches
@@ -824,14 +1413,32 @@ func (s *MemoryService) calculateRelevanceScore(memory *domain.Memory, queryTerm
 			}
 		}
 	}
-	score += float64(tagMatches) * 0.1
+	breakdown.TagBoost = float64(tagMatches) * 0.1
+
+	// Boost for recently updated or accessed memories, decaying linearly over the recency window
+	lastUsed := memory.UpdatedAt
+	if memory.LastAccessedAt != nil && memory.LastAccessedAt.After(lastUsed) {
+		lastUsed = *memory.LastAccessedAt
+	}
+	if !lastUsed.IsZero() && s.relevance.RecencyWindow > 0 {
+		age := time.Since(lastUsed)
+		if age < s.relevance.RecencyWindow {
+			breakdown.RecencyBoost = s.relevance.RecencyMaxBoost * (1 - float64(age)/float64(s.relevance.RecencyWindow))
+		}
+	}
+
+	// Boost for frequently retrieved memories, growing logarithmically up to saturation
+	if memory.AccessCount > 0 && s.relevance.PopularitySaturation > 0 {
+		popularity := math.Log1p(float64(memory.AccessCount)) / math.Log1p(float64(s.relevance.PopularitySaturation))
+		breakdown.PopularityBoost = s.relevance.PopularityMaxBoost * math.Min(popularity, 1)
+	}
 
 	// Boost for memory type relevance
 	if memory.Type == domain.MemoryTypeDecision {
-		score += 0.1 // Decisions are often important
+		breakdown.TypeBoost = 0.1 // Decisions are often important
 	}
 
-	return score
+	return breakdown
 }
 
 func (s *MemoryService) getMatchReasons(memory *domain.Memory, queryTerms []string) []string {
@@ -980,11 +1587,7 @@ func (s *MemoryService) RegenerateEmbedding(ctx context.Context, memoryID domain
 	}
 
 	// Store in vector store
-	if err := s.vectorStore.Store(ctx, string(memory.ID), embedding, map[string]interface{}{
-		"type":    string(memory.Type),
-		"title":   memory.Title,
-		"content": memory.Content,
-	}); err != nil {
+	if err := s.vectorStore.Store(ctx, string(memory.ID), embedding, s.vectorMetadata(memory)); err != nil {
 		s.logger.WithError(err).Warn("Failed to store embedding in vector store")
 		return fmt.Errorf("failed to store embedding: %w", err)
 	}
@@ -1051,3 +1654,215 @@ func (s *MemoryService) CleanupEmbeddings(ctx context.Context, projectID domain.
 
 	return result, nil
 }
+
+// SetPinned pins or unpins a memory so it is always part of the project's system prompt context
+func (s *MemoryService) SetPinned(ctx context.Context, id domain.MemoryID, pinned bool) (*domain.Memory, error) {
+	s.logger.WithFields(logrus.Fields{
+		"memory_id": id,
+		"pinned":    pinned,
+	}).Info("Setting memory pin state")
+
+	memory, err := s.memoryRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get memory: %w", err)
+	}
+
+	if memory.Pinned == pinned {
+		return memory, nil
+	}
+
+	memory.SetPinned(pinned)
+	if err := s.memoryRepo.Update(ctx, memory); err != nil {
+		return nil, fmt.Errorf("failed to update memory: %w", err)
+	}
+
+	return memory, nil
+}
+
+// ListPinnedMemories lists the pinned memories of a project
+func (s *MemoryService) ListPinnedMemories(ctx context.Context, projectID domain.ProjectID) ([]*domain.Memory, error) {
+	memories, err := s.memoryRepo.ListPinned(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pinned memories: %w", err)
+	}
+
+	// Drafts are not part of the system prompt context until they are approved
+	pinned := memories[:0]
+	for _, memory := range memories {
+		if !memory.IsDraft() {
+			pinned = append(pinned, memory)
+		}
+	}
+	return pinned, nil
+}
+
+// RecordAccess records that the given memories were returned to a user or agent
+func (s *MemoryService) RecordAccess(ctx context.Context, ids []domain.MemoryID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := s.memoryRepo.RecordAccess(ctx, ids, time.Now()); err != nil {
+		return fmt.Errorf("failed to record memory access: %w", err)
+	}
+
+	s.logger.WithField("count", len(ids)).Debug("Recorded memory access")
+	return nil
+}
+
+// ListUnusedMemories lists memories that have not been retrieved for at least unusedFor.
+// Memories that were never retrieved count from their creation time.
+func (s *MemoryService) ListUnusedMemories(ctx context.Context, projectID *domain.ProjectID, unusedFor time.Duration) ([]*domain.Memory, error) {
+	s.logger.WithFields(logrus.Fields{
+		"project_id": projectID,
+		"unused_for": unusedFor,
+	}).Info("Listing unused memories")
+
+	memories, err := s.memoryRepo.ListUnused(ctx, projectID, time.Now().Add(-unusedFor))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unused memories: %w", err)
+	}
+
+	return memories, nil
+}
+
+// ListReviewQueue lists the draft memories waiting for review, oldest first
+func (s *MemoryService) ListReviewQueue(ctx context.Context, projectID *domain.ProjectID) ([]*domain.Memory, error) {
+	memories, err := s.memoryRepo.ListDrafts(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list draft memories: %w", err)
+	}
+	return memories, nil
+}
+
+// ApproveMemory takes a draft out of the review queue so it is used in search and context
+func (s *MemoryService) ApproveMemory(ctx context.Context, id domain.MemoryID) (*domain.Memory, error) {
+	s.logger.WithField("memory_id", id).Info("Approving memory")
+
+	memory, err := s.memoryRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get memory: %w", err)
+	}
+
+	if !memory.IsDraft() {
+		return memory, nil
+	}
+
+	memory.Approve(time.Now())
+	memory.LastModifiedBy = s.provenanceFor(ctx, memory.ProjectID, nil)
+	if err := s.memoryRepo.Update(ctx, memory); err != nil {
+		return nil, fmt.Errorf("failed to update memory: %w", err)
+	}
+
+	return memory, nil
+}
+
+// RejectMemory deletes a draft. Approved memories cannot be rejected.
+func (s *MemoryService) RejectMemory(ctx context.Context, id domain.MemoryID) error {
+	s.logger.WithField("memory_id", id).Info("Rejecting memory")
+
+	memory, err := s.memoryRepo.GetByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get memory: %w", err)
+	}
+
+	if !memory.IsDraft() {
+		return fmt.Errorf("memory %s is not a draft", id)
+	}
+
+	return s.DeleteMemory(ctx, id)
+}
+
+// MergeMemory folds a draft into an existing memory of the same project and deletes
+// the draft. It returns the updated target memory.
+func (s *MemoryService) MergeMemory(ctx context.Context, draftID, targetID domain.MemoryID) (*domain.Memory, error) {
+	s.logger.WithFields(logrus.Fields{
+		"draft_id":  draftID,
+		"target_id": targetID,
+	}).Info("Merging draft memory")
+
+	if draftID == targetID {
+		return nil, fmt.Errorf("cannot merge a memory into itself")
+	}
+
+	draft, err := s.memoryRepo.GetByID(ctx, draftID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get draft memory: %w", err)
+	}
+	if !draft.IsDraft() {
+		return nil, fmt.Errorf("memory %s is not a draft", draftID)
+	}
+
+	target, err := s.memoryRepo.GetByID(ctx, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get target memory: %w", err)
+	}
+	if target.ProjectID != draft.ProjectID {
+		return nil, fmt.Errorf("cannot merge memories of different projects (%s, %s)", draft.ProjectID, target.ProjectID)
+	}
+
+	target.MergeDraft(draft)
+	if err := s.UpdateMemory(ctx, target); err != nil {
+		return nil, err
+	}
+
+	if err := s.DeleteMemory(ctx, draftID); err != nil {
+		return nil, fmt.Errorf("failed to delete merged draft: %w", err)
+	}
+
+	return target, nil
+}
+
+// PromoteMemory moves a project memory into a shared scope, or copies it with
+// req.Copy, so it is found in the searches of every project using the scope.
+// Session and code anchors are dropped as they only make sense in the project.
+func (s *MemoryService) PromoteMemory(ctx context.Context, req ports.PromoteMemoryRequest) (*domain.Memory, error) {
+	s.logger.WithFields(logrus.Fields{
+		"memory_id": req.ID,
+		"scope":     req.Scope,
+		"copy":      req.Copy,
+	}).Info("Promoting memory")
+
+	if !req.Scope.IsScope() {
+		return nil, fmt.Errorf("%s is not a shared scope", req.Scope)
+	}
+
+	memory, err := s.memoryRepo.GetByID(ctx, req.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get memory: %w", err)
+	}
+	if memory.IsDraft() {
+		return nil, fmt.Errorf("memory %s is a draft, review it before promoting", req.ID)
+	}
+	if memory.ProjectID == req.Scope {
+		return nil, fmt.Errorf("memory %s already belongs to %s", req.ID, req.Scope)
+	}
+
+	if !req.Copy {
+		memory.ProjectID = req.Scope
+		memory.SessionID = nil
+		memory.Anchors = nil
+		if err := s.UpdateMemory(ctx, memory); err != nil {
+			return nil, err
+		}
+		return memory, nil
+	}
+
+	promoted := domain.NewMemory(req.Scope, memory.Type, memory.Title, memory.Content, memory.Context)
+	promoted.Tags = append(promoted.Tags, memory.Tags...)
+	promoted.AutoTags = append(promoted.AutoTags, memory.AutoTags...)
+	promoted.Fields = memory.Fields
+	promoted.Redactions = memory.Redactions
+	promoted.Provenance = memory.Provenance
+	promoted.LastModifiedBy = s.provenanceFor(ctx, req.Scope, nil)
+
+	if err := s.memoryRepo.Store(ctx, promoted); err != nil {
+		return nil, fmt.Errorf("failed to store memory: %w", err)
+	}
+	s.indexForTagging(promoted)
+	if err := s.generateAndStoreEmbedding(ctx, promoted); err != nil {
+		s.logger.WithError(err).Warn("Failed to generate embedding, but memory was stored")
+	}
+
+	return promoted, nil
+}
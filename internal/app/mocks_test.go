@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/joern1811/memory-bank/internal/domain"
 	"github.com/joern1811/memory-bank/internal/ports"
@@ -159,15 +160,7 @@ func (m *MockMemoryRepository) ListByTags(ctx context.Context, projectID domain.
 	var results []*domain.Memory
 	for _, memory := range m.memories {
 		if memory.ProjectID == projectID {
-			// Check if memory contains all required tags
-			hasAllTags := true
-			for _, requiredTag := range tags {
-				if !memory.Tags.Contains(requiredTag) {
-					hasAllTags = false
-					break
-				}
-			}
-			if hasAllTags {
+			if memory.Tags.MatchesAll(tags) {
 				results = append(results, memory)
 			}
 		}
@@ -181,6 +174,120 @@ func (m *MockMemoryRepository) ListByTags(ctx context.Context, projectID domain.
 	return results, nil
 }
 
+func (m *MockMemoryRepository) ListPinned(ctx context.Context, projectID domain.ProjectID) ([]*domain.Memory, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var results []*domain.Memory
+	for _, memory := range m.memories {
+		if memory.ProjectID == projectID && memory.Pinned {
+			results = append(results, memory)
+		}
+	}
+
+	// Sort by updated time (newest first)
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].UpdatedAt.After(results[j].UpdatedAt)
+	})
+
+	return results, nil
+}
+
+func (m *MockMemoryRepository) ListAnchored(ctx context.Context, projectID *domain.ProjectID) ([]*domain.Memory, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var results []*domain.Memory
+	for _, memory := range m.memories {
+		if len(memory.Anchors) > 0 && (projectID == nil || memory.ProjectID == *projectID) {
+			results = append(results, memory)
+		}
+	}
+
+	// Sort by updated time (newest first)
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].UpdatedAt.After(results[j].UpdatedAt)
+	})
+
+	return results, nil
+}
+
+func (m *MockMemoryRepository) ListAll(ctx context.Context, projectID *domain.ProjectID) ([]*domain.Memory, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var results []*domain.Memory
+	for _, memory := range m.memories {
+		if projectID == nil || memory.ProjectID == *projectID {
+			results = append(results, memory)
+		}
+	}
+
+	// Sort by created time (newest first)
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].CreatedAt.After(results[j].CreatedAt)
+	})
+
+	return results, nil
+}
+
+func (m *MockMemoryRepository) ListTagged(ctx context.Context, projectID *domain.ProjectID) ([]*domain.Memory, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var results []*domain.Memory
+	for _, memory := range m.memories {
+		if len(memory.Tags) > 0 && (projectID == nil || memory.ProjectID == *projectID) {
+			results = append(results, memory)
+		}
+	}
+
+	// Sort by created time (newest first)
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].CreatedAt.After(results[j].CreatedAt)
+	})
+
+	return results, nil
+}
+
+func (m *MockMemoryRepository) ListPendingTagReview(ctx context.Context, projectID *domain.ProjectID) ([]*domain.Memory, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var results []*domain.Memory
+	for _, memory := range m.memories {
+		if len(memory.PendingTags()) > 0 && (projectID == nil || memory.ProjectID == *projectID) {
+			results = append(results, memory)
+		}
+	}
+
+	// Sort by created time (newest first)
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].CreatedAt.After(results[j].CreatedAt)
+	})
+
+	return results, nil
+}
+
+func (m *MockMemoryRepository) ListDrafts(ctx context.Context, projectID *domain.ProjectID) ([]*domain.Memory, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var results []*domain.Memory
+	for _, memory := range m.memories {
+		if memory.IsDraft() && (projectID == nil || memory.ProjectID == *projectID) {
+			results = append(results, memory)
+		}
+	}
+
+	// Sort by created time (oldest first)
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].CreatedAt.Before(results[j].CreatedAt)
+	})
+
+	return results, nil
+}
+
 func (m *MockMemoryRepository) ListBySession(ctx context.Context, sessionID domain.SessionID) ([]*domain.Memory, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -213,6 +320,41 @@ func (m *MockMemoryRepository) ResetEmbeddingFlags(ctx context.Context, projectI
 	return nil
 }
 
+func (m *MockMemoryRepository) RecordAccess(ctx context.Context, ids []domain.MemoryID, accessedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, id := range ids {
+		if memory, exists := m.memories[id]; exists {
+			memory.RecordAccess(accessedAt)
+		}
+	}
+
+	return nil
+}
+
+func (m *MockMemoryRepository) ListUnused(ctx context.Context, projectID *domain.ProjectID, since time.Time) ([]*domain.Memory, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var results []*domain.Memory
+	for _, memory := range m.memories {
+		if projectID != nil && memory.ProjectID != *projectID {
+			continue
+		}
+		if memory.LastUsedAt().Before(since) {
+			results = append(results, memory)
+		}
+	}
+
+	// Sort by last use (oldest first)
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].LastUsedAt().Before(results[j].LastUsedAt())
+	})
+
+	return results, nil
+}
+
 // MockEmbeddingProvider is a mock implementation of EmbeddingProvider
 type MockEmbeddingProvider struct {
 	mu               sync.RWMutex
@@ -403,6 +545,10 @@ func (m *MockVectorStore) BatchDelete(ctx context.Context, ids []string) error {
 }
 
 func (m *MockVectorStore) Search(ctx context.Context, vector domain.EmbeddingVector, limit int, threshold float32) ([]ports.SearchResult, error) {
+	return m.SearchWhere(ctx, vector, limit, threshold, nil)
+}
+
+func (m *MockVectorStore) SearchWhere(ctx context.Context, vector domain.EmbeddingVector, limit int, threshold float32, where map[string][]string) ([]ports.SearchResult, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
@@ -418,6 +564,9 @@ func (m *MockVectorStore) Search(ctx context.Context, vector domain.EmbeddingVec
 
 	var results []result
 	for id, entry := range m.vectors {
+		if !matchesWhere(entry.Metadata, where) {
+			continue
+		}
 		// Calculate dot product similarity
 		similarity := calculateDotProduct(vector, entry.Vector)
 		if float32(similarity) >= threshold {
@@ -452,10 +601,45 @@ func (m *MockVectorStore) Search(ctx context.Context, vector domain.EmbeddingVec
 	return searchResults, nil
 }
 
+// matchesWhere reports whether metadata matches a SearchWhere filter
+func matchesWhere(metadata map[string]interface{}, where map[string][]string) bool {
+	for key, values := range where {
+		if len(values) == 0 {
+			continue
+		}
+		value := fmt.Sprint(metadata[key])
+		matched := false
+		for _, allowed := range values {
+			matched = matched || value == allowed
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
 func (m *MockVectorStore) SearchByText(ctx context.Context, text string, limit int, threshold float32) ([]ports.SearchResult, error) {
 	return nil, fmt.Errorf("SearchByText not implemented in mock")
 }
 
+func (m *MockVectorStore) GetVectors(ctx context.Context, ids []string) (map[string]domain.EmbeddingVector, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if err, exists := m.failOn["get_vectors"]; exists {
+		return nil, err
+	}
+
+	vectors := make(map[string]domain.EmbeddingVector, len(ids))
+	for _, id := range ids {
+		if entry, exists := m.vectors[id]; exists {
+			vectors[id] = entry.Vector
+		}
+	}
+	return vectors, nil
+}
+
 func (m *MockVectorStore) CreateCollection(ctx context.Context, name string) error {
 	if err, exists := m.failOn["create_collection"]; exists {
 		return err
@@ -606,6 +790,109 @@ func (m *MockProjectRepository) List(ctx context.Context) ([]*domain.Project, er
 	return projects, nil
 }
 
+func (m *MockProjectRepository) ListByWorkspace(ctx context.Context, workspaceID domain.WorkspaceID) ([]*domain.Project, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var projects []*domain.Project
+	for _, project := range m.projects {
+		if project.InWorkspace(workspaceID) {
+			projects = append(projects, project)
+		}
+	}
+
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].Name < projects[j].Name
+	})
+
+	return projects, nil
+}
+
+// MockWorkspaceRepository is a mock implementation of WorkspaceRepository
+type MockWorkspaceRepository struct {
+	mu         sync.RWMutex
+	workspaces map[domain.WorkspaceID]*domain.Workspace
+}
+
+func NewMockWorkspaceRepository() *MockWorkspaceRepository {
+	return &MockWorkspaceRepository{
+		workspaces: make(map[domain.WorkspaceID]*domain.Workspace),
+	}
+}
+
+func (m *MockWorkspaceRepository) Store(ctx context.Context, workspace *domain.Workspace) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, existing := range m.workspaces {
+		if existing.ID == workspace.ID || existing.Name == workspace.Name {
+			return fmt.Errorf("workspace %s already exists", workspace.Name)
+		}
+	}
+
+	m.workspaces[workspace.ID] = workspace
+	return nil
+}
+
+func (m *MockWorkspaceRepository) GetByID(ctx context.Context, id domain.WorkspaceID) (*domain.Workspace, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	workspace, exists := m.workspaces[id]
+	if !exists {
+		return nil, fmt.Errorf("workspace not found: %s", id)
+	}
+	return workspace, nil
+}
+
+func (m *MockWorkspaceRepository) GetByName(ctx context.Context, name string) (*domain.Workspace, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, workspace := range m.workspaces {
+		if workspace.Name == name {
+			return workspace, nil
+		}
+	}
+	return nil, fmt.Errorf("workspace not found: %s", name)
+}
+
+func (m *MockWorkspaceRepository) Update(ctx context.Context, workspace *domain.Workspace) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.workspaces[workspace.ID]; !exists {
+		return fmt.Errorf("workspace not found: %s", workspace.ID)
+	}
+	m.workspaces[workspace.ID] = workspace
+	return nil
+}
+
+func (m *MockWorkspaceRepository) Delete(ctx context.Context, id domain.WorkspaceID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.workspaces[id]; !exists {
+		return fmt.Errorf("workspace not found: %s", id)
+	}
+	delete(m.workspaces, id)
+	return nil
+}
+
+func (m *MockWorkspaceRepository) List(ctx context.Context) ([]*domain.Workspace, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var workspaces []*domain.Workspace
+	for _, workspace := range m.workspaces {
+		workspaces = append(workspaces, workspace)
+	}
+	sort.Slice(workspaces, func(i, j int) bool {
+		return workspaces[i].Name < workspaces[j].Name
+	})
+	return workspaces, nil
+}
+
 // MockSessionRepository is a mock implementation of SessionRepository
 type MockSessionRepository struct {
 	mu           sync.RWMutex
@@ -0,0 +1,503 @@
+package app
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// maxDetectionFiles bounds the number of files examined for language shares
+const maxDetectionFiles = 20000
+
+// minLanguageShare is the smallest share of the source code reported as a language
+const minLanguageShare = 0.01
+
+// projectDetection is the result of analyzing a project directory
+type projectDetection struct {
+	Language     string
+	Framework    string
+	Languages    []domain.LanguageShare
+	Dependencies []domain.Dependency
+}
+
+// sourceExtensions maps source file extensions to languages
+var sourceExtensions = map[string]string{
+	".go":    "go",
+	".js":    "javascript",
+	".jsx":   "javascript",
+	".mjs":   "javascript",
+	".cjs":   "javascript",
+	".ts":    "typescript",
+	".tsx":   "typescript",
+	".py":    "python",
+	".java":  "java",
+	".kt":    "kotlin",
+	".kts":   "kotlin",
+	".rs":    "rust",
+	".rb":    "ruby",
+	".php":   "php",
+	".c":     "c",
+	".h":     "c",
+	".cc":    "cpp",
+	".cpp":   "cpp",
+	".cxx":   "cpp",
+	".hpp":   "cpp",
+	".cs":    "csharp",
+	".swift": "swift",
+	".scala": "scala",
+	".sh":    "shell",
+}
+
+// skippedDirs are directories holding dependencies or build output rather than sources
+var skippedDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"target":       true,
+	"dist":         true,
+	"build":        true,
+	"venv":         true,
+	"__pycache__":  true,
+}
+
+// manifestLanguages maps manifests to the language they imply, in order of precedence,
+// for projects without recognizable source files
+var manifestLanguages = []struct {
+	file     string
+	language string
+}{
+	{"go.mod", "go"},
+	{"Cargo.toml", "rust"},
+	{"pom.xml", "java"},
+	{"build.gradle", "java"},
+	{"pyproject.toml", "python"},
+	{"requirements.txt", "python"},
+	{"setup.py", "python"},
+	{"package.json", "javascript"},
+	{"Gemfile", "ruby"},
+	{"composer.json", "php"},
+}
+
+// languageEcosystems maps languages to the package ecosystem of their dependencies
+var languageEcosystems = map[string]string{
+	"go":         "go",
+	"javascript": "npm",
+	"typescript": "npm",
+	"python":     "pypi",
+	"rust":       "cargo",
+	"java":       "maven",
+	"kotlin":     "maven",
+}
+
+// frameworkRules recognize frameworks by dependency, in order of precedence within an
+// ecosystem: meta-frameworks before the libraries they build on
+var frameworkRules = []struct {
+	ecosystem string
+	prefix    string
+	framework string
+}{
+	{"go", "github.com/gin-gonic/gin", "gin"},
+	{"go", "github.com/labstack/echo", "echo"},
+	{"go", "github.com/gofiber/fiber", "fiber"},
+	{"go", "github.com/go-chi/chi", "chi"},
+	{"go", "github.com/gorilla/mux", "gorilla-mux"},
+	{"go", "github.com/spf13/cobra", "cobra"},
+	{"npm", "next", "nextjs"},
+	{"npm", "nuxt", "nuxt"},
+	{"npm", "@angular/core", "angular"},
+	{"npm", "@sveltejs/kit", "sveltekit"},
+	{"npm", "svelte", "svelte"},
+	{"npm", "@nestjs/core", "nestjs"},
+	{"npm", "vue", "vue"},
+	{"npm", "react", "react"},
+	{"npm", "express", "express"},
+	{"npm", "fastify", "fastify"},
+	{"pypi", "django", "django"},
+	{"pypi", "fastapi", "fastapi"},
+	{"pypi", "flask", "flask"},
+	{"cargo", "actix-web", "actix-web"},
+	{"cargo", "axum", "axum"},
+	{"cargo", "rocket", "rocket"},
+	{"maven", "org.springframework.boot", "spring-boot"},
+	{"maven", "io.quarkus", "quarkus"},
+	{"maven", "io.micronaut", "micronaut"},
+}
+
+// detectProject detects the languages, framework and dependencies of a project from
+// its source files and manifests
+func (s *ProjectService) detectProject(root string) projectDetection {
+	detection := projectDetection{
+		Languages:    s.detectLanguageShares(root),
+		Dependencies: s.detectDependencies(root),
+	}
+
+	detection.Language = "unknown"
+	if len(detection.Languages) > 0 {
+		detection.Language = detection.Languages[0].Language
+	} else {
+		for _, manifest := range manifestLanguages {
+			if s.fileExists(filepath.Join(root, manifest.file)) {
+				detection.Language = manifest.language
+				break
+			}
+		}
+	}
+
+	detection.Framework = detectFramework(detection.Dependencies, languageEcosystems[detection.Language])
+	return detection
+}
+
+// detectLanguageShares measures the share of each language in the source bytes below
+// root, skipping hidden, dependency and build directories
+func (s *ProjectService) detectLanguageShares(root string) []domain.LanguageShare {
+	bytesByLanguage := make(map[string]int64)
+	var total int64
+	files := 0
+
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			name := entry.Name()
+			if path != root && (strings.HasPrefix(name, ".") || skippedDirs[name]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		files++
+		if files > maxDetectionFiles {
+			return filepath.SkipAll
+		}
+		language, ok := sourceExtensions[strings.ToLower(filepath.Ext(path))]
+		if !ok || !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil
+		}
+		bytesByLanguage[language] += info.Size()
+		total += info.Size()
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		s.logger.WithError(err).WithField("path", root).Warn("Failed to scan project sources")
+	}
+	if total == 0 {
+		return nil
+	}
+
+	var shares []domain.LanguageShare
+	for language, size := range bytesByLanguage {
+		proportion := float64(size) / float64(total)
+		if proportion < minLanguageShare {
+			continue
+		}
+		shares = append(shares, domain.LanguageShare{
+			Language:   language,
+			Proportion: math.Round(proportion*1000) / 1000,
+		})
+	}
+	sort.Slice(shares, func(i, j int) bool {
+		if shares[i].Proportion != shares[j].Proportion {
+			return shares[i].Proportion > shares[j].Proportion
+		}
+		return shares[i].Language < shares[j].Language
+	})
+	return shares
+}
+
+// detectDependencies collects the direct dependencies declared in the manifests in root
+func (s *ProjectService) detectDependencies(root string) []domain.Dependency {
+	parsers := []struct {
+		file  string
+		parse func([]byte) ([]domain.Dependency, error)
+	}{
+		{"go.mod", parseGoMod},
+		{"package.json", parsePackageJSON},
+		{"pyproject.toml", parsePyProject},
+		{"requirements.txt", parseRequirements},
+		{"Cargo.toml", parseCargoToml},
+		{"pom.xml", parsePomXML},
+	}
+
+	var dependencies []domain.Dependency
+	seen := make(map[string]bool)
+	for _, parser := range parsers {
+		data, err := os.ReadFile(filepath.Join(root, parser.file))
+		if err != nil {
+			continue
+		}
+		parsed, err := parser.parse(data)
+		if err != nil {
+			s.logger.WithError(err).WithField("manifest", parser.file).Warn("Failed to parse manifest")
+			continue
+		}
+		for _, dependency := range parsed {
+			key := dependency.Ecosystem + ":" + dependency.Name
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			dependencies = append(dependencies, dependency)
+		}
+	}
+	return dependencies
+}
+
+// detectFramework returns the framework of the first matching rule, preferring rules
+// of the primary language's ecosystem
+func detectFramework(dependencies []domain.Dependency, ecosystem string) string {
+	fallback := ""
+	for _, rule := range frameworkRules {
+		for _, dependency := range dependencies {
+			if dependency.Ecosystem != rule.ecosystem || !matchesDependency(dependency.Name, rule.prefix, rule.ecosystem) {
+				continue
+			}
+			if rule.ecosystem == ecosystem {
+				return rule.framework
+			}
+			if fallback == "" {
+				fallback = rule.framework
+			}
+		}
+	}
+	return fallback
+}
+
+// matchesDependency reports whether a dependency is the package of a framework rule.
+// Go modules match major version suffixes, Maven artifacts their group.
+func matchesDependency(name, prefix, ecosystem string) bool {
+	switch ecosystem {
+	case "go":
+		return name == prefix || strings.HasPrefix(name, prefix+"/")
+	case "maven":
+		return strings.HasPrefix(name, prefix+":") || strings.HasPrefix(name, prefix+".")
+	default:
+		return name == prefix
+	}
+}
+
+// parseGoMod parses the direct requirements of a go.mod file. The Go version is
+// recorded as the dependency "go".
+func parseGoMod(data []byte) ([]domain.Dependency, error) {
+	var dependencies []domain.Dependency
+	inBlock := false
+
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		indirect := strings.Contains(line, "// indirect")
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+
+		switch {
+		case line == "require (":
+			inBlock = true
+			continue
+		case inBlock && line == ")":
+			inBlock = false
+			continue
+		case !inBlock && strings.HasPrefix(line, "go "):
+			dependencies = append(dependencies, domain.Dependency{Name: "go", Version: strings.TrimSpace(line[3:]), Ecosystem: "go"})
+			continue
+		case strings.HasPrefix(line, "require "):
+			line = strings.TrimSpace(strings.TrimPrefix(line, "require"))
+		case !inBlock:
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 || indirect {
+			continue
+		}
+		dependencies = append(dependencies, domain.Dependency{Name: fields[0], Version: fields[1], Ecosystem: "go"})
+	}
+	return dependencies, scanner.Err()
+}
+
+// parsePackageJSON parses the dependencies and development dependencies of package.json
+func parsePackageJSON(data []byte) ([]domain.Dependency, error) {
+	var manifest struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, err
+	}
+
+	dependencies := dependenciesFromMap(manifest.Dependencies, "npm", false)
+	return append(dependencies, dependenciesFromMap(manifest.DevDependencies, "npm", true)...), nil
+}
+
+// parsePyProject parses PEP 621 and Poetry dependencies of pyproject.toml
+func parsePyProject(data []byte) ([]domain.Dependency, error) {
+	var manifest struct {
+		Project struct {
+			Dependencies []string `toml:"dependencies"`
+		} `toml:"project"`
+		Tool struct {
+			Poetry struct {
+				Dependencies map[string]interface{} `toml:"dependencies"`
+			} `toml:"poetry"`
+		} `toml:"tool"`
+	}
+	if err := toml.Unmarshal(data, &manifest); err != nil {
+		return nil, err
+	}
+
+	var dependencies []domain.Dependency
+	for _, requirement := range manifest.Project.Dependencies {
+		if dependency, ok := parseRequirement(requirement); ok {
+			dependencies = append(dependencies, dependency)
+		}
+	}
+	delete(manifest.Tool.Poetry.Dependencies, "python")
+	versions := make(map[string]string, len(manifest.Tool.Poetry.Dependencies))
+	for name, value := range manifest.Tool.Poetry.Dependencies {
+		versions[strings.ToLower(name)] = tomlVersion(value)
+	}
+	return append(dependencies, dependenciesFromMap(versions, "pypi", false)...), nil
+}
+
+// parseRequirements parses a pip requirements file
+func parseRequirements(data []byte) ([]domain.Dependency, error) {
+	var dependencies []domain.Dependency
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		if dependency, ok := parseRequirement(scanner.Text()); ok {
+			dependencies = append(dependencies, dependency)
+		}
+	}
+	return dependencies, scanner.Err()
+}
+
+// parseRequirement parses a PEP 508 requirement such as "django>=4.2; python_version>'3.8'"
+func parseRequirement(line string) (domain.Dependency, bool) {
+	if i := strings.Index(line, "#"); i >= 0 {
+		line = line[:i]
+	}
+	if i := strings.Index(line, ";"); i >= 0 {
+		line = line[:i]
+	}
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "-") {
+		return domain.Dependency{}, false
+	}
+
+	end := strings.IndexAny(line, "=<>!~[ @(")
+	if end < 0 {
+		end = len(line)
+	}
+	name := strings.ToLower(strings.TrimSpace(line[:end]))
+	if name == "" {
+		return domain.Dependency{}, false
+	}
+
+	version := line[end:]
+	if i := strings.Index(version, "]"); i >= 0 {
+		version = version[i+1:]
+	}
+	version = strings.Trim(strings.TrimSpace(version), "()")
+	version = strings.TrimPrefix(version, "==")
+	if strings.HasPrefix(version, "@") {
+		version = "" // direct URL reference
+	}
+	return domain.Dependency{Name: name, Version: strings.TrimSpace(version), Ecosystem: "pypi"}, true
+}
+
+// parseCargoToml parses the dependencies and development dependencies of Cargo.toml
+func parseCargoToml(data []byte) ([]domain.Dependency, error) {
+	var manifest struct {
+		Dependencies    map[string]interface{} `toml:"dependencies"`
+		DevDependencies map[string]interface{} `toml:"dev-dependencies"`
+	}
+	if err := toml.Unmarshal(data, &manifest); err != nil {
+		return nil, err
+	}
+
+	versions := func(entries map[string]interface{}) map[string]string {
+		result := make(map[string]string, len(entries))
+		for name, value := range entries {
+			result[name] = tomlVersion(value)
+		}
+		return result
+	}
+	dependencies := dependenciesFromMap(versions(manifest.Dependencies), "cargo", false)
+	return append(dependencies, dependenciesFromMap(versions(manifest.DevDependencies), "cargo", true)...), nil
+}
+
+// parsePomXML parses the parent and the dependencies of a Maven pom.xml as
+// groupId:artifactId; test-scoped dependencies are development dependencies
+func parsePomXML(data []byte) ([]domain.Dependency, error) {
+	type artifact struct {
+		GroupID    string `xml:"groupId"`
+		ArtifactID string `xml:"artifactId"`
+		Version    string `xml:"version"`
+		Scope      string `xml:"scope"`
+	}
+	var manifest struct {
+		Parent       artifact   `xml:"parent"`
+		Dependencies []artifact `xml:"dependencies>dependency"`
+	}
+	if err := xml.Unmarshal(data, &manifest); err != nil {
+		return nil, err
+	}
+
+	var dependencies []domain.Dependency
+	for _, a := range append([]artifact{manifest.Parent}, manifest.Dependencies...) {
+		if a.GroupID == "" || a.ArtifactID == "" {
+			continue
+		}
+		dependencies = append(dependencies, domain.Dependency{
+			Name:      a.GroupID + ":" + a.ArtifactID,
+			Version:   strings.TrimSpace(a.Version),
+			Ecosystem: "maven",
+			Dev:       a.Scope == "test",
+		})
+	}
+	return dependencies, nil
+}
+
+// tomlVersion returns the version of a TOML dependency given as "1.0" or {version = "1.0"}
+func tomlVersion(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		if version, ok := v["version"].(string); ok {
+			return version
+		}
+	}
+	return ""
+}
+
+// dependenciesFromMap converts a name to version map into dependencies sorted by name
+func dependenciesFromMap(versions map[string]string, ecosystem string, dev bool) []domain.Dependency {
+	names := make([]string, 0, len(versions))
+	for name := range versions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	dependencies := make([]domain.Dependency, len(names))
+	for i, name := range names {
+		dependencies[i] = domain.Dependency{Name: name, Version: versions[name], Ecosystem: ecosystem, Dev: dev}
+	}
+	return dependencies
+}
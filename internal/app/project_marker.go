@@ -0,0 +1,54 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"gopkg.in/yaml.v3"
+)
+
+const projectMarkerHeader = `# Written by memory-bank. Identifies this directory as a memory-bank project,
+# so the project is found again after the repository is moved or cloned elsewhere.
+`
+
+// projectMarkerPath returns the path of the marker file in a project root
+func projectMarkerPath(root string) string {
+	return filepath.Join(root, domain.ProjectMarkerDir, domain.ProjectMarkerFile)
+}
+
+// readProjectMarker reads the marker file in a directory. The error wraps
+// fs.ErrNotExist if there is none.
+func readProjectMarker(root string) (*domain.ProjectMarker, error) {
+	data, err := os.ReadFile(projectMarkerPath(root))
+	if err != nil {
+		return nil, err
+	}
+
+	var marker domain.ProjectMarker
+	if err := yaml.Unmarshal(data, &marker); err != nil {
+		return nil, fmt.Errorf("failed to parse project marker: %w", err)
+	}
+	if marker.ProjectID == "" {
+		return nil, fmt.Errorf("project marker %s has no project_id", projectMarkerPath(root))
+	}
+	return &marker, nil
+}
+
+// writeProjectMarker writes the marker file of a project into its root directory,
+// which has to exist
+func writeProjectMarker(project *domain.Project) error {
+	if err := os.MkdirAll(filepath.Join(project.Path, domain.ProjectMarkerDir), 0755); err != nil {
+		return fmt.Errorf("failed to create marker directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(domain.ProjectMarker{ProjectID: project.ID, Name: project.Name})
+	if err != nil {
+		return fmt.Errorf("failed to encode project marker: %w", err)
+	}
+	if err := os.WriteFile(projectMarkerPath(project.Path), append([]byte(projectMarkerHeader), data...), 0644); err != nil {
+		return fmt.Errorf("failed to write project marker: %w", err)
+	}
+	return nil
+}
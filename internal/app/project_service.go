@@ -2,7 +2,9 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"time"
@@ -54,6 +56,7 @@ func (s *ProjectService) CreateProject(ctx context.Context, req ports.CreateProj
 	}
 
 	s.logger.WithField("project_id", project.ID).Info("Project created successfully")
+	s.ensureProjectMarker(project)
 	return project, nil
 }
 
@@ -62,7 +65,8 @@ func (s *ProjectService) GetProject(ctx context.Context, id domain.ProjectID) (*
 	return s.projectRepo.GetByID(ctx, id)
 }
 
-// GetByPath retrieves a project by path
+// GetByPath retrieves the project containing a path. It walks up from the path to the
+// nearest directory that is a registered project root or holds a project marker.
 func (s *ProjectService) GetByPath(ctx context.Context, path string) (*domain.Project, error) {
 	// Normalize path
 	absPath, err := filepath.Abs(path)
@@ -70,7 +74,19 @@ func (s *ProjectService) GetByPath(ctx context.Context, path string) (*domain.Pr
 		return nil, fmt.Errorf("failed to get absolute path: %w", err)
 	}
 
-	return s.projectRepo.GetByPath(ctx, absPath)
+	for dir := absPath; ; dir = filepath.Dir(dir) {
+		if project, err := s.projectRepo.GetByPath(ctx, dir); err == nil && project != nil {
+			return project, nil
+		}
+		if project := s.projectFromMarker(ctx, dir); project != nil {
+			return project, nil
+		}
+		if filepath.Dir(dir) == dir {
+			break
+		}
+	}
+
+	return nil, fmt.Errorf("no project found at or above path: %s", absPath)
 }
 
 // GetProjectByPath retrieves a project by path (deprecated alias)
@@ -92,6 +108,68 @@ func (s *ProjectService) UpdateProject(ctx context.Context, project *domain.Proj
 	return nil
 }
 
+// RelocateProject moves a project to a new root directory, e.g. after the repository
+// was moved, and writes the project marker there
+func (s *ProjectService) RelocateProject(ctx context.Context, id domain.ProjectID, path string) (*domain.Project, error) {
+	s.logger.WithFields(logrus.Fields{
+		"project_id": id,
+		"path":       path,
+	}).Info("Relocating project")
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get absolute path: %w", err)
+	}
+	if !s.dirExists(absPath) {
+		return nil, fmt.Errorf("directory does not exist: %s", absPath)
+	}
+
+	project, err := s.projectRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	if existing, err := s.projectRepo.GetByPath(ctx, absPath); err == nil && existing != nil && existing.ID != id {
+		return nil, fmt.Errorf("project %s is already registered at path: %s", existing.ID, absPath)
+	}
+	if marker, err := readProjectMarker(absPath); err == nil && marker.ProjectID != id {
+		return nil, fmt.Errorf("directory %s is marked as project %s", absPath, marker.ProjectID)
+	}
+
+	project.Path = absPath
+	if err := s.UpdateProject(ctx, project); err != nil {
+		return nil, err
+	}
+	s.ensureProjectMarker(project)
+
+	s.logger.WithField("project_id", id).Info("Project relocated successfully")
+	return project, nil
+}
+
+// RedetectProject detects the languages, framework and dependencies of a project again
+// from its current source files and manifests
+func (s *ProjectService) RedetectProject(ctx context.Context, id domain.ProjectID) (*domain.Project, error) {
+	s.logger.WithField("project_id", id).Info("Re-detecting project")
+
+	project, err := s.projectRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	if !s.dirExists(project.Path) {
+		return nil, fmt.Errorf("project path does not exist: %s", project.Path)
+	}
+
+	detection := s.detectProject(project.Path)
+	project.Language = detection.Language
+	project.Framework = detection.Framework
+	project.Languages = detection.Languages
+	project.Dependencies = detection.Dependencies
+
+	if err := s.UpdateProject(ctx, project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
 // DeleteProject deletes a project
 func (s *ProjectService) DeleteProject(ctx context.Context, id domain.ProjectID) error {
 	s.logger.WithField("project_id", id).Info("Deleting project")
@@ -121,10 +199,14 @@ func (s *ProjectService) InitializeProject(ctx context.Context, path string, req
 		return nil, fmt.Errorf("failed to get absolute path: %w", err)
 	}
 
-	// Check if project already exists
+	// Check if project already exists, registered at the path or named by its marker
 	existing, err := s.projectRepo.GetByPath(ctx, absPath)
-	if err == nil && existing != nil {
+	if err != nil || existing == nil {
+		existing = s.projectFromMarker(ctx, absPath)
+	}
+	if existing != nil {
 		s.logger.WithField("project_id", existing.ID).Info("Project already exists, returning existing")
+		s.ensureProjectMarker(existing)
 		return existing, nil
 	}
 
@@ -133,18 +215,20 @@ func (s *ProjectService) InitializeProject(ctx context.Context, path string, req
 		req.Name = filepath.Base(absPath)
 	}
 
+	detection := s.detectProject(absPath)
 	if req.Language == "" {
-		req.Language = s.detectLanguage(absPath)
+		req.Language = detection.Language
 	}
-
 	if req.Framework == "" {
-		req.Framework = s.detectFramework(absPath, req.Language)
+		req.Framework = detection.Framework
 	}
 
 	// Create project
 	project := domain.NewProject(req.Name, absPath, req.Description)
 	project.Language = req.Language
 	project.Framework = req.Framework
+	project.Languages = detection.Languages
+	project.Dependencies = detection.Dependencies
 
 	// Set configuration
 	if req.EmbeddingProvider != "" {
@@ -166,63 +250,55 @@ func (s *ProjectService) InitializeProject(ctx context.Context, path string, req
 	}
 
 	s.logger.WithField("project_id", project.ID).Info("Project initialized successfully")
+	s.ensureProjectMarker(project)
 	return project, nil
 }
 
-// detectLanguage attempts to detect the primary language of a project
-func (s *ProjectService) detectLanguage(path string) string {
-	// Simple heuristics based on common files
-	commonFiles := map[string]string{
-		"go.mod":           "go",
-		"package.json":     "javascript",
-		"pom.xml":          "java",
-		"build.gradle":     "java",
-		"Cargo.toml":       "rust",
-		"requirements.txt": "python",
-		"setup.py":         "python",
-		"Gemfile":          "ruby",
-		"composer.json":    "php",
-	}
-
-	for file, language := range commonFiles {
-		if s.fileExists(filepath.Join(path, file)) {
-			return language
+// projectFromMarker returns the project named by the marker file in a directory, or
+// nil if there is no marker or its project is unknown. If the project's registered
+// root no longer exists, the checkout was moved and the project follows it.
+func (s *ProjectService) projectFromMarker(ctx context.Context, dir string) *domain.Project {
+	marker, err := readProjectMarker(dir)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			s.logger.WithError(err).WithField("path", dir).Warn("Ignoring invalid project marker")
 		}
+		return nil
 	}
 
-	return "unknown"
-}
+	project, err := s.projectRepo.GetByID(ctx, marker.ProjectID)
+	if err != nil {
+		s.logger.WithFields(logrus.Fields{
+			"project_id": marker.ProjectID,
+			"path":       dir,
+		}).Debug("Project marker names an unknown project")
+		return nil
+	}
 
-// detectFramework attempts to detect the framework used in a project
-func (s *ProjectService) detectFramework(path, language string) string {
-	switch language {
-	case "go":
-		if s.fileExists(filepath.Join(path, "go.mod")) {
-			// Could check go.mod content for specific frameworks
-			return "gin" // Default assumption
-		}
-	case "javascript":
-		if s.fileExists(filepath.Join(path, "package.json")) {
-			// Could parse package.json for framework detection
-			return "react" // Default assumption
-		}
-	case "java":
-		if s.fileExists(filepath.Join(path, "pom.xml")) {
-			return "spring-boot" // Default assumption for Maven projects
-		}
-		if s.fileExists(filepath.Join(path, "build.gradle")) {
-			return "spring-boot" // Default assumption for Gradle projects
-		}
-	case "python":
-		if s.fileExists(filepath.Join(path, "manage.py")) {
-			return "django"
-		}
-		if s.fileExists(filepath.Join(path, "app.py")) {
-			return "flask"
+	if project.Path != dir && !s.dirExists(project.Path) {
+		s.logger.WithFields(logrus.Fields{
+			"project_id": project.ID,
+			"old_path":   project.Path,
+			"new_path":   dir,
+		}).Info("Project was moved, updating its path")
+		project.Path = dir
+		if err := s.UpdateProject(ctx, project); err != nil {
+			s.logger.WithError(err).Warn("Failed to update moved project path")
 		}
 	}
+	return project
+}
 
-	return ""
+// ensureProjectMarker writes the marker file of a project unless it exists or the
+// project root is not a directory on this machine. Failures are only logged, as the
+// marker is a convenience for finding the project again.
+func (s *ProjectService) ensureProjectMarker(project *domain.Project) {
+	if !s.dirExists(project.Path) || s.fileExists(projectMarkerPath(project.Path)) {
+		return
+	}
+	if err := writeProjectMarker(project); err != nil {
+		s.logger.WithError(err).WithField("project_id", project.ID).Warn("Failed to write project marker")
+	}
 }
 
 // fileExists checks if a file exists
@@ -230,3 +306,9 @@ func (s *ProjectService) fileExists(path string) bool {
 	_, err := os.Stat(path)
 	return err == nil
 }
+
+// dirExists checks if a directory exists
+func (s *ProjectService) dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -2,6 +2,8 @@ package app
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -579,34 +581,355 @@ func TestProjectService_InitializeProject_AutoDetection(t *testing.T) {
 	}
 }
 
-func TestProjectService_DetectLanguage(t *testing.T) {
+func TestProjectService_DetectProject_Empty(t *testing.T) {
 	service, _ := setupProjectServiceTest()
 
-	// Since fileExists always returns false in the current implementation,
-	// detectLanguage should always return "unknown"
-	language := service.detectLanguage("/any/path")
-	if language != "unknown" {
-		t.Errorf("Expected language 'unknown', got %s", language)
+	for _, path := range []string{"/any/path", t.TempDir()} {
+		detection := service.detectProject(path)
+		if detection.Language != "unknown" {
+			t.Errorf("Expected language 'unknown' for %s, got %s", path, detection.Language)
+		}
+		if detection.Framework != "" {
+			t.Errorf("Expected empty framework for %s, got %s", path, detection.Framework)
+		}
+		if len(detection.Languages) != 0 || len(detection.Dependencies) != 0 {
+			t.Errorf("Expected nothing detected for %s, got %+v", path, detection)
+		}
 	}
 }
 
-func TestProjectService_DetectFramework(t *testing.T) {
+func TestProjectService_DetectProject(t *testing.T) {
 	service, _ := setupProjectServiceTest()
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"go.mod": `module example.com/shop
+
+go 1.23
+
+require github.com/spf13/cobra v1.9.1
+
+require (
+	github.com/gin-gonic/gin v1.10.0 // HTTP API
+	golang.org/x/text v0.21.0 // indirect
+)
+
+exclude github.com/old/lib v1.0.0
+`,
+		"package.json":              `{"dependencies": {"react": "^18.3.1", "next": "14.2.0"}, "devDependencies": {"typescript": "^5.4.0"}}`,
+		"main.go":                   strings.Repeat("x", 3000),
+		"internal/api/handler.go":   strings.Repeat("x", 3000),
+		"web/app.tsx":               strings.Repeat("x", 2000),
+		"web/node_modules/react.js": strings.Repeat("x", 100000),
+		".git/hooks/pre-commit.sh":  strings.Repeat("x", 100000),
+		"README.md":                 strings.Repeat("x", 50000),
+	})
+
+	detection := service.detectProject(root)
+	if detection.Language != "go" {
+		t.Errorf("Expected primary language go, got %s", detection.Language)
+	}
+	if detection.Framework != "gin" {
+		t.Errorf("Expected the framework of the primary language's ecosystem, got %s", detection.Framework)
+	}
+	expectedShares := []domain.LanguageShare{{Language: "go", Proportion: 0.75}, {Language: "typescript", Proportion: 0.25}}
+	if len(detection.Languages) != len(expectedShares) {
+		t.Fatalf("Expected %v, got %v", expectedShares, detection.Languages)
+	}
+	for i, share := range expectedShares {
+		if detection.Languages[i] != share {
+			t.Errorf("Expected %v, got %v", share, detection.Languages[i])
+		}
+	}
+
+	dependencies := make(map[string]domain.Dependency)
+	for _, dependency := range detection.Dependencies {
+		dependencies[dependency.Name] = dependency
+	}
+	if len(dependencies) != 6 {
+		t.Errorf("Expected the Go version and 5 direct dependencies, got %+v", detection.Dependencies)
+	}
+	if dependency := dependencies["go"]; dependency.Version != "1.23" {
+		t.Errorf("Expected the Go version as dependency go, got %+v", dependency)
+	}
+	if dependency := dependencies["github.com/gin-gonic/gin"]; dependency.Version != "v1.10.0" || dependency.Ecosystem != "go" {
+		t.Errorf("Unexpected gin dependency %+v", dependency)
+	}
+	if _, ok := dependencies["golang.org/x/text"]; ok {
+		t.Error("Expected indirect requirements to be skipped")
+	}
+	if dependency := dependencies["typescript"]; !dependency.Dev || dependency.Ecosystem != "npm" {
+		t.Errorf("Expected typescript to be an npm development dependency, got %+v", dependency)
+	}
+}
 
-	// Since fileExists always returns false in the current implementation,
-	// detectFramework should always return empty string
-	framework := service.detectFramework("/any/path", "go")
-	if framework != "" {
-		t.Errorf("Expected empty framework, got %s", framework)
+func TestParseManifests(t *testing.T) {
+	tests := []struct {
+		name     string
+		parse    func([]byte) ([]domain.Dependency, error)
+		manifest string
+		expected []domain.Dependency
+	}{
+		{
+			name:  "requirements",
+			parse: parseRequirements,
+			manifest: `# web
+Django==4.2.7
+requests[security]>=2.31 ; python_version > "3.8"
+-r dev.txt
+mylib @ https://example.com/mylib.tar.gz
+`,
+			expected: []domain.Dependency{
+				{Name: "django", Version: "4.2.7", Ecosystem: "pypi"},
+				{Name: "requests", Version: ">=2.31", Ecosystem: "pypi"},
+				{Name: "mylib", Ecosystem: "pypi"},
+			},
+		},
+		{
+			name:  "pyproject",
+			parse: parsePyProject,
+			manifest: `[project]
+dependencies = ["fastapi>=0.110", "uvicorn"]
+
+[tool.poetry.dependencies]
+python = "^3.11"
+SQLAlchemy = {version = "^2.0", extras = ["asyncio"]}
+`,
+			expected: []domain.Dependency{
+				{Name: "fastapi", Version: ">=0.110", Ecosystem: "pypi"},
+				{Name: "uvicorn", Ecosystem: "pypi"},
+				{Name: "sqlalchemy", Version: "^2.0", Ecosystem: "pypi"},
+			},
+		},
+		{
+			name:  "cargo",
+			parse: parseCargoToml,
+			manifest: `[package]
+name = "shop"
+
+[dependencies]
+axum = "0.7"
+tokio = { version = "1", features = ["full"] }
+
+[dev-dependencies]
+insta = "1.39"
+`,
+			expected: []domain.Dependency{
+				{Name: "axum", Version: "0.7", Ecosystem: "cargo"},
+				{Name: "tokio", Version: "1", Ecosystem: "cargo"},
+				{Name: "insta", Version: "1.39", Ecosystem: "cargo", Dev: true},
+			},
+		},
+		{
+			name:  "pom",
+			parse: parsePomXML,
+			manifest: `<project>
+  <parent>
+    <groupId>org.springframework.boot</groupId>
+    <artifactId>spring-boot-starter-parent</artifactId>
+    <version>3.3.0</version>
+  </parent>
+  <dependencies>
+    <dependency>
+      <groupId>org.postgresql</groupId>
+      <artifactId>postgresql</artifactId>
+    </dependency>
+    <dependency>
+      <groupId>org.junit.jupiter</groupId>
+      <artifactId>junit-jupiter</artifactId>
+      <version>5.10.2</version>
+      <scope>test</scope>
+    </dependency>
+  </dependencies>
+</project>`,
+			expected: []domain.Dependency{
+				{Name: "org.springframework.boot:spring-boot-starter-parent", Version: "3.3.0", Ecosystem: "maven"},
+				{Name: "org.postgresql:postgresql", Ecosystem: "maven"},
+				{Name: "org.junit.jupiter:junit-jupiter", Version: "5.10.2", Ecosystem: "maven", Dev: true},
+			},
+		},
 	}
 
-	framework = service.detectFramework("/any/path", "javascript")
-	if framework != "" {
-		t.Errorf("Expected empty framework, got %s", framework)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dependencies, err := tt.parse([]byte(tt.manifest))
+			if err != nil {
+				t.Fatalf("Failed to parse manifest: %v", err)
+			}
+			if len(dependencies) != len(tt.expected) {
+				t.Fatalf("Expected %+v, got %+v", tt.expected, dependencies)
+			}
+			for i, expected := range tt.expected {
+				if dependencies[i] != expected {
+					t.Errorf("Expected %+v, got %+v", expected, dependencies[i])
+				}
+			}
+		})
+	}
+
+	if framework := detectFramework([]domain.Dependency{{Name: "org.springframework.boot:spring-boot-starter-web", Ecosystem: "maven"}}, "maven"); framework != "spring-boot" {
+		t.Errorf("Expected spring-boot, got %s", framework)
+	}
+	if framework := detectFramework([]domain.Dependency{{Name: "github.com/labstack/echo/v4", Ecosystem: "go"}}, "go"); framework != "echo" {
+		t.Errorf("Expected echo for a major version suffix, got %s", framework)
+	}
+}
+
+func TestProjectService_RedetectProject(t *testing.T) {
+	service, _ := setupProjectServiceTest()
+	ctx := context.Background()
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{"app.py": "print('hello')", "requirements.txt": "flask==3.0.0\n"})
+
+	project, err := service.InitializeProject(ctx, root, ports.InitializeProjectRequest{Name: "shop"})
+	if err != nil {
+		t.Fatalf("Failed to initialize project: %v", err)
+	}
+	if project.Language != "python" || project.Framework != "flask" || len(project.Dependencies) != 1 {
+		t.Fatalf("Expected a flask project, got %s/%s %+v", project.Language, project.Framework, project.Dependencies)
+	}
+
+	writeFiles(t, root, map[string]string{"requirements.txt": "django==5.0\nflask==3.0.0\n"})
+	redetected, err := service.RedetectProject(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("Failed to re-detect project: %v", err)
+	}
+	if redetected.Framework != "django" || len(redetected.Dependencies) != 2 {
+		t.Errorf("Expected django after re-detection, got %s %+v", redetected.Framework, redetected.Dependencies)
+	}
+}
+
+// writeFiles creates files with the given content below root
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+}
+
+func TestProjectService_GetByPath_Subdirectory(t *testing.T) {
+	service, _ := setupProjectServiceTest()
+	ctx := context.Background()
+
+	created, err := service.CreateProject(ctx, ports.CreateProjectRequest{Name: "Repo", Path: "/work/repo"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	nested, err := service.CreateProject(ctx, ports.CreateProjectRequest{Name: "Billing", Path: "/work/repo/services/billing"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	tests := map[string]domain.ProjectID{
+		"/work/repo/internal/app":            created.ID,
+		"/work/repo/services":                created.ID,
+		"/work/repo/services/billing/cmd/db": nested.ID,
+	}
+	for path, expected := range tests {
+		project, err := service.GetByPath(ctx, path)
+		if err != nil {
+			t.Fatalf("Failed to resolve %s: %v", path, err)
+		}
+		if project.ID != expected {
+			t.Errorf("Expected %s to resolve to %s, got %s", path, expected, project.ID)
+		}
+	}
+
+	if _, err := service.GetByPath(ctx, "/work/other"); err == nil {
+		t.Error("Expected a path outside all projects to be rejected")
+	}
+}
+
+func TestProjectService_ProjectMarker(t *testing.T) {
+	service, projectRepo := setupProjectServiceTest()
+	ctx := context.Background()
+	base := t.TempDir()
+	original := filepath.Join(base, "repo")
+	if err := os.MkdirAll(original, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	project, err := service.InitializeProject(ctx, original, ports.InitializeProjectRequest{Name: "repo"})
+	if err != nil {
+		t.Fatalf("Failed to initialize project: %v", err)
+	}
+	marker, err := readProjectMarker(original)
+	if err != nil || marker.ProjectID != project.ID {
+		t.Fatalf("Expected a marker naming %s, got %+v, %v", project.ID, marker, err)
+	}
+
+	// A second clone of the repository resolves to the project without taking it over
+	clone := filepath.Join(base, "clone")
+	if err := os.MkdirAll(filepath.Join(clone, domain.ProjectMarkerDir), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	data, _ := os.ReadFile(projectMarkerPath(original))
+	if err := os.WriteFile(projectMarkerPath(clone), data, 0644); err != nil {
+		t.Fatalf("Failed to copy marker: %v", err)
+	}
+	resolved, err := service.GetByPath(ctx, filepath.Join(clone, "internal"))
+	if err != nil || resolved.ID != project.ID {
+		t.Fatalf("Expected the clone to resolve to %s, got %+v, %v", project.ID, resolved, err)
+	}
+	if stored, _ := projectRepo.GetByID(ctx, project.ID); stored.Path != original {
+		t.Errorf("Expected the path to stay %s while the original exists, got %s", original, stored.Path)
+	}
+
+	// A moved repository takes the project with it
+	moved := filepath.Join(base, "moved")
+	if err := os.Rename(original, moved); err != nil {
+		t.Fatalf("Failed to move directory: %v", err)
+	}
+	resolved, err = service.GetByPath(ctx, moved)
+	if err != nil || resolved.ID != project.ID {
+		t.Fatalf("Expected the moved repository to resolve to %s, got %+v, %v", project.ID, resolved, err)
+	}
+	if stored, _ := projectRepo.GetByID(ctx, project.ID); stored.Path != moved {
+		t.Errorf("Expected the path to follow the move to %s, got %s", moved, stored.Path)
+	}
+
+	// Initializing a marked directory returns its project
+	again, err := service.InitializeProject(ctx, moved, ports.InitializeProjectRequest{Name: "other"})
+	if err != nil || again.ID != project.ID {
+		t.Errorf("Expected init to return %s, got %+v, %v", project.ID, again, err)
+	}
+}
+
+func TestProjectService_RelocateProject(t *testing.T) {
+	service, _ := setupProjectServiceTest()
+	ctx := context.Background()
+	target := t.TempDir()
+
+	project, err := service.CreateProject(ctx, ports.CreateProjectRequest{Name: "Repo", Path: "/old/location/repo"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+	other, err := service.CreateProject(ctx, ports.CreateProjectRequest{Name: "Other", Path: "/old/location/other"})
+	if err != nil {
+		t.Fatalf("Failed to create project: %v", err)
+	}
+
+	if _, err := service.RelocateProject(ctx, project.ID, filepath.Join(target, "missing")); err == nil {
+		t.Error("Expected relocating to a missing directory to fail")
+	}
+
+	relocated, err := service.RelocateProject(ctx, project.ID, target)
+	if err != nil {
+		t.Fatalf("Failed to relocate project: %v", err)
+	}
+	if relocated.Path != target {
+		t.Errorf("Expected path %s, got %s", target, relocated.Path)
+	}
+	if marker, err := readProjectMarker(target); err != nil || marker.ProjectID != project.ID {
+		t.Errorf("Expected a marker naming %s, got %+v, %v", project.ID, marker, err)
 	}
 
-	framework = service.detectFramework("/any/path", "unknown")
-	if framework != "" {
-		t.Errorf("Expected empty framework, got %s", framework)
+	if _, err := service.RelocateProject(ctx, other.ID, target); err == nil {
+		t.Error("Expected relocating onto another project to fail")
 	}
 }
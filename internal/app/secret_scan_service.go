@@ -0,0 +1,118 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/sirupsen/logrus"
+)
+
+// SecretScanService audits stored memories and sessions for secrets and personal
+// data, e.g. data stored before redaction was enabled or before a rule was added
+type SecretScanService struct {
+	memoryRepo    ports.MemoryRepository
+	sessionRepo   ports.SessionRepository
+	memoryService ports.MemoryService
+	redactor      *domain.Redactor
+	logger        *logrus.Logger
+}
+
+// NewSecretScanService creates a new secret scan service. Fixed memories are saved
+// through the memory service so that their embeddings are regenerated.
+func NewSecretScanService(
+	memoryRepo ports.MemoryRepository,
+	sessionRepo ports.SessionRepository,
+	memoryService ports.MemoryService,
+	redactor *domain.Redactor,
+	logger *logrus.Logger,
+) *SecretScanService {
+	return &SecretScanService{
+		memoryRepo:    memoryRepo,
+		sessionRepo:   sessionRepo,
+		memoryService: memoryService,
+		redactor:      redactor,
+		logger:        logger,
+	}
+}
+
+// ScanSecrets reports the memories and sessions containing secrets and, with Fix,
+// masks them
+func (s *SecretScanService) ScanSecrets(ctx context.Context, req ports.ScanSecretsRequest) (*ports.ScanSecretsResult, error) {
+	s.logger.WithFields(logrus.Fields{
+		"project_id": req.ProjectID,
+		"fix":        req.Fix,
+	}).Info("Scanning for secrets")
+
+	memories, err := s.memoryRepo.ListAll(ctx, req.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list memories: %w", err)
+	}
+	sessions, err := s.sessionRepo.ListWithFilters(ctx, ports.SessionFilters{ProjectID: req.ProjectID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	result := &ports.ScanSecretsResult{
+		MemoriesScanned: len(memories),
+		SessionsScanned: len(sessions),
+		Findings:        []ports.SecretFinding{},
+	}
+
+	for _, memory := range memories {
+		// Redact a copy so that a scan without Fix leaves the memory untouched
+		redacted := *memory
+		redactions := s.redactor.RedactMemory(&redacted)
+		if len(redactions) == 0 {
+			continue
+		}
+
+		finding := ports.SecretFinding{
+			Kind:       "memory",
+			ID:         string(memory.ID),
+			ProjectID:  memory.ProjectID,
+			Title:      redacted.Title,
+			Redactions: redactions,
+		}
+		if req.Fix {
+			if err := s.memoryService.UpdateMemory(ctx, &redacted); err != nil {
+				return nil, fmt.Errorf("failed to update memory %s: %w", memory.ID, err)
+			}
+			finding.Fixed = true
+			result.Fixed++
+		}
+		result.Findings = append(result.Findings, finding)
+	}
+
+	for _, session := range sessions {
+		redacted := *session
+		redactions := s.redactor.RedactSession(&redacted)
+		if len(redactions) == 0 {
+			continue
+		}
+
+		finding := ports.SecretFinding{
+			Kind:       "session",
+			ID:         string(session.ID),
+			ProjectID:  session.ProjectID,
+			Title:      redacted.Name,
+			Redactions: redactions,
+		}
+		if req.Fix {
+			if err := s.sessionRepo.Update(ctx, &redacted); err != nil {
+				return nil, fmt.Errorf("failed to update session %s: %w", session.ID, err)
+			}
+			finding.Fixed = true
+			result.Fixed++
+		}
+		result.Findings = append(result.Findings, finding)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"findings": len(result.Findings),
+		"fixed":    result.Fixed,
+	}).Info("Secret scan completed")
+
+	return result, nil
+}
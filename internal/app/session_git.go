@@ -0,0 +1,75 @@
+package app
+
+import (
+	"context"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/sirupsen/logrus"
+)
+
+// SetSourceControl enables recording the git state of sessions: the branch and HEAD
+// when a session starts and the commits and changed files when it is completed
+func (s *SessionService) SetSourceControl(sourceControl ports.SourceControl) {
+	s.sourceControl = sourceControl
+}
+
+// recordGitStart records the branch and HEAD of the project a session starts in.
+// Projects outside a git repository leave the session without git state.
+func (s *SessionService) recordGitStart(ctx context.Context, session *domain.Session, project *domain.Project) {
+	if s.sourceControl == nil || project.Path == "" {
+		return
+	}
+
+	head, err := s.sourceControl.HeadCommit(ctx, project.Path)
+	if err != nil {
+		s.logger.WithError(err).WithField("path", project.Path).Debug("No git commit available, starting session without git state")
+		return
+	}
+	branch, err := s.sourceControl.CurrentBranch(ctx, project.Path)
+	if err != nil {
+		s.logger.WithError(err).WithField("path", project.Path).Debug("Failed to get git branch")
+	}
+	session.Git = &domain.SessionGit{Branch: branch, StartCommit: head}
+}
+
+// recordGitEnd records the HEAD a session ends at and the commits, changed files and
+// line counts since it started. When the project is checked out on another branch than
+// the session started on, HEAD belongs to a different line of work (e.g. a parallel
+// session on another branch) and no end state is recorded. Failures are logged; the
+// session is completed anyway.
+func (s *SessionService) recordGitEnd(ctx context.Context, session *domain.Session) {
+	if s.sourceControl == nil || session.Git == nil || session.Git.StartCommit == "" {
+		return
+	}
+	project, err := s.projectRepo.GetByID(ctx, session.ProjectID)
+	if err != nil || project.Path == "" {
+		return
+	}
+
+	logger := s.logger.WithFields(logrus.Fields{"session_id": session.ID, "path": project.Path})
+	if session.Git.Branch != "" {
+		branch, err := s.sourceControl.CurrentBranch(ctx, project.Path)
+		if err != nil {
+			logger.WithError(err).Warn("Failed to get git branch at session end")
+			return
+		}
+		if branch != session.Git.Branch {
+			logger.WithFields(logrus.Fields{"session_branch": session.Git.Branch, "current_branch": branch}).
+				Warn("Project is on another branch than the session started on, not recording git changes")
+			return
+		}
+	}
+	head, err := s.sourceControl.HeadCommit(ctx, project.Path)
+	if err != nil {
+		logger.WithError(err).Warn("Failed to get git HEAD at session end")
+		return
+	}
+	changes, err := s.sourceControl.Changes(ctx, project.Path, session.Git.StartCommit, head)
+	if err != nil {
+		logger.WithError(err).Warn("Failed to get git changes of session")
+		return
+	}
+	session.Git.EndCommit = head
+	session.Git.GitChanges = changes
+}
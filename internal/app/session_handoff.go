@@ -0,0 +1,264 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/sirupsen/logrus"
+)
+
+// maxHandoffCandidates is the number of recently started sessions the handoff session is
+// chosen from when none is given
+const maxHandoffCandidates = 20
+
+// BuildHandoff summarises where the project's session last worked on, or the given session,
+// left off so that a new agent conversation can pick up the work
+func (s *contextService) BuildHandoff(ctx context.Context, req ports.HandoffRequest) (*ports.HandoffBrief, error) {
+	if s.sessionService == nil {
+		return nil, fmt.Errorf("session service is not available")
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = ports.DefaultContextLimit
+	}
+	budget := req.TokenBudget
+	if budget <= 0 {
+		budget = ports.DefaultContextTokenBudget
+	}
+
+	session, err := s.handoffSession(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"project_id": session.ProjectID,
+		"session_id": session.ID,
+	}).Info("Building session handoff")
+
+	brief := &ports.HandoffBrief{
+		ProjectID:  session.ProjectID,
+		Session:    session,
+		Milestones: lastEntries(userMilestones(session), limit),
+		OpenIssues: lastEntries(unresolvedIssues(session.Progress), limit),
+	}
+
+	// The goal and the files the session touched select related tasks and memories
+	query := contextQuery(session.TaskDescription, sessionFiles(session))
+	brief.OpenTasks = s.handoffTasks(ctx, session, query, limit)
+
+	memories, err := s.memoryService.SearchMemories(ctx, ports.SemanticSearchRequest{
+		Query:     query,
+		ProjectID: &session.ProjectID,
+		Limit:     limit * 2,
+		Threshold: ports.DefaultContextThreshold,
+		Filters:   &ports.SearchFilters{Types: contextKnowledgeTypes},
+	})
+	if err != nil {
+		s.logger.WithError(err).Warn("Failed to search memories for session handoff")
+	}
+	for _, result := range deduplicateResults(memories) {
+		if len(brief.Memories) >= limit {
+			break
+		}
+		brief.Memories = append(brief.Memories, result)
+	}
+
+	brief.Text = renderHandoff(brief, budget)
+	brief.EstimatedTokens = domain.EstimateTokens(brief.Text)
+
+	return brief, nil
+}
+
+// handoffSession returns the requested session or the session of the project that was
+// last worked on. With parallel sessions the latest started one may be a session that
+// was just opened, so sessions without progress of their own only serve as a fallback.
+func (s *contextService) handoffSession(ctx context.Context, req ports.HandoffRequest) (*domain.Session, error) {
+	if req.SessionID != nil {
+		session, err := s.sessionService.GetSession(ctx, *req.SessionID)
+		if err != nil {
+			return nil, fmt.Errorf("session not found: %w", err)
+		}
+		return session, nil
+	}
+
+	sessions, err := s.sessionService.ListSessions(ctx, ports.SessionFilters{ProjectID: &req.ProjectID, Limit: maxHandoffCandidates})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	if len(sessions) == 0 {
+		return nil, fmt.Errorf("no session found for project: %s", req.ProjectID)
+	}
+
+	var latest *domain.Session
+	for _, session := range sessions {
+		if hasOwnProgress(session) && (latest == nil || session.LastActivity().After(latest.LastActivity())) {
+			latest = session
+		}
+	}
+	if latest == nil {
+		return sessions[0], nil
+	}
+	return latest, nil
+}
+
+// hasOwnProgress reports whether the session has progress entries logged by its user
+func hasOwnProgress(session *domain.Session) bool {
+	for _, entry := range session.Progress {
+		if !session.IsLifecycleEntry(entry) {
+			return true
+		}
+	}
+	return false
+}
+
+// handoffTasks returns the open tasks the session's progress entries refer to, followed
+// by open tasks similar to the session's goal
+func (s *contextService) handoffTasks(ctx context.Context, session *domain.Session, query string, limit int) []*domain.Task {
+	if s.taskService == nil {
+		return nil
+	}
+
+	var tasks []*domain.Task
+	seen := make(map[domain.MemoryID]bool)
+	for i := len(session.Progress) - 1; i >= 0 && len(tasks) < limit; i-- {
+		ref := domain.MemoryID(session.Progress[i].Ref)
+		if ref == "" || seen[ref] {
+			continue
+		}
+		seen[ref] = true
+		// Refs may also name memories, which are not tasks
+		task, err := s.taskService.GetTask(ctx, ref)
+		if err != nil || task.Status == domain.TaskStatusDone {
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+
+	for _, task := range s.matchingOpenTasks(ctx, &session.ProjectID, query, limit, ports.DefaultContextThreshold) {
+		if len(tasks) >= limit {
+			break
+		}
+		if !seen[task.ID] {
+			seen[task.ID] = true
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks
+}
+
+// sessionFiles returns the distinct files the progress entries of a session refer to
+func sessionFiles(session *domain.Session) []string {
+	var files []string
+	seen := make(map[string]bool)
+	for _, entry := range session.Progress {
+		for _, file := range entry.Files {
+			if !seen[file] {
+				seen[file] = true
+				files = append(files, file)
+			}
+		}
+	}
+	if session.Git != nil {
+		for _, file := range session.Git.Files {
+			if !seen[file.Path] {
+				seen[file.Path] = true
+				files = append(files, file.Path)
+			}
+		}
+	}
+	return files
+}
+
+// userMilestones returns the milestone entries except the one logged on completion
+func userMilestones(session *domain.Session) []domain.ProgressEntry {
+	var milestones []domain.ProgressEntry
+	for _, entry := range session.GetMilestones() {
+		if !session.IsLifecycleEntry(entry) {
+			milestones = append(milestones, entry)
+		}
+	}
+	return milestones
+}
+
+// lastEntries returns the last n entries, oldest first
+func lastEntries(entries []domain.ProgressEntry, n int) []domain.ProgressEntry {
+	if len(entries) > n {
+		return entries[len(entries)-n:]
+	}
+	return entries
+}
+
+// renderHandoff formats a handoff brief as markdown within the token budget. The
+// session and its goal always come first.
+func renderHandoff(brief *ports.HandoffBrief, budget int) string {
+	session := brief.Session
+
+	var header strings.Builder
+	fmt.Fprintf(&header, "# Handoff: %s\n\n", session.Name)
+	fmt.Fprintf(&header, "Session %s of project %s, %s, started %s, last activity %s\n",
+		session.ID, session.ProjectID, session.Status,
+		session.StartTime.Format("2006-01-02 15:04"), session.LastActivity().Format("2006-01-02 15:04"))
+	if git := session.Git; git != nil {
+		if git.Branch != "" {
+			fmt.Fprintf(&header, "Branch: %s (%s)\n", git.Branch, git.Range())
+		} else {
+			fmt.Fprintf(&header, "Commits: %s\n", git.Range())
+		}
+	}
+	fmt.Fprintf(&header, "\n## Goal\n%s\n", session.TaskDescription)
+	if session.Outcome != "" {
+		fmt.Fprintf(&header, "\nOutcome: %s\n", session.Outcome)
+	}
+	header.WriteString("\n")
+
+	sections := []domain.PromptSection{
+		{Heading: "## Open Issues", Items: progressItems(brief.OpenIssues)},
+		{Heading: "## Last Milestones", Items: progressItems(brief.Milestones)},
+		{Heading: "## Open Tasks", Items: taskItems(brief.OpenTasks)},
+		{Heading: "## Relevant Memories", Items: memoryItems(brief.Memories)},
+	}
+	if latest := latestNote(session); latest != nil {
+		sections = append([]domain.PromptSection{{Heading: "## Latest Progress", Items: progressItems([]domain.ProgressEntry{*latest})}}, sections...)
+	}
+
+	return header.String() + domain.RenderPromptSections(sections, budget-domain.EstimateTokens(header.String()))
+}
+
+// latestNote returns the latest info entry that was not logged by a pause, resume or
+// abort, if any
+func latestNote(session *domain.Session) *domain.ProgressEntry {
+	for i := len(session.Progress) - 1; i >= 0; i-- {
+		entry := session.Progress[i]
+		if entry.Type == domain.ProgressTypeInfo && !session.IsLifecycleEntry(entry) {
+			return &entry
+		}
+	}
+	return nil
+}
+
+func progressItems(entries []domain.ProgressEntry) []string {
+	items := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		item := "- " + entry.Message
+		var refs []string
+		if len(entry.Files) > 0 {
+			refs = append(refs, strings.Join(entry.Files, ", "))
+		}
+		if entry.Ref != "" {
+			refs = append(refs, "ref "+entry.Ref)
+		}
+		if entry.Commit != "" {
+			refs = append(refs, "commit "+entry.Commit)
+		}
+		if len(refs) > 0 {
+			item += " (" + strings.Join(refs, "; ") + ")"
+		}
+		items = append(items, item+"\n")
+	}
+	return items
+}
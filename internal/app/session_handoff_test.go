@@ -0,0 +1,126 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+)
+
+func TestContextService_BuildHandoff(t *testing.T) {
+	service, memoryService, sessionRepo, projectID := setupContextServiceTest(t)
+	ctx := context.Background()
+
+	task, err := memoryService.CreateMemory(ctx, ports.CreateMemoryRequest{
+		ProjectID: projectID,
+		Type:      domain.MemoryTypeTask,
+		Title:     "Rotate signing keys",
+		Content:   "Add key rotation",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create task: %v", err)
+	}
+	if _, err := memoryService.CreateMemory(ctx, ports.CreateMemoryRequest{
+		ProjectID: projectID,
+		Type:      domain.MemoryTypeDecision,
+		Title:     "Use JWT",
+		Content:   "Tokens are signed with RS256",
+	}); err != nil {
+		t.Fatalf("Failed to create memory: %v", err)
+	}
+
+	session := domain.NewSession(projectID, "Auth refactor", "Refactor token validation")
+	session.LogMilestone("Middleware extracted")
+	session.LogIssue("Clock skew breaks validation")
+	session.LogSolution("Allow 30s leeway")
+	session.LogIssue("Refresh tokens are not revoked")
+	session.AddProgress(domain.ProgressEntry{Type: domain.ProgressTypeInfo, Message: "Revocation list next", Ref: string(task.ID)})
+	if err := session.PauseWithReason("end of day"); err != nil {
+		t.Fatalf("Failed to pause session: %v", err)
+	}
+	if err := sessionRepo.Store(ctx, session); err != nil {
+		t.Fatalf("Failed to store session: %v", err)
+	}
+
+	brief, err := service.BuildHandoff(ctx, ports.HandoffRequest{ProjectID: projectID})
+	if err != nil {
+		t.Fatalf("Failed to build handoff: %v", err)
+	}
+
+	if brief.Session.ID != session.ID {
+		t.Errorf("Expected the latest session %s, got %s", session.ID, brief.Session.ID)
+	}
+	if len(brief.OpenIssues) != 1 || brief.OpenIssues[0].Message != "Refresh tokens are not revoked" {
+		t.Errorf("Expected only the unresolved issue, got %v", brief.OpenIssues)
+	}
+	if len(brief.Milestones) != 1 || brief.Milestones[0].Message != "Middleware extracted" {
+		t.Errorf("Expected one milestone, got %v", brief.Milestones)
+	}
+	if len(brief.OpenTasks) == 0 || brief.OpenTasks[0].ID != task.ID {
+		t.Errorf("Expected the referenced task first, got %v", brief.OpenTasks)
+	}
+	if len(brief.Memories) != 1 || brief.Memories[0].Memory.Type != domain.MemoryTypeDecision {
+		t.Errorf("Expected the decision as relevant memory, got %v", brief.Memories)
+	}
+
+	for _, want := range []string{"# Handoff: Auth refactor", "## Goal", "Revocation list next", "## Open Issues", "Rotate signing keys", "Use JWT"} {
+		if !strings.Contains(brief.Text, want) {
+			t.Errorf("Expected brief to contain %q, got:\n%s", want, brief.Text)
+		}
+	}
+	if strings.Contains(brief.Text, "Session paused") {
+		t.Errorf("Expected lifecycle entries to be left out, got:\n%s", brief.Text)
+	}
+}
+
+func TestContextService_BuildHandoff_PicksSessionLastWorkedOn(t *testing.T) {
+	service, _, sessionRepo, projectID := setupContextServiceTest(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	// Parallel sessions: one worked on an hour ago, one started earlier but worked on
+	// recently, and one that was just opened without progress
+	worked := domain.NewSession(projectID, "Cache", "Add cache")
+	worked.StartTime = now.Add(-3 * time.Hour)
+	worked.AddProgress(domain.ProgressEntry{Type: domain.ProgressTypeInfo, Message: "Cache keys done", Timestamp: now.Add(-time.Hour).Format(time.RFC3339)})
+	recent := domain.NewSession(projectID, "Login", "Fix login redirect")
+	recent.StartTime = now.Add(-5 * time.Hour)
+	recent.AddProgress(domain.ProgressEntry{Type: domain.ProgressTypeInfo, Message: "Redirect fixed", Timestamp: now.Add(-10 * time.Minute).Format(time.RFC3339)})
+	fresh := domain.NewSession(projectID, "Docs", "Update docs")
+	fresh.StartTime = now.Add(-time.Minute)
+	for _, session := range []*domain.Session{worked, recent, fresh} {
+		if err := sessionRepo.Store(ctx, session); err != nil {
+			t.Fatalf("Failed to store session: %v", err)
+		}
+	}
+
+	brief, err := service.BuildHandoff(ctx, ports.HandoffRequest{ProjectID: projectID})
+	if err != nil {
+		t.Fatalf("Failed to build handoff: %v", err)
+	}
+	if brief.Session.ID != recent.ID {
+		t.Errorf("Expected the session last worked on %s, got %s", recent.ID, brief.Session.ID)
+	}
+
+	// Pausing a session for inactivity is no work on it
+	if err := worked.PauseWithReason(domain.InactivityReason(time.Hour)); err != nil {
+		t.Fatalf("Failed to pause session: %v", err)
+	}
+	if err := sessionRepo.Update(ctx, worked); err != nil {
+		t.Fatalf("Failed to update session: %v", err)
+	}
+	if brief, _ := service.BuildHandoff(ctx, ports.HandoffRequest{ProjectID: projectID}); brief.Session.ID != recent.ID {
+		t.Errorf("Expected the paused session to be skipped, got %s", brief.Session.ID)
+	}
+}
+
+func TestContextService_BuildHandoff_NoSession(t *testing.T) {
+	service, _, _, projectID := setupContextServiceTest(t)
+
+	if _, err := service.BuildHandoff(context.Background(), ports.HandoffRequest{ProjectID: projectID}); err == nil {
+		t.Error("Expected an error for a project without sessions")
+	}
+}
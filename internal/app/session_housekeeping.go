@@ -0,0 +1,160 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/sirupsen/logrus"
+)
+
+// StaleSessionPolicy controls when open sessions count as stale and when inactive
+// sessions are paused or aborted automatically. A zero threshold turns that step off.
+type StaleSessionPolicy struct {
+	// StaleAfter is the inactivity after which session_start and the system prompt warn
+	StaleAfter time.Duration
+	// PauseAfter is the inactivity after which active sessions are paused
+	PauseAfter time.Duration
+	// AbortAfter is the inactivity after which active and paused sessions are aborted
+	AbortAfter time.Duration
+}
+
+// DefaultStaleSessionPolicy returns the default policy: warn after a day and pause
+// after three days of inactivity, never abort automatically
+func DefaultStaleSessionPolicy() StaleSessionPolicy {
+	return StaleSessionPolicy{
+		StaleAfter: 24 * time.Hour,
+		PauseAfter: 72 * time.Hour,
+	}
+}
+
+// SetStalePolicy configures stale session detection and automatic housekeeping
+func (s *SessionService) SetStalePolicy(policy StaleSessionPolicy) {
+	s.stalePolicy = policy
+}
+
+// StaleSessions returns the open sessions, of one project or all, that have been
+// inactive for longer than the stale threshold, longest idle first
+func (s *SessionService) StaleSessions(ctx context.Context, projectID *domain.ProjectID) ([]ports.StaleSession, error) {
+	if s.stalePolicy.StaleAfter <= 0 {
+		return nil, nil
+	}
+	return s.idleSessions(ctx, projectID, s.stalePolicy.StaleAfter)
+}
+
+// PruneSessions pauses active sessions inactive for longer than the pause threshold
+// and aborts open sessions inactive for longer than the abort threshold. The reason
+// is recorded in the progress log of each session.
+func (s *SessionService) PruneSessions(ctx context.Context, req ports.PruneSessionsRequest) (*ports.PruneSessionsResult, error) {
+	pauseAfter, abortAfter := s.stalePolicy.PauseAfter, s.stalePolicy.AbortAfter
+	if req.PauseAfter > 0 {
+		pauseAfter = req.PauseAfter
+	}
+	if req.AbortAfter > 0 {
+		abortAfter = req.AbortAfter
+	}
+
+	threshold := minThreshold(pauseAfter, abortAfter)
+	if threshold <= 0 {
+		return &ports.PruneSessionsResult{}, nil
+	}
+	idle, err := s.idleSessions(ctx, req.ProjectID, threshold)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ports.PruneSessionsResult{}
+	for _, stale := range idle {
+		session := stale.Session
+		reason := domain.InactivityReason(stale.IdleFor)
+
+		switch {
+		case abortAfter > 0 && stale.IdleFor >= abortAfter:
+			if !req.DryRun {
+				session.Abort(reason)
+			}
+			result.Aborted = append(result.Aborted, stale)
+		case pauseAfter > 0 && stale.IdleFor >= pauseAfter && session.IsActive():
+			if !req.DryRun {
+				if err := session.PauseWithReason(reason); err != nil {
+					return nil, err
+				}
+			}
+			result.Paused = append(result.Paused, stale)
+		default:
+			continue
+		}
+		if req.DryRun {
+			continue
+		}
+
+		session.LastModifiedBy = s.provenanceFor(ctx)
+		if err := s.sessionRepo.Update(ctx, session); err != nil {
+			return nil, fmt.Errorf("failed to update session %s: %w", session.ID, err)
+		}
+		s.logger.WithFields(logrus.Fields{
+			"session_id": session.ID,
+			"status":     session.Status,
+			"idle_for":   stale.IdleFor,
+		}).Info("Pruned inactive session")
+	}
+
+	return result, nil
+}
+
+// Housekeep pauses and aborts the inactive sessions of a project, or of all projects
+// if nil, with the configured thresholds. Failures are logged; housekeeping never
+// blocks the operation that triggered it.
+func (s *SessionService) Housekeep(ctx context.Context, projectID *domain.ProjectID) {
+	if s.stalePolicy.PauseAfter <= 0 && s.stalePolicy.AbortAfter <= 0 {
+		return
+	}
+	result, err := s.PruneSessions(ctx, ports.PruneSessionsRequest{ProjectID: projectID})
+	if err != nil {
+		s.logger.WithError(err).Warn("Failed to prune inactive sessions")
+		return
+	}
+	if len(result.Paused) > 0 || len(result.Aborted) > 0 {
+		s.logger.WithFields(logrus.Fields{
+			"paused":  len(result.Paused),
+			"aborted": len(result.Aborted),
+		}).Info("Pruned inactive sessions")
+	}
+}
+
+// idleSessions returns the open sessions inactive for at least the given time,
+// longest idle first
+func (s *SessionService) idleSessions(ctx context.Context, projectID *domain.ProjectID, threshold time.Duration) ([]ports.StaleSession, error) {
+	now := time.Now()
+	var idle []ports.StaleSession
+	for _, status := range []domain.SessionStatus{domain.SessionStatusActive, domain.SessionStatusPaused} {
+		sessions, err := s.sessionRepo.ListWithFilters(ctx, ports.SessionFilters{ProjectID: projectID, Status: &status})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s sessions: %w", status, err)
+		}
+		for _, session := range sessions {
+			if idleFor := session.IdleFor(now); idleFor >= threshold {
+				idle = append(idle, ports.StaleSession{Session: session, IdleFor: idleFor})
+			}
+		}
+	}
+	sort.SliceStable(idle, func(i, j int) bool { return idle[i].IdleFor > idle[j].IdleFor })
+	return idle, nil
+}
+
+// minThreshold returns the smaller of two thresholds, ignoring those turned off
+func minThreshold(a, b time.Duration) time.Duration {
+	switch {
+	case a <= 0:
+		return b
+	case b <= 0:
+		return a
+	case a < b:
+		return a
+	default:
+		return b
+	}
+}
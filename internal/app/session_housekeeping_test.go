@@ -0,0 +1,145 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+)
+
+// storeIdleSession stores an open session of the project that has been inactive for the given time
+func storeIdleSession(t *testing.T, sessionRepo *MockSessionRepository, projectID domain.ProjectID, name string, idle time.Duration, paused bool) *domain.Session {
+	t.Helper()
+	session := domain.NewSession(projectID, name, name)
+	session.StartTime = time.Now().Add(-idle)
+	if paused {
+		pausedAt := session.StartTime
+		session.PausedAt = &pausedAt
+		session.Status = domain.SessionStatusPaused
+	}
+	if err := sessionRepo.Store(context.Background(), session); err != nil {
+		t.Fatalf("Failed to store session: %v", err)
+	}
+	return session
+}
+
+func TestSessionService_StaleSessions(t *testing.T) {
+	service, sessionRepo, _ := setupSessionServiceTest()
+	ctx := context.Background()
+	projectID := domain.ProjectID("proj_1")
+
+	storeIdleSession(t, sessionRepo, projectID, "fresh", time.Hour, false)
+	week := storeIdleSession(t, sessionRepo, projectID, "week", 7*24*time.Hour, true)
+	days := storeIdleSession(t, sessionRepo, projectID, "days", 30*time.Hour, false)
+	storeIdleSession(t, sessionRepo, "proj_2", "other project", 30*time.Hour, false)
+
+	stale, err := service.StaleSessions(ctx, &projectID)
+	if err != nil {
+		t.Fatalf("Failed to get stale sessions: %v", err)
+	}
+	if len(stale) != 2 || stale[0].Session.ID != week.ID || stale[1].Session.ID != days.ID {
+		t.Fatalf("Expected the week and days sessions, longest idle first, got %+v", stale)
+	}
+
+	service.SetStalePolicy(StaleSessionPolicy{})
+	if stale, _ := service.StaleSessions(ctx, &projectID); len(stale) != 0 {
+		t.Errorf("Expected no stale sessions with detection turned off, got %d", len(stale))
+	}
+}
+
+func TestSessionService_PruneSessions(t *testing.T) {
+	service, sessionRepo, _ := setupSessionServiceTest()
+	ctx := context.Background()
+	projectID := domain.ProjectID("proj_1")
+	service.SetStalePolicy(StaleSessionPolicy{StaleAfter: 24 * time.Hour, PauseAfter: 72 * time.Hour, AbortAfter: 14 * 24 * time.Hour})
+
+	fresh := storeIdleSession(t, sessionRepo, projectID, "fresh", 30*time.Hour, false)
+	idle := storeIdleSession(t, sessionRepo, projectID, "idle", 4*24*time.Hour, false)
+	pausedIdle := storeIdleSession(t, sessionRepo, projectID, "paused idle", 4*24*time.Hour, true)
+	abandoned := storeIdleSession(t, sessionRepo, projectID, "abandoned", 20*24*time.Hour, true)
+
+	// A dry run changes nothing
+	preview, err := service.PruneSessions(ctx, ports.PruneSessionsRequest{ProjectID: &projectID, DryRun: true})
+	if err != nil {
+		t.Fatalf("Failed to preview pruning: %v", err)
+	}
+	if len(preview.Paused) != 1 || len(preview.Aborted) != 1 {
+		t.Fatalf("Expected one session to pause and one to abort, got %+v", preview)
+	}
+	if stored, _ := sessionRepo.GetByID(ctx, idle.ID); stored.Status != domain.SessionStatusActive {
+		t.Errorf("Expected dry run to leave the session active, got %s", stored.Status)
+	}
+
+	result, err := service.PruneSessions(ctx, ports.PruneSessionsRequest{ProjectID: &projectID})
+	if err != nil {
+		t.Fatalf("Failed to prune sessions: %v", err)
+	}
+	if len(result.Paused) != 1 || result.Paused[0].Session.ID != idle.ID {
+		t.Errorf("Expected the idle session to be paused, got %+v", result.Paused)
+	}
+	if len(result.Aborted) != 1 || result.Aborted[0].Session.ID != abandoned.ID {
+		t.Errorf("Expected the abandoned session to be aborted, got %+v", result.Aborted)
+	}
+
+	expected := map[domain.SessionID]domain.SessionStatus{
+		fresh.ID:      domain.SessionStatusActive,
+		idle.ID:       domain.SessionStatusPaused,
+		pausedIdle.ID: domain.SessionStatusPaused,
+		abandoned.ID:  domain.SessionStatusAborted,
+	}
+	for id, status := range expected {
+		stored, err := sessionRepo.GetByID(ctx, id)
+		if err != nil {
+			t.Fatalf("Failed to get session: %v", err)
+		}
+		if stored.Status != status {
+			t.Errorf("Expected session %s to be %s, got %s", stored.Name, status, stored.Status)
+		}
+	}
+
+	// The reason is recorded in the progress log
+	stored, _ := sessionRepo.GetByID(ctx, idle.ID)
+	if last := stored.Progress[len(stored.Progress)-1].Message; last != "Session paused: inactive for 4d" {
+		t.Errorf("Expected pause reason, got %q", last)
+	}
+	stored, _ = sessionRepo.GetByID(ctx, abandoned.ID)
+	if last := stored.Progress[len(stored.Progress)-1].Message; !strings.HasPrefix(last, "Session aborted: inactive for 20d") {
+		t.Errorf("Expected abort reason, got %q", last)
+	}
+
+	// Explicit thresholds override the policy
+	result, err = service.PruneSessions(ctx, ports.PruneSessionsRequest{ProjectID: &projectID, AbortAfter: 24 * time.Hour})
+	if err != nil {
+		t.Fatalf("Failed to prune sessions: %v", err)
+	}
+	// The automatic pause of the idle session does not count as activity
+	if len(result.Aborted) != 3 {
+		t.Errorf("Expected the fresh, idle and paused idle sessions to be aborted, got %+v", result.Aborted)
+	}
+}
+
+func TestSessionService_StartSession_Housekeeping(t *testing.T) {
+	service, sessionRepo, projectRepo := setupSessionServiceTest()
+	ctx := context.Background()
+
+	project := domain.NewProject("Test", "/repo", "")
+	if err := projectRepo.Store(ctx, project); err != nil {
+		t.Fatalf("Failed to store project: %v", err)
+	}
+	forgotten := storeIdleSession(t, sessionRepo, project.ID, "forgotten", 5*24*time.Hour, false)
+
+	if _, err := service.StartSession(ctx, ports.StartSessionRequest{ProjectID: project.ID, TaskDescription: "New work"}); err != nil {
+		t.Fatalf("Failed to start session: %v", err)
+	}
+
+	stored, err := sessionRepo.GetByID(ctx, forgotten.ID)
+	if err != nil {
+		t.Fatalf("Failed to get session: %v", err)
+	}
+	if stored.Status != domain.SessionStatusPaused {
+		t.Errorf("Expected the forgotten session to be paused with the default policy, got %s", stored.Status)
+	}
+}
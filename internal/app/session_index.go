@@ -0,0 +1,264 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/sirupsen/logrus"
+)
+
+// sessionDocument is a part of a session embedded for session search. Its ID is the
+// session ID followed by the part, e.g. "<session>/progress/3".
+type sessionDocument struct {
+	id       string
+	text     string
+	metadata map[string]interface{}
+}
+
+func newSessionDocument(session *domain.Session, hit, text string, entryIndex int) sessionDocument {
+	id := string(session.ID) + "/" + hit
+	metadata := map[string]interface{}{
+		"session_id": string(session.ID),
+		"project_id": string(session.ProjectID),
+		"hit":        hit,
+	}
+	if hit == ports.SessionHitProgress {
+		id += "/" + strconv.Itoa(entryIndex)
+		metadata["entry_index"] = entryIndex
+	}
+	return sessionDocument{id: id, text: text, metadata: metadata}
+}
+
+// taskDocument embeds the session's name and task description
+func taskDocument(session *domain.Session) sessionDocument {
+	text := session.TaskDescription
+	if session.Name != "" && session.Name != session.TaskDescription {
+		text = session.Name + ": " + text
+	}
+	return newSessionDocument(session, ports.SessionHitTask, text, 0)
+}
+
+// progressDocument embeds the progress entry at index
+func progressDocument(session *domain.Session, index int) sessionDocument {
+	return newSessionDocument(session, ports.SessionHitProgress, session.Progress[index].Message, index)
+}
+
+// outcomeDocument embeds the session's outcome
+func outcomeDocument(session *domain.Session) sessionDocument {
+	return newSessionDocument(session, ports.SessionHitOutcome, session.Outcome, 0)
+}
+
+// sessionDocuments returns all searchable parts of a session. Entries logged by
+// pausing, resuming or completing it are left out.
+func sessionDocuments(session *domain.Session) []sessionDocument {
+	docs := []sessionDocument{taskDocument(session)}
+	for i, entry := range session.Progress {
+		if !session.IsLifecycleEntry(entry) {
+			docs = append(docs, progressDocument(session, i))
+		}
+	}
+	if session.Outcome != "" {
+		docs = append(docs, outcomeDocument(session))
+	}
+	return docs
+}
+
+// parseSessionDocumentID splits a session document ID into the session, the part and
+// the progress entry index
+func parseSessionDocumentID(id string) (domain.SessionID, string, int, bool) {
+	parts := strings.Split(id, "/")
+	switch {
+	case len(parts) == 2 && (parts[1] == ports.SessionHitTask || parts[1] == ports.SessionHitOutcome):
+		return domain.SessionID(parts[0]), parts[1], 0, true
+	case len(parts) == 3 && parts[1] == ports.SessionHitProgress:
+		index, err := strconv.Atoi(parts[2])
+		if err != nil {
+			return "", "", 0, false
+		}
+		return domain.SessionID(parts[0]), parts[1], index, true
+	}
+	return "", "", 0, false
+}
+
+// indexSession embeds parts of a session for session search. Failures are logged; the
+// session itself is already stored.
+func (s *SessionService) indexSession(ctx context.Context, docs ...sessionDocument) {
+	if s.sessionVectors == nil {
+		return
+	}
+	for _, doc := range docs {
+		if strings.TrimSpace(doc.text) == "" {
+			continue
+		}
+		vector, err := s.embeddingProvider.GenerateEmbedding(ctx, doc.text)
+		if err == nil {
+			err = s.sessionVectors.Store(ctx, doc.id, vector, doc.metadata)
+		}
+		if err != nil {
+			s.logger.WithError(err).WithField("document_id", doc.id).Warn("Failed to index session for search")
+		}
+	}
+}
+
+// ReindexSessions embeds all parts of the sessions of a project, or of all projects if
+// projectID is nil, replacing what was indexed before. It returns the number of
+// documents indexed.
+func (s *SessionService) ReindexSessions(ctx context.Context, projectID *domain.ProjectID) (int, error) {
+	if s.sessionVectors == nil {
+		return 0, fmt.Errorf("session search is not enabled")
+	}
+
+	sessions, err := s.sessionRepo.ListWithFilters(ctx, ports.SessionFilters{ProjectID: projectID})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	indexed := 0
+	for _, session := range sessions {
+		var docs []sessionDocument
+		var texts []string
+		for _, doc := range sessionDocuments(session) {
+			if strings.TrimSpace(doc.text) != "" {
+				docs = append(docs, doc)
+				texts = append(texts, doc.text)
+			}
+		}
+		if len(docs) == 0 {
+			continue
+		}
+
+		vectors, err := s.embeddingProvider.GenerateBatchEmbeddings(ctx, texts)
+		if err != nil {
+			return indexed, fmt.Errorf("failed to generate embeddings for session %s: %w", session.ID, err)
+		}
+		ids := make([]string, len(docs))
+		items := make([]ports.BatchStoreItem, len(docs))
+		for i, doc := range docs {
+			ids[i] = doc.id
+			items[i] = ports.BatchStoreItem{ID: doc.id, Vector: vectors[i], Metadata: doc.metadata}
+		}
+		// Adding an existing ID doesn't replace it, so drop the old documents first
+		if err := s.sessionVectors.BatchDelete(ctx, ids); err != nil {
+			s.logger.WithError(err).WithField("session_id", session.ID).Debug("Failed to delete indexed session documents")
+		}
+		if err := s.sessionVectors.BatchStore(ctx, items); err != nil {
+			return indexed, fmt.Errorf("failed to index session %s: %w", session.ID, err)
+		}
+		indexed += len(docs)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"sessions":  len(sessions),
+		"documents": indexed,
+	}).Info("Sessions reindexed for search")
+	return indexed, nil
+}
+
+// SearchSessions searches the indexed task descriptions, progress entries and outcomes
+// of sessions. Without a search index it finds nothing.
+func (s *SessionService) SearchSessions(ctx context.Context, req ports.SessionSearchRequest) ([]ports.SessionSearchResult, error) {
+	if s.sessionVectors == nil {
+		return nil, nil
+	}
+	if err := validateTimeFilter(req.TimeFilter); err != nil {
+		return nil, err
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+
+	queryVector, err := s.embeddingProvider.GenerateEmbedding(ctx, req.Query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate query embedding: %w", err)
+	}
+	// All projects share the session index, so the vector store filters by project.
+	// Extra hits make up for those the time filter drops.
+	hits, err := s.sessionVectors.SearchWhere(ctx, queryVector, limit*3, req.Threshold, sessionProjectFilter(req))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search session index: %w", err)
+	}
+
+	sessions := make(map[domain.SessionID]*domain.Session)
+	var results []ports.SessionSearchResult
+	for _, hit := range hits {
+		sessionID, part, index, ok := parseSessionDocumentID(hit.ID)
+		if !ok {
+			continue
+		}
+		session, seen := sessions[sessionID]
+		if !seen {
+			session, err = s.sessionRepo.GetByID(ctx, sessionID)
+			if err != nil {
+				s.logger.WithError(err).WithField("session_id", sessionID).Debug("Skipping search hit of missing session")
+				session = nil
+			}
+			sessions[sessionID] = session
+		}
+		if session == nil || !sessionInProjects(session, req) {
+			continue
+		}
+
+		result := ports.SessionSearchResult{Session: session, Hit: part, Similarity: hit.Similarity}
+		switch part {
+		case ports.SessionHitTask:
+			result.Timestamp = session.StartTime
+		case ports.SessionHitOutcome:
+			result.Timestamp = session.StartTime
+			if session.EndTime != nil {
+				result.Timestamp = *session.EndTime
+			}
+		case ports.SessionHitProgress:
+			if index >= len(session.Progress) {
+				continue
+			}
+			entry := session.Progress[index]
+			result.Entry = &entry
+			result.Timestamp = entry.Time()
+		}
+		if !timeInFilter(result.Timestamp, req.TimeFilter) {
+			continue
+		}
+
+		results = append(results, result)
+		if len(results) == limit {
+			break
+		}
+	}
+	return results, nil
+}
+
+// sessionProjectFilter restricts a session index search to the projects searched
+func sessionProjectFilter(req ports.SessionSearchRequest) map[string][]string {
+	if req.ProjectID != nil {
+		return map[string][]string{"project_id": {string(*req.ProjectID)}}
+	}
+	if len(req.ProjectIDs) == 0 {
+		return nil
+	}
+	projectIDs := make([]string, len(req.ProjectIDs))
+	for i, projectID := range req.ProjectIDs {
+		projectIDs[i] = string(projectID)
+	}
+	return map[string][]string{"project_id": projectIDs}
+}
+
+// sessionInProjects reports whether a session belongs to the projects searched
+func sessionInProjects(session *domain.Session, req ports.SessionSearchRequest) bool {
+	if req.ProjectID != nil {
+		return session.ProjectID == *req.ProjectID
+	}
+	if len(req.ProjectIDs) == 0 {
+		return true
+	}
+	for _, projectID := range req.ProjectIDs {
+		if session.ProjectID == projectID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+)
+
+func TestSessionService_SearchSessions(t *testing.T) {
+	service, sessionRepo, _ := setupSessionServiceTest()
+	embeddings := NewMockEmbeddingProvider()
+	vectors := NewMockVectorStore()
+	service.SetSearchIndex(embeddings, vectors)
+	ctx := context.Background()
+
+	embeddings.SetEmbedding("Fix ChromaDB startup", domain.EmbeddingVector{0, 1, 0})
+	embeddings.SetEmbedding("ChromaDB tenant error on startup", domain.EmbeddingVector{1, 0, 0})
+	embeddings.SetEmbedding("Tenant created on startup", domain.EmbeddingVector{0.8, 0, 0.6})
+	embeddings.SetEmbedding("tenant error", domain.EmbeddingVector{1, 0, 0})
+	embeddings.SetEmbedding("startup fix", domain.EmbeddingVector{0, 1, 0})
+
+	session := domain.NewSession("proj", "Fix ChromaDB startup", "Fix ChromaDB startup")
+	if err := sessionRepo.Store(ctx, session); err != nil {
+		t.Fatalf("Failed to store session: %v", err)
+	}
+	if _, err := service.LogProgress(ctx, ports.LogProgressRequest{SessionID: session.ID, Message: "ChromaDB tenant error on startup", Type: domain.ProgressTypeIssue}); err != nil {
+		t.Fatalf("Failed to log progress: %v", err)
+	}
+	if _, err := service.CompleteSession(ctx, ports.CompleteSessionRequest{SessionID: session.ID, Outcome: "Tenant created on startup"}); err != nil {
+		t.Fatalf("Failed to complete session: %v", err)
+	}
+
+	results, err := service.SearchSessions(ctx, ports.SessionSearchRequest{Query: "tenant error", Threshold: 0.5})
+	if err != nil {
+		t.Fatalf("Failed to search sessions: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected the progress entry and the outcome to match, got %d results", len(results))
+	}
+	if results[0].Hit != ports.SessionHitProgress || results[0].Entry == nil || results[0].Entry.Message != "ChromaDB tenant error on startup" {
+		t.Errorf("Expected the progress entry first, got %+v", results[0])
+	}
+	if results[0].Timestamp.IsZero() || results[0].Session.ID != session.ID {
+		t.Errorf("Expected the entry's session and timestamp, got %+v", results[0])
+	}
+	if results[1].Hit != ports.SessionHitOutcome {
+		t.Errorf("Expected the outcome second, got %s", results[1].Hit)
+	}
+
+	other := domain.ProjectID("other")
+	results, err = service.SearchSessions(ctx, ports.SessionSearchRequest{Query: "tenant error", ProjectID: &other, Threshold: 0.5})
+	if err != nil {
+		t.Fatalf("Failed to search sessions: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("Expected no results in another project, got %d", len(results))
+	}
+
+	// Reindexing adds the task of the session stored directly and skips the
+	// completion entry
+	indexed, err := service.ReindexSessions(ctx, nil)
+	if err != nil {
+		t.Fatalf("Failed to reindex sessions: %v", err)
+	}
+	if indexed != 3 || len(vectors.vectors) != 3 {
+		t.Errorf("Expected task, progress entry and outcome to be indexed, got %d documents and %d vectors", indexed, len(vectors.vectors))
+	}
+	results, err = service.SearchSessions(ctx, ports.SessionSearchRequest{Query: "startup fix", Threshold: 0.5, Limit: 1})
+	if err != nil {
+		t.Fatalf("Failed to search sessions: %v", err)
+	}
+	if len(results) != 1 || results[0].Hit != ports.SessionHitTask {
+		t.Errorf("Expected the task to match, got %+v", results)
+	}
+}
+
+func TestSessionService_SearchSessions_ProjectScoped(t *testing.T) {
+	service, sessionRepo, _ := setupSessionServiceTest()
+	embeddings := NewMockEmbeddingProvider()
+	service.SetSearchIndex(embeddings, NewMockVectorStore())
+	ctx := context.Background()
+
+	embeddings.SetEmbedding("Migrate the schema", domain.EmbeddingVector{1, 0, 0})
+	embeddings.SetEmbedding("Migrate the schema again", domain.EmbeddingVector{0.9, 0.1, 0})
+	embeddings.SetEmbedding("schema migration", domain.EmbeddingVector{1, 0, 0})
+
+	// Better matches in another project must not crowd out the project's own session
+	for i := 0; i < 5; i++ {
+		if err := sessionRepo.Store(ctx, domain.NewSession("other", "Migrate the schema", "Migrate the schema")); err != nil {
+			t.Fatalf("Failed to store session: %v", err)
+		}
+	}
+	session := domain.NewSession("proj", "Migrate the schema again", "Migrate the schema again")
+	if err := sessionRepo.Store(ctx, session); err != nil {
+		t.Fatalf("Failed to store session: %v", err)
+	}
+	if _, err := service.ReindexSessions(ctx, nil); err != nil {
+		t.Fatalf("Failed to reindex sessions: %v", err)
+	}
+
+	projectID := domain.ProjectID("proj")
+	results, err := service.SearchSessions(ctx, ports.SessionSearchRequest{Query: "schema migration", ProjectID: &projectID, Threshold: 0.5, Limit: 1})
+	if err != nil {
+		t.Fatalf("Failed to search sessions: %v", err)
+	}
+	if len(results) != 1 || results[0].Session.ID != session.ID {
+		t.Errorf("Expected the project's session, got %+v", results)
+	}
+}
+
+func TestSemanticSearchRequest_SessionSearch(t *testing.T) {
+	decision := domain.MemoryTypeDecision
+	after := "2026-01-01"
+	tests := []struct {
+		name string
+		req  ports.SemanticSearchRequest
+		ok   bool
+	}{
+		{"free text", ports.SemanticSearchRequest{Query: "tenant"}, true},
+		{"time filter", ports.SemanticSearchRequest{Query: "tenant", Filters: &ports.SearchFilters{TimeFilter: &ports.TimeFilter{After: &after}}}, true},
+		{"memory type", ports.SemanticSearchRequest{Query: "tenant", Type: &decision}, false},
+		{"tag filter", ports.SemanticSearchRequest{Query: "tenant", Filters: &ports.SearchFilters{Tags: domain.Tags{"db"}}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessionReq, ok := tt.req.SessionSearch()
+			if ok != tt.ok {
+				t.Fatalf("Expected ok=%v, got %v", tt.ok, ok)
+			}
+			if ok && sessionReq.Query != tt.req.Query {
+				t.Errorf("Expected the query to carry over, got %q", sessionReq.Query)
+			}
+		})
+	}
+}
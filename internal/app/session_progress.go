@@ -0,0 +1,119 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+)
+
+// defaultProgressSearchLimit caps progress search results if no limit is given
+const defaultProgressSearchLimit = 50
+
+// SearchProgress returns the progress entries of all matching sessions that pass the
+// filters, newest first
+func (s *SessionService) SearchProgress(ctx context.Context, req ports.SearchProgressRequest) ([]ports.ProgressSearchResult, error) {
+	for _, entryType := range req.Types {
+		if !domain.IsValidProgressType(entryType) {
+			return nil, fmt.Errorf("invalid progress type %q: must be one of info, milestone, issue, solution", entryType)
+		}
+	}
+
+	var sessions []*domain.Session
+	if req.SessionID != nil {
+		session, err := s.sessionRepo.GetByID(ctx, *req.SessionID)
+		if err != nil {
+			return nil, fmt.Errorf("session not found: %w", err)
+		}
+		sessions = []*domain.Session{session}
+	} else {
+		var err error
+		sessions, err = s.sessionRepo.ListWithFilters(ctx, ports.SessionFilters{ProjectID: req.ProjectID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list sessions: %w", err)
+		}
+	}
+
+	words := strings.Fields(strings.ToLower(req.Query))
+	var results []ports.ProgressSearchResult
+	for _, session := range sessions {
+		for _, entry := range session.Progress {
+			if !progressMatches(entry, req, words) {
+				continue
+			}
+			results = append(results, ports.ProgressSearchResult{
+				SessionID:   session.ID,
+				SessionName: session.Name,
+				ProjectID:   session.ProjectID,
+				Entry:       entry,
+			})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Entry.Time().After(results[j].Entry.Time())
+	})
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultProgressSearchLimit
+	}
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// progressMatches reports whether a progress entry passes the search filters
+func progressMatches(entry domain.ProgressEntry, req ports.SearchProgressRequest, words []string) bool {
+	if len(req.Types) > 0 {
+		entryType := entry.Type
+		if entryType == "" {
+			entryType = domain.ProgressTypeInfo
+		}
+		found := false
+		for _, t := range req.Types {
+			if t == entryType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if req.Since != nil && entry.Time().Before(*req.Since) {
+		return false
+	}
+	if req.Ref != "" && entry.Ref != req.Ref {
+		return false
+	}
+	if req.Commit != "" && (entry.Commit == "" || !strings.HasPrefix(entry.Commit, req.Commit)) {
+		return false
+	}
+	if req.File != "" && !referencesFile(entry.Files, req.File) {
+		return false
+	}
+	message := strings.ToLower(entry.Message)
+	for _, word := range words {
+		if !strings.Contains(message, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// referencesFile reports whether one of files is path or ends in it at a path boundary
+func referencesFile(files []string, path string) bool {
+	path = filepath.ToSlash(filepath.Clean(path))
+	for _, file := range files {
+		file = filepath.ToSlash(filepath.Clean(file))
+		if file == path || strings.HasSuffix(file, "/"+path) {
+			return true
+		}
+	}
+	return false
+}
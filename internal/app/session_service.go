@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/joern1811/memory-bank/internal/domain"
 	"github.com/joern1811/memory-bank/internal/ports"
@@ -14,6 +15,21 @@ type SessionService struct {
 	sessionRepo ports.SessionRepository
 	projectRepo ports.ProjectRepository
 	logger      *logrus.Logger
+	redactor    *domain.Redactor
+	provenance  domain.Provenance
+
+	memoryService  ports.MemoryService
+	summaryOptions SessionSummaryOptions
+
+	// Semantic search over sessions, see SetSearchIndex
+	embeddingProvider ports.EmbeddingProvider
+	sessionVectors    ports.VectorStore
+
+	// Git state of sessions, see SetSourceControl
+	sourceControl ports.SourceControl
+
+	// Stale session detection and housekeeping, see SetStalePolicy
+	stalePolicy StaleSessionPolicy
 }
 
 // NewSessionService creates a new session service
@@ -23,18 +39,53 @@ func NewSessionService(
 	logger *logrus.Logger,
 ) *SessionService {
 	return &SessionService{
-		sessionRepo: sessionRepo,
-		projectRepo: projectRepo,
-		logger:      logger,
+		sessionRepo:    sessionRepo,
+		projectRepo:    projectRepo,
+		logger:         logger,
+		summaryOptions: DefaultSessionSummaryOptions(),
+		stalePolicy:    DefaultStaleSessionPolicy(),
+	}
+}
+
+// SetMemoryService enables the memories written when a session is completed
+func (s *SessionService) SetMemoryService(memoryService ports.MemoryService) {
+	s.memoryService = memoryService
+}
+
+// SetSummaryOptions configures the memories written when a session is completed
+func (s *SessionService) SetSummaryOptions(options SessionSummaryOptions) {
+	s.summaryOptions = options
+}
+
+// SetSearchIndex enables semantic search over sessions: task descriptions, progress
+// entries and outcomes are embedded into a vector store of their own as they are written
+func (s *SessionService) SetSearchIndex(embeddingProvider ports.EmbeddingProvider, vectorStore ports.VectorStore) {
+	s.embeddingProvider = embeddingProvider
+	s.sessionVectors = vectorStore
+}
+
+// SetRedactor enables masking of secrets and personal data before sessions are stored
+func (s *SessionService) SetRedactor(redactor *domain.Redactor) {
+	s.redactor = redactor
+}
+
+// SetProvenance sets the provenance recorded on writes whose context carries none
+func (s *SessionService) SetProvenance(provenance domain.Provenance) {
+	s.provenance = provenance
+}
+
+// provenanceFor returns the provenance of a write, or nil if nothing is known
+func (s *SessionService) provenanceFor(ctx context.Context) *domain.Provenance {
+	provenance := s.provenance.Merge(domain.ProvenanceFromContext(ctx))
+	if provenance.IsEmpty() {
+		return nil
 	}
+	return &provenance
 }
 
 // StartSession starts a new development session
 func (s *SessionService) StartSession(ctx context.Context, req ports.StartSessionRequest) (*domain.Session, error) {
-	s.logger.WithFields(logrus.Fields{
-		"project_id": req.ProjectID,
-		"task":       req.TaskDescription,
-	}).Info("Starting session")
+	s.logger.WithField("project_id", req.ProjectID).Info("Starting session")
 
 	// Verify project exists
 	project, err := s.projectRepo.GetByID(ctx, req.ProjectID)
@@ -42,18 +93,18 @@ func (s *SessionService) StartSession(ctx context.Context, req ports.StartSessio
 		return nil, fmt.Errorf("project not found: %w", err)
 	}
 
-	// Check if there's already an active session for this project
-	activeSession, err := s.sessionRepo.GetActiveSession(ctx, req.ProjectID)
-	if err == nil && activeSession != nil {
-		s.logger.WithField("existing_session_id", activeSession.ID).Warn("Active session already exists, aborting it")
-		activeSession.Abort("Starting new session")
-		if err := s.sessionRepo.Update(ctx, activeSession); err != nil {
-			s.logger.WithError(err).Warn("Failed to abort existing session")
-		}
-	}
+	// Pause or abort sessions of the project left open for too long
+	s.Housekeep(ctx, &req.ProjectID)
 
-	// Create new session
-	session := domain.NewSession(req.ProjectID, req.TaskDescription, req.TaskDescription)
+	// Sessions run in parallel, e.g. one per branch or agent, so others stay active
+	name := req.Name
+	if name == "" {
+		name = req.TaskDescription
+	}
+	session := domain.NewSession(req.ProjectID, name, req.TaskDescription)
+	session.Provenance = s.provenanceFor(ctx)
+	s.recordGitStart(ctx, session, project)
+	s.redact(session)
 
 	// Store session
 	if err := s.sessionRepo.Store(ctx, session); err != nil {
@@ -64,8 +115,11 @@ func (s *SessionService) StartSession(ctx context.Context, req ports.StartSessio
 	s.logger.WithFields(logrus.Fields{
 		"session_id": session.ID,
 		"project":    project.Name,
+		"task":       session.TaskDescription,
 	}).Info("Session started successfully")
 
+	s.indexSession(ctx, taskDocument(session))
+
 	return session, nil
 }
 
@@ -79,59 +133,95 @@ func (s *SessionService) GetActiveSession(ctx context.Context, projectID domain.
 	return s.sessionRepo.GetActiveSession(ctx, projectID)
 }
 
-// LogProgress adds a progress entry to a session
-func (s *SessionService) LogProgress(ctx context.Context, sessionID domain.SessionID, entry string) error {
+// LogProgress adds a typed progress entry to an active session
+func (s *SessionService) LogProgress(ctx context.Context, req ports.LogProgressRequest) (*domain.ProgressEntry, error) {
 	s.logger.WithFields(logrus.Fields{
-		"session_id": sessionID,
-		"entry":      entry,
+		"session_id": req.SessionID,
+		"type":       req.Type,
 	}).Debug("Logging session progress")
 
+	if strings.TrimSpace(req.Message) == "" {
+		return nil, fmt.Errorf("progress message is required")
+	}
+	entryType := req.Type
+	if entryType == "" {
+		entryType = domain.ProgressTypeInfo
+	}
+	if !domain.IsValidProgressType(entryType) {
+		return nil, fmt.Errorf("invalid progress type %q: must be one of info, milestone, issue, solution", req.Type)
+	}
+
 	// Get session
-	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	session, err := s.sessionRepo.GetByID(ctx, req.SessionID)
 	if err != nil {
-		return fmt.Errorf("session not found: %w", err)
+		return nil, fmt.Errorf("session not found: %w", err)
 	}
 
 	// Check if session is active
 	if !session.IsActive() {
-		return fmt.Errorf("session is not active")
+		if session.Status == domain.SessionStatusPaused {
+			return nil, fmt.Errorf("session is paused, resume it first")
+		}
+		return nil, fmt.Errorf("session is not active")
 	}
 
 	// Add progress entry
-	session.LogInfo(entry)
+	var files []string
+	for _, file := range req.Files {
+		if file = strings.TrimSpace(file); file != "" {
+			files = append(files, file)
+		}
+	}
+	session.AddProgress(domain.ProgressEntry{
+		Message: req.Message,
+		Type:    entryType,
+		Files:   files,
+		Ref:     strings.TrimSpace(req.Ref),
+		Commit:  strings.TrimSpace(req.Commit),
+	})
+	session.LastModifiedBy = s.provenanceFor(ctx)
+	s.redact(session)
 
 	// Update session
 	if err := s.sessionRepo.Update(ctx, session); err != nil {
-		return fmt.Errorf("failed to update session: %w", err)
+		return nil, fmt.Errorf("failed to update session: %w", err)
 	}
 
-	return nil
+	index := len(session.Progress) - 1
+	s.indexSession(ctx, progressDocument(session, index))
+
+	entry := session.Progress[index]
+	return &entry, nil
 }
 
-// CompleteSession marks a session as completed
-func (s *SessionService) CompleteSession(ctx context.Context, sessionID domain.SessionID, outcome string) error {
+// CompleteSession marks a session as completed and, with a memory service, writes a
+// summary memory and optionally error solutions distilled from its progress log
+func (s *SessionService) CompleteSession(ctx context.Context, req ports.CompleteSessionRequest) (*ports.CompleteSessionResult, error) {
 	s.logger.WithFields(logrus.Fields{
-		"session_id": sessionID,
-		"outcome":    outcome,
+		"session_id": req.SessionID,
+		"outcome":    req.Outcome,
 	}).Info("Completing session")
 
 	// Get session
-	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	session, err := s.sessionRepo.GetByID(ctx, req.SessionID)
 	if err != nil {
-		return fmt.Errorf("session not found: %w", err)
+		return nil, fmt.Errorf("session not found: %w", err)
 	}
 
-	// Check if session is active
-	if !session.IsActive() {
-		return fmt.Errorf("session is not active")
+	// Active and paused sessions can be completed
+	if !session.IsOpen() {
+		return nil, fmt.Errorf("session is not active")
 	}
 
 	// Complete session
-	session.Complete(outcome)
+	session.Complete(req.Outcome)
+	s.recordGitEnd(ctx, session)
+	session.LastModifiedBy = s.provenanceFor(ctx)
+	s.redact(session)
 
 	// Update session
 	if err := s.sessionRepo.Update(ctx, session); err != nil {
-		return fmt.Errorf("failed to update session: %w", err)
+		return nil, fmt.Errorf("failed to update session: %w", err)
 	}
 
 	s.logger.WithFields(logrus.Fields{
@@ -139,7 +229,18 @@ func (s *SessionService) CompleteSession(ctx context.Context, sessionID domain.S
 		"duration":   session.Duration(),
 	}).Info("Session completed successfully")
 
-	return nil
+	if session.Outcome != "" {
+		s.indexSession(ctx, outcomeDocument(session))
+	}
+
+	result := &ports.CompleteSessionResult{Session: session}
+	distill := s.summaryOptions.DistillSolutions
+	if req.Distill != nil {
+		distill = *req.Distill
+	}
+	s.writeSessionMemories(ctx, result, distill)
+
+	return result, nil
 }
 
 // AbortSession marks a session as aborted
@@ -152,13 +253,14 @@ func (s *SessionService) AbortSession(ctx context.Context, sessionID domain.Sess
 		return fmt.Errorf("session not found: %w", err)
 	}
 
-	// Check if session is active
-	if !session.IsActive() {
+	// Active and paused sessions can be aborted
+	if !session.IsOpen() {
 		return fmt.Errorf("session is not active")
 	}
 
 	// Abort session
 	session.Abort("Manually aborted")
+	session.LastModifiedBy = s.provenanceFor(ctx)
 
 	// Update session
 	if err := s.sessionRepo.Update(ctx, session); err != nil {
@@ -173,36 +275,151 @@ func (s *SessionService) AbortSession(ctx context.Context, sessionID domain.Sess
 	return nil
 }
 
+// PauseSession pauses an active session; paused time does not count towards its duration
+func (s *SessionService) PauseSession(ctx context.Context, sessionID domain.SessionID) error {
+	s.logger.WithField("session_id", sessionID).Info("Pausing session")
+
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("session not found: %w", err)
+	}
+	if err := session.Pause(); err != nil {
+		return err
+	}
+	session.LastModifiedBy = s.provenanceFor(ctx)
+
+	if err := s.sessionRepo.Update(ctx, session); err != nil {
+		return fmt.Errorf("failed to update session: %w", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"session_id": session.ID,
+		"duration":   session.Duration(),
+	}).Info("Session paused")
+
+	return nil
+}
+
+// ResumeSession resumes a paused session
+func (s *SessionService) ResumeSession(ctx context.Context, sessionID domain.SessionID) error {
+	s.logger.WithField("session_id", sessionID).Info("Resuming session")
+
+	session, err := s.sessionRepo.GetByID(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("session not found: %w", err)
+	}
+	if err := session.Resume(); err != nil {
+		return err
+	}
+	session.LastModifiedBy = s.provenanceFor(ctx)
+
+	if err := s.sessionRepo.Update(ctx, session); err != nil {
+		return fmt.Errorf("failed to update session: %w", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"session_id":      session.ID,
+		"paused_duration": session.PausedDuration,
+	}).Info("Session resumed")
+
+	return nil
+}
+
+// FindSession finds a session of a project by ID or by name. Without a reference it
+// returns the project's only session with the given status and fails if there are
+// several, since a project can have parallel sessions.
+func (s *SessionService) FindSession(ctx context.Context, projectID domain.ProjectID, ref string, status domain.SessionStatus) (*domain.Session, error) {
+	if ref != "" {
+		if session, err := s.sessionRepo.GetByID(ctx, domain.SessionID(ref)); err == nil {
+			return session, nil
+		}
+	}
+
+	filters := ports.SessionFilters{ProjectID: &projectID}
+	if ref == "" {
+		filters.Status = &status
+	}
+	sessions, err := s.sessionRepo.ListWithFilters(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	var matches []*domain.Session
+	for _, session := range sessions {
+		if ref == "" || (session.Name == ref && session.IsOpen()) {
+			matches = append(matches, session)
+		}
+	}
+
+	switch {
+	case len(matches) == 1:
+		return matches[0], nil
+	case len(matches) == 0 && ref != "":
+		return nil, fmt.Errorf("session not found: %s", ref)
+	case len(matches) == 0:
+		return nil, fmt.Errorf("no %s session found for project: %s", status, projectID)
+	}
+
+	candidates := make([]string, len(matches))
+	for i, session := range matches {
+		candidates[i] = fmt.Sprintf("%s (%s)", session.ID, session.Name)
+	}
+	if ref != "" {
+		return nil, fmt.Errorf("several sessions are named %q, specify one of: %s", ref, strings.Join(candidates, ", "))
+	}
+	return nil, fmt.Errorf("project %s has %d %s sessions, specify one of: %s", projectID, len(matches), status, strings.Join(candidates, ", "))
+}
+
 // Update updates an existing session
 func (s *SessionService) Update(ctx context.Context, session *domain.Session) error {
+	session.LastModifiedBy = s.provenanceFor(ctx)
+	s.redact(session)
 	return s.sessionRepo.Update(ctx, session)
 }
 
+// redact masks secrets in a session before it is stored
+func (s *SessionService) redact(session *domain.Session) {
+	if s.redactor == nil {
+		return
+	}
+	if redactions := s.redactor.RedactSession(session); len(redactions) > 0 {
+		s.logger.WithFields(logrus.Fields{
+			"session_id": session.ID,
+			"redactions": redactions,
+		}).Warn("Masked secrets in session")
+	}
+}
+
 // ListSessions lists sessions with filters
 func (s *SessionService) ListSessions(ctx context.Context, filters ports.SessionFilters) ([]*domain.Session, error) {
 	return s.sessionRepo.ListWithFilters(ctx, filters)
 }
 
-// AbortActiveSessionsForProject aborts all active sessions for a project
+// AbortActiveSessionsForProject aborts all active and paused sessions for a project
 func (s *SessionService) AbortActiveSessionsForProject(ctx context.Context, projectID domain.ProjectID) ([]domain.SessionID, error) {
 	s.logger.WithField("project_id", projectID).Info("Aborting all active sessions for project")
 
-	// Get all active sessions for the project
-	filters := ports.SessionFilters{
-		ProjectID: &projectID,
-		Status:    &[]domain.SessionStatus{domain.SessionStatusActive}[0],
-		Limit:     100, // Reasonable limit for batch abort
-	}
+	// Get all open sessions for the project
+	var sessions []*domain.Session
+	for _, status := range []domain.SessionStatus{domain.SessionStatusActive, domain.SessionStatusPaused} {
+		filters := ports.SessionFilters{
+			ProjectID: &projectID,
+			Status:    &status,
+			Limit:     100, // Reasonable limit for batch abort
+		}
 
-	sessions, err := s.sessionRepo.ListWithFilters(ctx, filters)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list active sessions: %w", err)
+		found, err := s.sessionRepo.ListWithFilters(ctx, filters)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s sessions: %w", status, err)
+		}
+		sessions = append(sessions, found...)
 	}
 
 	var abortedIDs []domain.SessionID
 	for _, session := range sessions {
-		if session.IsActive() {
+		if session.IsOpen() {
 			session.Abort("Project-wide session abort")
+			session.LastModifiedBy = s.provenanceFor(ctx)
 			if err := s.sessionRepo.Update(ctx, session); err != nil {
 				s.logger.WithError(err).WithField("session_id", session.ID).Warn("Failed to abort session")
 				continue
@@ -113,7 +113,7 @@ func TestSessionService_StartSession_ProjectNotFound(t *testing.T) {
 	}
 }
 
-func TestSessionService_StartSession_AbortsExistingActiveSession(t *testing.T) {
+func TestSessionService_StartSession_KeepsParallelSessions(t *testing.T) {
 	service, sessionRepo, projectRepo := setupSessionServiceTest()
 	ctx := context.Background()
 
@@ -156,10 +156,11 @@ func TestSessionService_StartSession_AbortsExistingActiveSession(t *testing.T) {
 		t.Error("Expected first session to be active")
 	}
 
-	// Start second session via service (should abort first)
+	// Start second session via service (should keep the first one running)
 	req2 := ports.StartSessionRequest{
 		ProjectID:       projectID,
 		TaskDescription: "Second task " + generateUniqueTestID("task"),
+		Name:            "feature/login",
 	}
 
 	session2, err := service.StartSession(ctx, req2)
@@ -171,14 +172,39 @@ func TestSessionService_StartSession_AbortsExistingActiveSession(t *testing.T) {
 	if !session2.IsActive() {
 		t.Error("Expected second session to be active")
 	}
+	if session2.Name != "feature/login" {
+		t.Errorf("Expected Name 'feature/login', got '%s'", session2.Name)
+	}
 
-	// Verify first session was aborted
+	// Verify first session is still active
 	updated1, err := sessionRepo.GetByID(ctx, session1.ID)
 	if err != nil {
 		t.Fatalf("Failed to retrieve first session: %v", err)
 	}
-	if updated1.Status != domain.SessionStatusAborted {
-		t.Errorf("Expected first session to be aborted, got status: %s", updated1.Status)
+	if updated1.Status != domain.SessionStatusActive {
+		t.Errorf("Expected first session to stay active, got status: %s", updated1.Status)
+	}
+
+	// Without a reference, the active session is ambiguous
+	_, err = service.FindSession(ctx, projectID, "", domain.SessionStatusActive)
+	if err == nil || !strings.Contains(err.Error(), "specify one of") {
+		t.Errorf("Expected an ambiguity error, got: %v", err)
+	}
+
+	// Sessions can be found by ID or name
+	found, err := service.FindSession(ctx, projectID, "feature/login", domain.SessionStatusActive)
+	if err != nil {
+		t.Fatalf("Failed to find session by name: %v", err)
+	}
+	if found.ID != session2.ID {
+		t.Errorf("Expected session %s, got %s", session2.ID, found.ID)
+	}
+	found, err = service.FindSession(ctx, projectID, string(session1ID), domain.SessionStatusActive)
+	if err != nil {
+		t.Fatalf("Failed to find session by ID: %v", err)
+	}
+	if found.ID != session1ID {
+		t.Errorf("Expected session %s, got %s", session1ID, found.ID)
 	}
 }
 
@@ -322,7 +348,7 @@ func TestSessionService_LogProgress(t *testing.T) {
 
 	// Test logging progress
 	progressMessage := "Implemented user authentication middleware"
-	err = service.LogProgress(ctx, sessionID, progressMessage)
+	_, err = service.LogProgress(ctx, ports.LogProgressRequest{SessionID: sessionID, Message: progressMessage})
 	if err != nil {
 		t.Fatalf("Failed to log progress: %v", err)
 	}
@@ -346,6 +372,33 @@ func TestSessionService_LogProgress(t *testing.T) {
 	}
 }
 
+func TestSessionService_LogProgress_Redaction(t *testing.T) {
+	service, sessionRepo, _ := setupSessionServiceTest()
+	service.SetRedactor(domain.NewRedactor(domain.DefaultRedactionRules(), nil))
+	ctx := context.Background()
+
+	session := domain.NewSession("proj", "Debug deploy", "Debug deploy")
+	if err := sessionRepo.Store(ctx, session); err != nil {
+		t.Fatalf("Failed to store test session: %v", err)
+	}
+
+	if _, err := service.LogProgress(ctx, ports.LogProgressRequest{SessionID: session.ID, Message: "Deploy used DB_PASSWORD=hunter2024 from the env dump"}); err != nil {
+		t.Fatalf("Failed to log progress: %v", err)
+	}
+
+	updated, err := sessionRepo.GetByID(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve updated session: %v", err)
+	}
+	entry := updated.Progress[0]
+	if entry.Message != "Deploy used DB_PASSWORD=[REDACTED:password] from the env dump" {
+		t.Errorf("Expected the password to be masked, got %q", entry.Message)
+	}
+	if len(entry.Redactions) != 1 || entry.Redactions[0].Rule != "password" {
+		t.Errorf("Expected the redaction to be recorded on the entry, got %+v", entry.Redactions)
+	}
+}
+
 func TestSessionService_LogProgress_SessionNotActive(t *testing.T) {
 	service, sessionRepo, _ := setupSessionServiceTest()
 	ctx := context.Background()
@@ -372,7 +425,7 @@ func TestSessionService_LogProgress_SessionNotActive(t *testing.T) {
 	}
 
 	// Test logging progress to inactive session
-	err = service.LogProgress(ctx, sessionID, "Should fail")
+	_, err = service.LogProgress(ctx, ports.LogProgressRequest{SessionID: sessionID, Message: "Should fail"})
 	if err == nil {
 		t.Error("Expected error when logging progress to inactive session")
 	}
@@ -381,6 +434,106 @@ func TestSessionService_LogProgress_SessionNotActive(t *testing.T) {
 	}
 }
 
+func TestSessionService_LogProgress_TypedEntry(t *testing.T) {
+	service, sessionRepo, _ := setupSessionServiceTest()
+	ctx := context.Background()
+
+	session := domain.NewSession("proj", "Fix login", "Fix login")
+	if err := sessionRepo.Store(ctx, session); err != nil {
+		t.Fatalf("Failed to store test session: %v", err)
+	}
+
+	entry, err := service.LogProgress(ctx, ports.LogProgressRequest{
+		SessionID: session.ID,
+		Message:   "Token refresh races with logout",
+		Type:      domain.ProgressTypeIssue,
+		Files:     []string{"internal/auth/token.go", " "},
+		Ref:       "task-42",
+		Commit:    "abc1234",
+	})
+	if err != nil {
+		t.Fatalf("Failed to log progress: %v", err)
+	}
+	if entry.Type != domain.ProgressTypeIssue || entry.Timestamp == "" {
+		t.Errorf("Expected a timestamped issue entry, got %+v", entry)
+	}
+
+	updated, err := sessionRepo.GetByID(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve updated session: %v", err)
+	}
+	stored := updated.Progress[0]
+	if len(stored.Files) != 1 || stored.Files[0] != "internal/auth/token.go" {
+		t.Errorf("Expected the blank file to be dropped, got %v", stored.Files)
+	}
+	if stored.Ref != "task-42" || stored.Commit != "abc1234" {
+		t.Errorf("Expected ref and commit to be stored, got %+v", stored)
+	}
+
+	if _, err := service.LogProgress(ctx, ports.LogProgressRequest{SessionID: session.ID, Message: "x", Type: "bug"}); err == nil {
+		t.Error("Expected an error for an unknown progress type")
+	}
+}
+
+func TestSessionService_SearchProgress(t *testing.T) {
+	service, sessionRepo, _ := setupSessionServiceTest()
+	ctx := context.Background()
+
+	first := domain.NewSession("proj", "Auth", "Auth")
+	first.AddProgress(domain.ProgressEntry{Timestamp: "2026-10-01T10:00:00Z", Message: "Token refresh fails after logout", Type: domain.ProgressTypeIssue, Files: []string{"internal/auth/token.go"}})
+	first.AddProgress(domain.ProgressEntry{Timestamp: "2026-10-01T11:00:00Z", Message: "Clear the refresh token on logout", Type: domain.ProgressTypeSolution, Commit: "abc1234"})
+	second := domain.NewSession("proj", "Search", "Search")
+	second.AddProgress(domain.ProgressEntry{Timestamp: "2026-10-02T09:00:00Z", Message: "Refresh index on startup", Type: domain.ProgressTypeInfo, Ref: "task-7"})
+	other := domain.NewSession("other", "Other", "Other")
+	other.AddProgress(domain.ProgressEntry{Timestamp: "2026-10-03T09:00:00Z", Message: "Refresh cache", Type: domain.ProgressTypeInfo})
+	for _, session := range []*domain.Session{first, second, other} {
+		if err := sessionRepo.Store(ctx, session); err != nil {
+			t.Fatalf("Failed to store test session: %v", err)
+		}
+	}
+
+	projectID := domain.ProjectID("proj")
+	tests := []struct {
+		name     string
+		req      ports.SearchProgressRequest
+		expected []string
+	}{
+		{"query across sessions, newest first", ports.SearchProgressRequest{ProjectID: &projectID, Query: "REFRESH"},
+			[]string{"Refresh index on startup", "Clear the refresh token on logout", "Token refresh fails after logout"}},
+		{"all query words", ports.SearchProgressRequest{ProjectID: &projectID, Query: "refresh logout"},
+			[]string{"Clear the refresh token on logout", "Token refresh fails after logout"}},
+		{"type", ports.SearchProgressRequest{ProjectID: &projectID, Types: []string{domain.ProgressTypeIssue}},
+			[]string{"Token refresh fails after logout"}},
+		{"file suffix", ports.SearchProgressRequest{File: "auth/token.go"},
+			[]string{"Token refresh fails after logout"}},
+		{"commit prefix", ports.SearchProgressRequest{Commit: "abc"},
+			[]string{"Clear the refresh token on logout"}},
+		{"ref", ports.SearchProgressRequest{Ref: "task-7"},
+			[]string{"Refresh index on startup"}},
+		{"limit", ports.SearchProgressRequest{Query: "refresh", Limit: 1},
+			[]string{"Refresh cache"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := service.SearchProgress(ctx, tt.req)
+			if err != nil {
+				t.Fatalf("Failed to search progress: %v", err)
+			}
+			var messages []string
+			for _, result := range results {
+				messages = append(messages, result.Entry.Message)
+			}
+			if strings.Join(messages, "|") != strings.Join(tt.expected, "|") {
+				t.Errorf("Expected %v, got %v", tt.expected, messages)
+			}
+		})
+	}
+
+	if _, err := service.SearchProgress(ctx, ports.SearchProgressRequest{Types: []string{"bug"}}); err == nil {
+		t.Error("Expected an error for an unknown progress type")
+	}
+}
+
 func TestSessionService_CompleteSession(t *testing.T) {
 	service, sessionRepo, _ := setupSessionServiceTest()
 	ctx := context.Background()
@@ -406,7 +559,7 @@ func TestSessionService_CompleteSession(t *testing.T) {
 
 	// Test completing the session
 	outcome := "Successfully implemented user authentication"
-	err = service.CompleteSession(ctx, sessionID, outcome)
+	_, err = service.CompleteSession(ctx, ports.CompleteSessionRequest{SessionID: sessionID, Outcome: outcome})
 	if err != nil {
 		t.Fatalf("Failed to complete session: %v", err)
 	}
@@ -470,7 +623,7 @@ func TestSessionService_CompleteSession_NotActive(t *testing.T) {
 	}
 
 	// Test completing already completed session
-	err = service.CompleteSession(ctx, sessionID, "Should fail")
+	_, err = service.CompleteSession(ctx, ports.CompleteSessionRequest{SessionID: sessionID, Outcome: "Should fail"})
 	if err == nil {
 		t.Error("Expected error when completing already completed session")
 	}
@@ -572,6 +725,81 @@ func TestSessionService_AbortSession_NotActive(t *testing.T) {
 	}
 }
 
+func TestSessionService_PauseResumeSession(t *testing.T) {
+	service, sessionRepo, _ := setupSessionServiceTest()
+	ctx := context.Background()
+
+	projectID := domain.ProjectID(generateUniqueTestID("proj"))
+	sessionID := domain.SessionID(generateUniqueTestID("sess"))
+	testSession := &domain.Session{
+		ID:              sessionID,
+		ProjectID:       projectID,
+		Name:            "Pausable Session",
+		TaskDescription: "Pausable task",
+		StartTime:       time.Now().Add(-time.Hour),
+		Status:          domain.SessionStatusActive,
+		Progress:        make([]domain.ProgressEntry, 0),
+		Tags:            make(domain.Tags, 0),
+	}
+	if err := sessionRepo.Store(ctx, testSession); err != nil {
+		t.Fatalf("Failed to store session: %v", err)
+	}
+
+	if err := service.PauseSession(ctx, sessionID); err != nil {
+		t.Fatalf("Failed to pause session: %v", err)
+	}
+	paused, _ := sessionRepo.GetByID(ctx, sessionID)
+	if paused.Status != domain.SessionStatusPaused || paused.PausedAt == nil {
+		t.Errorf("Expected a paused session, got status %s", paused.Status)
+	}
+
+	// Paused sessions reject progress and a second pause
+	_, err := service.LogProgress(ctx, ports.LogProgressRequest{SessionID: sessionID, Message: "while paused"})
+	if err == nil || !strings.Contains(err.Error(), "resume it first") {
+		t.Errorf("Expected 'resume it first' error, got: %v", err)
+	}
+	if err := service.PauseSession(ctx, sessionID); err == nil {
+		t.Error("Expected error when pausing a paused session")
+	}
+
+	found, err := service.FindSession(ctx, projectID, "", domain.SessionStatusPaused)
+	if err != nil || found.ID != sessionID {
+		t.Fatalf("Expected to find the paused session, got %v, %v", found, err)
+	}
+
+	// Simulate a pause of ten minutes
+	pausedAt := time.Now().Add(-10 * time.Minute)
+	paused.PausedAt = &pausedAt
+	if err := service.ResumeSession(ctx, sessionID); err != nil {
+		t.Fatalf("Failed to resume session: %v", err)
+	}
+	resumed, _ := sessionRepo.GetByID(ctx, sessionID)
+	if resumed.Status != domain.SessionStatusActive || resumed.PausedAt != nil {
+		t.Errorf("Expected an active session, got status %s", resumed.Status)
+	}
+	if resumed.PausedDuration < 10*time.Minute {
+		t.Errorf("Expected at least 10m paused, got %s", resumed.PausedDuration)
+	}
+	if resumed.Duration() > 50*time.Minute+time.Second {
+		t.Errorf("Expected paused time to be excluded from the duration, got %s", resumed.Duration())
+	}
+	if err := service.ResumeSession(ctx, sessionID); err == nil {
+		t.Error("Expected error when resuming an active session")
+	}
+
+	// Paused sessions can be completed
+	if err := service.PauseSession(ctx, sessionID); err != nil {
+		t.Fatalf("Failed to pause session again: %v", err)
+	}
+	if _, err := service.CompleteSession(ctx, ports.CompleteSessionRequest{SessionID: sessionID, Outcome: "Done"}); err != nil {
+		t.Fatalf("Failed to complete paused session: %v", err)
+	}
+	completed, _ := sessionRepo.GetByID(ctx, sessionID)
+	if completed.Status != domain.SessionStatusCompleted || completed.PausedAt != nil {
+		t.Errorf("Expected a completed session without pause, got status %s", completed.Status)
+	}
+}
+
 func TestSessionService_ListSessions(t *testing.T) {
 	service, sessionRepo, _ := setupSessionServiceTest()
 	ctx := context.Background()
@@ -875,3 +1103,239 @@ func TestSessionService_Update(t *testing.T) {
 		t.Errorf("Expected tag 'updated', got %v", updated.Tags)
 	}
 }
+
+func TestSessionService_Provenance(t *testing.T) {
+	service, sessionRepo, projectRepo := setupSessionServiceTest()
+	service.SetProvenance(domain.Provenance{Author: "alice", Source: domain.ProvenanceSourceCLI})
+	ctx := context.Background()
+
+	project := domain.NewProject("Test Project", "/test/path", "Test description")
+	if err := projectRepo.Store(ctx, project); err != nil {
+		t.Fatalf("Failed to store project: %v", err)
+	}
+
+	session, err := service.StartSession(ctx, ports.StartSessionRequest{ProjectID: project.ID, TaskDescription: "Add login"})
+	if err != nil {
+		t.Fatalf("Failed to start session: %v", err)
+	}
+	if session.Provenance == nil || session.Provenance.Author != "alice" || session.LastModifiedBy != nil {
+		t.Fatalf("Expected the starter to be recorded, got %+v / %+v", session.Provenance, session.LastModifiedBy)
+	}
+
+	agentCtx := domain.WithProvenance(ctx, domain.Provenance{Source: domain.ProvenanceSourceMCP, Client: "cursor"})
+	if _, err := service.LogProgress(agentCtx, ports.LogProgressRequest{SessionID: session.ID, Message: "Wrote the handler"}); err != nil {
+		t.Fatalf("Failed to log progress: %v", err)
+	}
+	stored, err := sessionRepo.GetByID(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("Failed to get session: %v", err)
+	}
+	if stored.LastModifiedBy == nil || stored.LastModifiedBy.Client != "cursor" || stored.Provenance.Source != domain.ProvenanceSourceCLI {
+		t.Errorf("Unexpected provenance after progress: %+v / %+v", stored.Provenance, stored.LastModifiedBy)
+	}
+}
+
+func TestSessionService_CompleteSession_WritesMemories(t *testing.T) {
+	service, sessionRepo, _ := setupSessionServiceTest()
+	memoryService, _, _, _ := setupMemoryServiceTest()
+	memoryService.SetSessionRepository(sessionRepo)
+	service.SetMemoryService(memoryService)
+	ctx := context.Background()
+
+	projectID := domain.ProjectID(generateUniqueTestID("proj"))
+	session := domain.NewSession(projectID, "chroma", "Fix ChromaDB tenant errors")
+	session.LogMilestone("Reproduced the failure locally")
+	session.LogIssue("ChromaDB returns 404 for the default tenant")
+	session.LogInfo("Checked the server version")
+	session.LogSolution("Create the tenant on startup")
+	session.LogIssue("Flaky health check")
+	if err := sessionRepo.Store(ctx, session); err != nil {
+		t.Fatalf("Failed to store session: %v", err)
+	}
+
+	// A commit recorded by 'git scan-commits' while the session was active
+	commit, err := memoryService.CreateMemory(ctx, ports.CreateMemoryRequest{
+		ProjectID: projectID,
+		Type:      domain.MemoryTypeSession,
+		Title:     "Git Progress: Task 42 (progress)",
+		Content:   "Git commit abcdef12: Create ChromaDB tenant on startup",
+		Tags:      domain.Tags{"git-progress"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create commit memory: %v", err)
+	}
+	if commit.Provenance == nil || commit.Provenance.SessionID == nil || *commit.Provenance.SessionID != session.ID {
+		t.Fatalf("Expected the commit memory to be attributed to the session, got %+v", commit.Provenance)
+	}
+
+	distill := true
+	result, err := service.CompleteSession(ctx, ports.CompleteSessionRequest{SessionID: session.ID, Outcome: "Tenant created on startup", Distill: &distill})
+	if err != nil {
+		t.Fatalf("Failed to complete session: %v", err)
+	}
+
+	summary := result.Summary
+	if summary == nil {
+		t.Fatal("Expected a summary memory")
+	}
+	if summary.Type != domain.MemoryTypeSession || summary.SessionID == nil || *summary.SessionID != session.ID {
+		t.Errorf("Expected a session memory linked to %s, got %s %v", session.ID, summary.Type, summary.SessionID)
+	}
+	if !summary.Tags.Contains(SessionSummaryTag) {
+		t.Errorf("Expected tag %s, got %v", SessionSummaryTag, summary.Tags)
+	}
+	for _, expected := range []string{
+		"## Outcome\nTenant created on startup",
+		"## Milestones\n- Reproduced the failure locally",
+		"## Issues\n- ChromaDB returns 404 for the default tenant\n- Flaky health check",
+		"## Notes\n- Checked the server version",
+		"## Commits\n- Git commit abcdef12: Create ChromaDB tenant on startup",
+	} {
+		if !strings.Contains(summary.Content, expected) {
+			t.Errorf("Expected summary to contain %q, got:\n%s", expected, summary.Content)
+		}
+	}
+	if strings.Contains(summary.Content, "Session completed") {
+		t.Errorf("Expected lifecycle entries to be left out, got:\n%s", summary.Content)
+	}
+
+	// Only the issue followed by a solution is distilled
+	if len(result.Distilled) != 1 {
+		t.Fatalf("Expected one distilled error solution, got %d", len(result.Distilled))
+	}
+	distilled := result.Distilled[0]
+	if distilled.Type != domain.MemoryTypeErrorSolution || distilled.Title != "ChromaDB returns 404 for the default tenant" {
+		t.Errorf("Unexpected distilled memory %s %q", distilled.Type, distilled.Title)
+	}
+	if distilled.SessionID == nil || *distilled.SessionID != session.ID || !distilled.Tags.Contains(DistilledTag) {
+		t.Errorf("Expected the distilled memory to be linked to the session and tagged, got %v %v", distilled.SessionID, distilled.Tags)
+	}
+}
+
+func TestSessionService_CompleteSession_SummaryDisabled(t *testing.T) {
+	service, sessionRepo, _ := setupSessionServiceTest()
+	memoryService, _, _, _ := setupMemoryServiceTest()
+	service.SetMemoryService(memoryService)
+	service.SetSummaryOptions(SessionSummaryOptions{})
+	ctx := context.Background()
+
+	session := domain.NewSession(domain.ProjectID(generateUniqueTestID("proj")), "task", "Task")
+	session.LogIssue("Broken build")
+	session.LogSolution("Pinned the toolchain")
+	if err := sessionRepo.Store(ctx, session); err != nil {
+		t.Fatalf("Failed to store session: %v", err)
+	}
+
+	result, err := service.CompleteSession(ctx, ports.CompleteSessionRequest{SessionID: session.ID, Outcome: "done"})
+	if err != nil {
+		t.Fatalf("Failed to complete session: %v", err)
+	}
+	if result.Summary != nil || len(result.Distilled) != 0 {
+		t.Errorf("Expected no memories, got summary %v and %d distilled", result.Summary, len(result.Distilled))
+	}
+}
+
+func TestSessionService_RecordsGitState(t *testing.T) {
+	service, _, projectRepo := setupSessionServiceTest()
+	ctx := context.Background()
+
+	sourceControl := &stubSourceControl{head: "1111111aaaa", branch: "feature/cache"}
+	service.SetSourceControl(sourceControl)
+
+	project := domain.NewProject("Test", "/repo", "")
+	if err := projectRepo.Store(ctx, project); err != nil {
+		t.Fatalf("Failed to store project: %v", err)
+	}
+
+	session, err := service.StartSession(ctx, ports.StartSessionRequest{ProjectID: project.ID, TaskDescription: "Add cache"})
+	if err != nil {
+		t.Fatalf("Failed to start session: %v", err)
+	}
+	if session.Git == nil || session.Git.Branch != "feature/cache" || session.Git.StartCommit != "1111111aaaa" {
+		t.Fatalf("Expected branch and start commit, got %+v", session.Git)
+	}
+
+	sourceControl.head = "2222222bbbb"
+	sourceControl.changes = domain.GitChanges{
+		Commits:    []domain.GitCommit{{Hash: "2222222bbbb", Subject: "Add cache"}},
+		Files:      []domain.GitFileChange{{Path: "cache.go", Status: domain.GitFileAdded, Insertions: 5}},
+		Insertions: 5,
+	}
+	result, err := service.CompleteSession(ctx, ports.CompleteSessionRequest{SessionID: session.ID, Outcome: "Done"})
+	if err != nil {
+		t.Fatalf("Failed to complete session: %v", err)
+	}
+
+	stored, err := service.GetSession(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("Failed to get session: %v", err)
+	}
+	git := stored.Git
+	if git == nil || git.EndCommit != "2222222bbbb" || len(git.Commits) != 1 || len(git.Files) != 1 || git.Insertions != 5 {
+		t.Fatalf("Expected end commit and changes, got %+v", git)
+	}
+	if git.Range() != "1111111..2222222" {
+		t.Errorf("Expected range 1111111..2222222, got %s", git.Range())
+	}
+	if lines := gitSummaryLines(result.Session.Git); len(lines) != 3 || lines[2] != "2222222 Add cache" {
+		t.Errorf("Expected branch, range and commit summary lines, got %v", lines)
+	}
+}
+
+func TestSessionService_RecordsNoGitChangesFromAnotherBranch(t *testing.T) {
+	service, _, projectRepo := setupSessionServiceTest()
+	ctx := context.Background()
+
+	sourceControl := &stubSourceControl{head: "1111111aaaa", branch: "feature/cache"}
+	service.SetSourceControl(sourceControl)
+
+	project := domain.NewProject("Test", "/repo", "")
+	if err := projectRepo.Store(ctx, project); err != nil {
+		t.Fatalf("Failed to store project: %v", err)
+	}
+
+	session, err := service.StartSession(ctx, ports.StartSessionRequest{ProjectID: project.ID, TaskDescription: "Add cache"})
+	if err != nil {
+		t.Fatalf("Failed to start session: %v", err)
+	}
+
+	// A parallel session switched the checkout to its own branch in the meantime
+	sourceControl.branch = "fix/login"
+	sourceControl.head = "3333333cccc"
+	sourceControl.changes = domain.GitChanges{
+		Commits: []domain.GitCommit{{Hash: "3333333cccc", Subject: "Fix login"}},
+	}
+	if _, err := service.CompleteSession(ctx, ports.CompleteSessionRequest{SessionID: session.ID, Outcome: "Done"}); err != nil {
+		t.Fatalf("Failed to complete session: %v", err)
+	}
+
+	stored, err := service.GetSession(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("Failed to get session: %v", err)
+	}
+	if stored.Git == nil || stored.Git.StartCommit != "1111111aaaa" {
+		t.Fatalf("Expected the start state to be kept, got %+v", stored.Git)
+	}
+	if stored.Git.EndCommit != "" || len(stored.Git.Commits) != 0 {
+		t.Errorf("Expected no changes from another branch, got %+v", stored.Git)
+	}
+}
+
+func TestSessionService_RecordsNoGitStateOutsideRepository(t *testing.T) {
+	service, _, projectRepo := setupSessionServiceTest()
+	ctx := context.Background()
+	service.SetSourceControl(&stubSourceControl{})
+
+	project := domain.NewProject("Test", "", "")
+	if err := projectRepo.Store(ctx, project); err != nil {
+		t.Fatalf("Failed to store project: %v", err)
+	}
+
+	session, err := service.StartSession(ctx, ports.StartSessionRequest{ProjectID: project.ID, TaskDescription: "Notes"})
+	if err != nil {
+		t.Fatalf("Failed to start session: %v", err)
+	}
+	if session.Git != nil {
+		t.Errorf("Expected no git state without a project path, got %+v", session.Git)
+	}
+}
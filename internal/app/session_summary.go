@@ -0,0 +1,248 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+)
+
+const (
+	// SessionSummaryTag marks the memory summarising a completed session
+	SessionSummaryTag = "session-summary"
+	// DistilledTag marks error solutions distilled from a session's progress log
+	DistilledTag = "distilled"
+
+	// gitProgressTag marks the memories 'git scan-commits' writes for commits
+	gitProgressTag = "git-progress"
+
+	// maxSummaryNotes limits the info entries in a summary to the most recent ones
+	maxSummaryNotes = 20
+)
+
+// SessionSummaryOptions controls the memories written when a session is completed
+type SessionSummaryOptions struct {
+	// Enabled writes a session memory from the progress log, outcome and linked commits
+	Enabled bool
+	// DistillSolutions turns issue entries followed by a solution entry into
+	// error_solution memories
+	DistillSolutions bool
+}
+
+// DefaultSessionSummaryOptions returns the default summary options: a summary memory
+// without distilled error solutions
+func DefaultSessionSummaryOptions() SessionSummaryOptions {
+	return SessionSummaryOptions{Enabled: true}
+}
+
+// writeSessionMemories writes the summary and distilled memories of a completed
+// session. Failures are logged; the session stays completed.
+func (s *SessionService) writeSessionMemories(ctx context.Context, result *ports.CompleteSessionResult, distill bool) {
+	if s.memoryService == nil {
+		return
+	}
+	session := result.Session
+
+	if s.summaryOptions.Enabled {
+		summary, err := s.memoryService.CreateMemory(ctx, s.summaryRequest(ctx, session))
+		if err != nil {
+			s.logger.WithError(err).WithField("session_id", session.ID).Warn("Failed to write session summary memory")
+		} else {
+			result.Summary = summary
+		}
+	}
+
+	if !distill {
+		return
+	}
+	for _, pair := range issueSolutions(session.Progress) {
+		errorSolution, err := s.memoryService.CreateErrorSolution(ctx, ports.CreateErrorSolutionRequest{
+			CreateMemoryRequest: ports.CreateMemoryRequest{
+				ProjectID: session.ProjectID,
+				SessionID: &session.ID,
+				Type:      domain.MemoryTypeErrorSolution,
+				Title:     truncateText(pair.issue.Message, 80),
+				Context:   fmt.Sprintf("Distilled from session %s: %s", session.ID, session.TaskDescription),
+				Tags:      domain.Tags{DistilledTag},
+			},
+			ErrorSignature: pair.issue.Message,
+			Solution:       pair.solution.Message,
+		})
+		if err != nil {
+			s.logger.WithError(err).WithField("session_id", session.ID).Warn("Failed to distill error solution")
+			continue
+		}
+		result.Distilled = append(result.Distilled, errorSolution.Memory)
+	}
+}
+
+// summaryRequest builds the session memory summarising a completed session
+func (s *SessionService) summaryRequest(ctx context.Context, session *domain.Session) ports.CreateMemoryRequest {
+	commits, memories := s.linkedMemories(ctx, session)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Task\n%s\n\n", session.TaskDescription)
+	outcome := session.Outcome
+	if outcome == "" {
+		outcome = "No outcome recorded"
+	}
+	fmt.Fprintf(&b, "## Outcome\n%s\n\nActive time: %s\n", outcome, session.Duration().Truncate(time.Second))
+
+	var milestones, issues, solutions, notes []string
+	for _, entry := range session.Progress {
+		if session.IsLifecycleEntry(entry) {
+			continue
+		}
+		switch entry.Type {
+		case domain.ProgressTypeMilestone:
+			milestones = append(milestones, entry.Message)
+		case domain.ProgressTypeIssue:
+			issues = append(issues, entry.Message)
+		case domain.ProgressTypeSolution:
+			solutions = append(solutions, entry.Message)
+		default:
+			notes = append(notes, entry.Message)
+		}
+	}
+	if len(notes) > maxSummaryNotes {
+		notes = append([]string{fmt.Sprintf("(%d earlier notes omitted)", len(notes)-maxSummaryNotes)}, notes[len(notes)-maxSummaryNotes:]...)
+	}
+	writeSummarySection(&b, "Milestones", milestones)
+	writeSummarySection(&b, "Issues", issues)
+	writeSummarySection(&b, "Solutions", solutions)
+	writeSummarySection(&b, "Notes", notes)
+
+	var commitLines, memoryLines []string
+	for _, memory := range commits {
+		commitLines = append(commitLines, memory.Content)
+	}
+	for _, memory := range memories {
+		memoryLines = append(memoryLines, fmt.Sprintf("%s: %s (%s)", memory.Type, memory.Title, memory.ID))
+	}
+	writeSummarySection(&b, "Commits", commitLines)
+	writeSummarySection(&b, "Git", gitSummaryLines(session.Git))
+	writeSummarySection(&b, "Memories", memoryLines)
+
+	tags := append(domain.Tags{SessionSummaryTag}, session.Tags...)
+	return ports.CreateMemoryRequest{
+		ProjectID: session.ProjectID,
+		SessionID: &session.ID,
+		Type:      domain.MemoryTypeSession,
+		Title:     "Session: " + truncateText(session.TaskDescription, 80),
+		Content:   strings.TrimSpace(b.String()),
+		Context:   fmt.Sprintf("Session %s (%s), %s", session.ID, session.Name, session.StartTime.Format("2006-01-02 15:04")),
+		Tags:      tags,
+	}
+}
+
+// linkedMemories returns the commits and other memories written in a session, oldest
+// first: those linked to it and those created while it was active
+func (s *SessionService) linkedMemories(ctx context.Context, session *domain.Session) (commits, memories []*domain.Memory) {
+	projectMemories, err := s.memoryService.ListMemories(ctx, ports.ListMemoriesRequest{ProjectID: &session.ProjectID})
+	if err != nil {
+		s.logger.WithError(err).WithField("session_id", session.ID).Warn("Failed to list memories of session")
+		return nil, nil
+	}
+
+	var linked []*domain.Memory
+	for _, memory := range projectMemories {
+		inSession := memory.SessionID != nil && *memory.SessionID == session.ID
+		if memory.Provenance != nil && memory.Provenance.SessionID != nil && *memory.Provenance.SessionID == session.ID {
+			inSession = true
+		}
+		if inSession && !memory.Tags.Contains(SessionSummaryTag) {
+			linked = append(linked, memory)
+		}
+	}
+	sort.Slice(linked, func(i, j int) bool { return linked[i].CreatedAt.Before(linked[j].CreatedAt) })
+
+	for _, memory := range linked {
+		if memory.Tags.Contains(gitProgressTag) {
+			commits = append(commits, memory)
+		} else {
+			memories = append(memories, memory)
+		}
+	}
+	return commits, memories
+}
+
+// issueSolution is an issue entry and the solution entry that resolved it
+type issueSolution struct {
+	issue, solution domain.ProgressEntry
+}
+
+// issueSolutions pairs each solution entry with the latest unresolved issue before it
+func issueSolutions(progress []domain.ProgressEntry) []issueSolution {
+	var open []domain.ProgressEntry
+	var pairs []issueSolution
+	for _, entry := range progress {
+		switch entry.Type {
+		case domain.ProgressTypeIssue:
+			open = append(open, entry)
+		case domain.ProgressTypeSolution:
+			if len(open) == 0 {
+				continue
+			}
+			pairs = append(pairs, issueSolution{issue: open[len(open)-1], solution: entry})
+			open = open[:len(open)-1]
+		}
+	}
+	return pairs
+}
+
+// unresolvedIssues returns the issue entries that no later solution entry resolved,
+// pairing solutions with issues like issueSolutions
+func unresolvedIssues(progress []domain.ProgressEntry) []domain.ProgressEntry {
+	var open []domain.ProgressEntry
+	for _, entry := range progress {
+		switch entry.Type {
+		case domain.ProgressTypeIssue:
+			open = append(open, entry)
+		case domain.ProgressTypeSolution:
+			if len(open) > 0 {
+				open = open[:len(open)-1]
+			}
+		}
+	}
+	return open
+}
+
+// gitSummaryLines describes the branch, commit range and changes a session covered
+func gitSummaryLines(git *domain.SessionGit) []string {
+	if git == nil || git.EndCommit == "" {
+		return nil
+	}
+	var lines []string
+	if git.Branch != "" {
+		lines = append(lines, "Branch: "+git.Branch)
+	}
+	lines = append(lines, fmt.Sprintf("Range: %s (%d commits, %d files changed, +%d -%d)",
+		git.Range(), len(git.Commits), len(git.Files), git.Insertions, git.Deletions))
+	for _, commit := range git.Commits {
+		lines = append(lines, commit.ShortHash()+" "+commit.Subject)
+	}
+	return lines
+}
+
+func writeSummarySection(b *strings.Builder, title string, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n## %s\n", title)
+	for _, line := range lines {
+		fmt.Fprintf(b, "- %s\n", line)
+	}
+}
+
+// truncateText shortens text to at most n runes, marking the cut with "..."
+func truncateText(text string, n int) string {
+	runes := []rune(text)
+	if len(runes) <= n {
+		return text
+	}
+	return string(runes[:n-3]) + "..."
+}
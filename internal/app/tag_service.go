@@ -0,0 +1,261 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/sirupsen/logrus"
+)
+
+// TagService implements listing, renaming and merging of tags across memories
+// as well as the review of tags added or suggested by the auto-tagger
+type TagService struct {
+	memoryService *MemoryService
+	memoryRepo    ports.MemoryRepository
+	normalizer    domain.TagNormalizer
+	autoTagger    *AutoTagger
+	logger        *logrus.Logger
+}
+
+// NewTagService creates a new tag service. Tag changes are stored through memoryService,
+// whose normalizer is used for all tags.
+func NewTagService(memoryService *MemoryService, memoryRepo ports.MemoryRepository, logger *logrus.Logger) *TagService {
+	return &TagService{
+		memoryService: memoryService,
+		memoryRepo:    memoryRepo,
+		normalizer:    memoryService.TagNormalizer(),
+		logger:        logger,
+	}
+}
+
+// SetAutoTagger enables on-demand tag suggestions
+func (s *TagService) SetAutoTagger(tagger *AutoTagger) {
+	s.autoTagger = tagger
+}
+
+// ListTags returns usage counts for all tags, or only for a prefix and the tags below it.
+// With an origin only tags added by hand or by the auto-tagger are counted.
+func (s *TagService) ListTags(ctx context.Context, req ports.ListTagsRequest) ([]domain.TagCount, error) {
+	memories, err := s.memoryRepo.ListTagged(ctx, req.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tagged memories: %w", err)
+	}
+
+	if req.Origin != "" {
+		filtered := make([]*domain.Memory, 0, len(memories))
+		for _, memory := range memories {
+			tags := memory.TagsWithOrigin(req.Origin)
+			if len(tags) > 0 {
+				filtered = append(filtered, &domain.Memory{ID: memory.ID, Tags: tags})
+			}
+		}
+		memories = filtered
+	}
+
+	return domain.CountTags(memories, s.normalizer.Normalize(req.Prefix)), nil
+}
+
+// RenameTag renames a tag in every memory using it. Tags below it keep their
+// lower levels, so renaming "db" to "infra/db" turns "db/sqlite" into "infra/db/sqlite".
+func (s *TagService) RenameTag(ctx context.Context, req ports.RenameTagRequest) (*ports.TagChangeResult, error) {
+	return s.MergeTags(ctx, ports.MergeTagsRequest{
+		ProjectID: req.ProjectID,
+		Sources:   []string{req.From},
+		Target:    req.To,
+		DryRun:    req.DryRun,
+	})
+}
+
+// MergeTags replaces each source tag, and the tags below it, with the target tag
+func (s *TagService) MergeTags(ctx context.Context, req ports.MergeTagsRequest) (*ports.TagChangeResult, error) {
+	target := s.normalizer.Normalize(req.Target)
+	if target == "" {
+		return nil, fmt.Errorf("target tag is required")
+	}
+
+	// Sources name tags as stored, so aliases are not resolved for them
+	sourceNormalizer := domain.TagNormalizer{Lowercase: s.normalizer.Lowercase}
+
+	var sources []string
+	for _, source := range req.Sources {
+		source = sourceNormalizer.Normalize(source)
+		if source == "" {
+			return nil, fmt.Errorf("source tag cannot be empty")
+		}
+		if source == target {
+			continue
+		}
+		if domain.TagMatches(target, source) {
+			return nil, fmt.Errorf("cannot move tag %s below itself (%s)", source, target)
+		}
+		sources = append(sources, source)
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("at least one source tag different from %s is required", target)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"project_id": req.ProjectID,
+		"sources":    sources,
+		"target":     target,
+		"dry_run":    req.DryRun,
+	}).Info("Merging tags")
+
+	return s.updateTags(ctx, req.ProjectID, req.DryRun, func(tags *domain.Tags) bool {
+		changed := false
+		for _, source := range sources {
+			if tags.Rename(source, target) {
+				changed = true
+			}
+		}
+		return changed
+	})
+}
+
+// NormalizeTags applies the configured normalization to the tags of existing memories
+func (s *TagService) NormalizeTags(ctx context.Context, projectID *domain.ProjectID, dryRun bool) (*ports.TagChangeResult, error) {
+	s.logger.WithFields(logrus.Fields{
+		"project_id": projectID,
+		"dry_run":    dryRun,
+	}).Info("Normalizing tags")
+
+	return s.updateTags(ctx, projectID, dryRun, func(tags *domain.Tags) bool {
+		normalized := s.normalizer.NormalizeTags(*tags)
+		if equalTags(normalized, *tags) {
+			return false
+		}
+		*tags = normalized
+		return true
+	})
+}
+
+// SuggestTags runs the auto-tagger on a memory. With apply the suggestions are added
+// as auto tags, otherwise they are only returned.
+func (s *TagService) SuggestTags(ctx context.Context, id domain.MemoryID, apply bool) (*ports.TagSuggestionResult, error) {
+	if s.autoTagger == nil {
+		return nil, fmt.Errorf("auto-tagging is not available")
+	}
+
+	memory, err := s.memoryRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get memory: %w", err)
+	}
+
+	suggestions, err := s.autoTagger.Suggest(ctx, memory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest tags: %w", err)
+	}
+
+	result := &ports.TagSuggestionResult{MemoryID: id, Suggestions: suggestions}
+	if apply && len(suggestions) > 0 {
+		tags := make([]string, 0, len(suggestions))
+		for _, suggestion := range suggestions {
+			tags = append(tags, suggestion.Tag)
+		}
+		memory.ApplyAutoTags(tags)
+		if err := s.memoryService.UpdateMemoryMetadata(ctx, memory); err != nil {
+			return nil, err
+		}
+		result.Applied = true
+	}
+	return result, nil
+}
+
+// ListPendingReview returns the memories with auto or suggested tags that have not been reviewed
+func (s *TagService) ListPendingReview(ctx context.Context, projectID *domain.ProjectID) ([]*domain.Memory, error) {
+	memories, err := s.memoryRepo.ListPendingTagReview(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list memories pending tag review: %w", err)
+	}
+	return memories, nil
+}
+
+// AcceptTags turns auto and suggested tags of a memory into manual tags, all pending tags if none are given
+func (s *TagService) AcceptTags(ctx context.Context, id domain.MemoryID, tags []string) (*ports.TagReviewResult, error) {
+	return s.reviewTags(ctx, id, tags, (*domain.Memory).AcceptTags)
+}
+
+// RejectTags removes auto and suggested tags from a memory, all pending tags if none are given
+func (s *TagService) RejectTags(ctx context.Context, id domain.MemoryID, tags []string) (*ports.TagReviewResult, error) {
+	return s.reviewTags(ctx, id, tags, (*domain.Memory).RejectTags)
+}
+
+func (s *TagService) reviewTags(ctx context.Context, id domain.MemoryID, tags []string, review func(*domain.Memory, ...string) domain.Tags) (*ports.TagReviewResult, error) {
+	memory, err := s.memoryRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get memory: %w", err)
+	}
+
+	changed := review(memory, s.normalizer.NormalizeTags(tags)...)
+	if len(changed) > 0 {
+		if err := s.memoryService.UpdateMemoryMetadata(ctx, memory); err != nil {
+			return nil, err
+		}
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"memory_id": id,
+		"tags":      changed,
+	}).Info("Reviewed pending tags")
+
+	if changed == nil {
+		changed = domain.Tags{}
+	}
+	return &ports.TagReviewResult{
+		MemoryID: id,
+		Changed:  changed,
+		Tags:     memory.Tags,
+		Pending:  memory.PendingTags(),
+	}, nil
+}
+
+// updateTags applies change to the tags of every tagged memory and stores the memories that changed.
+// Auto and suggested tags are changed the same way so that tag origins are kept.
+// The update time is left alone so that bulk tag edits do not affect recency ranking.
+func (s *TagService) updateTags(ctx context.Context, projectID *domain.ProjectID, dryRun bool, change func(*domain.Tags) bool) (*ports.TagChangeResult, error) {
+	memories, err := s.memoryRepo.ListTagged(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tagged memories: %w", err)
+	}
+
+	result := &ports.TagChangeResult{MemoryIDs: []domain.MemoryID{}, DryRun: dryRun}
+	for _, memory := range memories {
+		tags := append(domain.Tags(nil), memory.Tags...)
+		autoTags := append(domain.Tags(nil), memory.AutoTags...)
+		suggestedTags := append(domain.Tags(nil), memory.SuggestedTags...)
+		tagsChanged := change(&tags)
+		autoChanged := change(&autoTags)
+		suggestedChanged := change(&suggestedTags)
+		if !tagsChanged && !autoChanged && !suggestedChanged {
+			continue
+		}
+		if !dryRun {
+			memory.Tags = tags
+			memory.AutoTags = autoTags
+			memory.SuggestedTags = suggestedTags
+			memory.ReconcileTagOrigins()
+			if err := s.memoryService.UpdateMemoryMetadata(ctx, memory); err != nil {
+				return result, fmt.Errorf("memory %s: %w", memory.ID, err)
+			}
+		}
+		result.MemoryIDs = append(result.MemoryIDs, memory.ID)
+	}
+	result.Updated = len(result.MemoryIDs)
+
+	s.logger.WithField("updated", result.Updated).Info("Tags updated")
+	return result, nil
+}
+
+func equalTags(a, b domain.Tags) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,139 @@
+package app
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/sirupsen/logrus"
+)
+
+func setupTagServiceTest(t *testing.T, tagSets ...domain.Tags) (*TagService, *MockMemoryRepository, []*domain.Memory) {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	memoryRepo := NewMockMemoryRepository()
+	var memories []*domain.Memory
+	for _, tags := range tagSets {
+		memory := domain.NewMemory("proj", domain.MemoryTypeDecision, "Title", "Content", "")
+		memory.Tags = tags
+		if err := memoryRepo.Store(context.Background(), memory); err != nil {
+			t.Fatalf("Failed to store memory: %v", err)
+		}
+		memories = append(memories, memory)
+	}
+
+	memoryService := NewMemoryService(memoryRepo, NewMockEmbeddingProvider(), NewMockVectorStore(), logger)
+	memoryService.SetTagNormalizer(domain.TagNormalizer{Lowercase: true, Aliases: map[string]string{"authn": "auth"}})
+	memoryService.SetProvenance(domain.Provenance{Author: "alice", Source: domain.ProvenanceSourceCLI})
+	return NewTagService(memoryService, memoryRepo, logger), memoryRepo, memories
+}
+
+func TestTagService_ListTags(t *testing.T) {
+	service, _, _ := setupTagServiceTest(t,
+		domain.Tags{"auth", "infra/db/sqlite"},
+		domain.Tags{"auth"},
+		domain.Tags{},
+	)
+
+	counts, err := service.ListTags(context.Background(), ports.ListTagsRequest{})
+	if err != nil {
+		t.Fatalf("Failed to list tags: %v", err)
+	}
+	expected := []domain.TagCount{
+		{Tag: "auth", Count: 2, Total: 2},
+		{Tag: "infra", Count: 0, Total: 1},
+		{Tag: "infra/db", Count: 0, Total: 1},
+		{Tag: "infra/db/sqlite", Count: 1, Total: 1},
+	}
+	if !reflect.DeepEqual(counts, expected) {
+		t.Errorf("Unexpected tag counts:\n got %+v\nwant %+v", counts, expected)
+	}
+}
+
+func TestTagService_RenameTag(t *testing.T) {
+	service, memoryRepo, memories := setupTagServiceTest(t,
+		domain.Tags{"db", "db/sqlite"},
+		domain.Tags{"api"},
+	)
+	ctx := context.Background()
+
+	if _, err := service.RenameTag(ctx, ports.RenameTagRequest{From: "db", To: "db/legacy"}); err == nil {
+		t.Error("Expected error when renaming a tag below itself")
+	}
+
+	// A dry run reports the change without storing it
+	result, err := service.RenameTag(ctx, ports.RenameTagRequest{From: "DB", To: "infra/db", DryRun: true})
+	if err != nil {
+		t.Fatalf("Failed to rename tag: %v", err)
+	}
+	if result.Updated != 1 || !reflect.DeepEqual(memories[0].Tags, domain.Tags{"db", "db/sqlite"}) {
+		t.Errorf("Expected a dry run to leave tags unchanged, got %+v / %v", result, memories[0].Tags)
+	}
+
+	result, err = service.RenameTag(ctx, ports.RenameTagRequest{From: "db", To: "infra/db"})
+	if err != nil {
+		t.Fatalf("Failed to rename tag: %v", err)
+	}
+	if result.Updated != 1 || result.MemoryIDs[0] != memories[0].ID {
+		t.Errorf("Expected one updated memory, got %+v", result)
+	}
+
+	updated, _ := memoryRepo.GetByID(ctx, memories[0].ID)
+	if !reflect.DeepEqual(updated.Tags, domain.Tags{"infra/db", "infra/db/sqlite"}) {
+		t.Errorf("Unexpected tags after rename: %v", updated.Tags)
+	}
+	if updated.LastModifiedBy == nil || updated.LastModifiedBy.Author != "alice" {
+		t.Errorf("Expected the rename to record who modified the memory, got %+v", updated.LastModifiedBy)
+	}
+}
+
+func TestTagService_MergeTags(t *testing.T) {
+	service, memoryRepo, memories := setupTagServiceTest(t,
+		domain.Tags{"authentication", "api"},
+		domain.Tags{"authn"},
+		domain.Tags{"auth", "authentication"},
+	)
+	ctx := context.Background()
+
+	result, err := service.MergeTags(ctx, ports.MergeTagsRequest{Sources: []string{"authentication", "authn"}, Target: "auth"})
+	if err != nil {
+		t.Fatalf("Failed to merge tags: %v", err)
+	}
+	if result.Updated != 3 {
+		t.Errorf("Expected 3 updated memories, got %d", result.Updated)
+	}
+
+	expected := []domain.Tags{{"auth", "api"}, {"auth"}, {"auth"}}
+	for i, memory := range memories {
+		stored, _ := memoryRepo.GetByID(ctx, memory.ID)
+		if !reflect.DeepEqual(stored.Tags, expected[i]) {
+			t.Errorf("Memory %d: expected %v, got %v", i, expected[i], stored.Tags)
+		}
+	}
+}
+
+func TestTagService_NormalizeTags(t *testing.T) {
+	service, memoryRepo, memories := setupTagServiceTest(t,
+		domain.Tags{"Auth", "authn", "Error Handling"},
+		domain.Tags{"api"},
+	)
+	ctx := context.Background()
+
+	result, err := service.NormalizeTags(ctx, nil, false)
+	if err != nil {
+		t.Fatalf("Failed to normalize tags: %v", err)
+	}
+	if result.Updated != 1 {
+		t.Errorf("Expected 1 updated memory, got %d", result.Updated)
+	}
+
+	stored, _ := memoryRepo.GetByID(ctx, memories[0].ID)
+	if !reflect.DeepEqual(stored.Tags, domain.Tags{"auth", "error-handling"}) {
+		t.Errorf("Unexpected normalized tags: %v", stored.Tags)
+	}
+}
@@ -185,10 +185,11 @@ func (s *taskService) ListTasks(ctx context.Context, filters ports.TaskFilters)
 	// Convert task filters to memory filters
 	taskType := domain.MemoryTypeTask
 	memoryFilters := ports.ListMemoriesRequest{
-		ProjectID: filters.ProjectID,
-		Type:      &taskType,
-		Tags:      filters.Tags,
-		Limit:     filters.Limit,
+		ProjectID:  filters.ProjectID,
+		ProjectIDs: filters.ProjectIDs,
+		Type:       &taskType,
+		Tags:       filters.Tags,
+		Limit:      filters.Limit,
 	}
 
 	memories, err := s.memoryService.ListMemories(ctx, memoryFilters)
@@ -394,6 +395,33 @@ func (s *taskService) GetTaskStatistics(ctx context.Context, projectID domain.Pr
 		return nil, err
 	}
 
+	return s.taskStatistics(tasks), nil
+}
+
+// GetTaskStatisticsForProjects aggregates the task statistics of several projects,
+// e.g. of a workspace, and counts the tasks per project
+func (s *taskService) GetTaskStatisticsForProjects(ctx context.Context, projectIDs []domain.ProjectID) (*ports.TaskStatistics, error) {
+	if len(projectIDs) == 0 {
+		return nil, fmt.Errorf("at least one project is required")
+	}
+
+	tasks, err := s.ListTasks(ctx, ports.TaskFilters{ProjectIDs: projectIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := s.taskStatistics(tasks)
+	stats.TasksByProject = make(map[domain.ProjectID]int, len(projectIDs))
+	for _, projectID := range projectIDs {
+		stats.TasksByProject[projectID] = 0
+	}
+	for _, task := range tasks {
+		stats.TasksByProject[task.ProjectID]++
+	}
+	return stats, nil
+}
+
+func (s *taskService) taskStatistics(tasks []*domain.Task) *ports.TaskStatistics {
 	stats := &ports.TaskStatistics{
 		TotalTasks:      len(tasks),
 		TasksByPriority: make(map[domain.Priority]int),
@@ -445,7 +473,7 @@ func (s *taskService) GetTaskStatistics(ctx context.Context, projectID domain.Pr
 		stats.CompletionRate = float64(stats.CompletedTasks) / float64(stats.TotalTasks) * 100
 	}
 
-	return stats, nil
+	return stats
 }
 
 func (s *taskService) GetTaskEfficiencyReport(ctx context.Context, projectID domain.ProjectID) (*ports.EfficiencyReport, error) {
@@ -129,6 +129,39 @@ func (m *mockMemoryService) RegenerateEmbedding(ctx context.Context, memoryID do
 func (m *mockMemoryService) CleanupEmbeddings(ctx context.Context, projectID domain.ProjectID) (*ports.CleanupResult, error) {
 	return nil, nil
 }
+func (m *mockMemoryService) SetPinned(ctx context.Context, id domain.MemoryID, pinned bool) (*domain.Memory, error) {
+	return nil, nil
+}
+func (m *mockMemoryService) ListPinnedMemories(ctx context.Context, projectID domain.ProjectID) ([]*domain.Memory, error) {
+	return nil, nil
+}
+func (m *mockMemoryService) RecordAccess(ctx context.Context, ids []domain.MemoryID) error {
+	return nil
+}
+func (m *mockMemoryService) ListUnusedMemories(ctx context.Context, projectID *domain.ProjectID, unusedFor time.Duration) ([]*domain.Memory, error) {
+	return nil, nil
+}
+
+func (m *mockMemoryService) ListMemoryTypes(ctx context.Context, projectID *domain.ProjectID) []domain.MemoryTypeDefinition {
+	return domain.NewMemoryTypeRegistry().Types(projectID)
+}
+
+func (m *mockMemoryService) ListReviewQueue(ctx context.Context, projectID *domain.ProjectID) ([]*domain.Memory, error) {
+	return nil, nil
+}
+func (m *mockMemoryService) ApproveMemory(ctx context.Context, id domain.MemoryID) (*domain.Memory, error) {
+	return nil, nil
+}
+func (m *mockMemoryService) RejectMemory(ctx context.Context, id domain.MemoryID) error {
+	return nil
+}
+func (m *mockMemoryService) MergeMemory(ctx context.Context, draftID, targetID domain.MemoryID) (*domain.Memory, error) {
+	return nil, nil
+}
+
+func (m *mockMemoryService) PromoteMemory(ctx context.Context, req ports.PromoteMemoryRequest) (*domain.Memory, error) {
+	return nil, nil
+}
 
 // NotFoundError represents a resource not found error
 type NotFoundError struct {
@@ -0,0 +1,170 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/sirupsen/logrus"
+)
+
+// WorkspaceService implements the workspace use cases
+type WorkspaceService struct {
+	workspaceRepo ports.WorkspaceRepository
+	projectRepo   ports.ProjectRepository
+	logger        *logrus.Logger
+}
+
+// NewWorkspaceService creates a new workspace service
+func NewWorkspaceService(
+	workspaceRepo ports.WorkspaceRepository,
+	projectRepo ports.ProjectRepository,
+	logger *logrus.Logger,
+) *WorkspaceService {
+	return &WorkspaceService{
+		workspaceRepo: workspaceRepo,
+		projectRepo:   projectRepo,
+		logger:        logger,
+	}
+}
+
+// CreateWorkspace creates a new workspace with a unique name
+func (s *WorkspaceService) CreateWorkspace(ctx context.Context, req ports.CreateWorkspaceRequest) (*domain.Workspace, error) {
+	s.logger.WithField("name", req.Name).Info("Creating workspace")
+
+	workspace := domain.NewWorkspace(req.Name, req.Description)
+	if err := workspace.Validate(); err != nil {
+		return nil, err
+	}
+
+	if existing, err := s.workspaceRepo.GetByName(ctx, workspace.Name); err == nil && existing != nil {
+		return nil, fmt.Errorf("workspace already exists: %s", workspace.Name)
+	}
+
+	if err := s.workspaceRepo.Store(ctx, workspace); err != nil {
+		return nil, fmt.Errorf("failed to store workspace: %w", err)
+	}
+
+	s.logger.WithField("workspace_id", workspace.ID).Info("Workspace created successfully")
+	return workspace, nil
+}
+
+// ResolveWorkspace retrieves a workspace by ID or, failing that, by name
+func (s *WorkspaceService) ResolveWorkspace(ctx context.Context, idOrName string) (*domain.Workspace, error) {
+	if workspace, err := s.workspaceRepo.GetByID(ctx, domain.WorkspaceID(idOrName)); err == nil {
+		return workspace, nil
+	}
+
+	workspace, err := s.workspaceRepo.GetByName(ctx, idOrName)
+	if err != nil {
+		return nil, fmt.Errorf("workspace not found: %s", idOrName)
+	}
+	return workspace, nil
+}
+
+// ListWorkspaces lists all workspaces
+func (s *WorkspaceService) ListWorkspaces(ctx context.Context) ([]*domain.Workspace, error) {
+	return s.workspaceRepo.List(ctx)
+}
+
+// DeleteWorkspace deletes a workspace. Its projects are detached by the repository
+// and keep their memories.
+func (s *WorkspaceService) DeleteWorkspace(ctx context.Context, id domain.WorkspaceID) error {
+	s.logger.WithField("workspace_id", id).Info("Deleting workspace")
+
+	if err := s.workspaceRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete workspace: %w", err)
+	}
+	return nil
+}
+
+// AddProject attaches a project to a workspace, moving it from its previous workspace
+func (s *WorkspaceService) AddProject(ctx context.Context, id domain.WorkspaceID, projectID domain.ProjectID) error {
+	s.logger.WithFields(logrus.Fields{
+		"workspace_id": id,
+		"project_id":   projectID,
+	}).Info("Adding project to workspace")
+
+	if _, err := s.workspaceRepo.GetByID(ctx, id); err != nil {
+		return fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+	if project.InWorkspace(id) {
+		return nil
+	}
+
+	project.WorkspaceID = &id
+	if err := s.projectRepo.Update(ctx, project); err != nil {
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+	return nil
+}
+
+// AttachProject adds a project to the workspace with the given ID or name, creating
+// a workspace of that name if none exists
+func (s *WorkspaceService) AttachProject(ctx context.Context, idOrName string, projectID domain.ProjectID) (*domain.Workspace, error) {
+	workspace, err := s.ResolveWorkspace(ctx, idOrName)
+	if err != nil {
+		workspace, err = s.CreateWorkspace(ctx, ports.CreateWorkspaceRequest{Name: idOrName})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.AddProject(ctx, workspace.ID, projectID); err != nil {
+		return nil, err
+	}
+	return workspace, nil
+}
+
+// RemoveProject detaches a project from its workspace
+func (s *WorkspaceService) RemoveProject(ctx context.Context, projectID domain.ProjectID) error {
+	s.logger.WithField("project_id", projectID).Info("Removing project from workspace")
+
+	project, err := s.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+	if project.WorkspaceID == nil {
+		return fmt.Errorf("project %s is not in a workspace", projectID)
+	}
+
+	project.WorkspaceID = nil
+	if err := s.projectRepo.Update(ctx, project); err != nil {
+		return fmt.Errorf("failed to update project: %w", err)
+	}
+	return nil
+}
+
+// ListProjects lists the projects of a workspace ordered by name
+func (s *WorkspaceService) ListProjects(ctx context.Context, id domain.WorkspaceID) ([]*domain.Project, error) {
+	projects, err := s.projectRepo.ListByWorkspace(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspace projects: %w", err)
+	}
+	return projects, nil
+}
+
+// ProjectIDs returns the IDs of the projects of a workspace for workspace-wide search,
+// listing and statistics. A workspace without projects is an error, as an empty
+// project filter would not restrict the results at all.
+func (s *WorkspaceService) ProjectIDs(ctx context.Context, id domain.WorkspaceID) ([]domain.ProjectID, error) {
+	projects, err := s.ListProjects(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(projects) == 0 {
+		return nil, fmt.Errorf("workspace %s has no projects", id)
+	}
+
+	ids := make([]domain.ProjectID, len(projects))
+	for i, project := range projects {
+		ids[i] = project.ID
+	}
+	return ids, nil
+}
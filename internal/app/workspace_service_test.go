@@ -0,0 +1,136 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/sirupsen/logrus"
+)
+
+func TestWorkspaceService(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	projectRepo := NewMockProjectRepository()
+	service := NewWorkspaceService(NewMockWorkspaceRepository(), projectRepo, logger)
+	ctx := context.Background()
+
+	workspace, err := service.CreateWorkspace(ctx, ports.CreateWorkspaceRequest{Name: "platform"})
+	if err != nil {
+		t.Fatalf("Failed to create workspace: %v", err)
+	}
+	if _, err := service.CreateWorkspace(ctx, ports.CreateWorkspaceRequest{Name: "platform"}); err == nil {
+		t.Error("Expected duplicate workspace names to be rejected")
+	}
+
+	// Resolve by ID and by name
+	for _, ref := range []string{string(workspace.ID), "platform"} {
+		if resolved, err := service.ResolveWorkspace(ctx, ref); err != nil || resolved.ID != workspace.ID {
+			t.Errorf("Failed to resolve workspace %q: %v", ref, err)
+		}
+	}
+	if _, err := service.ResolveWorkspace(ctx, "unknown"); err == nil {
+		t.Error("Expected an unknown workspace to be rejected")
+	}
+
+	if _, err := service.ProjectIDs(ctx, workspace.ID); err == nil {
+		t.Error("Expected a workspace without projects to be rejected for filtering")
+	}
+
+	for _, name := range []string{"web", "api", "tools"} {
+		project := domain.NewProject(name, "/repo/"+name, "")
+		project.ID = domain.ProjectID(name)
+		if err := projectRepo.Store(ctx, project); err != nil {
+			t.Fatalf("Failed to store project: %v", err)
+		}
+	}
+	for _, projectID := range []domain.ProjectID{"web", "api"} {
+		if err := service.AddProject(ctx, workspace.ID, projectID); err != nil {
+			t.Fatalf("Failed to add project: %v", err)
+		}
+	}
+	if err := service.AddProject(ctx, "missing", "tools"); err == nil {
+		t.Error("Expected adding to an unknown workspace to fail")
+	}
+
+	ids, err := service.ProjectIDs(ctx, workspace.ID)
+	if err != nil || len(ids) != 2 || ids[0] != "api" || ids[1] != "web" {
+		t.Errorf("Expected api and web, got %v, %v", ids, err)
+	}
+
+	if err := service.RemoveProject(ctx, "web"); err != nil {
+		t.Fatalf("Failed to remove project: %v", err)
+	}
+	if err := service.RemoveProject(ctx, "web"); err == nil {
+		t.Error("Expected removing a project without workspace to fail")
+	}
+	if projects, _ := service.ListProjects(ctx, workspace.ID); len(projects) != 1 || projects[0].ID != "api" {
+		t.Errorf("Expected only api to remain, got %+v", projects)
+	}
+
+	// Attaching to an unknown workspace name creates the workspace
+	attached, err := service.AttachProject(ctx, "tooling", "tools")
+	if err != nil {
+		t.Fatalf("Failed to attach project: %v", err)
+	}
+	if attached.Name != "tooling" {
+		t.Errorf("Expected workspace tooling, got %s", attached.Name)
+	}
+	if again, err := service.AttachProject(ctx, "tooling", "web"); err != nil || again.ID != attached.ID {
+		t.Errorf("Expected the existing workspace to be reused, got %+v, %v", again, err)
+	}
+}
+
+func TestWorkspaceSearchAndTaskStatistics(t *testing.T) {
+	memoryService, _, _, _ := setupMemoryServiceTest()
+	memoryService.SetScopes(domain.ScopeSettings{Weight: 1})
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	taskService := NewTaskService(memoryService, logger)
+	ctx := context.Background()
+	workspace := []domain.ProjectID{"api", "web"}
+
+	for _, projectID := range []domain.ProjectID{"api", "web", "other"} {
+		if _, err := memoryService.CreateMemory(ctx, ports.CreateMemoryRequest{
+			ProjectID: projectID, Type: domain.MemoryTypeDecision, Title: "Auth in " + string(projectID), Content: "Use JWT",
+		}); err != nil {
+			t.Fatalf("Failed to create memory: %v", err)
+		}
+		if _, err := taskService.CreateTask(ctx, ports.CreateTaskRequest{
+			ProjectID: projectID, Title: "Release " + string(projectID), Priority: domain.PriorityHigh,
+		}); err != nil {
+			t.Fatalf("Failed to create task: %v", err)
+		}
+	}
+
+	decision := domain.MemoryTypeDecision
+	listed, err := memoryService.ListMemories(ctx, ports.ListMemoriesRequest{ProjectIDs: workspace, Type: &decision})
+	if err != nil || len(listed) != 2 {
+		t.Fatalf("Expected the decisions of both workspace projects, got %d, %v", len(listed), err)
+	}
+
+	results, err := memoryService.SearchMemories(ctx, ports.SemanticSearchRequest{Query: "auth", ProjectIDs: workspace, Type: &decision, Limit: 10, Threshold: -1e9})
+	if err != nil {
+		t.Fatalf("Failed to search: %v", err)
+	}
+	if len(results) != 2 {
+		t.Errorf("Expected results of the workspace projects only, got %d", len(results))
+	}
+	for _, result := range results {
+		if result.Memory.ProjectID == "other" {
+			t.Errorf("Unexpected result of another project: %s", result.Memory.Title)
+		}
+	}
+
+	stats, err := taskService.GetTaskStatisticsForProjects(ctx, workspace)
+	if err != nil {
+		t.Fatalf("Failed to get task statistics: %v", err)
+	}
+	if stats.TotalTasks != 2 || stats.TasksByProject["api"] != 1 || stats.TasksByProject["web"] != 1 {
+		t.Errorf("Unexpected workspace task statistics %+v", stats)
+	}
+	if _, err := taskService.GetTaskStatisticsForProjects(ctx, nil); err == nil {
+		t.Error("Expected statistics without projects to be rejected")
+	}
+}
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// CodeAnchor links a memory to a file, line range or symbol in the project's source tree
+type CodeAnchor struct {
+	Path      string `json:"path"` // slash separated, relative to the project root; may name a directory
+	StartLine int    `json:"start_line,omitempty"`
+	EndLine   int    `json:"end_line,omitempty"`
+	Symbol    string `json:"symbol,omitempty"`
+	Commit    string `json:"commit,omitempty"` // git revision the anchor was recorded at
+}
+
+// ParseCodeAnchor parses an anchor of the form path[:start[-end]][#symbol],
+// e.g. "internal/infra/vector/chromadb.go:120-180#ChromaDBVectorStore.Search"
+func ParseCodeAnchor(s string) (CodeAnchor, error) {
+	var anchor CodeAnchor
+
+	rest := strings.TrimSpace(s)
+	if i := strings.LastIndex(rest, "#"); i >= 0 {
+		anchor.Symbol = strings.TrimSpace(rest[i+1:])
+		rest = rest[:i]
+	}
+
+	// A trailing :N or :N-M is a line range; other colons belong to the path
+	if i := strings.LastIndex(rest, ":"); i >= 0 {
+		if start, end, ok := parseLineRange(rest[i+1:]); ok {
+			anchor.StartLine, anchor.EndLine = start, end
+			rest = rest[:i]
+		}
+	}
+
+	anchor.Path = NormalizeAnchorPath(rest)
+	if err := anchor.Validate(); err != nil {
+		return CodeAnchor{}, fmt.Errorf("invalid anchor %q: %w", s, err)
+	}
+	return anchor, nil
+}
+
+func parseLineRange(s string) (int, int, bool) {
+	startStr, endStr, hasEnd := strings.Cut(s, "-")
+	start, err := strconv.Atoi(startStr)
+	if err != nil {
+		return 0, 0, false
+	}
+	end := start
+	if hasEnd {
+		if end, err = strconv.Atoi(endStr); err != nil {
+			return 0, 0, false
+		}
+	}
+	return start, end, true
+}
+
+// NormalizeAnchorPath converts a path to the slash separated, cleaned form used in anchors
+func NormalizeAnchorPath(p string) string {
+	p = strings.TrimSpace(p)
+	if p == "" {
+		return ""
+	}
+	p = path.Clean(filepath.ToSlash(p))
+	return strings.TrimPrefix(p, "./")
+}
+
+// Validate checks that the anchor is well formed
+func (a CodeAnchor) Validate() error {
+	if a.Path == "" || a.Path == "." {
+		return errors.New("path is required")
+	}
+	if path.IsAbs(a.Path) || a.Path == ".." || strings.HasPrefix(a.Path, "../") {
+		return errors.New("path must be relative to the project root")
+	}
+	if a.StartLine < 0 || a.EndLine < 0 {
+		return errors.New("line numbers cannot be negative")
+	}
+	if a.EndLine != 0 && a.EndLine < a.StartLine {
+		return errors.New("end line is before start line")
+	}
+	return nil
+}
+
+// Covers reports whether the anchor refers to the given file, either directly or
+// because the anchor names a directory containing it
+func (a CodeAnchor) Covers(filePath string) bool {
+	filePath = NormalizeAnchorPath(filePath)
+	return a.Path == filePath || strings.HasPrefix(filePath, a.Path+"/")
+}
+
+// String formats the anchor in the form accepted by ParseCodeAnchor
+func (a CodeAnchor) String() string {
+	s := a.Path
+	if a.StartLine > 0 {
+		s += fmt.Sprintf(":%d", a.StartLine)
+		if a.EndLine > a.StartLine {
+			s += fmt.Sprintf("-%d", a.EndLine)
+		}
+	}
+	if a.Symbol != "" {
+		s += "#" + a.Symbol
+	}
+	return s
+}
+
+// sameLocation reports whether two anchors point at the same place, ignoring the commit
+func (a CodeAnchor) sameLocation(other CodeAnchor) bool {
+	return a.Path == other.Path && a.StartLine == other.StartLine && a.EndLine == other.EndLine && a.Symbol == other.Symbol
+}
+
+// AnchorStatus describes how an anchored file changed since the anchor was recorded
+type AnchorStatus string
+
+const (
+	AnchorStatusCurrent  AnchorStatus = "current"
+	AnchorStatusModified AnchorStatus = "modified" // heavily changed
+	AnchorStatusRenamed  AnchorStatus = "renamed"
+	AnchorStatusDeleted  AnchorStatus = "deleted"
+	AnchorStatusUnknown  AnchorStatus = "unknown" // no git history to compare against
+)
+
+// AnchorCheck is the result of checking an anchor against the source tree
+type AnchorCheck struct {
+	Anchor       CodeAnchor   `json:"anchor"`
+	Status       AnchorStatus `json:"status"`
+	NewPath      string       `json:"new_path,omitempty"`      // for renamed files
+	ChangedLines int          `json:"changed_lines,omitempty"` // lines added plus removed since the anchor's commit
+	Detail       string       `json:"detail,omitempty"`
+}
+
+// PossiblyStale reports whether the anchored code changed enough that the memory may be outdated
+func (c AnchorCheck) PossiblyStale() bool {
+	switch c.Status {
+	case AnchorStatusModified, AnchorStatusRenamed, AnchorStatusDeleted:
+		return true
+	}
+	return false
+}
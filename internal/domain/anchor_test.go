@@ -0,0 +1,86 @@
+package domain
+
+import "testing"
+
+func TestParseCodeAnchor(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected CodeAnchor
+	}{
+		{"internal/infra/vector/chromadb.go", CodeAnchor{Path: "internal/infra/vector/chromadb.go"}},
+		{"./internal/app/", CodeAnchor{Path: "internal/app"}},
+		{"main.go:42", CodeAnchor{Path: "main.go", StartLine: 42, EndLine: 42}},
+		{"main.go:10-20", CodeAnchor{Path: "main.go", StartLine: 10, EndLine: 20}},
+		{"main.go#main", CodeAnchor{Path: "main.go", Symbol: "main"}},
+		{"store.go:5-9#ChromaDBVectorStore.Search", CodeAnchor{Path: "store.go", StartLine: 5, EndLine: 9, Symbol: "ChromaDBVectorStore.Search"}},
+		{"docs/a:b.md", CodeAnchor{Path: "docs/a:b.md"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			anchor, err := ParseCodeAnchor(tt.input)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if anchor != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, anchor)
+			}
+		})
+	}
+}
+
+func TestParseCodeAnchor_Invalid(t *testing.T) {
+	for _, input := range []string{"", "#Symbol", "/abs/path.go", "../outside.go", "main.go:20-10"} {
+		if _, err := ParseCodeAnchor(input); err == nil {
+			t.Errorf("Expected error for %q", input)
+		}
+	}
+}
+
+func TestCodeAnchor_StringRoundTrip(t *testing.T) {
+	for _, input := range []string{"main.go", "main.go:42", "main.go:10-20#run", "internal/app#MemoryService"} {
+		anchor, err := ParseCodeAnchor(input)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if anchor.String() != input {
+			t.Errorf("Expected %q, got %q", input, anchor.String())
+		}
+	}
+}
+
+func TestCodeAnchor_Covers(t *testing.T) {
+	dir := CodeAnchor{Path: "internal/infra"}
+	file := CodeAnchor{Path: "internal/infra/vector/chromadb.go"}
+
+	if !dir.Covers("internal/infra/vector/chromadb.go") {
+		t.Error("Expected directory anchor to cover files below it")
+	}
+	if dir.Covers("internal/infrastructure/x.go") {
+		t.Error("Expected directory anchor not to match a sibling with the same prefix")
+	}
+	if !file.Covers("./internal/infra/vector/chromadb.go") {
+		t.Error("Expected file anchor to cover the normalised path")
+	}
+	if file.Covers("internal/infra/vector") {
+		t.Error("Expected file anchor not to cover its directory")
+	}
+}
+
+func TestMemory_AddAnchor(t *testing.T) {
+	memory := NewMemory("proj", MemoryTypeDecision, "Title", "Content", "")
+
+	memory.AddAnchor(CodeAnchor{Path: "a.go", Commit: "old"})
+	memory.AddAnchor(CodeAnchor{Path: "b.go"})
+	memory.AddAnchor(CodeAnchor{Path: "a.go", Commit: "new"})
+
+	if len(memory.Anchors) != 2 {
+		t.Fatalf("Expected 2 anchors, got %d", len(memory.Anchors))
+	}
+	if memory.Anchors[0].Commit != "new" {
+		t.Errorf("Expected re-anchoring to update the commit, got %q", memory.Anchors[0].Commit)
+	}
+	if anchors := memory.AnchorsFor("b.go"); len(anchors) != 1 {
+		t.Errorf("Expected 1 anchor for b.go, got %d", len(anchors))
+	}
+}
@@ -0,0 +1,373 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DependencyConstraint limits a memory to projects using a dependency, optionally
+// in a range of versions
+type DependencyConstraint struct {
+	Name      string `json:"name"`
+	Ecosystem string `json:"ecosystem,omitempty"` // any ecosystem if empty
+	// Versions is a version range such as "^1.9", "~0.2.1", ">=1.21, <1.23", "2.x"
+	// or "1.2 || 1.4"; any version if empty
+	Versions string `json:"versions,omitempty"`
+}
+
+// ParseDependencyConstraint parses a constraint written as "name" or "name@range",
+// e.g. "github.com/gin-gonic/gin@^1.9" or "@types/node@>=20"
+func ParseDependencyConstraint(value string) (DependencyConstraint, error) {
+	value = strings.TrimSpace(value)
+	constraint := DependencyConstraint{Name: value}
+	// The name of scoped npm packages starts with '@'
+	if i := strings.LastIndex(value, "@"); i > 0 {
+		constraint.Name = strings.TrimSpace(value[:i])
+		constraint.Versions = strings.TrimSpace(value[i+1:])
+	}
+	if constraint.Name == "" {
+		return DependencyConstraint{}, fmt.Errorf("invalid dependency constraint %q, use name or name@range", value)
+	}
+	if err := constraint.Validate(); err != nil {
+		return DependencyConstraint{}, err
+	}
+	return constraint, nil
+}
+
+// Validate checks that the version range of the constraint can be parsed
+func (c DependencyConstraint) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("dependency constraint has no name")
+	}
+	if _, err := parseVersionRange(c.Versions); err != nil {
+		return fmt.Errorf("invalid version range for %s: %w", c.Name, err)
+	}
+	return nil
+}
+
+// String returns the constraint as "name@range", or the name if any version applies
+func (c DependencyConstraint) String() string {
+	if c.Versions == "" {
+		return c.Name
+	}
+	return c.Name + "@" + c.Versions
+}
+
+// Matches reports whether a dependency is the one the constraint refers to
+func (c DependencyConstraint) Matches(dependency Dependency) bool {
+	if c.Ecosystem != "" && dependency.Ecosystem != "" && c.Ecosystem != dependency.Ecosystem {
+		return false
+	}
+	return strings.EqualFold(c.Name, dependency.Name)
+}
+
+// SatisfiedBy reports whether a version lies in the range of the constraint. The
+// second result is false if the version cannot be compared, e.g. "latest".
+func (c DependencyConstraint) SatisfiedBy(version string) (satisfied, known bool) {
+	versionRange, err := parseVersionRange(c.Versions)
+	if err != nil {
+		return false, false
+	}
+	if versionRange == nil {
+		return true, true
+	}
+	v, ok := parseVersion(version)
+	if !ok {
+		return false, false
+	}
+	return versionRange.contains(v), true
+}
+
+// ApplicabilityStatus tells whether a memory applies to a project's current dependencies
+type ApplicabilityStatus string
+
+const (
+	// ApplicabilityMatch means the project satisfies all constraints of the memory
+	ApplicabilityMatch ApplicabilityStatus = "match"
+	// ApplicabilityMismatch means a dependency is missing or outside the required range
+	ApplicabilityMismatch ApplicabilityStatus = "mismatch"
+	// ApplicabilityUnknown means some versions could not be compared
+	ApplicabilityUnknown ApplicabilityStatus = "unknown"
+)
+
+// DependencyConflict is a constraint of a memory that the project does not satisfy
+type DependencyConflict struct {
+	Constraint DependencyConstraint `json:"constraint"`
+	Version    string               `json:"version,omitempty"` // the project's version, empty if it does not use the dependency
+}
+
+// String describes the conflict, e.g. "requires gin@^1.9, project uses v2.0.0"
+func (c DependencyConflict) String() string {
+	if c.Version == "" {
+		return fmt.Sprintf("requires %s, project does not use it", c.Constraint)
+	}
+	return fmt.Sprintf("requires %s, project uses %s", c.Constraint, c.Version)
+}
+
+// Applicability is the result of checking the constraints of a memory against a project
+type Applicability struct {
+	Status    ApplicabilityStatus  `json:"status"`
+	Conflicts []DependencyConflict `json:"conflicts,omitempty"`
+}
+
+// CheckApplicability checks dependency constraints against the dependencies of a project
+func CheckApplicability(constraints []DependencyConstraint, dependencies []Dependency) Applicability {
+	result := Applicability{Status: ApplicabilityMatch}
+	for _, constraint := range constraints {
+		var dependency *Dependency
+		for i := range dependencies {
+			if constraint.Matches(dependencies[i]) {
+				dependency = &dependencies[i]
+				break
+			}
+		}
+		if dependency == nil {
+			result.Conflicts = append(result.Conflicts, DependencyConflict{Constraint: constraint})
+			continue
+		}
+
+		satisfied, known := constraint.SatisfiedBy(dependency.Version)
+		switch {
+		case !known:
+			if result.Status == ApplicabilityMatch {
+				result.Status = ApplicabilityUnknown
+			}
+		case !satisfied:
+			result.Conflicts = append(result.Conflicts, DependencyConflict{Constraint: constraint, Version: dependency.Version})
+		}
+	}
+	if len(result.Conflicts) > 0 {
+		result.Status = ApplicabilityMismatch
+	}
+	return result
+}
+
+// ConstraintFor returns a constraint that the dependency's current version satisfies
+// and that stays satisfied until the next breaking release, e.g. "^1.9.1" for v1.9.1
+func ConstraintFor(dependency Dependency) DependencyConstraint {
+	constraint := DependencyConstraint{Name: dependency.Name, Ecosystem: dependency.Ecosystem}
+	if v, ok := parseVersion(dependency.Version); ok {
+		constraint.Versions = "^" + v.String()
+	}
+	return constraint
+}
+
+// version is a numeric release version; pre-release and build suffixes are ignored
+type version struct {
+	parts [3]int
+	n     int // number of parts given, e.g. 2 for "1.21"
+}
+
+// parseVersion reads the first version number of a string, so that "v1.9.1",
+// "^4.18.2", ">=2.0" and "1.0.0-rc1" can be compared
+func parseVersion(value string) (version, bool) {
+	start := strings.IndexAny(value, "0123456789")
+	if start < 0 {
+		return version{}, false
+	}
+
+	var v version
+	for _, part := range strings.SplitN(value[start:], ".", 3) {
+		end := 0
+		for end < len(part) && part[end] >= '0' && part[end] <= '9' {
+			end++
+		}
+		if end == 0 {
+			break
+		}
+		number, err := strconv.Atoi(part[:end])
+		if err != nil {
+			return version{}, false
+		}
+		v.parts[v.n] = number
+		v.n++
+		if end < len(part) {
+			break
+		}
+	}
+	return v, true
+}
+
+func (v version) String() string {
+	parts := make([]string, v.n)
+	for i := range parts {
+		parts[i] = strconv.Itoa(v.parts[i])
+	}
+	return strings.Join(parts, ".")
+}
+
+func (v version) compare(other version) int {
+	for i := range v.parts {
+		if v.parts[i] != other.parts[i] {
+			if v.parts[i] < other.parts[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// bump returns the first version after all versions starting with the first n parts,
+// e.g. 1.3.0 for 1.2.5 and n = 2
+func (v version) bump(n int) version {
+	if n < 1 {
+		n = 1
+	}
+	next := version{n: 3}
+	copy(next.parts[:n], v.parts[:n])
+	next.parts[n-1]++
+	return next
+}
+
+// comparator is a single bound of a version range
+type comparator struct {
+	op      string
+	version version
+}
+
+func (c comparator) contains(v version) bool {
+	cmp := v.compare(c.version)
+	switch c.op {
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case "!=":
+		return cmp != 0
+	default:
+		return cmp == 0
+	}
+}
+
+// versionRange is a union of intersections of comparators
+type versionRange [][]comparator
+
+func (r versionRange) contains(v version) bool {
+	for _, comparators := range r {
+		matches := true
+		for _, c := range comparators {
+			if !c.contains(v) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+// parseVersionRange parses npm, Cargo, Go and PEP 440 style ranges. It returns nil
+// for an empty range or "*".
+func parseVersionRange(value string) (versionRange, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "*" {
+		return nil, nil
+	}
+
+	var result versionRange
+	for _, alternative := range strings.Split(value, "||") {
+		terms := rangeTerms(alternative)
+		if len(terms) == 0 {
+			return nil, fmt.Errorf("empty alternative in %q", value)
+		}
+		// An alternative without comparators, e.g. "*", matches every version
+		comparators := []comparator{}
+		for _, term := range terms {
+			parsed, err := parseRangeTerm(term)
+			if err != nil {
+				return nil, err
+			}
+			comparators = append(comparators, parsed...)
+		}
+		result = append(result, comparators)
+	}
+	return result, nil
+}
+
+// rangeTerms splits a range at commas and spaces, keeping operators with their versions
+func rangeTerms(value string) []string {
+	var terms []string
+	pending := ""
+	for _, field := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' }) {
+		if strings.Trim(field, "<>=!~^") == "" {
+			pending += field
+			continue
+		}
+		terms = append(terms, pending+field)
+		pending = ""
+	}
+	if pending != "" {
+		terms = append(terms, pending)
+	}
+	return terms
+}
+
+// parseRangeTerm turns a term such as "^1.2", "~=2.2", "<3" or "1.x" into comparators
+func parseRangeTerm(term string) ([]comparator, error) {
+	op := ""
+	for _, prefix := range []string{">=", "<=", "==", "!=", "~=", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(term, prefix) {
+			op = prefix
+			break
+		}
+	}
+	rest := strings.TrimPrefix(strings.TrimSpace(strings.TrimPrefix(term, op)), "v")
+	if op == "" && (rest == "*" || rest == "x") {
+		return nil, nil
+	}
+
+	// Wildcards such as 1.x and 1.2.* match all versions starting with the given parts
+	wildcard := false
+	if i := strings.IndexAny(rest, "xX*"); i >= 0 {
+		rest = strings.TrimSuffix(rest[:i], ".")
+		wildcard = true
+	}
+	v, ok := parseVersion(rest)
+	if !ok || !strings.HasPrefix(rest, strconv.Itoa(v.parts[0])) {
+		return nil, fmt.Errorf("invalid version %q", term)
+	}
+	if wildcard && op != "" && op != "=" && op != "==" {
+		return nil, fmt.Errorf("wildcard with operator in %q", term)
+	}
+
+	lower := comparator{op: ">=", version: v}
+	switch op {
+	case ">", ">=", "<", "<=", "!=":
+		return []comparator{{op: op, version: v}}, nil
+	case "^":
+		// Compatible with the first non-zero part
+		n := 1
+		for n < v.n && v.parts[n-1] == 0 {
+			n++
+		}
+		return []comparator{lower, {op: "<", version: v.bump(n)}}, nil
+	case "~":
+		// Patch updates if the minor version is given, minor updates otherwise
+		n := v.n
+		if n > 2 {
+			n = 2
+		}
+		return []comparator{lower, {op: "<", version: v.bump(n)}}, nil
+	case "~=":
+		// Compatible release: all parts but the last are fixed
+		if v.n < 2 {
+			return nil, fmt.Errorf("compatible release %q needs at least two parts", term)
+		}
+		return []comparator{lower, {op: "<", version: v.bump(v.n - 1)}}, nil
+	default:
+		// Exact versions with all three parts match themselves only, shorter ones
+		// and wildcards match all versions starting with the given parts
+		if v.n == 3 && !wildcard {
+			return []comparator{{op: "=", version: v}}, nil
+		}
+		return []comparator{lower, {op: "<", version: v.bump(v.n)}}, nil
+	}
+}
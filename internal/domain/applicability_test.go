@@ -0,0 +1,130 @@
+package domain
+
+import "testing"
+
+func TestParseDependencyConstraint(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected DependencyConstraint
+		wantErr  bool
+	}{
+		{"github.com/gin-gonic/gin@^1.9", DependencyConstraint{Name: "github.com/gin-gonic/gin", Versions: "^1.9"}, false},
+		{"@types/node@>=20, <22", DependencyConstraint{Name: "@types/node", Versions: ">=20, <22"}, false},
+		{"@types/node", DependencyConstraint{Name: "@types/node"}, false},
+		{"react", DependencyConstraint{Name: "react"}, false},
+		{"go@>= 1.21", DependencyConstraint{Name: "go", Versions: ">= 1.21"}, false},
+		{"react@banana", DependencyConstraint{}, true},
+		{"react@>=1.x", DependencyConstraint{}, true},
+		{"", DependencyConstraint{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDependencyConstraint(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDependencyConstraint(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("ParseDependencyConstraint(%q) = %+v, expected %+v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDependencyConstraint_SatisfiedBy(t *testing.T) {
+	tests := []struct {
+		versions  string
+		version   string
+		satisfied bool
+		known     bool
+	}{
+		{"", "anything", true, true},
+		{"*", "v3.0.0", true, true},
+		{"^1.9", "v1.10.0", true, true},
+		{"^1.9", "v2.0.0", false, true},
+		{"^1.9", "v1.8.5", false, true},
+		{"^0.2.1", "v0.2.9", true, true},
+		{"^0.2.1", "v0.3.0", false, true},
+		{"~1.2.3", "1.2.9", true, true},
+		{"~1.2.3", "1.3.0", false, true},
+		{"~=2.2", "2.9", true, true},
+		{"~=2.2", "3.0", false, true},
+		{">=1.21, <1.23", "1.22", true, true},
+		{">=1.21, <1.23", "1.23", false, true},
+		{">= 1.21 < 1.23", "1.21.4", true, true},
+		{"2.x", "v2.7.1", true, true},
+		{"1.2.*", "1.3.0", false, true},
+		{"1.2", "1.2.7", true, true},
+		{"1.2.3", "1.2.4", false, true},
+		{"1.2 || 1.4", "1.4.1", true, true},
+		{"1.2 || 1.4", "1.3.0", false, true},
+		{"^4.18", "^4.18.2", true, true},
+		{"^18", ">=17.0", false, true},
+		{"^0.0.0", "v0.0.0-20240101000000-abcdef123456", true, true},
+		{"^1.9", "latest", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.versions+" "+tt.version, func(t *testing.T) {
+			satisfied, known := DependencyConstraint{Name: "dep", Versions: tt.versions}.SatisfiedBy(tt.version)
+			if satisfied != tt.satisfied || known != tt.known {
+				t.Errorf("SatisfiedBy(%q) = %v, %v, expected %v, %v", tt.version, satisfied, known, tt.satisfied, tt.known)
+			}
+		})
+	}
+}
+
+func TestCheckApplicability(t *testing.T) {
+	dependencies := []Dependency{
+		{Name: "github.com/gin-gonic/gin", Version: "v2.0.0", Ecosystem: "go"},
+		{Name: "go", Version: "1.23", Ecosystem: "go"},
+		{Name: "react", Version: "latest", Ecosystem: "npm"},
+	}
+
+	result := CheckApplicability([]DependencyConstraint{{Name: "go", Versions: ">=1.21"}}, dependencies)
+	if result.Status != ApplicabilityMatch || len(result.Conflicts) != 0 {
+		t.Errorf("Expected a match, got %+v", result)
+	}
+
+	result = CheckApplicability([]DependencyConstraint{{Name: "go"}, {Name: "react", Versions: "^18"}}, dependencies)
+	if result.Status != ApplicabilityUnknown {
+		t.Errorf("Expected unknown for an incomparable version, got %+v", result)
+	}
+
+	result = CheckApplicability([]DependencyConstraint{
+		{Name: "github.com/gin-gonic/gin", Ecosystem: "go", Versions: "^1.9"},
+		{Name: "chromadb", Versions: "^0.4"},
+		{Name: "react", Ecosystem: "pypi"},
+	}, dependencies)
+	if result.Status != ApplicabilityMismatch || len(result.Conflicts) != 3 {
+		t.Fatalf("Expected three conflicts, got %+v", result)
+	}
+	if got := result.Conflicts[0].String(); got != "requires github.com/gin-gonic/gin@^1.9, project uses v2.0.0" {
+		t.Errorf("Unexpected conflict description %q", got)
+	}
+	if got := result.Conflicts[1].String(); got != "requires chromadb@^0.4, project does not use it" {
+		t.Errorf("Unexpected conflict description %q", got)
+	}
+}
+
+func TestConstraintFor(t *testing.T) {
+	tests := []struct {
+		dependency Dependency
+		expected   string
+	}{
+		{Dependency{Name: "github.com/gin-gonic/gin", Version: "v1.9.1"}, "^1.9.1"},
+		{Dependency{Name: "react", Version: "^18.3.1"}, "^18.3.1"},
+		{Dependency{Name: "fastapi", Version: ">=0.110"}, "^0.110"},
+		{Dependency{Name: "next", Version: "latest"}, ""},
+	}
+
+	for _, tt := range tests {
+		constraint := ConstraintFor(tt.dependency)
+		if constraint.Versions != tt.expected {
+			t.Errorf("ConstraintFor(%+v) = %q, expected %q", tt.dependency, constraint.Versions, tt.expected)
+		}
+		if satisfied, known := constraint.SatisfiedBy(tt.dependency.Version); known && !satisfied {
+			t.Errorf("Expected %s to satisfy %s", tt.dependency.Version, constraint)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package domain
+
+import "time"
+
+// File change statuses reported for a diff between two commits
+const (
+	GitFileAdded    = "added"
+	GitFileModified = "modified"
+	GitFileDeleted  = "deleted"
+	GitFileRenamed  = "renamed"
+	GitFileCopied   = "copied"
+)
+
+// GitCommit is a commit in the history covered by a session
+type GitCommit struct {
+	Hash    string    `json:"hash"`
+	Author  string    `json:"author,omitempty"`
+	Date    time.Time `json:"date"`
+	Subject string    `json:"subject"`
+}
+
+// ShortHash returns the abbreviated commit hash
+func (c GitCommit) ShortHash() string {
+	return ShortCommitHash(c.Hash)
+}
+
+// GitFileChange is a file changed between two commits
+type GitFileChange struct {
+	Path       string `json:"path"`
+	OldPath    string `json:"old_path,omitempty"` // previous path of renamed and copied files
+	Status     string `json:"status"`
+	Insertions int    `json:"insertions"`
+	Deletions  int    `json:"deletions"`
+	Binary     bool   `json:"binary,omitempty"`
+}
+
+// GitChanges are the commits between two commits and the files changed between them
+type GitChanges struct {
+	Commits    []GitCommit     `json:"commits,omitempty"`
+	Files      []GitFileChange `json:"files,omitempty"`
+	Insertions int             `json:"insertions"`
+	Deletions  int             `json:"deletions"`
+}
+
+// SessionGit records the git state a session covered: the branch and HEAD it started
+// at and, once completed, the HEAD it ended at and what changed in between
+type SessionGit struct {
+	Branch      string `json:"branch,omitempty"`
+	StartCommit string `json:"start_commit,omitempty"`
+	EndCommit   string `json:"end_commit,omitempty"`
+	GitChanges
+}
+
+// Range returns the commit range of the session, e.g. "1a2b3c4..5d6e7f8", or the start
+// commit alone while the session has not ended
+func (g *SessionGit) Range() string {
+	if g.EndCommit == "" || g.EndCommit == g.StartCommit {
+		return ShortCommitHash(g.StartCommit)
+	}
+	return ShortCommitHash(g.StartCommit) + ".." + ShortCommitHash(g.EndCommit)
+}
+
+// ShortCommitHash abbreviates a commit hash to seven characters
+func ShortCommitHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
@@ -2,6 +2,8 @@ package domain
 
 import (
 	"crypto/rand"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -20,6 +22,40 @@ type Memory struct {
 
 	// Embedding is stored separately but linked
 	HasEmbedding bool `json:"has_embedding"`
+
+	// Pinned memories are always included in the project's system prompt context
+	Pinned bool `json:"pinned"`
+
+	// Anchors link the memory to the source files and symbols it describes
+	Anchors []CodeAnchor `json:"anchors,omitempty"`
+
+	// AppliesTo lists the dependencies and version ranges the memory is valid for
+	AppliesTo []DependencyConstraint `json:"applies_to,omitempty"`
+
+	// AutoTags lists the tags in Tags that were added by the auto-tagger rather than by hand.
+	// SuggestedTags were proposed by the auto-tagger but not applied.
+	AutoTags      Tags `json:"auto_tags,omitempty"`
+	SuggestedTags Tags `json:"suggested_tags,omitempty"`
+
+	// Fields holds the values of the type-specific fields of user-defined memory types
+	Fields map[string]interface{} `json:"fields,omitempty"`
+
+	// Redactions records which kinds of secrets were masked before the memory was stored
+	Redactions []Redaction `json:"redactions,omitempty"`
+
+	// Provenance records who created the memory and through which channel,
+	// LastModifiedBy who changed it last
+	Provenance     *Provenance `json:"provenance,omitempty"`
+	LastModifiedBy *Provenance `json:"last_modified_by,omitempty"`
+
+	// ReviewStatus is draft while the memory waits in the review queue,
+	// ReviewedAt when it was approved
+	ReviewStatus ReviewStatus `json:"review_status"`
+	ReviewedAt   *time.Time   `json:"reviewed_at,omitempty"`
+
+	// Usage tracking, updated whenever the memory is returned to a user or agent
+	AccessCount    int        `json:"access_count"`
+	LastAccessedAt *time.Time `json:"last_accessed_at,omitempty"`
 }
 
 // NewMemory creates a new memory entry
@@ -36,6 +72,7 @@ func NewMemory(projectID ProjectID, memoryType MemoryType, title, content, conte
 		CreatedAt:    now,
 		UpdatedAt:    now,
 		HasEmbedding: false,
+		ReviewStatus: ReviewStatusApproved,
 	}
 }
 
@@ -45,6 +82,105 @@ func (m *Memory) AddTag(tag string) {
 	m.UpdatedAt = time.Now()
 }
 
+// TagOrigin reports whether a tag of the memory was added by hand or by the auto-tagger
+func (m *Memory) TagOrigin(tag string) TagOrigin {
+	if m.AutoTags.Contains(tag) {
+		return TagOriginAuto
+	}
+	return TagOriginManual
+}
+
+// TagsWithOrigin returns the tags of the memory that were added by hand or by the auto-tagger
+func (m *Memory) TagsWithOrigin(origin TagOrigin) Tags {
+	tags := make(Tags, 0, len(m.Tags))
+	for _, tag := range m.Tags {
+		if m.TagOrigin(tag) == origin {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// ApplyAutoTags adds tags chosen by the auto-tagger, skipping tags the memory already has
+func (m *Memory) ApplyAutoTags(tags []string) {
+	for _, tag := range tags {
+		if !m.Tags.Contains(tag) {
+			m.Tags.Add(tag)
+			m.AutoTags.Add(tag)
+		}
+	}
+	m.ReconcileTagOrigins()
+}
+
+// SuggestTags records tags proposed by the auto-tagger for review, skipping tags the memory already has
+func (m *Memory) SuggestTags(tags []string) {
+	for _, tag := range tags {
+		if !m.Tags.Contains(tag) {
+			m.SuggestedTags.Add(tag)
+		}
+	}
+}
+
+// AcceptTags turns auto and suggested tags into manual tags. Without arguments all
+// pending tags are accepted. It returns the accepted tags.
+func (m *Memory) AcceptTags(tags ...string) Tags {
+	pending := m.PendingTags()
+	if len(tags) == 0 {
+		tags = pending
+	}
+
+	var accepted Tags
+	for _, tag := range tags {
+		if !pending.Contains(tag) {
+			continue
+		}
+		m.Tags.Add(tag)
+		accepted.Add(tag)
+	}
+	m.AutoTags = m.AutoTags.without(accepted)
+	m.SuggestedTags = m.SuggestedTags.without(accepted)
+	return accepted
+}
+
+// RejectTags removes auto and suggested tags. Without arguments all pending tags are
+// rejected. Manual tags are never removed. It returns the rejected tags.
+func (m *Memory) RejectTags(tags ...string) Tags {
+	pending := m.PendingTags()
+	if len(tags) == 0 {
+		tags = pending
+	}
+
+	var rejected Tags
+	for _, tag := range tags {
+		if pending.Contains(tag) {
+			rejected.Add(tag)
+		}
+	}
+	m.Tags = m.Tags.without(m.AutoTags.intersect(rejected))
+	m.AutoTags = m.AutoTags.without(rejected)
+	m.SuggestedTags = m.SuggestedTags.without(rejected)
+	return rejected
+}
+
+// PendingTags returns the auto and suggested tags that have not been reviewed yet
+func (m *Memory) PendingTags() Tags {
+	pending := make(Tags, 0, len(m.AutoTags)+len(m.SuggestedTags))
+	for _, tag := range m.AutoTags {
+		pending.Add(tag)
+	}
+	for _, tag := range m.SuggestedTags {
+		pending.Add(tag)
+	}
+	return pending
+}
+
+// ReconcileTagOrigins drops auto tags that are no longer tags of the memory and
+// suggestions that have become tags, e.g. after the tags were edited
+func (m *Memory) ReconcileTagOrigins() {
+	m.AutoTags = m.AutoTags.intersect(m.Tags)
+	m.SuggestedTags = m.SuggestedTags.without(m.Tags)
+}
+
 // SetEmbedding marks that this memory has an embedding
 func (m *Memory) SetEmbedding() {
 	m.HasEmbedding = true
@@ -53,7 +189,71 @@ func (m *Memory) SetEmbedding() {
 
 // GetEmbeddingText returns the text that should be embedded
 func (m *Memory) GetEmbeddingText() string {
-	return m.Title + "\n" + m.Content + "\n" + m.Context
+	text := m.Title + "\n" + m.Content + "\n" + m.Context
+	if len(m.Fields) == 0 {
+		return text
+	}
+	return text + "\n" + m.FieldsText()
+}
+
+// FieldsText renders the type-specific fields as "name: value" lines sorted by name
+func (m *Memory) FieldsText() string {
+	names := make([]string, 0, len(m.Fields))
+	for name := range m.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := make([]string, len(names))
+	for i, name := range names {
+		lines[i] = name + ": " + FormatFieldValue(m.Fields[name])
+	}
+	return strings.Join(lines, "\n")
+}
+
+// SetPinned pins or unpins the memory
+func (m *Memory) SetPinned(pinned bool) {
+	m.Pinned = pinned
+	m.UpdatedAt = time.Now()
+}
+
+// AddAnchor links the memory to a source location. An anchor for the same
+// location replaces the existing one.
+func (m *Memory) AddAnchor(anchor CodeAnchor) {
+	for i, existing := range m.Anchors {
+		if existing.sameLocation(anchor) {
+			m.Anchors[i] = anchor
+			m.UpdatedAt = time.Now()
+			return
+		}
+	}
+	m.Anchors = append(m.Anchors, anchor)
+	m.UpdatedAt = time.Now()
+}
+
+// AnchorsFor returns the anchors that cover the given file
+func (m *Memory) AnchorsFor(filePath string) []CodeAnchor {
+	var anchors []CodeAnchor
+	for _, anchor := range m.Anchors {
+		if anchor.Covers(filePath) {
+			anchors = append(anchors, anchor)
+		}
+	}
+	return anchors
+}
+
+// RecordAccess counts a retrieval of the memory at the given time
+func (m *Memory) RecordAccess(at time.Time) {
+	m.AccessCount++
+	m.LastAccessedAt = &at
+}
+
+// LastUsedAt returns when the memory was last accessed, or its creation time if never accessed
+func (m *Memory) LastUsedAt() time.Time {
+	if m.LastAccessedAt != nil {
+		return *m.LastAccessedAt
+	}
+	return m.CreatedAt
 }
 
 // IsType checks if the memory is of a specific type
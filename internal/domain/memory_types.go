@@ -0,0 +1,400 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldType is the value type of a field of a user-defined memory type
+type FieldType string
+
+const (
+	FieldTypeString  FieldType = "string"
+	FieldTypeNumber  FieldType = "number"
+	FieldTypeInteger FieldType = "integer"
+	FieldTypeBoolean FieldType = "boolean"
+	FieldTypeDate    FieldType = "date" // YYYY-MM-DD or RFC 3339
+	FieldTypeEnum    FieldType = "enum" // one of Values
+	FieldTypeList    FieldType = "list" // list of strings
+)
+
+// IsValid checks if the field type is known
+func (t FieldType) IsValid() bool {
+	switch t {
+	case FieldTypeString, FieldTypeNumber, FieldTypeInteger, FieldTypeBoolean, FieldTypeDate, FieldTypeEnum, FieldTypeList:
+		return true
+	}
+	return false
+}
+
+// FieldDefinition describes a type-specific field of a memory
+type FieldDefinition struct {
+	Name        string    `json:"name"`
+	Type        FieldType `json:"type"`
+	Description string    `json:"description,omitempty"`
+	Required    bool      `json:"required,omitempty"`
+	Values      []string  `json:"values,omitempty"` // allowed values of an enum field
+}
+
+// MemoryTypeDefinition describes a memory type and the fields memories of that type carry
+type MemoryTypeDefinition struct {
+	Name        MemoryType        `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Fields      []FieldDefinition `json:"fields,omitempty"`
+	Builtin     bool              `json:"builtin,omitempty"`
+	ProjectID   *ProjectID        `json:"project_id,omitempty"` // only available in this project if set
+}
+
+var memoryTypeNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// IsValidName checks if the memory type name is well-formed: lower case letters,
+// digits and underscores, starting with a letter
+func (t MemoryType) IsValidName() bool {
+	return memoryTypeNamePattern.MatchString(string(t))
+}
+
+// Validate checks that the definition is well-formed
+func (d MemoryTypeDefinition) Validate() error {
+	if !d.Name.IsValidName() {
+		return fmt.Errorf("invalid memory type name %q (use lower case letters, digits and underscores)", d.Name)
+	}
+
+	seen := make(map[string]bool)
+	for _, field := range d.Fields {
+		if !memoryTypeNamePattern.MatchString(field.Name) {
+			return fmt.Errorf("memory type %s: invalid field name %q", d.Name, field.Name)
+		}
+		if seen[field.Name] {
+			return fmt.Errorf("memory type %s: duplicate field %s", d.Name, field.Name)
+		}
+		seen[field.Name] = true
+
+		if !field.Type.IsValid() {
+			return fmt.Errorf("memory type %s: field %s has unknown type %q", d.Name, field.Name, field.Type)
+		}
+		if field.Type == FieldTypeEnum && len(field.Values) == 0 {
+			return fmt.Errorf("memory type %s: enum field %s needs values", d.Name, field.Name)
+		}
+		if field.Type != FieldTypeEnum && len(field.Values) > 0 {
+			return fmt.Errorf("memory type %s: only enum fields can have values (field %s)", d.Name, field.Name)
+		}
+	}
+	return nil
+}
+
+// Field returns the definition of the named field
+func (d MemoryTypeDefinition) Field(name string) (FieldDefinition, bool) {
+	for _, field := range d.Fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return FieldDefinition{}, false
+}
+
+// ValidateFields checks field values against the definition and returns them in
+// canonical form: integers as int64, dates as RFC 3339 and lists as []string.
+// Unknown fields and missing required fields are errors.
+func (d MemoryTypeDefinition) ValidateFields(fields map[string]interface{}) (map[string]interface{}, error) {
+	validated := make(map[string]interface{}, len(fields))
+	for name, value := range fields {
+		field, ok := d.Field(name)
+		if !ok {
+			return nil, fmt.Errorf("memory type %s has no field %s", d.Name, name)
+		}
+		if value == nil {
+			continue
+		}
+		converted, err := field.convert(value)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", name, err)
+		}
+		validated[name] = converted
+	}
+
+	for _, field := range d.Fields {
+		if _, ok := validated[field.Name]; field.Required && !ok {
+			return nil, fmt.Errorf("field %s is required for memory type %s", field.Name, d.Name)
+		}
+	}
+
+	if len(validated) == 0 {
+		return nil, nil
+	}
+	return validated, nil
+}
+
+// convert checks a single value and converts it to the canonical representation
+func (f FieldDefinition) convert(value interface{}) (interface{}, error) {
+	switch f.Type {
+	case FieldTypeString:
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a string, got %T", value)
+		}
+		return s, nil
+
+	case FieldTypeNumber:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case int:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		case string:
+			n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				return nil, fmt.Errorf("expected a number, got %q", v)
+			}
+			return n, nil
+		}
+		return nil, fmt.Errorf("expected a number, got %T", value)
+
+	case FieldTypeInteger:
+		switch v := value.(type) {
+		case int:
+			return int64(v), nil
+		case int64:
+			return v, nil
+		case float64:
+			if v != math.Trunc(v) {
+				return nil, fmt.Errorf("expected an integer, got %v", v)
+			}
+			return int64(v), nil
+		case string:
+			n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("expected an integer, got %q", v)
+			}
+			return n, nil
+		}
+		return nil, fmt.Errorf("expected an integer, got %T", value)
+
+	case FieldTypeBoolean:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			b, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err != nil {
+				return nil, fmt.Errorf("expected true or false, got %q", v)
+			}
+			return b, nil
+		}
+		return nil, fmt.Errorf("expected true or false, got %T", value)
+
+	case FieldTypeDate:
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a date string, got %T", value)
+		}
+		for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+			if t, err := time.Parse(layout, strings.TrimSpace(s)); err == nil {
+				if layout == "2006-01-02" {
+					return t.Format("2006-01-02"), nil
+				}
+				return t.Format(time.RFC3339), nil
+			}
+		}
+		return nil, fmt.Errorf("expected a date (YYYY-MM-DD or RFC 3339), got %q", s)
+
+	case FieldTypeEnum:
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected one of %s, got %T", strings.Join(f.Values, ", "), value)
+		}
+		for _, allowed := range f.Values {
+			if strings.EqualFold(s, allowed) {
+				return allowed, nil
+			}
+		}
+		return nil, fmt.Errorf("expected one of %s, got %q", strings.Join(f.Values, ", "), s)
+
+	case FieldTypeList:
+		switch v := value.(type) {
+		case []string:
+			return v, nil
+		case []interface{}:
+			list := make([]string, 0, len(v))
+			for _, item := range v {
+				s, ok := item.(string)
+				if !ok {
+					return nil, fmt.Errorf("expected a list of strings, got an item of type %T", item)
+				}
+				list = append(list, s)
+			}
+			return list, nil
+		case string:
+			// Comma separated, as given on the command line
+			var list []string
+			for _, item := range strings.Split(v, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					list = append(list, item)
+				}
+			}
+			return list, nil
+		}
+		return nil, fmt.Errorf("expected a list of strings, got %T", value)
+	}
+
+	return nil, fmt.Errorf("unknown field type %q", f.Type)
+}
+
+// FormatFieldValue renders a field value for display and embedding
+func FormatFieldValue(value interface{}) string {
+	switch v := value.(type) {
+	case []string:
+		return strings.Join(v, ", ")
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = FormatFieldValue(item)
+		}
+		return strings.Join(items, ", ")
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// FieldMatches reports whether a field value equals the filter value, ignoring case.
+// A list matches if any of its items equals the filter value.
+func FieldMatches(value interface{}, filter string) bool {
+	switch v := value.(type) {
+	case []string:
+		for _, item := range v {
+			if strings.EqualFold(item, filter) {
+				return true
+			}
+		}
+		return false
+	case []interface{}:
+		for _, item := range v {
+			if FieldMatches(item, filter) {
+				return true
+			}
+		}
+		return false
+	case nil:
+		return false
+	default:
+		return strings.EqualFold(FormatFieldValue(v), filter)
+	}
+}
+
+// builtinMemoryTypeDescriptions describes the built-in memory types
+var builtinMemoryTypeDescriptions = map[MemoryType]string{
+	MemoryTypeDecision:      "Architectural or technical decision with rationale",
+	MemoryTypePattern:       "Reusable design or code pattern",
+	MemoryTypeErrorSolution: "Error signature and its solution",
+	MemoryTypeCode:          "Code snippet or implementation note",
+	MemoryTypeDocumentation: "Project documentation",
+	MemoryTypeSession:       "Development session",
+	MemoryTypeTask:          "Task with status and priority",
+}
+
+// MemoryTypeRegistry holds the built-in memory types and user-defined types,
+// which may be global or limited to a project
+type MemoryTypeRegistry struct {
+	global   map[MemoryType]MemoryTypeDefinition
+	projects map[ProjectID]map[MemoryType]MemoryTypeDefinition
+}
+
+// NewMemoryTypeRegistry creates a registry containing the built-in memory types
+func NewMemoryTypeRegistry() *MemoryTypeRegistry {
+	registry := &MemoryTypeRegistry{
+		global:   make(map[MemoryType]MemoryTypeDefinition),
+		projects: make(map[ProjectID]map[MemoryType]MemoryTypeDefinition),
+	}
+	for _, builtin := range BuiltinMemoryTypes() {
+		registry.global[builtin] = MemoryTypeDefinition{
+			Name:        builtin,
+			Description: builtinMemoryTypeDescriptions[builtin],
+			Builtin:     true,
+		}
+	}
+	return registry
+}
+
+// Register adds a user-defined memory type. Built-in types cannot be redefined,
+// and a project type cannot shadow a global one.
+func (r *MemoryTypeRegistry) Register(def MemoryTypeDefinition) error {
+	if err := def.Validate(); err != nil {
+		return err
+	}
+	def.Builtin = false
+
+	if existing, ok := r.global[def.Name]; ok {
+		if existing.Builtin {
+			return fmt.Errorf("memory type %s is built in and cannot be redefined", def.Name)
+		}
+		return fmt.Errorf("memory type %s is already defined", def.Name)
+	}
+
+	if def.ProjectID == nil {
+		for projectID, types := range r.projects {
+			if _, ok := types[def.Name]; ok {
+				return fmt.Errorf("memory type %s is already defined for project %s", def.Name, projectID)
+			}
+		}
+		r.global[def.Name] = def
+		return nil
+	}
+
+	types, ok := r.projects[*def.ProjectID]
+	if !ok {
+		types = make(map[MemoryType]MemoryTypeDefinition)
+		r.projects[*def.ProjectID] = types
+	}
+	if _, ok := types[def.Name]; ok {
+		return fmt.Errorf("memory type %s is already defined for project %s", def.Name, *def.ProjectID)
+	}
+	types[def.Name] = def
+	return nil
+}
+
+// Lookup returns the definition of a memory type as seen from a project
+func (r *MemoryTypeRegistry) Lookup(projectID ProjectID, memoryType MemoryType) (MemoryTypeDefinition, bool) {
+	if def, ok := r.global[memoryType]; ok {
+		return def, true
+	}
+	def, ok := r.projects[projectID][memoryType]
+	return def, ok
+}
+
+// Types returns the memory types available in a project, or all types if projectID
+// is nil: built-in types first, then user-defined types by name
+func (r *MemoryTypeRegistry) Types(projectID *ProjectID) []MemoryTypeDefinition {
+	var custom []MemoryTypeDefinition
+	for _, def := range r.global {
+		if !def.Builtin {
+			custom = append(custom, def)
+		}
+	}
+	for id, types := range r.projects {
+		if projectID != nil && id != *projectID {
+			continue
+		}
+		for _, def := range types {
+			custom = append(custom, def)
+		}
+	}
+	sort.Slice(custom, func(i, j int) bool {
+		return custom[i].Name < custom[j].Name
+	})
+
+	types := make([]MemoryTypeDefinition, 0, len(BuiltinMemoryTypes())+len(custom))
+	for _, builtin := range BuiltinMemoryTypes() {
+		types = append(types, r.global[builtin])
+	}
+	return append(types, custom...)
+}
@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func runbookType() MemoryTypeDefinition {
+	return MemoryTypeDefinition{
+		Name: "runbook",
+		Fields: []FieldDefinition{
+			{Name: "service", Type: FieldTypeString, Required: true},
+			{Name: "severity", Type: FieldTypeEnum, Values: []string{"low", "high"}},
+			{Name: "steps", Type: FieldTypeInteger},
+			{Name: "reviewed", Type: FieldTypeDate},
+			{Name: "owners", Type: FieldTypeList},
+			{Name: "automated", Type: FieldTypeBoolean},
+		},
+	}
+}
+
+func TestMemoryTypeDefinition_Validate(t *testing.T) {
+	if err := runbookType().Validate(); err != nil {
+		t.Fatalf("Expected runbook to be valid, got %v", err)
+	}
+
+	invalid := []MemoryTypeDefinition{
+		{Name: "Runbook"},
+		{Name: "runbook", Fields: []FieldDefinition{{Name: "a", Type: "uuid"}}},
+		{Name: "runbook", Fields: []FieldDefinition{{Name: "a", Type: FieldTypeEnum}}},
+		{Name: "runbook", Fields: []FieldDefinition{{Name: "a", Type: FieldTypeString, Values: []string{"x"}}}},
+		{Name: "runbook", Fields: []FieldDefinition{{Name: "a", Type: FieldTypeString}, {Name: "a", Type: FieldTypeDate}}},
+	}
+	for _, def := range invalid {
+		if err := def.Validate(); err == nil {
+			t.Errorf("Expected %+v to be invalid", def)
+		}
+	}
+}
+
+func TestMemoryTypeDefinition_ValidateFields(t *testing.T) {
+	def := runbookType()
+
+	fields, err := def.ValidateFields(map[string]interface{}{
+		"service":   "billing",
+		"severity":  "HIGH",
+		"steps":     float64(4),
+		"reviewed":  "2026-03-01",
+		"owners":    []interface{}{"ops", "billing-team"},
+		"automated": "true",
+	})
+	if err != nil {
+		t.Fatalf("Failed to validate fields: %v", err)
+	}
+	expected := map[string]interface{}{
+		"service":   "billing",
+		"severity":  "high",
+		"steps":     int64(4),
+		"reviewed":  "2026-03-01",
+		"owners":    []string{"ops", "billing-team"},
+		"automated": true,
+	}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("Expected %v, got %v", expected, fields)
+	}
+
+	invalid := []map[string]interface{}{
+		{"severity": "low"},                       // service missing
+		{"service": "billing", "owner": "ops"},    // unknown field
+		{"service": "billing", "severity": "mid"}, // not an allowed value
+		{"service": "billing", "steps": 1.5},
+		{"service": "billing", "reviewed": "yesterday"},
+		{"service": 42},
+	}
+	for _, values := range invalid {
+		if _, err := def.ValidateFields(values); err == nil {
+			t.Errorf("Expected %v to be rejected", values)
+		}
+	}
+
+	optional := MemoryTypeDefinition{Name: "note", Fields: []FieldDefinition{{Name: "topic", Type: FieldTypeString}}}
+	if fields, err := optional.ValidateFields(map[string]interface{}{"topic": nil}); err != nil || fields != nil {
+		t.Errorf("Expected no fields, got %v, %v", fields, err)
+	}
+}
+
+func TestFieldMatches(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		filter   string
+		expected bool
+	}{
+		{"Billing", "billing", true},
+		{"billing", "payments", false},
+		{int64(4), "4", true},
+		{float64(4), "4", true},
+		{true, "true", true},
+		{[]string{"ops", "billing"}, "Billing", true},
+		{[]interface{}{"ops"}, "billing", false},
+		{nil, "", false},
+	}
+	for _, tt := range tests {
+		if got := FieldMatches(tt.value, tt.filter); got != tt.expected {
+			t.Errorf("FieldMatches(%v, %q) = %v, expected %v", tt.value, tt.filter, got, tt.expected)
+		}
+	}
+}
+
+func TestMemoryTypeRegistry(t *testing.T) {
+	registry := NewMemoryTypeRegistry()
+	project := ProjectID("payments")
+	other := ProjectID("web")
+
+	if err := registry.Register(runbookType()); err != nil {
+		t.Fatalf("Failed to register runbook: %v", err)
+	}
+	if err := registry.Register(MemoryTypeDefinition{Name: "api_contract", ProjectID: &project}); err != nil {
+		t.Fatalf("Failed to register project type: %v", err)
+	}
+
+	conflicts := []MemoryTypeDefinition{
+		{Name: MemoryTypeDecision},
+		{Name: "runbook"},
+		{Name: "runbook", ProjectID: &project},
+		{Name: "api_contract"},
+		{Name: "api_contract", ProjectID: &project},
+	}
+	for _, def := range conflicts {
+		if err := registry.Register(def); err == nil {
+			t.Errorf("Expected registering %+v to fail", def)
+		}
+	}
+	if err := registry.Register(MemoryTypeDefinition{Name: "api_contract", ProjectID: &other}); err != nil {
+		t.Errorf("Expected the same type in another project to be allowed, got %v", err)
+	}
+
+	if def, ok := registry.Lookup(project, "runbook"); !ok || len(def.Fields) == 0 {
+		t.Error("Expected global types to be visible in every project")
+	}
+	if _, ok := registry.Lookup(project, "api_contract"); !ok {
+		t.Error("Expected the project type to be visible in its project")
+	}
+	if _, ok := registry.Lookup("other", "api_contract"); ok {
+		t.Error("Expected the project type not to be visible in other projects")
+	}
+
+	types := registry.Types(&project)
+	builtins := len(BuiltinMemoryTypes())
+	if len(types) != builtins+2 {
+		t.Fatalf("Expected %d types, got %d", builtins+2, len(types))
+	}
+	if !types[0].Builtin || types[builtins].Name != "api_contract" || types[builtins+1].Name != "runbook" {
+		t.Errorf("Expected built-in types first, then custom types by name, got %+v", types)
+	}
+	if all := registry.Types(nil); len(all) != builtins+3 {
+		t.Errorf("Expected all %d types, got %d", builtins+3, len(all))
+	}
+}
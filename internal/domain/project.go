@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -15,12 +17,48 @@ type Project struct {
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 
+	// WorkspaceID is the workspace grouping the project with related projects, if any
+	WorkspaceID *WorkspaceID `json:"workspace_id,omitempty"`
+
+	// Languages with their share of the source code and direct dependencies declared
+	// in the manifests, as detected on initialization or re-detection
+	Languages    []LanguageShare `json:"languages,omitempty"`
+	Dependencies []Dependency    `json:"dependencies,omitempty"`
+
 	// Configuration
 	EmbeddingProvider string            `json:"embedding_provider"`
 	VectorStore       string            `json:"vector_store"`
 	Config            map[string]string `json:"config"`
 }
 
+// LanguageShare is the share of a language in the source code of a project
+type LanguageShare struct {
+	Language   string  `json:"language"`
+	Proportion float64 `json:"proportion"` // share of the source bytes, 0 to 1
+}
+
+// Dependency is a direct dependency declared in a project manifest
+type Dependency struct {
+	Name      string `json:"name"`
+	Version   string `json:"version,omitempty"`
+	Ecosystem string `json:"ecosystem"` // go, npm, pypi, cargo or maven
+	Dev       bool   `json:"dev,omitempty"`
+}
+
+// Location of the marker file identifying a project root, relative to the root
+const (
+	ProjectMarkerDir  = ".memory-bank"
+	ProjectMarkerFile = "project.yaml"
+)
+
+// ProjectMarker is the content of the marker file in a project root. It keeps the
+// project ID with the checkout, so moved or re-cloned repositories are re-associated
+// with their project.
+type ProjectMarker struct {
+	ProjectID ProjectID `yaml:"project_id"`
+	Name      string    `yaml:"name,omitempty"`
+}
+
 // NewProject creates a new project
 func NewProject(name, path, description string) *Project {
 	now := time.Now()
@@ -76,6 +114,19 @@ type Session struct {
 	EstimatedHours *int        `json:"estimated_hours,omitempty"`
 	ActualHours    *int        `json:"actual_hours,omitempty"`
 	Dependencies   []SessionID `json:"dependencies,omitempty"`
+
+	// Provenance records who started the session, LastModifiedBy who changed it last
+	Provenance     *Provenance `json:"provenance,omitempty"`
+	LastModifiedBy *Provenance `json:"last_modified_by,omitempty"`
+
+	// PausedAt is set while the session is paused, PausedDuration is the time spent
+	// in earlier pauses. Both are excluded from the active time.
+	PausedAt       *time.Time    `json:"paused_at,omitempty"`
+	PausedDuration time.Duration `json:"paused_duration,omitempty"`
+
+	// Git records the branch and commits the session covered if its project is a git
+	// repository
+	Git *SessionGit `json:"git,omitempty"`
 }
 
 // NewSession creates a new development session
@@ -106,32 +157,35 @@ func NewSessionWithTaskInfo(projectID ProjectID, name, taskDescription string, p
 
 // LogProgress adds a progress entry to the session
 func (s *Session) LogProgress(message, entryType string) {
-	entry := ProgressEntry{
-		Timestamp: time.Now().Format(time.RFC3339),
-		Message:   message,
-		Type:      entryType,
+	s.AddProgress(ProgressEntry{Message: message, Type: entryType})
+}
+
+// AddProgress appends a progress entry, stamping it with the current time if it has none
+func (s *Session) AddProgress(entry ProgressEntry) {
+	if entry.Timestamp == "" {
+		entry.Timestamp = time.Now().Format(time.RFC3339)
 	}
 	s.Progress = append(s.Progress, entry)
 }
 
 // LogInfo logs an informational progress entry
 func (s *Session) LogInfo(message string) {
-	s.LogProgress(message, "info")
+	s.LogProgress(message, ProgressTypeInfo)
 }
 
 // LogMilestone logs a milestone progress entry
 func (s *Session) LogMilestone(message string) {
-	s.LogProgress(message, "milestone")
+	s.LogProgress(message, ProgressTypeMilestone)
 }
 
 // LogIssue logs an issue progress entry
 func (s *Session) LogIssue(message string) {
-	s.LogProgress(message, "issue")
+	s.LogProgress(message, ProgressTypeIssue)
 }
 
 // LogSolution logs a solution progress entry
 func (s *Session) LogSolution(message string) {
-	s.LogProgress(message, "solution")
+	s.LogProgress(message, ProgressTypeSolution)
 }
 
 // AddTag adds a tag to the session
@@ -157,46 +211,79 @@ func (s *Session) GetProgressByType(entryType string) []ProgressEntry {
 
 // GetMilestones returns all milestone entries
 func (s *Session) GetMilestones() []ProgressEntry {
-	return s.GetProgressByType("milestone")
+	return s.GetProgressByType(ProgressTypeMilestone)
 }
 
 // GetIssues returns all issue entries
 func (s *Session) GetIssues() []ProgressEntry {
-	return s.GetProgressByType("issue")
+	return s.GetProgressByType(ProgressTypeIssue)
 }
 
 // GetSolutions returns all solution entries
 func (s *Session) GetSolutions() []ProgressEntry {
-	return s.GetProgressByType("solution")
+	return s.GetProgressByType(ProgressTypeSolution)
 }
 
-// CalculateDuration calculates the session duration
+// CalculateDuration calculates the active time of the session, excluding pauses
 func (s *Session) CalculateDuration() time.Duration {
+	end := time.Now()
 	if s.EndTime != nil {
-		return s.EndTime.Sub(s.StartTime)
+		end = *s.EndTime
+	}
+	paused := s.PausedDuration
+	if s.PausedAt != nil && end.After(*s.PausedAt) {
+		paused += end.Sub(*s.PausedAt)
 	}
-	return time.Since(s.StartTime)
+	return end.Sub(s.StartTime) - paused
 }
 
-// Pause pauses the session
-func (s *Session) Pause() {
-	if s.Status == SessionStatusActive {
-		s.Status = SessionStatusPaused
+// Pause pauses an active session
+func (s *Session) Pause() error {
+	return s.PauseWithReason("")
+}
+
+// PauseWithReason pauses an active session, recording the reason in its progress log
+func (s *Session) PauseWithReason(reason string) error {
+	if s.Status != SessionStatusActive {
+		return fmt.Errorf("session is %s, only active sessions can be paused", s.Status)
+	}
+	now := time.Now()
+	s.PausedAt = &now
+	s.Status = SessionStatusPaused
+	if reason != "" {
+		s.LogInfo("Session paused: " + reason)
+	} else {
 		s.LogInfo("Session paused")
 	}
+	return nil
 }
 
 // Resume resumes a paused session
-func (s *Session) Resume() {
-	if s.Status == SessionStatusPaused {
-		s.Status = SessionStatusActive
-		s.LogInfo("Session resumed")
+func (s *Session) Resume() error {
+	if s.Status != SessionStatusPaused {
+		return fmt.Errorf("session is %s, only paused sessions can be resumed", s.Status)
+	}
+	s.endPause(time.Now())
+	s.Status = SessionStatusActive
+	s.LogInfo("Session resumed")
+	return nil
+}
+
+// endPause adds the current pause, if any, to the paused time
+func (s *Session) endPause(at time.Time) {
+	if s.PausedAt == nil {
+		return
 	}
+	if at.After(*s.PausedAt) {
+		s.PausedDuration += at.Sub(*s.PausedAt)
+	}
+	s.PausedAt = nil
 }
 
 // Complete marks the session as completed
 func (s *Session) Complete(outcome string) {
 	now := time.Now()
+	s.endPause(now)
 	s.EndTime = &now
 	s.Outcome = outcome
 	s.Status = SessionStatusCompleted
@@ -208,6 +295,7 @@ func (s *Session) Complete(outcome string) {
 // Abort marks the session as aborted
 func (s *Session) Abort(reason string) {
 	now := time.Now()
+	s.endPause(now)
 	s.EndTime = &now
 	s.Status = SessionStatusAborted
 	duration := s.CalculateDuration()
@@ -224,10 +312,77 @@ func (s *Session) IsActive() bool {
 	return s.Status == SessionStatusActive
 }
 
-// Duration returns the duration of the session
-func (s *Session) Duration() time.Duration {
-	if s.EndTime != nil {
-		return s.EndTime.Sub(s.StartTime)
+// IsOpen checks if the session is active or paused, i.e. neither completed nor aborted
+func (s *Session) IsOpen() bool {
+	return s.Status == SessionStatusActive || s.Status == SessionStatusPaused
+}
+
+// LastActivity returns when the session was last worked on: its latest progress
+// entry, manual pause or resume, or its start. Entries logged by completing or aborting
+// the session and pauses for inactivity do not count as work on it.
+func (s *Session) LastActivity() time.Time {
+	last := s.StartTime
+	for _, entry := range s.Progress {
+		if s.IsLifecycleEntry(entry) && !isManualPauseOrResume(entry) {
+			continue
+		}
+		if at, err := time.Parse(time.RFC3339, entry.Timestamp); err == nil && at.After(last) {
+			last = at
+		}
+	}
+	return last
+}
+
+// IsLifecycleEntry reports whether a progress entry was logged by pausing, resuming,
+// aborting or completing the session rather than by its user
+func (s *Session) IsLifecycleEntry(entry ProgressEntry) bool {
+	switch {
+	case entry.Type == ProgressTypeInfo && (strings.HasPrefix(entry.Message, "Session paused") || entry.Message == "Session resumed" ||
+		strings.HasPrefix(entry.Message, "Session aborted")):
+		return true
+	case entry.Type == ProgressTypeMilestone && entry.Message == "Session completed: "+s.Outcome:
+		return true
 	}
-	return time.Since(s.StartTime)
+	return false
+}
+
+// isManualPauseOrResume reports whether a lifecycle entry was logged by a user pausing
+// or resuming the session, as opposed to a pause for inactivity
+func isManualPauseOrResume(entry ProgressEntry) bool {
+	return entry.Type == ProgressTypeInfo && (entry.Message == "Session paused" || entry.Message == "Session resumed")
+}
+
+// InactivityReason is the reason recorded when a session is paused or aborted for
+// having been inactive for the given time
+func InactivityReason(idle time.Duration) string {
+	return "inactive for " + FormatIdleTime(idle)
+}
+
+// IdleFor returns how long the session has been inactive at the given time
+func (s *Session) IdleFor(now time.Time) time.Duration {
+	if idle := now.Sub(s.LastActivity()); idle > 0 {
+		return idle
+	}
+	return 0
+}
+
+// FormatIdleTime renders an inactivity period coarsely, e.g. "3d 4h", "5h" or "42m"
+func FormatIdleTime(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		days := int(d / (24 * time.Hour))
+		if hours := int(d%(24*time.Hour)) / int(time.Hour); hours > 0 {
+			return fmt.Sprintf("%dd %dh", days, hours)
+		}
+		return fmt.Sprintf("%dd", days)
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	default:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	}
+}
+
+// Duration returns the active time of the session, excluding pauses
+func (s *Session) Duration() time.Duration {
+	return s.CalculateDuration()
 }
@@ -465,7 +465,9 @@ func TestSession_StateTransitions(t *testing.T) {
 	}
 
 	// Test pause
-	session.Pause()
+	if err := session.Pause(); err != nil {
+		t.Fatalf("Failed to pause session: %v", err)
+	}
 	if session.Status != SessionStatusPaused {
 		t.Errorf("Expected Status %s after pause, got %s", SessionStatusPaused, session.Status)
 	}
@@ -473,8 +475,23 @@ func TestSession_StateTransitions(t *testing.T) {
 		t.Error("Expected paused session to not be active")
 	}
 
+	if session.PausedAt == nil {
+		t.Error("Expected PausedAt to be set after pause")
+	}
+	if err := session.Pause(); err == nil {
+		t.Error("Expected an error pausing a paused session")
+	}
+
 	// Test resume
-	session.Resume()
+	if err := session.Resume(); err != nil {
+		t.Fatalf("Failed to resume session: %v", err)
+	}
+	if session.PausedAt != nil {
+		t.Error("Expected PausedAt to be cleared after resume")
+	}
+	if err := session.Resume(); err == nil {
+		t.Error("Expected an error resuming an active session")
+	}
 	if session.Status != SessionStatusActive {
 		t.Errorf("Expected Status %s after resume, got %s", SessionStatusActive, session.Status)
 	}
@@ -565,3 +582,94 @@ func TestSession_Duration(t *testing.T) {
 		t.Errorf("Duration() and CalculateDuration() mismatch: %v vs %v", completedDuration, duration)
 	}
 }
+
+func TestSession_DurationExcludesPauses(t *testing.T) {
+	start := time.Now().Add(-3 * time.Hour)
+	session := NewSession("proj_1", "Test", "Task")
+	session.StartTime = start
+	session.PausedDuration = 30 * time.Minute
+
+	end := start.Add(2 * time.Hour)
+	session.EndTime = &end
+	if got := session.Duration(); got != 90*time.Minute {
+		t.Errorf("Expected 1h30m of active time, got %s", got)
+	}
+
+	// An ongoing pause counts until the end of the session
+	pausedAt := start.Add(time.Hour)
+	session.PausedAt = &pausedAt
+	if got := session.Duration(); got != 30*time.Minute {
+		t.Errorf("Expected 30m of active time during a pause, got %s", got)
+	}
+
+	// Completing a paused session closes the pause
+	session.EndTime = nil
+	session.Status = SessionStatusPaused
+	session.Complete("done")
+	if session.PausedAt != nil {
+		t.Error("Expected PausedAt to be cleared on completion")
+	}
+	if got := *session.SessionDuration; got != 30*time.Minute {
+		t.Errorf("Expected 30m of active time after completion, got %s", got)
+	}
+}
+
+func TestSession_LastActivity(t *testing.T) {
+	start := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+	session := NewSession("proj_1", "Test", "Task")
+	session.StartTime = start
+
+	if !session.LastActivity().Equal(start) {
+		t.Errorf("Expected last activity at start %v, got %v", start, session.LastActivity())
+	}
+
+	logged := start.Add(12 * time.Hour)
+	session.AddProgress(ProgressEntry{Message: "Worked", Type: ProgressTypeInfo, Timestamp: logged.Format(time.RFC3339)})
+	session.AddProgress(ProgressEntry{Message: "Bad timestamp", Type: ProgressTypeInfo, Timestamp: "yesterday"})
+	if !session.LastActivity().Equal(logged) {
+		t.Errorf("Expected last activity at latest entry %v, got %v", logged, session.LastActivity())
+	}
+	if idle := session.IdleFor(logged.Add(36 * time.Hour)); idle != 36*time.Hour {
+		t.Errorf("Expected 36h idle, got %s", idle)
+	}
+	if idle := session.IdleFor(start); idle != 0 {
+		t.Errorf("Expected no idle time before the last activity, got %s", idle)
+	}
+
+	if err := session.PauseWithReason("inactive for 36h"); err != nil {
+		t.Fatalf("Failed to pause session: %v", err)
+	}
+	if last := session.Progress[len(session.Progress)-1].Message; last != "Session paused: inactive for 36h" {
+		t.Errorf("Expected pause reason in progress log, got %q", last)
+	}
+	if !session.LastActivity().Equal(logged) {
+		t.Errorf("Expected a pause for inactivity not to count as activity, got %v", session.LastActivity())
+	}
+
+	if err := session.Resume(); err != nil {
+		t.Fatalf("Failed to resume session: %v", err)
+	}
+	if idle := session.IdleFor(time.Now()); idle > time.Minute {
+		t.Errorf("Expected resuming to count as activity, got %s idle", idle)
+	}
+
+	resumed := session.LastActivity()
+	session.Abort(InactivityReason(36 * time.Hour))
+	if !session.LastActivity().Equal(resumed) {
+		t.Errorf("Expected aborting not to count as activity, got %v", session.LastActivity())
+	}
+}
+
+func TestFormatIdleTime(t *testing.T) {
+	tests := map[time.Duration]string{
+		42 * time.Minute:              "42m",
+		5*time.Hour + 10*time.Minute:  "5h",
+		72 * time.Hour:                "3d",
+		76*time.Hour + 30*time.Minute: "3d 4h",
+	}
+	for idle, expected := range tests {
+		if got := FormatIdleTime(idle); got != expected {
+			t.Errorf("FormatIdleTime(%s) = %q, expected %q", idle, got, expected)
+		}
+	}
+}
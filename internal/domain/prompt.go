@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// minTruncatedItemTokens is the smallest remainder worth filling with a truncated item
+const minTruncatedItemTokens = 32
+
+// PromptSection is a block of prompt text. Sections are rendered in priority order.
+type PromptSection struct {
+	Heading string   // rendered on its own line, may be empty
+	Items   []string // each item should end with a newline
+}
+
+// EstimateTokens approximates the token count of text at roughly four characters per token
+func EstimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+// RenderPromptSections renders sections in order until the token budget is used up.
+// The first item that does not fit is truncated when enough budget remains; every
+// item after it is omitted and counted in a closing note.
+func RenderPromptSections(sections []PromptSection, budget int) string {
+	var out strings.Builder
+	remaining := budget
+	omitted := 0
+	truncated := false
+
+	for _, section := range sections {
+		if len(section.Items) == 0 {
+			continue
+		}
+		if omitted > 0 {
+			omitted += len(section.Items)
+			continue
+		}
+
+		heading := ""
+		if section.Heading != "" {
+			heading = section.Heading + "\n"
+		}
+		if EstimateTokens(heading) >= remaining {
+			omitted += len(section.Items)
+			continue
+		}
+		out.WriteString(heading)
+		remaining -= EstimateTokens(heading)
+
+		for i, item := range section.Items {
+			cost := EstimateTokens(item)
+			if cost <= remaining {
+				out.WriteString(item)
+				remaining -= cost
+				continue
+			}
+
+			if remaining >= minTruncatedItemTokens {
+				out.WriteString(truncateToTokens(item, remaining))
+				out.WriteString("\n")
+				truncated = true
+				i++
+			}
+			omitted += len(section.Items) - i
+			remaining = 0
+			break
+		}
+		out.WriteString("\n")
+	}
+
+	switch {
+	case omitted > 0:
+		out.WriteString(fmt.Sprintf("_%d more item(s) omitted to fit the token budget._\n", omitted))
+	case truncated:
+		out.WriteString("_Truncated to fit the token budget._\n")
+	}
+
+	return out.String()
+}
+
+// truncateToTokens shortens text to roughly the given number of tokens
+func truncateToTokens(text string, tokens int) string {
+	maxLen := tokens*4 - len("…")
+	if maxLen <= 0 {
+		return ""
+	}
+	if len(text) <= maxLen {
+		return text
+	}
+	// Avoid cutting a multi-byte character in half
+	for maxLen > 0 && !utf8RuneStart(text[maxLen]) {
+		maxLen--
+	}
+	return strings.TrimRight(text[:maxLen], " \n") + "…"
+}
+
+func utf8RuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
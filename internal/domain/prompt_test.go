@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderPromptSections_Budget(t *testing.T) {
+	sections := []PromptSection{
+		{Heading: "## First", Items: []string{strings.Repeat("a", 200) + "\n"}},
+		{Heading: "## Second", Items: []string{strings.Repeat("b", 400) + "\n", "- dropped\n"}},
+		{Heading: "## Third", Items: []string{"- dropped too\n"}},
+	}
+
+	// Everything fits
+	full := RenderPromptSections(sections, 1000)
+	if !strings.Contains(full, "dropped too") || strings.Contains(full, "omitted") {
+		t.Errorf("Expected all sections without omission note, got:\n%s", full)
+	}
+
+	// The first section fits, the second is truncated and the rest omitted
+	limited := RenderPromptSections(sections, 120)
+	if !strings.Contains(limited, strings.Repeat("a", 200)) {
+		t.Error("Expected the highest priority section to be complete")
+	}
+	if strings.Contains(limited, strings.Repeat("b", 400)) || !strings.Contains(limited, "…") {
+		t.Error("Expected the second section item to be truncated")
+	}
+	if strings.Contains(limited, "dropped") {
+		t.Error("Expected lower priority items to be omitted")
+	}
+	if !strings.Contains(limited, "_2 more item(s) omitted") {
+		t.Errorf("Expected omission note for 2 items, got:\n%s", limited)
+	}
+	if EstimateTokens(limited) > 120+EstimateTokens("_2 more item(s) omitted to fit the token budget._\n") {
+		t.Errorf("Expected output within budget, got %d tokens", EstimateTokens(limited))
+	}
+}
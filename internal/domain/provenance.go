@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"context"
+	"strings"
+)
+
+// ProvenanceSource is the channel through which a memory or session was written
+type ProvenanceSource string
+
+const (
+	ProvenanceSourceCLI    ProvenanceSource = "cli"
+	ProvenanceSourceMCP    ProvenanceSource = "mcp"
+	ProvenanceSourceGit    ProvenanceSource = "git"
+	ProvenanceSourceImport ProvenanceSource = "import"
+)
+
+// IsValid checks if the provenance source is one of the known sources
+func (s ProvenanceSource) IsValid() bool {
+	switch s {
+	case ProvenanceSourceCLI, ProvenanceSourceMCP, ProvenanceSourceGit, ProvenanceSourceImport:
+		return true
+	}
+	return false
+}
+
+// Provenance records who wrote a memory or session and through which channel
+type Provenance struct {
+	// Author is the user or commit author the write is attributed to
+	Author string `json:"author,omitempty"`
+	// Source is the channel of the write
+	Source ProvenanceSource `json:"source,omitempty"`
+	// Client is the name and version of the MCP client, e.g. "claude-code/1.0.3"
+	Client string `json:"client,omitempty"`
+	// SessionID is the development session that was active when the write happened
+	SessionID *SessionID `json:"session_id,omitempty"`
+}
+
+// IsEmpty reports whether nothing is recorded
+func (p Provenance) IsEmpty() bool {
+	return p.Author == "" && p.Source == "" && p.Client == "" && p.SessionID == nil
+}
+
+// Merge returns the provenance with the non-empty values of override applied
+func (p Provenance) Merge(override Provenance) Provenance {
+	if override.Author != "" {
+		p.Author = override.Author
+	}
+	if override.Source != "" {
+		p.Source = override.Source
+	}
+	if override.Client != "" {
+		p.Client = override.Client
+	}
+	if override.SessionID != nil {
+		p.SessionID = override.SessionID
+	}
+	return p
+}
+
+// String formats the provenance for display, e.g. "alice via mcp (claude-code/1.0.3)"
+func (p Provenance) String() string {
+	var b strings.Builder
+	b.WriteString(p.Author)
+	if b.Len() == 0 {
+		b.WriteString("unknown")
+	}
+	if p.Source != "" {
+		b.WriteString(" via " + string(p.Source))
+	}
+	if p.Client != "" {
+		b.WriteString(" (" + p.Client + ")")
+	}
+	if p.SessionID != nil {
+		b.WriteString(" in session " + string(*p.SessionID))
+	}
+	return b.String()
+}
+
+// ClientName formats an MCP client name and version as recorded in Provenance.Client
+func ClientName(name, version string) string {
+	name = strings.TrimSpace(name)
+	version = strings.TrimSpace(version)
+	if name == "" || version == "" {
+		return name
+	}
+	return name + "/" + version
+}
+
+// ProvenanceFilter selects memories by their provenance. Empty values match everything.
+type ProvenanceFilter struct {
+	Author string           `json:"author,omitempty"`
+	Source ProvenanceSource `json:"source,omitempty"`
+	// Client matches the full client name and version, or the name alone
+	Client string `json:"client,omitempty"`
+}
+
+// IsEmpty reports whether the filter matches everything
+func (f ProvenanceFilter) IsEmpty() bool {
+	return f.Author == "" && f.Source == "" && f.Client == ""
+}
+
+// Matches checks a provenance against the filter. Comparisons ignore case; a
+// memory without provenance only matches an empty filter.
+func (f ProvenanceFilter) Matches(p *Provenance) bool {
+	if f.IsEmpty() {
+		return true
+	}
+	if p == nil {
+		return false
+	}
+	if f.Author != "" && !strings.EqualFold(f.Author, p.Author) {
+		return false
+	}
+	if f.Source != "" && !strings.EqualFold(string(f.Source), string(p.Source)) {
+		return false
+	}
+	if f.Client != "" {
+		name, _, _ := strings.Cut(p.Client, "/")
+		if !strings.EqualFold(f.Client, p.Client) && !strings.EqualFold(f.Client, name) {
+			return false
+		}
+	}
+	return true
+}
+
+type provenanceContextKey struct{}
+
+// WithProvenance returns a context carrying the provenance of the writes made with it.
+// Values already in the context are kept unless p overrides them.
+func WithProvenance(ctx context.Context, p Provenance) context.Context {
+	return context.WithValue(ctx, provenanceContextKey{}, ProvenanceFromContext(ctx).Merge(p))
+}
+
+// ProvenanceFromContext returns the provenance carried by the context, if any
+func ProvenanceFromContext(ctx context.Context) Provenance {
+	if p, ok := ctx.Value(provenanceContextKey{}).(Provenance); ok {
+		return p
+	}
+	return Provenance{}
+}
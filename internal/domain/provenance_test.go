@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"context"
+	"testing"
+)
+
+func TestProvenance_Merge(t *testing.T) {
+	sessionID := SessionID("sess_1")
+	base := Provenance{Author: "alice", Source: ProvenanceSourceCLI}
+
+	merged := base.Merge(Provenance{Source: ProvenanceSourceGit, SessionID: &sessionID})
+	if merged.Author != "alice" || merged.Source != ProvenanceSourceGit || merged.SessionID == nil {
+		t.Errorf("Unexpected merge result %+v", merged)
+	}
+	if base.Source != ProvenanceSourceCLI {
+		t.Error("Expected the receiver to be unchanged")
+	}
+}
+
+func TestProvenance_String(t *testing.T) {
+	sessionID := SessionID("sess_1")
+	p := Provenance{Author: "alice", Source: ProvenanceSourceMCP, Client: ClientName("cursor", "0.42"), SessionID: &sessionID}
+	if got := p.String(); got != "alice via mcp (cursor/0.42) in session sess_1" {
+		t.Errorf("Unexpected string %q", got)
+	}
+	if got := (Provenance{Source: ProvenanceSourceGit}).String(); got != "unknown via git" {
+		t.Errorf("Unexpected string %q", got)
+	}
+}
+
+func TestProvenanceContext(t *testing.T) {
+	ctx := context.Background()
+	if !ProvenanceFromContext(ctx).IsEmpty() {
+		t.Error("Expected no provenance in a plain context")
+	}
+
+	ctx = WithProvenance(ctx, Provenance{Source: ProvenanceSourceMCP, Client: "claude-code/1.0"})
+	ctx = WithProvenance(ctx, Provenance{Author: "bob"})
+
+	p := ProvenanceFromContext(ctx)
+	if p.Author != "bob" || p.Source != ProvenanceSourceMCP || p.Client != "claude-code/1.0" {
+		t.Errorf("Expected nested provenance to be merged, got %+v", p)
+	}
+}
+
+func TestProvenanceFilter_Matches(t *testing.T) {
+	p := &Provenance{Author: "Alice", Source: ProvenanceSourceMCP, Client: ClientName("claude-code", "1.0.3")}
+
+	tests := []struct {
+		name     string
+		filter   ProvenanceFilter
+		expected bool
+	}{
+		{"empty filter", ProvenanceFilter{}, true},
+		{"source", ProvenanceFilter{Source: ProvenanceSourceMCP}, true},
+		{"other source", ProvenanceFilter{Source: ProvenanceSourceCLI}, false},
+		{"author ignores case", ProvenanceFilter{Author: "alice"}, true},
+		{"client name", ProvenanceFilter{Client: "claude-code"}, true},
+		{"client name and version", ProvenanceFilter{Client: "claude-code/1.0.3"}, true},
+		{"other client version", ProvenanceFilter{Client: "claude-code/2.0"}, false},
+		{"combined", ProvenanceFilter{Source: ProvenanceSourceMCP, Author: "bob"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(p); got != tt.expected {
+				t.Errorf("Matches() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+
+	if (ProvenanceFilter{Source: ProvenanceSourceCLI}).Matches(nil) {
+		t.Error("Expected memories without provenance not to match a filter")
+	}
+}
@@ -0,0 +1,263 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// RedactionMarkerPrefix starts the placeholder that replaces a redacted value
+const RedactionMarkerPrefix = "[REDACTED:"
+
+// RedactionRule detects one kind of secret or personal data
+type RedactionRule struct {
+	Name    string
+	Pattern *regexp.Regexp
+	// Group is the submatch that holds the secret; 0 masks the whole match
+	Group int
+	// MinEntropy is the Shannon entropy in bits per character the secret must reach.
+	// It keeps rules that match on context, such as "token=...", from masking
+	// ordinary words and code.
+	MinEntropy float64
+}
+
+// Redaction records that values matched by a rule were masked in a field.
+// The masked values themselves are never recorded.
+type Redaction struct {
+	Field string `json:"field"`
+	Rule  string `json:"rule"`
+	Count int    `json:"count"`
+}
+
+// DefaultRedactionRules returns the built-in rules for API keys, tokens, private keys,
+// passwords in URLs and assignments, and e-mail addresses
+func DefaultRedactionRules() []RedactionRule {
+	return []RedactionRule{
+		{Name: "private_key", Pattern: regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?(?:-----END [A-Z ]*PRIVATE KEY-----|$)`)},
+		{Name: "aws_access_key", Pattern: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+		{Name: "github_token", Pattern: regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`)},
+		{Name: "slack_token", Pattern: regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}\b`)},
+		{Name: "google_api_key", Pattern: regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+		{Name: "api_key", Pattern: regexp.MustCompile(`\b(?:sk|rk|pk)_(?:live|test)_[A-Za-z0-9]{16,}\b|\bsk-[A-Za-z0-9_-]{20,}`)},
+		{Name: "jwt", Pattern: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`)},
+		{Name: "bearer_token", Pattern: regexp.MustCompile(`(?i)\bbearer\s+([A-Za-z0-9._~+/-]{20,}=*)`), Group: 1, MinEntropy: 3.0},
+		{Name: "url_password", Pattern: regexp.MustCompile(`\b[a-zA-Z][a-zA-Z0-9+.-]*://[^/\s:@]+:([^/\s@]+)@`), Group: 1},
+		{Name: "password", Pattern: regexp.MustCompile("(?i)\\b[a-z0-9_.-]*(?:password|passwd|pwd)[\"']?\\s*[:=]\\s*[\"']?([^\\s\"'`,;(){}<>]{6,})"), Group: 1, MinEntropy: 2.0},
+		{Name: "secret", Pattern: regexp.MustCompile(`(?i)\b[a-z0-9_.-]*(?:secret|token|api[_-]?key|access[_-]?key|credential)s?["']?\s*[:=]\s*["']?([A-Za-z0-9+/_.~-]{16,}=*)`), Group: 1, MinEntropy: 3.5},
+		{Name: "email", Pattern: regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`)},
+	}
+}
+
+// Placeholders and references such as ${DB_PASSWORD}, <token> or cfg.Database.Password
+// are not secrets themselves
+var redactionPlaceholderPattern = regexp.MustCompile(`^(?:\$\{?[A-Za-z_][A-Za-z0-9_]*\}?|<[^>]*>|\*+|x+|[A-Za-z_][A-Za-z0-9_]*(?:\.[A-Za-z_][A-Za-z0-9_]*)+)$`)
+
+// Redactor masks secrets and personal data in text
+type Redactor struct {
+	rules     []RedactionRule
+	allowlist []*regexp.Regexp
+}
+
+// NewRedactor creates a redactor applying the rules in order. Values matching an
+// allowlist pattern are left as they are.
+func NewRedactor(rules []RedactionRule, allowlist []*regexp.Regexp) *Redactor {
+	return &Redactor{rules: rules, allowlist: allowlist}
+}
+
+// Rules returns the names of the rules the redactor applies
+func (r *Redactor) Rules() []string {
+	names := make([]string, len(r.rules))
+	for i, rule := range r.rules {
+		names[i] = rule.Name
+	}
+	return names
+}
+
+// Redact masks every detected value in text and returns the masked text with the
+// number of values masked per rule
+func (r *Redactor) Redact(text string) (string, map[string]int) {
+	var counts map[string]int
+	for _, rule := range r.rules {
+		var count int
+		text, count = r.apply(rule, text)
+		if count > 0 {
+			if counts == nil {
+				counts = make(map[string]int)
+			}
+			counts[rule.Name] += count
+		}
+	}
+	return text, counts
+}
+
+// apply masks the values matched by a single rule
+func (r *Redactor) apply(rule RedactionRule, text string) (string, int) {
+	matches := rule.Pattern.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return text, 0
+	}
+
+	var out strings.Builder
+	last, count := 0, 0
+	for _, match := range matches {
+		start, end := match[2*rule.Group], match[2*rule.Group+1]
+		if start < 0 || !r.isSecret(rule, text[start:end]) {
+			continue
+		}
+		out.WriteString(text[last:start])
+		out.WriteString(RedactionMarkerPrefix + rule.Name + "]")
+		last = end
+		count++
+	}
+	if count == 0 {
+		return text, 0
+	}
+	out.WriteString(text[last:])
+	return out.String(), count
+}
+
+// isSecret checks a matched value against the entropy threshold and the allowlist
+func (r *Redactor) isSecret(rule RedactionRule, value string) bool {
+	if strings.Contains(value, RedactionMarkerPrefix) {
+		return false
+	}
+	if rule.MinEntropy > 0 {
+		if redactionPlaceholderPattern.MatchString(value) || ShannonEntropy(value) < rule.MinEntropy {
+			return false
+		}
+	}
+	for _, allowed := range r.allowlist {
+		if allowed.MatchString(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// RedactMemory masks secrets in the title, content, context and string fields of a
+// memory, adds the redactions to the memory's record and returns them
+func (r *Redactor) RedactMemory(memory *Memory) []Redaction {
+	var redactions []Redaction
+	redact := func(field string, text string) string {
+		masked, counts := r.Redact(text)
+		redactions = append(redactions, redactionsFor(field, counts)...)
+		return masked
+	}
+
+	memory.Title = redact("title", memory.Title)
+	memory.Content = redact("content", memory.Content)
+	memory.Context = redact("context", memory.Context)
+
+	if len(memory.Fields) > 0 {
+		fields := make(map[string]interface{}, len(memory.Fields))
+		for name, value := range memory.Fields {
+			switch v := value.(type) {
+			case string:
+				fields[name] = redact("fields."+name, v)
+			case []string:
+				items := make([]string, len(v))
+				for i, item := range v {
+					items[i] = redact("fields."+name, item)
+				}
+				fields[name] = items
+			default:
+				fields[name] = value
+			}
+		}
+		memory.Fields = fields
+	}
+
+	memory.Redactions = MergeRedactions(memory.Redactions, redactions)
+	return MergeRedactions(nil, redactions)
+}
+
+// RedactSession masks secrets in the task description, outcome, summary and progress
+// of a session. Redactions in progress entries are recorded on the entry.
+func (r *Redactor) RedactSession(session *Session) []Redaction {
+	var redactions []Redaction
+	redact := func(field string, text string) string {
+		masked, counts := r.Redact(text)
+		redactions = append(redactions, redactionsFor(field, counts)...)
+		return masked
+	}
+
+	session.Name = redact("name", session.Name)
+	session.TaskDescription = redact("task_description", session.TaskDescription)
+	session.Outcome = redact("outcome", session.Outcome)
+	session.Summary = redact("summary", session.Summary)
+
+	progress := make([]ProgressEntry, len(session.Progress))
+	for i, entry := range session.Progress {
+		masked, counts := r.Redact(entry.Message)
+		entryRedactions := redactionsFor("message", counts)
+		entry.Message = masked
+		entry.Redactions = MergeRedactions(entry.Redactions, entryRedactions)
+		progress[i] = entry
+		for _, redaction := range entryRedactions {
+			redaction.Field = fmt.Sprintf("progress[%d]", i)
+			redactions = append(redactions, redaction)
+		}
+	}
+	session.Progress = progress
+
+	return MergeRedactions(nil, redactions)
+}
+
+// redactionsFor turns per-rule counts into redaction records sorted by rule
+func redactionsFor(field string, counts map[string]int) []Redaction {
+	redactions := make([]Redaction, 0, len(counts))
+	for rule, count := range counts {
+		redactions = append(redactions, Redaction{Field: field, Rule: rule, Count: count})
+	}
+	sort.Slice(redactions, func(i, j int) bool {
+		return redactions[i].Rule < redactions[j].Rule
+	})
+	return redactions
+}
+
+// MergeRedactions adds redaction records to an existing record, summing the counts
+// of the same rule in the same field
+func MergeRedactions(existing, added []Redaction) []Redaction {
+	if len(added) == 0 {
+		return existing
+	}
+
+	merged := append([]Redaction(nil), existing...)
+	for _, redaction := range added {
+		found := false
+		for i := range merged {
+			if merged[i].Field == redaction.Field && merged[i].Rule == redaction.Rule {
+				merged[i].Count += redaction.Count
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, redaction)
+		}
+	}
+	return merged
+}
+
+// ShannonEntropy returns the entropy of s in bits per character
+func ShannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+
+	frequencies := make(map[rune]int)
+	total := 0
+	for _, r := range s {
+		frequencies[r]++
+		total++
+	}
+
+	entropy := 0.0
+	for _, count := range frequencies {
+		p := float64(count) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// ReviewStatus is the approval state of a memory
+type ReviewStatus string
+
+const (
+	// ReviewStatusDraft memories wait in the review queue and are only returned
+	// by search and list when drafts are requested explicitly
+	ReviewStatusDraft ReviewStatus = "draft"
+	// ReviewStatusApproved memories are used everywhere. Memories written
+	// before the review queue existed are approved.
+	ReviewStatusApproved ReviewStatus = "approved"
+)
+
+// IsValid checks if the review status is one of the known states
+func (s ReviewStatus) IsValid() bool {
+	return s == ReviewStatusDraft || s == ReviewStatusApproved
+}
+
+// ReviewPolicy decides which new memories are created as drafts
+type ReviewPolicy struct {
+	// Sources lists the write channels whose memories need review, e.g. mcp
+	Sources []ProvenanceSource
+}
+
+// RequiresReview reports whether a new memory must be reviewed before it is used.
+// Tasks and sessions are tracked by status and never need review.
+func (p ReviewPolicy) RequiresReview(memory *Memory) bool {
+	if memory.Type == MemoryTypeTask || memory.Type == MemoryTypeSession || memory.Provenance == nil {
+		return false
+	}
+	for _, source := range p.Sources {
+		if strings.EqualFold(string(source), string(memory.Provenance.Source)) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDraft reports whether the memory waits in the review queue
+func (m *Memory) IsDraft() bool {
+	return m.ReviewStatus == ReviewStatusDraft
+}
+
+// MarkDraft puts the memory into the review queue
+func (m *Memory) MarkDraft() {
+	m.ReviewStatus = ReviewStatusDraft
+	m.ReviewedAt = nil
+}
+
+// Approve takes the memory out of the review queue
+func (m *Memory) Approve(at time.Time) {
+	m.ReviewStatus = ReviewStatusApproved
+	m.ReviewedAt = &at
+	m.UpdatedAt = at
+}
+
+// MergeDraft folds a draft into the memory: its content and context are appended
+// and its tags and anchors are added
+func (m *Memory) MergeDraft(draft *Memory) {
+	m.Content = appendParagraph(m.Content, draft.Content)
+	m.Context = appendParagraph(m.Context, draft.Context)
+	for _, tag := range draft.Tags {
+		m.Tags.Add(tag)
+	}
+	for _, anchor := range draft.Anchors {
+		m.AddAnchor(anchor)
+	}
+	m.UpdatedAt = time.Now()
+}
+
+// appendParagraph appends text as a new paragraph, skipping empty or repeated text
+func appendParagraph(text, addition string) string {
+	addition = strings.TrimSpace(addition)
+	if addition == "" || strings.Contains(text, addition) {
+		return text
+	}
+	if strings.TrimSpace(text) == "" {
+		return addition
+	}
+	return text + "\n\n" + addition
+}
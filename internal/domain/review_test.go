@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReviewPolicy_RequiresReview(t *testing.T) {
+	policy := ReviewPolicy{Sources: []ProvenanceSource{ProvenanceSourceMCP}}
+
+	tests := []struct {
+		name       string
+		memoryType MemoryType
+		provenance *Provenance
+		expected   bool
+	}{
+		{"mcp decision", MemoryTypeDecision, &Provenance{Source: ProvenanceSourceMCP}, true},
+		{"cli decision", MemoryTypeDecision, &Provenance{Source: ProvenanceSourceCLI}, false},
+		{"no provenance", MemoryTypeDecision, nil, false},
+		{"mcp task", MemoryTypeTask, &Provenance{Source: ProvenanceSourceMCP}, false},
+		{"mcp session", MemoryTypeSession, &Provenance{Source: ProvenanceSourceMCP}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memory := NewMemory("proj", tt.memoryType, "Title", "Content", "")
+			memory.Provenance = tt.provenance
+			if got := policy.RequiresReview(memory); got != tt.expected {
+				t.Errorf("RequiresReview() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+
+	if (ReviewPolicy{}).RequiresReview(&Memory{Type: MemoryTypeDecision, Provenance: &Provenance{Source: ProvenanceSourceMCP}}) {
+		t.Error("Expected an empty policy not to require review")
+	}
+}
+
+func TestMemory_ReviewLifecycle(t *testing.T) {
+	memory := NewMemory("proj", MemoryTypePattern, "Title", "Content", "")
+	if memory.IsDraft() || memory.ReviewStatus != ReviewStatusApproved {
+		t.Fatalf("Expected new memories to be approved, got %q", memory.ReviewStatus)
+	}
+
+	memory.MarkDraft()
+	if !memory.IsDraft() {
+		t.Fatal("Expected memory to be a draft")
+	}
+
+	at := time.Now()
+	memory.Approve(at)
+	if memory.IsDraft() || memory.ReviewedAt == nil || !memory.ReviewedAt.Equal(at) {
+		t.Errorf("Expected approved memory with review time, got %+v", memory)
+	}
+}
+
+func TestMemory_MergeDraft(t *testing.T) {
+	target := NewMemory("proj", MemoryTypeErrorSolution, "Timeout", "Increase the timeout.", "")
+	target.AddTag("http")
+
+	draft := NewMemory("proj", MemoryTypeErrorSolution, "Timeout again", "Retry with backoff.", "Seen in CI")
+	draft.AddTag("http")
+	draft.AddTag("ci")
+	draft.AddAnchor(CodeAnchor{Path: "client.go"})
+
+	target.MergeDraft(draft)
+	if target.Content != "Increase the timeout.\n\nRetry with backoff." {
+		t.Errorf("Unexpected content %q", target.Content)
+	}
+	if target.Context != "Seen in CI" {
+		t.Errorf("Unexpected context %q", target.Context)
+	}
+	if len(target.Tags) != 2 || !target.Tags.Contains("ci") {
+		t.Errorf("Expected tags to be merged, got %v", target.Tags)
+	}
+	if len(target.Anchors) != 1 {
+		t.Errorf("Expected anchors to be merged, got %v", target.Anchors)
+	}
+
+	target.MergeDraft(draft)
+	if target.Content != "Increase the timeout.\n\nRetry with backoff." {
+		t.Errorf("Expected repeated content to be skipped, got %q", target.Content)
+	}
+}
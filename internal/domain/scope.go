@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Shared scopes hold knowledge that is not tied to a single project, such as team
+// conventions. Their memories are stored under a project ID with the scope prefix
+// and are included in the searches of the projects that use the scope.
+const scopePrefix = "scope:"
+
+// GlobalScope is the shared scope included in every project by default
+const GlobalScope ProjectID = scopePrefix + "global"
+
+// teamNamePattern restricts team scope names to lower case letters, digits, '-' and '_'
+var teamNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// TeamScope returns the shared scope of a team
+func TeamScope(team string) ProjectID {
+	return ProjectID(scopePrefix + "team:" + team)
+}
+
+// ParseScope parses a scope name: "global", "team:<name>" or either with the
+// "scope:" prefix used in project IDs
+func ParseScope(name string) (ProjectID, error) {
+	name = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(name)), scopePrefix)
+	if name == "global" {
+		return GlobalScope, nil
+	}
+	if team, ok := strings.CutPrefix(name, "team:"); ok && teamNamePattern.MatchString(team) {
+		return TeamScope(team), nil
+	}
+	return "", fmt.Errorf("invalid scope %q, use global or team:<name>", name)
+}
+
+// IsScope reports whether the project ID names a shared scope rather than a project
+func (id ProjectID) IsScope() bool {
+	return strings.HasPrefix(string(id), scopePrefix)
+}
+
+// ScopeName returns the name of a shared scope without the prefix, e.g. "team:backend"
+func (id ProjectID) ScopeName() string {
+	return strings.TrimPrefix(string(id), scopePrefix)
+}
+
+// ScopeSettings decides which shared scopes are searched together with a project
+type ScopeSettings struct {
+	// Weight scales the similarity of shared memories in project searches, 0..1
+	Weight float64
+	// Include lists the scopes searched with every project
+	Include []ProjectID
+	// Projects lists additional scopes per project, e.g. a team scope
+	Projects map[ProjectID][]ProjectID
+}
+
+// DefaultScopeSettings includes the global scope in every project at a slightly lower weight
+func DefaultScopeSettings() ScopeSettings {
+	return ScopeSettings{Weight: 0.8, Include: []ProjectID{GlobalScope}}
+}
+
+// ScopesFor returns the shared scopes searched with a project. Searching a scope
+// itself includes no other scopes.
+func (s ScopeSettings) ScopesFor(projectID ProjectID) []ProjectID {
+	if projectID.IsScope() {
+		return nil
+	}
+	var scopes []ProjectID
+	for _, scope := range append(append([]ProjectID{}, s.Include...), s.Projects[projectID]...) {
+		if !containsProjectID(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// Includes reports whether memories of the given project or scope are part of a
+// project's searches
+func (s ScopeSettings) Includes(projectID, memoryProjectID ProjectID) bool {
+	return memoryProjectID == projectID || containsProjectID(s.ScopesFor(projectID), memoryProjectID)
+}
+
+func containsProjectID(ids []ProjectID, id ProjectID) bool {
+	for _, existing := range ids {
+		if existing == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package domain
+
+import "testing"
+
+func TestParseScope(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected ProjectID
+		wantErr  bool
+	}{
+		{"global", GlobalScope, false},
+		{"Global", GlobalScope, false},
+		{"scope:global", GlobalScope, false},
+		{"team:backend", "scope:team:backend", false},
+		{"scope:team:go_devs", "scope:team:go_devs", false},
+		{"team:", "", true},
+		{"team:Back End", "", true},
+		{"my-project", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseScope(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseScope(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("ParseScope(%q) = %q, expected %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+
+	if !GlobalScope.IsScope() || ProjectID("proj").IsScope() {
+		t.Error("Expected only scope IDs to be scopes")
+	}
+	if name := TeamScope("backend").ScopeName(); name != "team:backend" {
+		t.Errorf("Unexpected scope name %q", name)
+	}
+}
+
+func TestScopeSettings_ScopesFor(t *testing.T) {
+	settings := ScopeSettings{
+		Weight:   0.8,
+		Include:  []ProjectID{GlobalScope},
+		Projects: map[ProjectID][]ProjectID{"api": {TeamScope("backend"), GlobalScope}},
+	}
+
+	if scopes := settings.ScopesFor("api"); len(scopes) != 2 || scopes[1] != TeamScope("backend") {
+		t.Errorf("Expected global and team scope without duplicates, got %v", scopes)
+	}
+	if scopes := settings.ScopesFor("web"); len(scopes) != 1 || scopes[0] != GlobalScope {
+		t.Errorf("Expected only the global scope, got %v", scopes)
+	}
+	if scopes := settings.ScopesFor(GlobalScope); len(scopes) != 0 {
+		t.Errorf("Expected scopes to include no other scopes, got %v", scopes)
+	}
+
+	if !settings.Includes("api", "api") || !settings.Includes("api", TeamScope("backend")) {
+		t.Error("Expected the project and its scopes to be included")
+	}
+	if settings.Includes("web", TeamScope("backend")) || settings.Includes("web", "api") {
+		t.Error("Expected other projects and scopes to be excluded")
+	}
+}
@@ -0,0 +1,257 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// SearchQuery is the structured form of a search query string such as
+// `type:decision tag:auth -tag:legacy after:2025-01-01 "exact phrase" jwt rotation`
+type SearchQuery struct {
+	Text        string            `json:"text,omitempty"`    // free text terms, space separated
+	Phrases     []string          `json:"phrases,omitempty"` // quoted phrases that must appear verbatim
+	Types       []MemoryType      `json:"types,omitempty"`
+	Tags        Tags              `json:"tags,omitempty"`
+	ExcludeTags Tags              `json:"exclude_tags,omitempty"`
+	Fields      map[string]string `json:"fields,omitempty"` // type-specific field values, see FieldMatches
+	ProjectID   *ProjectID        `json:"project_id,omitempty"`
+	After       *time.Time        `json:"after,omitempty"`  // inclusive lower bound on creation time
+	Before      *time.Time        `json:"before,omitempty"` // exclusive upper bound on creation time
+	Provenance  *ProvenanceFilter `json:"provenance,omitempty"`
+}
+
+// SearchQueryError describes a malformed search query
+type SearchQueryError struct {
+	Position int    // byte offset of the offending token
+	Token    string // the offending token
+	Message  string
+}
+
+func (e *SearchQueryError) Error() string {
+	return fmt.Sprintf("invalid search query at position %d (%q): %s", e.Position, e.Token, e.Message)
+}
+
+// Search query fields understood by ParseSearchQuery
+const (
+	QueryFieldType    = "type"
+	QueryFieldField   = "field"
+	QueryFieldTag     = "tag"
+	QueryFieldProject = "project"
+	QueryFieldAfter   = "after"
+	QueryFieldBefore  = "before"
+	QueryFieldSource  = "source"
+	QueryFieldAuthor  = "created_by"
+	QueryFieldClient  = "client"
+)
+
+var queryDateLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"}
+
+// ParseSearchQuery parses a search query string into structured filters and free text.
+// Recognised fields are type:, tag:, -tag:, field:<name>=<value>, project:, after:, before:,
+// source:, created_by: and client:; double quoted
+// text becomes a phrase. Tokens with an unknown field prefix (e.g. "http://host") are
+// kept as free text.
+func ParseSearchQuery(input string) (*SearchQuery, error) {
+	tokens, err := tokenizeSearchQuery(input)
+	if err != nil {
+		return nil, err
+	}
+
+	query := &SearchQuery{}
+	var terms []string
+
+	for _, tok := range tokens {
+		if tok.quoted {
+			if tok.value == "" {
+				return nil, &SearchQueryError{Position: tok.pos, Token: tok.raw, Message: "empty phrase"}
+			}
+			query.Phrases = append(query.Phrases, tok.value)
+			continue
+		}
+
+		negated := strings.HasPrefix(tok.value, "-")
+		field, value, isField := splitQueryField(strings.TrimPrefix(tok.value, "-"))
+		if !isField {
+			terms = append(terms, tok.value)
+			continue
+		}
+
+		if value == "" {
+			return nil, &SearchQueryError{Position: tok.pos, Token: tok.raw, Message: fmt.Sprintf("missing value for %s:", field)}
+		}
+		if negated && field != QueryFieldTag {
+			return nil, &SearchQueryError{Position: tok.pos, Token: tok.raw, Message: fmt.Sprintf("negation is only supported for %s:", QueryFieldTag)}
+		}
+
+		switch field {
+		case QueryFieldType:
+			// User-defined types are not known to the parser, so only the name is checked
+			memoryType := MemoryType(value)
+			if !memoryType.IsValidName() {
+				return nil, &SearchQueryError{Position: tok.pos, Token: tok.raw, Message: fmt.Sprintf("invalid memory type %q (built-in types are %s)", value, joinMemoryTypes(BuiltinMemoryTypes()))}
+			}
+			query.Types = append(query.Types, memoryType)
+		case QueryFieldField:
+			name, fieldValue, ok := strings.Cut(value, "=")
+			if !ok || name == "" || fieldValue == "" {
+				return nil, &SearchQueryError{Position: tok.pos, Token: tok.raw, Message: "expected field:<name>=<value>"}
+			}
+			if query.Fields == nil {
+				query.Fields = make(map[string]string)
+			}
+			query.Fields[name] = fieldValue
+		case QueryFieldTag:
+			if negated {
+				query.ExcludeTags.Add(value)
+			} else {
+				query.Tags.Add(value)
+			}
+		case QueryFieldProject:
+			if query.ProjectID != nil && *query.ProjectID != ProjectID(value) {
+				return nil, &SearchQueryError{Position: tok.pos, Token: tok.raw, Message: "only one project: may be given"}
+			}
+			projectID := ProjectID(value)
+			query.ProjectID = &projectID
+		case QueryFieldAfter, QueryFieldBefore:
+			t, ok := parseQueryDate(value)
+			if !ok {
+				return nil, &SearchQueryError{Position: tok.pos, Token: tok.raw, Message: "invalid date, use YYYY-MM-DD or RFC 3339"}
+			}
+			if field == QueryFieldAfter {
+				query.After = &t
+			} else {
+				query.Before = &t
+			}
+		case QueryFieldSource, QueryFieldAuthor, QueryFieldClient:
+			if query.Provenance == nil {
+				query.Provenance = &ProvenanceFilter{}
+			}
+			switch field {
+			case QueryFieldSource:
+				source := ProvenanceSource(strings.ToLower(value))
+				if !source.IsValid() {
+					return nil, &SearchQueryError{Position: tok.pos, Token: tok.raw, Message: "invalid source, use cli, mcp, git or import"}
+				}
+				query.Provenance.Source = source
+			case QueryFieldAuthor:
+				query.Provenance.Author = value
+			default:
+				query.Provenance.Client = value
+			}
+		}
+	}
+
+	for _, tag := range query.Tags {
+		if query.ExcludeTags.Contains(tag) {
+			return nil, &SearchQueryError{Position: 0, Token: tag, Message: "tag is both required and excluded"}
+		}
+	}
+	if query.After != nil && query.Before != nil && !query.After.Before(*query.Before) {
+		return nil, &SearchQueryError{Position: 0, Token: input, Message: "after: must be earlier than before:"}
+	}
+
+	query.Text = strings.Join(terms, " ")
+	return query, nil
+}
+
+// SearchText returns the text to embed for semantic search: free text followed by phrases
+func (q *SearchQuery) SearchText() string {
+	parts := make([]string, 0, len(q.Phrases)+1)
+	if q.Text != "" {
+		parts = append(parts, q.Text)
+	}
+	parts = append(parts, q.Phrases...)
+	return strings.Join(parts, " ")
+}
+
+// HasFilters reports whether the query contains any structured filters
+func (q *SearchQuery) HasFilters() bool {
+	return len(q.Phrases) > 0 || len(q.Types) > 0 || len(q.Tags) > 0 || len(q.ExcludeTags) > 0 || len(q.Fields) > 0 ||
+		q.ProjectID != nil || q.After != nil || q.Before != nil || q.Provenance != nil
+}
+
+type queryToken struct {
+	value  string
+	raw    string
+	pos    int
+	quoted bool
+}
+
+// tokenizeSearchQuery splits input on whitespace, keeping double quoted phrases together.
+// A field value may itself be quoted, e.g. tag:"two words".
+func tokenizeSearchQuery(input string) ([]queryToken, error) {
+	var tokens []queryToken
+	runes := []rune(input)
+	bytePos := func(i int) int { return len(string(runes[:i])) }
+
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		start := i
+		var value strings.Builder
+		quoted := false
+		for i < len(runes) && !unicode.IsSpace(runes[i]) {
+			if runes[i] != '"' {
+				value.WriteRune(runes[i])
+				i++
+				continue
+			}
+			// A bare quoted token is a phrase; a quote after a prefix quotes the field value
+			quoted = quoted || value.Len() == 0
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, &SearchQueryError{Position: bytePos(i), Token: string(runes[i:]), Message: "unterminated quote"}
+			}
+			value.WriteString(string(runes[i+1 : end]))
+			i = end + 1
+		}
+
+		tokens = append(tokens, queryToken{
+			value:  value.String(),
+			raw:    string(runes[start:i]),
+			pos:    bytePos(start),
+			quoted: quoted,
+		})
+	}
+
+	return tokens, nil
+}
+
+// splitQueryField splits "field:value" when field is a recognised query field
+func splitQueryField(token string) (string, string, bool) {
+	field, value, found := strings.Cut(token, ":")
+	if !found {
+		return "", "", false
+	}
+	switch strings.ToLower(field) {
+	case QueryFieldType, QueryFieldTag, QueryFieldField, QueryFieldProject, QueryFieldAfter, QueryFieldBefore,
+		QueryFieldSource, QueryFieldAuthor, QueryFieldClient:
+		return strings.ToLower(field), value, true
+	}
+	return "", "", false
+}
+
+func parseQueryDate(value string) (time.Time, bool) {
+	for _, layout := range queryDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func joinMemoryTypes(types []MemoryType) string {
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = string(t)
+	}
+	return strings.Join(names, ", ")
+}
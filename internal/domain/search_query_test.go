@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	query, err := ParseSearchQuery(`type:decision tag:auth -tag:legacy after:2025-01-01 "exact phrase" jwt rotation`)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if query.Text != "jwt rotation" {
+		t.Errorf("Expected text 'jwt rotation', got %q", query.Text)
+	}
+	if len(query.Phrases) != 1 || query.Phrases[0] != "exact phrase" {
+		t.Errorf("Expected phrase 'exact phrase', got %v", query.Phrases)
+	}
+	if len(query.Types) != 1 || query.Types[0] != MemoryTypeDecision {
+		t.Errorf("Expected type decision, got %v", query.Types)
+	}
+	if !query.Tags.Contains("auth") || len(query.Tags) != 1 {
+		t.Errorf("Expected tags [auth], got %v", query.Tags)
+	}
+	if !query.ExcludeTags.Contains("legacy") || len(query.ExcludeTags) != 1 {
+		t.Errorf("Expected excluded tags [legacy], got %v", query.ExcludeTags)
+	}
+	expectedAfter := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	if query.After == nil || !query.After.Equal(expectedAfter) {
+		t.Errorf("Expected after %v, got %v", expectedAfter, query.After)
+	}
+	if query.Before != nil {
+		t.Errorf("Expected no before bound, got %v", query.Before)
+	}
+	if query.SearchText() != "jwt rotation exact phrase" {
+		t.Errorf("Expected search text 'jwt rotation exact phrase', got %q", query.SearchText())
+	}
+}
+
+func TestParseSearchQuery_CustomTypeAndFields(t *testing.T) {
+	query, err := ParseSearchQuery(`type:runbook field:service=billing field:severity=high outage`)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(query.Types) != 1 || query.Types[0] != "runbook" {
+		t.Errorf("Expected user-defined type runbook, got %v", query.Types)
+	}
+	if query.Fields["service"] != "billing" || query.Fields["severity"] != "high" {
+		t.Errorf("Expected field filters, got %v", query.Fields)
+	}
+	if query.Text != "outage" || !query.HasFilters() {
+		t.Errorf("Expected text 'outage' with filters, got %q", query.Text)
+	}
+}
+
+func TestParseSearchQuery_PlainText(t *testing.T) {
+	query, err := ParseSearchQuery("  how to configure http://localhost:8080  ")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if query.Text != "how to configure http://localhost:8080" {
+		t.Errorf("Expected unknown fields to stay free text, got %q", query.Text)
+	}
+	if query.HasFilters() {
+		t.Error("Expected no filters for plain text query")
+	}
+}
+
+func TestParseSearchQuery_QuotedValueAndProject(t *testing.T) {
+	query, err := ParseSearchQuery(`project:my-proj tag:"two words" Type:pattern before:2025-06-01T12:00:00Z caching`)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if query.ProjectID == nil || *query.ProjectID != "my-proj" {
+		t.Errorf("Expected project my-proj, got %v", query.ProjectID)
+	}
+	if !query.Tags.Contains("two words") {
+		t.Errorf("Expected quoted tag value, got %v", query.Tags)
+	}
+	if len(query.Types) != 1 || query.Types[0] != MemoryTypePattern {
+		t.Errorf("Expected field names to be case-insensitive, got %v", query.Types)
+	}
+	if query.Before == nil || query.Before.Hour() != 12 {
+		t.Errorf("Expected RFC 3339 before bound, got %v", query.Before)
+	}
+	if len(query.Phrases) != 0 {
+		t.Errorf("Expected quoted field value not to be a phrase, got %v", query.Phrases)
+	}
+}
+
+func TestParseSearchQuery_Provenance(t *testing.T) {
+	query, err := ParseSearchQuery(`source:MCP client:claude-code created_by:"Jane Doe" retry`)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := ProvenanceFilter{Author: "Jane Doe", Source: ProvenanceSourceMCP, Client: "claude-code"}
+	if query.Provenance == nil || *query.Provenance != expected {
+		t.Errorf("Expected provenance filter %+v, got %+v", expected, query.Provenance)
+	}
+	if query.Text != "retry" || !query.HasFilters() {
+		t.Errorf("Expected the remaining text and filters, got %q", query.Text)
+	}
+}
+
+func TestParseSearchQuery_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		message string
+	}{
+		{"unterminated quote", `jwt "rotation`, "unterminated quote"},
+		{"empty phrase", `jwt ""`, "empty phrase"},
+		{"missing value", `tag: jwt`, "missing value for tag:"},
+		{"invalid type", `type:Run-Book jwt`, "invalid memory type"},
+		{"field without value", `field:severity jwt`, "expected field:<name>=<value>"},
+		{"invalid date", `after:yesterday jwt`, "invalid date"},
+		{"negated type", `-type:decision jwt`, "negation is only supported for tag:"},
+		{"conflicting tags", `tag:auth -tag:auth jwt`, "both required and excluded"},
+		{"inverted range", `after:2025-02-01 before:2025-01-01 jwt`, "after: must be earlier than before:"},
+		{"two projects", `project:a project:b jwt`, "only one project:"},
+		{"invalid source", `source:slack jwt`, "invalid source"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSearchQuery(tt.input)
+			if err == nil {
+				t.Fatalf("Expected error for %q", tt.input)
+			}
+			var queryErr *SearchQueryError
+			if !errors.As(err, &queryErr) {
+				t.Fatalf("Expected SearchQueryError, got %T", err)
+			}
+			if !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("Expected error containing %q, got %q", tt.message, err.Error())
+			}
+		})
+	}
+}
+
+func TestParseSearchQuery_ErrorPosition(t *testing.T) {
+	_, err := ParseSearchQuery(`jwt type:Unknown`)
+	var queryErr *SearchQueryError
+	if !errors.As(err, &queryErr) {
+		t.Fatalf("Expected SearchQueryError, got %v", err)
+	}
+	if queryErr.Position != 4 {
+		t.Errorf("Expected position 4, got %d", queryErr.Position)
+	}
+	if queryErr.Token != "type:Unknown" {
+		t.Errorf("Expected token 'type:Unknown', got %q", queryErr.Token)
+	}
+}
@@ -0,0 +1,249 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+)
+
+// TagSeparator separates the levels of a hierarchical tag such as "infra/db/sqlite"
+const TagSeparator = "/"
+
+// TagOrigin records how a tag was added to a memory
+type TagOrigin string
+
+const (
+	TagOriginManual TagOrigin = "manual"
+	TagOriginAuto   TagOrigin = "auto"
+)
+
+// AutoTagMode controls what the auto-tagger does with the tags it extracts
+type AutoTagMode string
+
+const (
+	AutoTagModeOff     AutoTagMode = "off"
+	AutoTagModeSuggest AutoTagMode = "suggest" // store as suggestions for review
+	AutoTagModeApply   AutoTagMode = "apply"   // add as tags with origin auto
+)
+
+// IsValid checks if the auto-tag mode is known
+func (m AutoTagMode) IsValid() bool {
+	return m == AutoTagModeOff || m == AutoTagModeSuggest || m == AutoTagModeApply
+}
+
+// Sources of tag suggestions
+const (
+	TagSourceTechnology = "technology" // known language or framework name
+	TagSourceCode       = "code"       // identifier from a code block
+	TagSourceKeyword    = "keyword"    // distinctive term by TF-IDF
+)
+
+// TagSuggestion is a tag proposed by the auto-tagger
+type TagSuggestion struct {
+	Tag    string  `json:"tag"`
+	Score  float64 `json:"score"` // 0..1
+	Source string  `json:"source"`
+}
+
+// TagNormalizer turns free-form tags into their canonical form
+type TagNormalizer struct {
+	Lowercase bool              `json:"lowercase"`
+	Aliases   map[string]string `json:"aliases,omitempty"` // alias -> canonical tag, e.g. "authn" -> "auth"
+}
+
+// DefaultTagNormalizer returns the normalizer used when none is configured
+func DefaultTagNormalizer() TagNormalizer {
+	return TagNormalizer{Lowercase: true}
+}
+
+// Normalize returns the canonical form of a tag: surrounding whitespace is trimmed,
+// inner whitespace becomes "-", empty hierarchy levels are dropped and aliases are
+// resolved. An alias also applies to the leading levels of a hierarchical tag, so
+// with the alias "db" -> "infra/db" the tag "db/sqlite" becomes "infra/db/sqlite".
+func (n TagNormalizer) Normalize(tag string) string {
+	tag = n.clean(tag)
+	if tag == "" || len(n.Aliases) == 0 {
+		return tag
+	}
+
+	// Resolve the longest aliased prefix
+	levels := strings.Split(tag, TagSeparator)
+	for i := len(levels); i > 0; i-- {
+		prefix := strings.Join(levels[:i], TagSeparator)
+		if canonical, ok := n.alias(prefix); ok {
+			return joinTag(canonical, strings.Join(levels[i:], TagSeparator))
+		}
+	}
+	return tag
+}
+
+// NormalizeTags normalizes a set of tags, dropping empty and duplicate tags
+func (n TagNormalizer) NormalizeTags(tags Tags) Tags {
+	normalized := make(Tags, 0, len(tags))
+	for _, tag := range tags {
+		if tag = n.Normalize(tag); tag != "" {
+			normalized.Add(tag)
+		}
+	}
+	return normalized
+}
+
+func (n TagNormalizer) alias(tag string) (string, bool) {
+	for alias, canonical := range n.Aliases {
+		if n.clean(alias) == tag {
+			return n.clean(canonical), true
+		}
+	}
+	return "", false
+}
+
+func (n TagNormalizer) clean(tag string) string {
+	if n.Lowercase {
+		tag = strings.ToLower(tag)
+	}
+
+	var levels []string
+	for _, level := range strings.Split(tag, TagSeparator) {
+		if level = strings.Join(strings.Fields(level), "-"); level != "" {
+			levels = append(levels, level)
+		}
+	}
+	return strings.Join(levels, TagSeparator)
+}
+
+func joinTag(parent, child string) string {
+	if child == "" {
+		return parent
+	}
+	if parent == "" {
+		return child
+	}
+	return parent + TagSeparator + child
+}
+
+// TagMatches reports whether a tag matches a filter. A filter matches the tag itself
+// and every tag below it in the hierarchy, ignoring case: "infra/db" matches
+// "infra/db" and "infra/db/sqlite" but not "infra/dbx".
+func TagMatches(tag, filter string) bool {
+	if len(tag) < len(filter) {
+		return false
+	}
+	if !strings.EqualFold(tag[:len(filter)], filter) {
+		return false
+	}
+	return len(tag) == len(filter) || strings.HasPrefix(tag[len(filter):], TagSeparator)
+}
+
+// TagParents returns the ancestors of a hierarchical tag, nearest last:
+// "infra/db/sqlite" has the parents "infra" and "infra/db".
+func TagParents(tag string) []string {
+	var parents []string
+	for i := 0; i < len(tag); i++ {
+		if strings.HasPrefix(tag[i:], TagSeparator) {
+			parents = append(parents, tag[:i])
+		}
+	}
+	return parents
+}
+
+func (t Tags) without(remove Tags) Tags {
+	kept := make(Tags, 0, len(t))
+	for _, tag := range t {
+		if !remove.Contains(tag) {
+			kept = append(kept, tag)
+		}
+	}
+	return kept
+}
+
+func (t Tags) intersect(other Tags) Tags {
+	kept := make(Tags, 0, len(t))
+	for _, tag := range t {
+		if other.Contains(tag) {
+			kept = append(kept, tag)
+		}
+	}
+	return kept
+}
+
+// Matches reports whether any tag matches the filter, see TagMatches
+func (t Tags) Matches(filter string) bool {
+	for _, tag := range t {
+		if TagMatches(tag, filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesAll reports whether every filter matches at least one tag
+func (t Tags) MatchesAll(filters Tags) bool {
+	for _, filter := range filters {
+		if !t.Matches(filter) {
+			return false
+		}
+	}
+	return true
+}
+
+// Rename replaces the tag from, and every tag below it, with the tag to, keeping
+// the levels below: renaming "db" to "infra/db" turns "db/sqlite" into
+// "infra/db/sqlite". It reports whether any tag changed.
+func (t *Tags) Rename(from, to string) bool {
+	renamed := make(Tags, 0, len(*t))
+	changed := false
+	for _, tag := range *t {
+		if TagMatches(tag, from) {
+			tag = joinTag(to, strings.TrimPrefix(tag[len(from):], TagSeparator))
+			changed = true
+		}
+		renamed.Add(tag)
+	}
+	if changed {
+		*t = renamed
+	}
+	return changed
+}
+
+// TagCount is the usage count of a tag
+type TagCount struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"` // memories with exactly this tag
+	Total int    `json:"total"` // memories with this tag or a tag below it
+}
+
+// CountTags counts tag usage across memories. Parents of hierarchical tags are
+// included even when no memory uses them directly. The result is sorted by tag.
+func CountTags(memories []*Memory, prefix string) []TagCount {
+	counts := make(map[string]*TagCount)
+	entry := func(tag string) *TagCount {
+		if counts[tag] == nil {
+			counts[tag] = &TagCount{Tag: tag}
+		}
+		return counts[tag]
+	}
+
+	for _, memory := range memories {
+		seen := make(map[string]bool)
+		for _, tag := range memory.Tags {
+			if prefix != "" && !TagMatches(tag, prefix) {
+				continue
+			}
+			entry(tag).Count++
+			for _, t := range append(TagParents(tag), tag) {
+				if !seen[t] && (prefix == "" || TagMatches(t, prefix)) {
+					seen[t] = true
+					entry(t).Total++
+				}
+			}
+		}
+	}
+
+	result := make([]TagCount, 0, len(counts))
+	for _, count := range counts {
+		result = append(result, *count)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Tag < result[j].Tag
+	})
+	return result
+}
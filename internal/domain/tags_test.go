@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTagNormalizer_Normalize(t *testing.T) {
+	normalizer := TagNormalizer{
+		Lowercase: true,
+		Aliases: map[string]string{
+			"authn":          "auth",
+			"Authentication": "auth",
+			"db":             "infra/db",
+		},
+	}
+
+	tests := map[string]string{
+		"  Auth ":         "auth",
+		"authn":           "auth",
+		"AUTHENTICATION":  "auth",
+		"error handling":  "error-handling",
+		"/infra//db/":     "infra/db",
+		"db":              "infra/db",
+		"db/SQLite":       "infra/db/sqlite",
+		"dbx":             "dbx",
+		"infra/db/sqlite": "infra/db/sqlite",
+		"   ":             "",
+	}
+
+	for input, expected := range tests {
+		if got := normalizer.Normalize(input); got != expected {
+			t.Errorf("Normalize(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+
+	caseSensitive := TagNormalizer{}
+	if got := caseSensitive.Normalize("Auth"); got != "Auth" {
+		t.Errorf("Expected case to be kept, got %q", got)
+	}
+}
+
+func TestTagNormalizer_NormalizeTags(t *testing.T) {
+	normalizer := TagNormalizer{Lowercase: true, Aliases: map[string]string{"authn": "auth"}}
+
+	tags := normalizer.NormalizeTags(Tags{"Auth", "authn", "", "api"})
+	if !reflect.DeepEqual(tags, Tags{"auth", "api"}) {
+		t.Errorf("Expected deduplicated tags, got %v", tags)
+	}
+}
+
+func TestTagMatches(t *testing.T) {
+	tests := []struct {
+		tag, filter string
+		expected    bool
+	}{
+		{"infra/db/sqlite", "infra/db", true},
+		{"infra/db/sqlite", "infra", true},
+		{"infra/db", "infra/db", true},
+		{"Infra/DB", "infra/db", true},
+		{"infra/dbx", "infra/db", false},
+		{"infra", "infra/db", false},
+		{"auth", "authn", false},
+	}
+
+	for _, tt := range tests {
+		if got := TagMatches(tt.tag, tt.filter); got != tt.expected {
+			t.Errorf("TagMatches(%q, %q) = %v, expected %v", tt.tag, tt.filter, got, tt.expected)
+		}
+	}
+}
+
+func TestTags_Rename(t *testing.T) {
+	tags := Tags{"db", "db/sqlite", "dbx", "api"}
+
+	if !tags.Rename("db", "infra/db") {
+		t.Fatal("Expected tags to change")
+	}
+	if !reflect.DeepEqual(tags, Tags{"infra/db", "infra/db/sqlite", "dbx", "api"}) {
+		t.Errorf("Unexpected tags after rename: %v", tags)
+	}
+
+	// Renaming onto an existing tag merges them
+	tags = Tags{"authn", "auth"}
+	tags.Rename("authn", "auth")
+	if !reflect.DeepEqual(tags, Tags{"auth"}) {
+		t.Errorf("Expected merged tags, got %v", tags)
+	}
+
+	if tags.Rename("missing", "other") {
+		t.Error("Expected no change for an unused tag")
+	}
+}
+
+func TestCountTags(t *testing.T) {
+	first := NewMemory("proj", MemoryTypeDecision, "First", "content", "")
+	first.Tags = Tags{"infra/db/sqlite", "infra/db", "api"}
+	second := NewMemory("proj", MemoryTypeDecision, "Second", "content", "")
+	second.Tags = Tags{"infra/cache"}
+
+	counts := CountTags([]*Memory{first, second}, "")
+	expected := []TagCount{
+		{Tag: "api", Count: 1, Total: 1},
+		{Tag: "infra", Count: 0, Total: 2},
+		{Tag: "infra/cache", Count: 1, Total: 1},
+		{Tag: "infra/db", Count: 1, Total: 1},
+		{Tag: "infra/db/sqlite", Count: 1, Total: 1},
+	}
+	if !reflect.DeepEqual(counts, expected) {
+		t.Errorf("Unexpected counts:\n got %+v\nwant %+v", counts, expected)
+	}
+
+	counts = CountTags([]*Memory{first, second}, "infra/db")
+	if len(counts) != 2 || counts[0].Tag != "infra/db" {
+		t.Errorf("Expected only tags below infra/db, got %+v", counts)
+	}
+}
+
+func TestMemory_TagReview(t *testing.T) {
+	memory := NewMemory("proj", MemoryTypeDecision, "Title", "Content", "")
+	memory.Tags = Tags{"auth"}
+
+	memory.ApplyAutoTags([]string{"auth", "jwt", "go"})
+	memory.SuggestTags([]string{"jwt", "middleware", "tokens"})
+
+	if !reflect.DeepEqual(memory.Tags, Tags{"auth", "jwt", "go"}) || !reflect.DeepEqual(memory.AutoTags, Tags{"jwt", "go"}) {
+		t.Fatalf("Expected auto tags next to the manual tag, got %v / %v", memory.Tags, memory.AutoTags)
+	}
+	if !reflect.DeepEqual(memory.SuggestedTags, Tags{"middleware", "tokens"}) {
+		t.Fatalf("Expected suggestions without existing tags, got %v", memory.SuggestedTags)
+	}
+	if memory.TagOrigin("auth") != TagOriginManual || memory.TagOrigin("jwt") != TagOriginAuto {
+		t.Error("Unexpected tag origins")
+	}
+
+	if accepted := memory.AcceptTags("jwt", "middleware", "auth"); !reflect.DeepEqual(accepted, Tags{"jwt", "middleware"}) {
+		t.Errorf("Expected only pending tags to be accepted, got %v", accepted)
+	}
+	if memory.TagOrigin("jwt") != TagOriginManual || !memory.Tags.Contains("middleware") {
+		t.Errorf("Expected accepted tags to become manual tags, got %v / %v", memory.Tags, memory.AutoTags)
+	}
+
+	rejected := memory.RejectTags()
+	if !reflect.DeepEqual(rejected, Tags{"go", "tokens"}) {
+		t.Errorf("Expected all remaining pending tags to be rejected, got %v", rejected)
+	}
+	if !reflect.DeepEqual(memory.Tags, Tags{"auth", "jwt", "middleware"}) || len(memory.PendingTags()) != 0 {
+		t.Errorf("Unexpected tags after review: %v, pending %v", memory.Tags, memory.PendingTags())
+	}
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 // MemoryID is a unique identifier for a memory entry
 type MemoryID string
 
@@ -9,6 +11,9 @@ type ProjectID string
 // SessionID is a unique identifier for a development session
 type SessionID string
 
+// WorkspaceID is a unique identifier for a workspace
+type WorkspaceID string
+
 // SessionStatus represents the status of a development session
 type SessionStatus string
 
@@ -39,11 +44,41 @@ const (
 	PriorityUrgent Priority = "urgent"
 )
 
+// Progress entry types
+const (
+	ProgressTypeInfo      = "info"
+	ProgressTypeMilestone = "milestone"
+	ProgressTypeIssue     = "issue"
+	ProgressTypeSolution  = "solution"
+)
+
+// IsValidProgressType reports whether entryType is a known progress entry type
+func IsValidProgressType(entryType string) bool {
+	switch entryType {
+	case ProgressTypeInfo, ProgressTypeMilestone, ProgressTypeIssue, ProgressTypeSolution:
+		return true
+	}
+	return false
+}
+
 // ProgressEntry represents a single progress entry in a session
 type ProgressEntry struct {
 	Timestamp string `json:"timestamp"`
 	Message   string `json:"message"`
 	Type      string `json:"type,omitempty"` // info, milestone, issue, solution
+	// Files, Ref and Commit link the entry to source files, a task or memory ID and
+	// a git commit
+	Files  []string `json:"files,omitempty"`
+	Ref    string   `json:"ref,omitempty"`
+	Commit string   `json:"commit,omitempty"`
+	// Redactions records which kinds of secrets were masked in the message
+	Redactions []Redaction `json:"redactions,omitempty"`
+}
+
+// Time returns the time the entry was logged, or the zero time if it can't be parsed
+func (e ProgressEntry) Time() time.Time {
+	t, _ := time.Parse(time.RFC3339, e.Timestamp)
+	return t
 }
 
 // EmbeddingVector represents a vector embedding
@@ -62,6 +97,29 @@ const (
 	MemoryTypeTask          MemoryType = "task"
 )
 
+// BuiltinMemoryTypes returns all memory types known to the system
+func BuiltinMemoryTypes() []MemoryType {
+	return []MemoryType{
+		MemoryTypeDecision,
+		MemoryTypePattern,
+		MemoryTypeErrorSolution,
+		MemoryTypeCode,
+		MemoryTypeDocumentation,
+		MemoryTypeSession,
+		MemoryTypeTask,
+	}
+}
+
+// IsBuiltin checks if the memory type is one of the built-in types
+func (t MemoryType) IsBuiltin() bool {
+	for _, builtin := range BuiltinMemoryTypes() {
+		if t == builtin {
+			return true
+		}
+	}
+	return false
+}
+
 // Tags represents a collection of tags for categorization
 type Tags []string
 
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Workspace groups related projects, e.g. the services of a monorepo, so they can
+// be searched and reported on together
+type Workspace struct {
+	ID          WorkspaceID `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// NewWorkspace creates a new workspace
+func NewWorkspace(name, description string) *Workspace {
+	now := time.Now()
+	return &Workspace{
+		ID:          WorkspaceID(generateID()),
+		Name:        strings.TrimSpace(name),
+		Description: description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// Validate checks that the workspace has a usable name
+func (w *Workspace) Validate() error {
+	if w.Name == "" {
+		return fmt.Errorf("workspace name is required")
+	}
+	if strings.ContainsAny(w.Name, " \t\n") {
+		return fmt.Errorf("workspace name %q must not contain whitespace", w.Name)
+	}
+	return nil
+}
+
+// InWorkspace reports whether the project belongs to the workspace
+func (p *Project) InWorkspace(id WorkspaceID) bool {
+	return p.WorkspaceID != nil && *p.WorkspaceID == id
+}
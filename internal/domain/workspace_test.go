@@ -0,0 +1,32 @@
+package domain
+
+import "testing"
+
+func TestNewWorkspace(t *testing.T) {
+	workspace := NewWorkspace(" platform ", "Services of the platform monorepo")
+	if workspace.ID == "" || workspace.Name != "platform" || workspace.CreatedAt.IsZero() {
+		t.Errorf("Unexpected workspace %+v", workspace)
+	}
+	if err := workspace.Validate(); err != nil {
+		t.Errorf("Expected a valid workspace, got %v", err)
+	}
+
+	for _, name := range []string{"", "my platform"} {
+		if err := NewWorkspace(name, "").Validate(); err == nil {
+			t.Errorf("Expected workspace name %q to be rejected", name)
+		}
+	}
+}
+
+func TestProject_InWorkspace(t *testing.T) {
+	project := NewProject("api", "/repo/api", "")
+	if project.InWorkspace("ws") {
+		t.Error("Expected a project without workspace not to be in any workspace")
+	}
+
+	id := WorkspaceID("ws")
+	project.WorkspaceID = &id
+	if !project.InWorkspace("ws") || project.InWorkspace("other") {
+		t.Error("Expected the project to be in its workspace only")
+	}
+}
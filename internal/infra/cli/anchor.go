@@ -0,0 +1,219 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/spf13/cobra"
+)
+
+var memoryForCmd = &cobra.Command{
+	Use:   "for [path]",
+	Short: "Show memories anchored to a file",
+	Long: `Show the memories anchored to a file or to a directory containing it.
+Anchors whose code was renamed, deleted or heavily modified since the memory
+was anchored are flagged as possibly stale. Renames are only detected once
+they are staged or committed.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectFlag, _ := cmd.Flags().GetString("project")
+		noCheck, _ := cmd.Flags().GetBool("no-check")
+
+		path, err := filepath.Abs(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to resolve path: %w", err)
+		}
+
+		// Get services
+		services, err := GetServicesForCLI(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+
+		ctx := context.Background()
+
+		projectID, err := resolveAnchorProject(ctx, services, projectFlag)
+		if err != nil {
+			return err
+		}
+
+		memories, err := services.AnchorService.MemoriesForFile(ctx, projectID, path, !noCheck)
+		if err != nil {
+			return fmt.Errorf("failed to get memories for file: %w", err)
+		}
+
+		if len(memories) == 0 {
+			fmt.Printf("No memories anchored to %s\n", args[0])
+			return nil
+		}
+
+		ids := make([]domain.MemoryID, len(memories))
+		for i, memory := range memories {
+			ids[i] = memory.Memory.ID
+		}
+		if err := services.MemoryService.RecordAccess(ctx, ids); err != nil {
+			services.Logger.WithError(err).Warn("Failed to record memory access")
+		}
+
+		fmt.Printf("Found %d memories anchored to %s:\n\n", len(memories), args[0])
+		printAnchoredMemories(memories)
+		return nil
+	},
+}
+
+var memoryAnchorCmd = &cobra.Command{
+	Use:   "anchor [memory-id] [anchor...]",
+	Short: "Anchor a memory to source files",
+	Long: `Anchor a memory to source locations given as path[:start[-end]][#symbol],
+relative to the project root. Anchors are stamped with the current git commit.
+With --remove, all anchors pointing at the given paths are removed instead.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		remove, _ := cmd.Flags().GetBool("remove")
+
+		// Get services
+		services, err := GetServicesForCLI(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+
+		ctx := context.Background()
+		id := domain.MemoryID(args[0])
+
+		var memory *domain.Memory
+		if remove {
+			memory, err = services.AnchorService.RemoveAnchors(ctx, id, args[1:])
+			if err != nil {
+				return fmt.Errorf("failed to remove anchors: %w", err)
+			}
+		} else {
+			anchors := make([]domain.CodeAnchor, 0, len(args)-1)
+			for _, value := range args[1:] {
+				anchor, err := domain.ParseCodeAnchor(value)
+				if err != nil {
+					return err
+				}
+				anchors = append(anchors, anchor)
+			}
+			memory, err = services.AnchorService.AddAnchors(ctx, id, anchors)
+			if err != nil {
+				return fmt.Errorf("failed to add anchors: %w", err)
+			}
+		}
+
+		fmt.Printf("✓ Anchors updated: %s (ID: %s)\n", memory.Title, memory.ID)
+		for _, anchor := range memory.Anchors {
+			fmt.Printf("  %s\n", anchor.String())
+		}
+		return nil
+	},
+}
+
+var memoryStaleCmd = &cobra.Command{
+	Use:   "stale",
+	Short: "List memories whose anchored code changed",
+	Long: `Check every anchored memory of a project against the git working tree and
+list the memories whose anchored code was renamed, deleted or heavily modified.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectFlag, _ := cmd.Flags().GetString("project")
+
+		// Get services
+		services, err := GetServicesForCLI(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+
+		ctx := context.Background()
+
+		projectID, err := resolveAnchorProject(ctx, services, projectFlag)
+		if err != nil {
+			return err
+		}
+
+		memories, err := services.AnchorService.CheckAnchors(ctx, projectID)
+		if err != nil {
+			return fmt.Errorf("failed to check anchors: %w", err)
+		}
+
+		var stale []ports.AnchoredMemory
+		for _, memory := range memories {
+			if memory.PossiblyStale {
+				stale = append(stale, memory)
+			}
+		}
+
+		if len(stale) == 0 {
+			fmt.Printf("✓ All %d anchored memories are current\n", len(memories))
+			return nil
+		}
+
+		fmt.Printf("%d of %d anchored memories are possibly stale:\n\n", len(stale), len(memories))
+		printAnchoredMemories(stale)
+		return nil
+	},
+}
+
+// resolveAnchorProject returns the given project or the project of the current directory
+func resolveAnchorProject(ctx context.Context, services *ServiceContainer, projectID string) (domain.ProjectID, error) {
+	if projectID != "" {
+		return domain.ProjectID(projectID), nil
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	project, err := services.ProjectService.GetProjectByPath(ctx, cwd)
+	if err != nil {
+		return "", fmt.Errorf("no project found for %s, use --project: %w", cwd, err)
+	}
+	return project.ID, nil
+}
+
+// printAnchoredMemories prints memories with their anchors and check results
+func printAnchoredMemories(memories []ports.AnchoredMemory) {
+	for i, memory := range memories {
+		flag := ""
+		if memory.PossiblyStale {
+			flag = " ⚠ possibly stale"
+		}
+		fmt.Printf("%d. %s (ID: %s)%s\n", i+1, memory.Memory.Title, memory.Memory.ID, flag)
+		fmt.Printf("   Type: %s\n", memory.Memory.Type)
+
+		if len(memory.Checks) == 0 {
+			for _, anchor := range memory.Memory.Anchors {
+				fmt.Printf("   Anchor: %s\n", anchor.String())
+			}
+		}
+		for _, check := range memory.Checks {
+			line := fmt.Sprintf("   Anchor: %s [%s]", check.Anchor.String(), check.Status)
+			if check.Detail != "" {
+				line += " - " + check.Detail
+			}
+			fmt.Println(line)
+		}
+
+		fmt.Printf("   Content: %s\n\n", truncateString(memory.Memory.Content, 100))
+	}
+}
+
+func init() {
+	memoryCmd.AddCommand(memoryForCmd)
+	memoryCmd.AddCommand(memoryAnchorCmd)
+	memoryCmd.AddCommand(memoryStaleCmd)
+
+	// Flags for for command
+	memoryForCmd.Flags().StringP("project", "p", "", "project ID (defaults to the project of the current directory)")
+	memoryForCmd.Flags().Bool("no-check", false, "skip the staleness check against git")
+
+	// Flags for anchor command
+	memoryAnchorCmd.Flags().Bool("remove", false, "remove the anchors for the given paths")
+
+	// Flags for stale command
+	memoryStaleCmd.Flags().StringP("project", "p", "", "project ID (defaults to the project of the current directory)")
+}
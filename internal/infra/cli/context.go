@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/spf13/cobra"
+)
+
+var contextCmd = &cobra.Command{
+	Use:   "context [task-description]",
+	Short: "Print the relevant context for a task",
+	Long: `Print a deduplicated, token-budgeted context pack for a task: relevant decisions,
+patterns and error solutions, matching open tasks and the outcome of the last completed
+session. The output is markdown meant to be pasted into a prompt.
+
+Without --project, the project containing the current directory is used.`,
+	Example: `  memory-bank context "Add refresh tokens" --file internal/auth/jwt.go
+  memory-bank context "Fix flaky upload test" --budget 1000 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectID, _ := cmd.Flags().GetString("project")
+		files, _ := cmd.Flags().GetStringSlice("file")
+		budget, _ := cmd.Flags().GetInt("budget")
+		limit, _ := cmd.Flags().GetInt("limit")
+		threshold, _ := cmd.Flags().GetFloat32("threshold")
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		// Get services
+		services, err := GetServicesForCLI(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+
+		ctx := context.Background()
+
+		req := ports.ContextPackRequest{
+			Task:        args[0],
+			Files:       files,
+			TokenBudget: budget,
+			Limit:       limit,
+			Threshold:   threshold,
+		}
+		if projectID != "" {
+			pid := domain.ProjectID(projectID)
+			req.ProjectID = &pid
+		} else if cwd, err := os.Getwd(); err == nil {
+			if project, err := services.ProjectService.GetProjectByPath(ctx, cwd); err == nil {
+				req.ProjectID = &project.ID
+			}
+		}
+
+		pack, err := services.ContextService.BuildContextPack(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to build context pack: %w", err)
+		}
+
+		if err := services.MemoryService.RecordAccess(ctx, pack.MemoryIDs()); err != nil {
+			services.Logger.WithError(err).Warn("Failed to record memory access")
+		}
+
+		if asJSON {
+			output, err := json.MarshalIndent(pack, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal context pack: %w", err)
+			}
+			fmt.Println(string(output))
+			return nil
+		}
+
+		fmt.Print(pack.Text)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(contextCmd)
+
+	contextCmd.Flags().StringP("project", "p", "", "project ID (default: project of the current directory)")
+	contextCmd.Flags().StringSliceP("file", "f", nil, "file path the task touches (repeatable)")
+	contextCmd.Flags().Int("budget", ports.DefaultContextTokenBudget, "approximate size limit in tokens")
+	contextCmd.Flags().IntP("limit", "l", ports.DefaultContextLimit, "maximum entries per section")
+	contextCmd.Flags().Float32("threshold", ports.DefaultContextThreshold, "minimum similarity of included memories")
+	contextCmd.Flags().Bool("json", false, "print the structured context pack as JSON")
+}
@@ -210,7 +210,13 @@ func showMemoryStats(ctx context.Context, services *ServiceContainer, projectID
 		return err
 	}
 
-	if len(memories) == 0 {
+	// Drafts are not listed, so they are counted separately
+	drafts, err := services.MemoryService.ListReviewQueue(ctx, &projectID)
+	if err != nil {
+		return err
+	}
+
+	if len(memories) == 0 && len(drafts) == 0 {
 		fmt.Println("No memories found.")
 		fmt.Println()
 		return nil
@@ -242,6 +248,9 @@ func showMemoryStats(ctx context.Context, services *ServiceContainer, projectID
 		}
 		fmt.Printf("%s %s: %d\n", emoji, memType, count)
 	}
+	if len(drafts) > 0 {
+		fmt.Printf("⏳ Awaiting review: %d (review with: memory-bank memory review)\n", len(drafts))
+	}
 	fmt.Println()
 
 	return nil
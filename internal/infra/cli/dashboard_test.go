@@ -164,7 +164,7 @@ func createTestData(t *testing.T, services *ServiceContainer, project *domain.Pr
 
 		// Add progress entries
 		for _, progress := range sessionData.progress {
-			err = services.SessionService.LogProgress(ctx, session.ID, progress)
+			_, err = services.SessionService.LogProgress(ctx, ports.LogProgressRequest{SessionID: session.ID, Message: progress})
 			if err != nil {
 				t.Fatalf("Failed to log session progress: %v", err)
 			}
@@ -174,10 +174,9 @@ func createTestData(t *testing.T, services *ServiceContainer, project *domain.Pr
 		if sessionData.status != domain.SessionStatusActive {
 			switch sessionData.status {
 			case domain.SessionStatusCompleted:
-				err = services.SessionService.CompleteSession(ctx, session.ID, "Successfully completed")
+				_, err = services.SessionService.CompleteSession(ctx, ports.CompleteSessionRequest{SessionID: session.ID, Outcome: "Successfully completed"})
 			case domain.SessionStatusPaused:
-				// For paused, we'd need to implement pause functionality
-				// For now, we'll leave it as active in tests
+				err = services.SessionService.PauseSession(ctx, session.ID)
 			}
 			if err != nil {
 				t.Fatalf("Failed to update session status: %v", err)
@@ -331,6 +330,32 @@ func TestShowMemoryStats(t *testing.T) {
 	}
 }
 
+func TestShowMemoryStats_ReviewQueue(t *testing.T) {
+	services, project := setupTestDashboard(t)
+	memoryService := services.MemoryService
+	memoryService.SetReviewPolicy(domain.ReviewPolicy{Sources: []domain.ProvenanceSource{domain.ProvenanceSourceMCP}})
+
+	ctx := domain.WithProvenance(context.Background(), domain.Provenance{Source: domain.ProvenanceSourceMCP})
+	if _, err := memoryService.CreateMemory(ctx, ports.CreateMemoryRequest{
+		ProjectID: project.ID,
+		Type:      domain.MemoryTypeDecision,
+		Title:     "Agent decision",
+		Content:   "Proposed by an agent",
+	}); err != nil {
+		t.Fatalf("Failed to create memory: %v", err)
+	}
+
+	output := captureOutput(func() {
+		if err := showMemoryStats(ctx, services, project.ID); err != nil {
+			t.Errorf("Failed to show memory stats: %v", err)
+		}
+	})
+
+	if !strings.Contains(output, "Awaiting review: 1") {
+		t.Errorf("Expected the draft count in memory stats output, got: %s", output)
+	}
+}
+
 func TestExtractStatusFromContext(t *testing.T) {
 	testCases := []struct {
 		context  string
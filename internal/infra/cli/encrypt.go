@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/joern1811/memory-bank/internal/infra/config"
+	"github.com/joern1811/memory-bank/internal/infra/database"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var encryptCmd = &cobra.Command{
+	Use:   "encrypt",
+	Short: "Show the encryption status or encrypt an existing database",
+	Long: `Show how many titles, contents, contexts and session descriptions are stored as
+plaintext and under which keys the others are encrypted.
+
+With --migrate all values are encrypted with the current key of the encryption
+configuration. Values encrypted with a previous key are re-encrypted, so after a key
+rotation run this command with the new key as "key" and the old one in "previous".`,
+	Example: `  memory-bank encrypt
+  memory-bank encrypt --migrate`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runEncryptionCommand(cmd, true)
+	},
+}
+
+var decryptCmd = &cobra.Command{
+	Use:   "decrypt",
+	Short: "Show the encryption status or decrypt an existing database",
+	Long: `Show the encryption status of the database, like "memory-bank encrypt".
+
+With --migrate all values are decrypted with the keys of the encryption configuration.
+Set "enabled: false" first so that new data is stored as plaintext as well.`,
+	Example: `  memory-bank decrypt
+  memory-bank decrypt --migrate`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runEncryptionCommand(cmd, false)
+	},
+}
+
+// runEncryptionCommand prints the encryption status or, with --migrate, encrypts or
+// decrypts all values
+func runEncryptionCommand(cmd *cobra.Command, encrypt bool) error {
+	configPath, _ := cmd.Flags().GetString("config")
+	verbose, _ := cmd.Flags().GetBool("verbose")
+	migrate, _ := cmd.Flags().GetBool("migrate")
+	ctx := context.Background()
+
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := cfg.ValidateConfig(); err != nil {
+		return fmt.Errorf("configuration validation failed: %w", err)
+	}
+
+	logger := logrus.New()
+	if !verbose {
+		logger.SetLevel(logrus.FatalLevel)
+	}
+
+	db, err := database.NewSQLiteDatabase(cfg.Database.Path, logger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			logger.WithError(err).Error("Failed to close database")
+		}
+	}()
+
+	if !migrate {
+		return printEncryptionStatus(ctx, cfg, db)
+	}
+
+	if !cfg.Encryption.Key.Configured() {
+		return fmt.Errorf("no encryption key is configured")
+	}
+	keyring, err := keyringFromConfig(ctx, cfg.Encryption, db)
+	if err != nil {
+		return err
+	}
+
+	if encrypt {
+		if !cfg.Encryption.Enabled {
+			return fmt.Errorf("encryption is not enabled; set encryption.enabled to true first so that new data is encrypted as well")
+		}
+		changed, err := database.RewriteEncryptedColumns(ctx, db, keyring.Reencrypt)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt database: %w", err)
+		}
+		fmt.Printf("✓ Encrypted %d rows with key %s\n", changed, keyring.PrimaryKeyID())
+		return nil
+	}
+
+	if cfg.Encryption.Enabled {
+		return fmt.Errorf("encryption is still enabled; set encryption.enabled to false first so that new data is stored as plaintext")
+	}
+	changed, err := database.RewriteEncryptedColumns(ctx, db, keyring.Decrypt)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt database: %w", err)
+	}
+	fmt.Printf("✓ Decrypted %d rows\n", changed)
+	return nil
+}
+
+// printEncryptionStatus prints the number of plaintext and encrypted values per table
+func printEncryptionStatus(ctx context.Context, cfg *config.Config, db *sql.DB) error {
+	statuses, err := database.GetEncryptionStatus(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to get encryption status: %w", err)
+	}
+
+	current := ""
+	if cfg.Encryption.Enabled {
+		keyring, err := keyringFromConfig(ctx, cfg.Encryption, db)
+		if err != nil {
+			return err
+		}
+		current = keyring.PrimaryKeyID()
+		fmt.Printf("Encryption: enabled (key %s)\n\n", current)
+	} else {
+		fmt.Printf("Encryption: disabled\n\n")
+	}
+
+	pending := 0
+	for _, status := range statuses {
+		keyIDs := make([]string, 0, len(status.ByKey))
+		encrypted := 0
+		for id, count := range status.ByKey {
+			keyIDs = append(keyIDs, id)
+			encrypted += count
+			if id != current {
+				pending += count
+			}
+		}
+		sort.Strings(keyIDs)
+
+		fmt.Printf("%-9s %d values, %d plaintext, %d encrypted", status.Table+":", status.Values, status.Plaintext, encrypted)
+		if len(keyIDs) > 0 {
+			byKey := make([]string, len(keyIDs))
+			for i, id := range keyIDs {
+				byKey[i] = fmt.Sprintf("%s: %d", id, status.ByKey[id])
+			}
+			fmt.Printf(" (%s)", strings.Join(byKey, ", "))
+		}
+		fmt.Println()
+
+		if cfg.Encryption.Enabled {
+			pending += status.Plaintext
+		}
+	}
+
+	if pending > 0 {
+		if cfg.Encryption.Enabled {
+			fmt.Printf("\nRun 'memory-bank encrypt --migrate' to encrypt %d values with the current key\n", pending)
+		} else {
+			fmt.Printf("\nRun 'memory-bank decrypt --migrate' to decrypt %d values\n", pending)
+		}
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(encryptCmd)
+	rootCmd.AddCommand(decryptCmd)
+
+	encryptCmd.Flags().Bool("migrate", false, "encrypt all values with the current key")
+	decryptCmd.Flags().Bool("migrate", false, "decrypt all values")
+}
@@ -67,6 +67,9 @@ var gitScanCommitsCmd = &cobra.Command{
 				continue
 			}
 
+			// Attribute the resulting writes to the commit author
+			commitCtx := domain.WithProvenance(ctx, domain.Provenance{Author: commit.Author, Source: domain.ProvenanceSourceGit})
+
 			// Process each task action
 			for _, action := range actions {
 				// Try to find task by ID (assuming task IDs are Memory IDs)
@@ -107,7 +110,7 @@ var gitScanCommitsCmd = &cobra.Command{
 						}
 
 						// Update task
-						_, err := services.TaskService.UpdateTask(ctx, updateReq)
+						_, err := services.TaskService.UpdateTask(commitCtx, updateReq)
 						if err != nil {
 							fmt.Printf("Warning: Failed to update task %s: %v\n", action.TaskID, err)
 						} else {
@@ -123,7 +126,7 @@ var gitScanCommitsCmd = &cobra.Command{
 						}
 
 						// Also create a progress memory for historical tracking
-						createProgressMemory(services, project.ID, action, commit)
+						createProgressMemory(commitCtx, services, project.ID, action, commit)
 						continue
 					}
 				}
@@ -135,7 +138,7 @@ var gitScanCommitsCmd = &cobra.Command{
 				}
 
 				// Fallback: create progress memory entry (original behavior)
-				if createProgressMemory(services, project.ID, action, commit) {
+				if createProgressMemory(commitCtx, services, project.ID, action, commit) {
 					progressCount++
 					fmt.Printf("✅ Recorded progress for task %s from commit %s\n", action.TaskID, commit.Hash[:8])
 				}
@@ -441,9 +444,7 @@ func extractTaskIDsFromCommit(message string) []string {
 }
 
 // createProgressMemory creates a memory entry for git progress tracking
-func createProgressMemory(services *ServiceContainer, projectID domain.ProjectID, action TaskAction, commit GitCommit) bool {
-	ctx := context.Background()
-
+func createProgressMemory(ctx context.Context, services *ServiceContainer, projectID domain.ProjectID, action TaskAction, commit GitCommit) bool {
 	progressMemory := fmt.Sprintf("Git commit %s: %s", commit.Hash[:8], commit.Message)
 
 	actionDesc := action.Action
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/joern1811/memory-bank/internal/domain"
 	"github.com/joern1811/memory-bank/internal/ports"
 	"github.com/spf13/cobra"
 )
@@ -32,6 +33,7 @@ If no path is provided, the current directory will be used.`,
 		description, _ := cmd.Flags().GetString("description")
 		language, _ := cmd.Flags().GetString("language")
 		framework, _ := cmd.Flags().GetString("framework")
+		workspaceRef, _ := cmd.Flags().GetString("workspace")
 
 		if name == "" {
 			name = filepath.Base(projectPath)
@@ -58,6 +60,15 @@ If no path is provided, the current directory will be used.`,
 			return fmt.Errorf("failed to initialize project: %w", err)
 		}
 
+		// Attach the project to its workspace, creating the workspace if needed
+		var workspace *domain.Workspace
+		if workspaceRef != "" {
+			workspace, err = services.WorkspaceService.AttachProject(ctx, workspaceRef, project.ID)
+			if err != nil {
+				return fmt.Errorf("failed to add project to workspace: %w", err)
+			}
+		}
+
 		// Display results
 		fmt.Printf("✓ Project initialized successfully\n")
 		fmt.Printf("  ID: %s\n", project.ID)
@@ -72,6 +83,9 @@ If no path is provided, the current directory will be used.`,
 		if project.Framework != "" {
 			fmt.Printf("  Framework: %s\n", project.Framework)
 		}
+		if workspace != nil {
+			fmt.Printf("  Workspace: %s\n", workspace.Name)
+		}
 		fmt.Printf("  Created: %s\n", project.CreatedAt.Format("2006-01-02 15:04:05"))
 
 		return nil
@@ -84,4 +98,5 @@ func init() {
 	initCmd.Flags().StringP("description", "d", "", "project description")
 	initCmd.Flags().StringP("language", "l", "", "programming language (auto-detected if not specified)")
 	initCmd.Flags().StringP("framework", "f", "", "framework used (auto-detected if not specified)")
+	initCmd.Flags().StringP("workspace", "w", "", "attach the project to this workspace (name or ID), creating it if needed")
 }
@@ -8,8 +8,11 @@ import (
 	"syscall"
 
 	"github.com/joern1811/memory-bank/internal/app"
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/infra/config"
 	"github.com/joern1811/memory-bank/internal/infra/database"
 	"github.com/joern1811/memory-bank/internal/infra/embedding"
+	"github.com/joern1811/memory-bank/internal/infra/git"
 	"github.com/joern1811/memory-bank/internal/infra/mcp"
 	"github.com/joern1811/memory-bank/internal/infra/vector"
 	"github.com/joern1811/memory-bank/internal/ports"
@@ -73,6 +76,12 @@ func runServer() error {
 }
 
 func runMCPServer(ctx context.Context, logger *logrus.Logger) error {
+	// A config that cannot be read must not silently turn off encryption or review
+	cfg, err := config.LoadConfig("")
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
 	// Initialize database
 	dbPath := getEnvOrDefault("MEMORY_BANK_DB_PATH", "./memory_bank.db")
 	db, err := database.NewSQLiteDatabase(dbPath, logger)
@@ -124,24 +133,89 @@ func runMCPServer(ctx context.Context, logger *logrus.Logger) error {
 	chromaDBStore := vector.NewChromaDBVectorStore(vectorConfig, logger)
 
 	// Test vector store connection
+	chromaAvailable := true
 	if err := chromaDBStore.HealthCheck(ctx); err != nil {
 		logger.WithError(err).Warn("ChromaDB health check failed, using mock vector store")
 		vectorStore = vector.NewMockVectorStore(logger)
+		chromaAvailable = false
 	} else {
 		vectorStore = chromaDBStore
 	}
 
 	// Initialize services
 	memoryService := app.NewMemoryService(memoryRepo, embeddingProvider, vectorStore, logger)
+	memoryService.SetRelevanceOptions(relevanceOptionsFromConfig(cfg.Search))
+	memoryService.SetTagNormalizer(tagNormalizerFromConfig(cfg.Tags))
+	memoryTypes, err := memoryTypeRegistryFromConfig(cfg.MemoryTypes)
+	if err != nil {
+		return err
+	}
+	memoryService.SetMemoryTypes(memoryTypes)
+	if cfg.Encryption.Enabled {
+		keyring, err := keyringFromConfig(ctx, cfg.Encryption, db)
+		if err != nil {
+			return err
+		}
+		memoryRepo.SetCipher(keyring)
+		sessionRepo.SetCipher(keyring)
+		memoryService.SetEncrypted(true)
+	}
+	reviewPolicy, err := reviewPolicyFromConfig(cfg.Review)
+	if err != nil {
+		return err
+	}
+	scopes, err := scopesFromConfig(cfg.Scopes)
+	if err != nil {
+		return err
+	}
+	redactor, err := redactorFromConfig(cfg.Redaction)
+	if err != nil {
+		return err
+	}
+	autoTagger := app.NewAutoTagger(memoryRepo, memoryService.TagNormalizer(), autoTagOptionsFromConfig(cfg.Tags.Auto), logger)
+	memoryService.SetAutoTagger(autoTagger)
+	tagService := app.NewTagService(memoryService, memoryRepo, logger)
+	tagService.SetAutoTagger(autoTagger)
 	projectService := app.NewProjectService(projectRepo, logger)
 	sessionService := app.NewSessionService(sessionRepo, projectRepo, logger)
+	sourceControl := git.NewSourceControl(git.DefaultConfig(), logger)
+	if cfg.Redaction.Enabled {
+		memoryService.SetRedactor(redactor)
+		sessionService.SetRedactor(redactor)
+	}
+	provenance := provenanceFromConfig(cfg.Provenance, domain.ProvenanceSourceMCP)
+	memoryService.SetProvenance(provenance)
+	memoryService.SetSessionRepository(sessionRepo)
+	memoryService.SetProjectRepository(projectRepo)
+	memoryService.SetReviewPolicy(reviewPolicy)
+	memoryService.SetScopes(scopes)
+	sessionService.SetProvenance(provenance)
+	sessionService.SetMemoryService(memoryService)
+	sessionService.SetSourceControl(sourceControl)
+	sessionService.SetSummaryOptions(sessionSummaryOptionsFromConfig(cfg.Sessions))
+	sessionService.SetStalePolicy(stalePolicyFromConfig(cfg.Sessions))
+	if cfg.Sessions.SearchIndex {
+		sessionService.SetSearchIndex(embeddingProvider, sessionVectorStore(vectorConfig, chromaAvailable, logger))
+	}
 	taskService := app.NewTaskService(memoryService, logger)
 
 	// Initialize MCP server
-	mcpServer := server.NewMCPServer("memory-bank", serverVersion)
+	mcpServer := server.NewMCPServer("memory-bank", serverVersion,
+		server.WithToolHandlerMiddleware(mcp.ClientProvenanceMiddleware),
+	)
 	memoryBankServer := mcp.NewMemoryBankServer(memoryService, projectService, sessionService, taskService, logger)
+	memoryBankServer.SetContextService(app.NewContextService(memoryService, taskService, sessionService, logger))
+	memoryBankServer.SetAnchorService(app.NewAnchorService(memoryService, memoryRepo, projectRepo, sourceControl, logger))
+	memoryBankServer.SetTagService(tagService)
+	memoryBankServer.SetWorkspaceService(app.NewWorkspaceService(database.NewSQLiteWorkspaceRepository(db, logger), projectRepo, logger))
+	if cfg.MCP.SystemPromptTokenBudget > 0 {
+		memoryBankServer.SetSystemPromptTokenBudget(cfg.MCP.SystemPromptTokenBudget)
+	}
 	memoryBankServer.RegisterMethods(mcpServer)
 
+	// Pause or abort sessions left open by earlier conversations
+	sessionService.Housekeep(ctx, nil)
+
 	logger.Info("Memory Bank MCP Server started successfully")
 
 	// Start serving MCP protocol over stdio in a goroutine
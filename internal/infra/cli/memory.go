@@ -3,7 +3,9 @@ package cli
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/joern1811/memory-bank/internal/domain"
 	"github.com/joern1811/memory-bank/internal/ports"
@@ -28,18 +30,52 @@ var memoryCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new memory entry",
 	Long: `Create a new memory entry with specified type, title, and content.
-Supported types: decision, pattern, error-solution, code, documentation`,
+Supported types: decision, pattern, error-solution, code, documentation,
+plus user-defined types from the memory_types configuration (see 'memory types').
+Fields of user-defined types are given as --field name=value.
+Without --applies-to, the project dependencies mentioned in the memory are
+recorded with their current version range.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		memoryType, _ := cmd.Flags().GetString("type")
 		title, _ := cmd.Flags().GetString("title")
 		content, _ := cmd.Flags().GetString("content")
 		tagsStr, _ := cmd.Flags().GetString("tags")
-		projectID, _ := cmd.Flags().GetString("project")
+		anchorValues, _ := cmd.Flags().GetStringArray("anchor")
+		fieldValues, _ := cmd.Flags().GetStringArray("field")
+		appliesToValues, _ := cmd.Flags().GetStringArray("applies-to")
 
 		if memoryType == "" || title == "" || content == "" {
 			return fmt.Errorf("type, title, and content are required")
 		}
 
+		projectID, err := projectOrScope(cmd)
+		if err != nil {
+			return err
+		}
+
+		fieldFlags, err := parseFieldFlags(fieldValues)
+		if err != nil {
+			return err
+		}
+
+		anchors := make([]domain.CodeAnchor, 0, len(anchorValues))
+		for _, value := range anchorValues {
+			anchor, err := domain.ParseCodeAnchor(value)
+			if err != nil {
+				return err
+			}
+			anchors = append(anchors, anchor)
+		}
+
+		appliesTo := make([]domain.DependencyConstraint, 0, len(appliesToValues))
+		for _, value := range appliesToValues {
+			constraint, err := domain.ParseDependencyConstraint(value)
+			if err != nil {
+				return err
+			}
+			appliesTo = append(appliesTo, constraint)
+		}
+
 		var tags []string
 		if tagsStr != "" {
 			tags = strings.Split(tagsStr, ",")
@@ -69,6 +105,12 @@ Supported types: decision, pattern, error-solution, code, documentation`,
 		if projectID != "" {
 			fmt.Printf("  Project: %s\n", projectID)
 		}
+		if len(anchorValues) > 0 {
+			fmt.Printf("  Anchors: %s\n", strings.Join(anchorValues, ", "))
+		}
+		if len(fieldValues) > 0 {
+			fmt.Printf("  Fields: %s\n", strings.Join(fieldValues, ", "))
+		}
 
 		// Create memory request
 		req := ports.CreateMemoryRequest{
@@ -76,6 +118,15 @@ Supported types: decision, pattern, error-solution, code, documentation`,
 			Title:   title,
 			Content: content,
 			Tags:    tags,
+
+			AppliesTo: appliesTo,
+		}
+		if len(fieldFlags) > 0 {
+			// Values are converted to the field types by the memory service
+			req.Fields = make(map[string]interface{}, len(fieldFlags))
+			for name, value := range fieldFlags {
+				req.Fields[name] = value
+			}
 		}
 
 		// Set project ID if provided
@@ -93,7 +144,25 @@ Supported types: decision, pattern, error-solution, code, documentation`,
 			return fmt.Errorf("failed to create memory: %w", err)
 		}
 
+		if len(anchors) > 0 {
+			if _, err := services.AnchorService.AddAnchors(ctx, memory.ID, anchors); err != nil {
+				return fmt.Errorf("failed to anchor memory: %w", err)
+			}
+		}
+
 		fmt.Printf("✓ Memory entry created successfully (ID: %s)\n", memory.ID)
+		if len(memory.AutoTags) > 0 {
+			fmt.Printf("  Auto tags: %s\n", strings.Join(memory.AutoTags, ", "))
+		}
+		if len(memory.SuggestedTags) > 0 {
+			fmt.Printf("  Suggested tags: %s (review with 'memory-bank tag review')\n", strings.Join(memory.SuggestedTags, ", "))
+		}
+		if len(memory.AppliesTo) > 0 {
+			fmt.Printf("  Applies to: %s\n", formatConstraints(memory.AppliesTo))
+		}
+		if memory.IsDraft() {
+			fmt.Println("  Status: draft (review with 'memory-bank memory review')")
+		}
 		return nil
 	},
 }
@@ -108,6 +177,7 @@ var memorySearchCmd = &cobra.Command{
 		projectID, _ := cmd.Flags().GetString("project")
 		limit, _ := cmd.Flags().GetInt("limit")
 		threshold, _ := cmd.Flags().GetFloat32("threshold")
+		includeDrafts, _ := cmd.Flags().GetBool("include-drafts")
 
 		// Get services
 		services, err := GetServicesForCLI(cmd)
@@ -120,20 +190,25 @@ var memorySearchCmd = &cobra.Command{
 		fmt.Printf("Searching for: %s\n", query)
 		if projectID != "" {
 			fmt.Printf("Project filter: %s\n", projectID)
+		} else if workspace, _ := cmd.Flags().GetString("workspace"); workspace != "" {
+			fmt.Printf("Workspace filter: %s\n", workspace)
 		}
 		fmt.Printf("Limit: %d, Threshold: %.2f\n", limit, threshold)
 
 		// Create search request
 		searchReq := ports.SemanticSearchRequest{
-			Query:     query,
-			Limit:     limit,
-			Threshold: threshold,
+			Query:         query,
+			Limit:         limit,
+			Threshold:     threshold,
+			IncludeDrafts: includeDrafts,
 		}
 
 		// Set project filter if provided
 		if projectID != "" {
 			pid := domain.ProjectID(projectID)
 			searchReq.ProjectID = &pid
+		} else if searchReq.ProjectIDs, err = workspaceProjectIDs(ctx, cmd, services); err != nil {
+			return err
 		}
 
 		// Search memories
@@ -142,17 +217,20 @@ var memorySearchCmd = &cobra.Command{
 			return fmt.Errorf("failed to search memories: %w", err)
 		}
 
+		recordSearchAccess(ctx, services, results)
+
 		fmt.Printf("\nSearch Results (%d found):\n", len(results))
 		if len(results) == 0 {
 			fmt.Println("No memories found matching your query.")
 		} else {
 			for i, result := range results {
-				fmt.Printf("\n%d. %s (Score: %.3f)\n", i+1, result.Memory.Title, result.Similarity)
+				fmt.Printf("\n%d. %s%s (Score: %.3f)\n", i+1, result.Memory.Title, draftLabel(result.Memory), result.Similarity)
 				fmt.Printf("   Type: %s, Project: %s\n", result.Memory.Type, result.Memory.ProjectID)
 				fmt.Printf("   Content: %s\n", truncateString(result.Memory.Content, 100))
 				if len(result.Memory.Tags) > 0 {
 					fmt.Printf("   Tags: %s\n", strings.Join(result.Memory.Tags, ", "))
 				}
+				printApplicability(result.Applicability)
 			}
 		}
 
@@ -163,11 +241,28 @@ var memorySearchCmd = &cobra.Command{
 var memoryListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List memory entries",
-	Long:  `List all memory entries, optionally filtered by project or type.`,
+	Long:  `List all memory entries, optionally filtered by project or shared scope, type, field values or provenance.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		projectID, _ := cmd.Flags().GetString("project")
+		projectID, err := projectOrScope(cmd)
+		if err != nil {
+			return err
+		}
 		memoryType, _ := cmd.Flags().GetString("type")
 		limit, _ := cmd.Flags().GetInt("limit")
+		fieldValues, _ := cmd.Flags().GetStringArray("field")
+		source, _ := cmd.Flags().GetString("source")
+		createdBy, _ := cmd.Flags().GetString("created-by")
+		client, _ := cmd.Flags().GetString("client")
+		includeDrafts, _ := cmd.Flags().GetBool("include-drafts")
+
+		fieldFilters, err := parseFieldFlags(fieldValues)
+		if err != nil {
+			return err
+		}
+		provenanceSource := domain.ProvenanceSource(strings.ToLower(source))
+		if provenanceSource != "" && !provenanceSource.IsValid() {
+			return fmt.Errorf("invalid source %q, use cli, mcp, git or import", source)
+		}
 
 		// Get services
 		services, err := GetServicesForCLI(cmd)
@@ -180,6 +275,8 @@ var memoryListCmd = &cobra.Command{
 		fmt.Printf("Listing memory entries")
 		if projectID != "" {
 			fmt.Printf(" for project: %s", projectID)
+		} else if workspace, _ := cmd.Flags().GetString("workspace"); workspace != "" {
+			fmt.Printf(" for workspace: %s", workspace)
 		}
 		if memoryType != "" {
 			fmt.Printf(" of type: %s", memoryType)
@@ -188,13 +285,17 @@ var memoryListCmd = &cobra.Command{
 
 		// Create list request
 		listReq := ports.ListMemoriesRequest{
-			Limit: limit,
+			Limit:         limit,
+			Fields:        fieldFilters,
+			IncludeDrafts: includeDrafts,
 		}
 
 		// Set project filter if provided
 		if projectID != "" {
 			pid := domain.ProjectID(projectID)
 			listReq.ProjectID = &pid
+		} else if listReq.ProjectIDs, err = workspaceProjectIDs(ctx, cmd, services); err != nil {
+			return err
 		}
 
 		// Set type filter if provided
@@ -203,6 +304,11 @@ var memoryListCmd = &cobra.Command{
 			listReq.Type = &mtype
 		}
 
+		// Set provenance filter if provided
+		if provenanceSource != "" || createdBy != "" || client != "" {
+			listReq.Provenance = &domain.ProvenanceFilter{Author: createdBy, Source: provenanceSource, Client: client}
+		}
+
 		// List memories
 		memories, err := services.MemoryService.ListMemories(ctx, listReq)
 		if err != nil {
@@ -218,21 +324,262 @@ var memoryListCmd = &cobra.Command{
 			}
 		} else {
 			for i, memory := range memories {
-				fmt.Printf("\n%d. %s\n", i+1, memory.Title)
+				fmt.Printf("\n%d. %s%s\n", i+1, memory.Title, draftLabel(memory))
 				fmt.Printf("   ID: %s\n", memory.ID)
 				fmt.Printf("   Type: %s, Project: %s\n", memory.Type, memory.ProjectID)
 				fmt.Printf("   Content: %s\n", truncateString(memory.Content, 100))
 				if len(memory.Tags) > 0 {
 					fmt.Printf("   Tags: %s\n", strings.Join(memory.Tags, ", "))
 				}
+				if len(memory.Fields) > 0 {
+					fmt.Printf("   Fields: %s\n", strings.ReplaceAll(memory.FieldsText(), "\n", "; "))
+				}
 				fmt.Printf("   Created: %s\n", memory.CreatedAt.Format("2006-01-02 15:04:05"))
+				if memory.Provenance != nil {
+					fmt.Printf("   Created by: %s\n", memory.Provenance)
+				}
+			}
+		}
+
+		return nil
+	},
+}
+
+var memoryStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show memory usage statistics",
+	Long: `Show how often memories are retrieved by search and memory_get.
+With --unused, list memories that have not been retrieved for the given number of days
+as candidates for cleanup. Memories that were never retrieved count from their creation date.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectID, _ := cmd.Flags().GetString("project")
+		unused, _ := cmd.Flags().GetBool("unused")
+		days, _ := cmd.Flags().GetInt("days")
+
+		if days <= 0 {
+			return fmt.Errorf("--days must be positive")
+		}
+
+		// Get services
+		services, err := GetServicesForCLI(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+
+		ctx := context.Background()
+
+		var pid *domain.ProjectID
+		if projectID != "" {
+			p := domain.ProjectID(projectID)
+			pid = &p
+		}
+
+		if unused {
+			memories, err := services.MemoryService.ListUnusedMemories(ctx, pid, time.Duration(days)*24*time.Hour)
+			if err != nil {
+				return fmt.Errorf("failed to list unused memories: %w", err)
 			}
+
+			fmt.Printf("Memories not retrieved in the last %d days (%d found):\n", days, len(memories))
+			for i, memory := range memories {
+				fmt.Printf("\n%d. %s\n", i+1, memory.Title)
+				fmt.Printf("   ID: %s\n", memory.ID)
+				fmt.Printf("   Type: %s, Project: %s\n", memory.Type, memory.ProjectID)
+				if memory.LastAccessedAt != nil {
+					fmt.Printf("   Last accessed: %s (%d accesses)\n", memory.LastAccessedAt.Format("2006-01-02 15:04:05"), memory.AccessCount)
+				} else {
+					fmt.Printf("   Never accessed, created: %s\n", memory.CreatedAt.Format("2006-01-02 15:04:05"))
+				}
+			}
+			return nil
+		}
+
+		if pid == nil {
+			return fmt.Errorf("--project is required unless --unused is given")
+		}
+
+		memories, err := services.MemoryService.ListMemories(ctx, ports.ListMemoriesRequest{ProjectID: pid})
+		if err != nil {
+			return fmt.Errorf("failed to list memories: %w", err)
 		}
 
+		printUsageStats(memories)
 		return nil
 	},
 }
 
+var memoryPinCmd = &cobra.Command{
+	Use:   "pin [memory-id]",
+	Short: "Pin a memory entry",
+	Long: `Pin a memory entry so it is always included in the MCP system prompt,
+ahead of any other context.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setMemoryPinned(cmd, domain.MemoryID(args[0]), true)
+	},
+}
+
+var memoryUnpinCmd = &cobra.Command{
+	Use:   "unpin [memory-id]",
+	Short: "Unpin a memory entry",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setMemoryPinned(cmd, domain.MemoryID(args[0]), false)
+	},
+}
+
+var memoryTypesCmd = &cobra.Command{
+	Use:   "types",
+	Short: "List memory types and their fields",
+	Long: `List the built-in memory types and the user-defined types from the
+memory_types configuration, including the fields each user-defined type carries.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectID, _ := cmd.Flags().GetString("project")
+
+		// Get services
+		services, err := GetServicesForCLI(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+
+		var pid *domain.ProjectID
+		if projectID != "" {
+			id := domain.ProjectID(projectID)
+			pid = &id
+		}
+
+		for _, def := range services.MemoryService.ListMemoryTypes(context.Background(), pid) {
+			label := string(def.Name)
+			if def.Builtin {
+				label += " (built-in)"
+			} else if def.ProjectID != nil {
+				label += fmt.Sprintf(" (project %s)", *def.ProjectID)
+			}
+			fmt.Printf("%s\n", label)
+			if def.Description != "" {
+				fmt.Printf("   %s\n", def.Description)
+			}
+			for _, field := range def.Fields {
+				line := fmt.Sprintf("   - %s: %s", field.Name, field.Type)
+				if field.Type == domain.FieldTypeEnum {
+					line += fmt.Sprintf(" [%s]", strings.Join(field.Values, "|"))
+				}
+				if field.Required {
+					line += " (required)"
+				}
+				if field.Description != "" {
+					line += " - " + field.Description
+				}
+				fmt.Println(line)
+			}
+		}
+		return nil
+	},
+}
+
+// parseFieldFlags parses repeated --field name=value flags
+func parseFieldFlags(values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	fields := make(map[string]string, len(values))
+	for _, value := range values {
+		name, fieldValue, ok := strings.Cut(value, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid field %q: expected name=value", value)
+		}
+		fields[name] = strings.TrimSpace(fieldValue)
+	}
+	return fields, nil
+}
+
+// setMemoryPinned updates the pin state of a memory and reports the result
+func setMemoryPinned(cmd *cobra.Command, id domain.MemoryID, pinned bool) error {
+	// Get services
+	services, err := GetServicesForCLI(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize services: %w", err)
+	}
+
+	memory, err := services.MemoryService.SetPinned(context.Background(), id, pinned)
+	if err != nil {
+		return fmt.Errorf("failed to update pin state: %w", err)
+	}
+
+	if pinned {
+		fmt.Printf("✓ Memory pinned: %s (ID: %s)\n", memory.Title, memory.ID)
+	} else {
+		fmt.Printf("✓ Memory unpinned: %s (ID: %s)\n", memory.Title, memory.ID)
+	}
+	return nil
+}
+
+// printUsageStats prints access statistics for a set of memories
+// formatConstraints renders dependency constraints as a comma-separated list of name@range
+func formatConstraints(constraints []domain.DependencyConstraint) string {
+	values := make([]string, len(constraints))
+	for i, constraint := range constraints {
+		values[i] = constraint.String()
+	}
+	return strings.Join(values, ", ")
+}
+
+// printApplicability warns about search results whose dependency constraints the
+// project no longer satisfies
+func printApplicability(applicability *domain.Applicability) {
+	if applicability == nil {
+		return
+	}
+	for _, conflict := range applicability.Conflicts {
+		fmt.Printf("   ⚠ Outdated: %s\n", conflict)
+	}
+}
+
+func printUsageStats(memories []*domain.Memory) {
+	totalAccesses := 0
+	neverAccessed := 0
+	for _, memory := range memories {
+		totalAccesses += memory.AccessCount
+		if memory.AccessCount == 0 {
+			neverAccessed++
+		}
+	}
+
+	fmt.Printf("Total memories: %d\n", len(memories))
+	fmt.Printf("Total accesses: %d\n", totalAccesses)
+	fmt.Printf("Never accessed: %d\n", neverAccessed)
+
+	// Most retrieved memories first
+	sorted := make([]*domain.Memory, len(memories))
+	copy(sorted, memories)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].AccessCount > sorted[j].AccessCount
+	})
+
+	if len(sorted) > 0 && sorted[0].AccessCount > 0 {
+		fmt.Printf("\nMost accessed:\n")
+		for i, memory := range sorted {
+			if i >= 5 || memory.AccessCount == 0 {
+				break
+			}
+			fmt.Printf("  %d. %s (%d)\n", i+1, memory.Title, memory.AccessCount)
+		}
+	}
+}
+
+// recordSearchAccess updates usage tracking for search results shown to the user
+func recordSearchAccess(ctx context.Context, services *ServiceContainer, results []ports.MemorySearchResult) {
+	ids := make([]domain.MemoryID, len(results))
+	for i, result := range results {
+		ids[i] = result.Memory.ID
+	}
+	if err := services.MemoryService.RecordAccess(ctx, ids); err != nil {
+		services.Logger.WithError(err).Warn("Failed to record memory access")
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(memoryCmd)
 
@@ -240,6 +587,10 @@ func init() {
 	memoryCmd.AddCommand(memoryCreateCmd)
 	memoryCmd.AddCommand(memorySearchCmd)
 	memoryCmd.AddCommand(memoryListCmd)
+	memoryCmd.AddCommand(memoryStatsCmd)
+	memoryCmd.AddCommand(memoryPinCmd)
+	memoryCmd.AddCommand(memoryUnpinCmd)
+	memoryCmd.AddCommand(memoryTypesCmd)
 
 	// Flags for create command
 	memoryCreateCmd.Flags().StringP("type", "t", "", "memory type (decision, pattern, error-solution, code, documentation)")
@@ -247,14 +598,35 @@ func init() {
 	memoryCreateCmd.Flags().StringP("content", "", "", "memory content")
 	memoryCreateCmd.Flags().StringP("tags", "", "", "comma-separated tags")
 	memoryCreateCmd.Flags().StringP("project", "p", "", "project ID")
+	memoryCreateCmd.Flags().String("scope", "", "create the memory in a shared scope instead of a project (global, team:<name>)")
+	memoryCreateCmd.Flags().StringArray("anchor", nil, "code anchor path[:start[-end]][#symbol] relative to the project root (repeatable)")
+	memoryCreateCmd.Flags().StringArray("field", nil, "field of a user-defined memory type as name=value (repeatable)")
+	memoryCreateCmd.Flags().StringArray("applies-to", nil, "dependency the memory is valid for as name or name@range, e.g. github.com/gin-gonic/gin@^1.9 (repeatable)")
 
 	// Flags for search command
 	memorySearchCmd.Flags().StringP("project", "p", "", "filter by project ID")
 	memorySearchCmd.Flags().IntP("limit", "l", 10, "maximum number of results")
 	memorySearchCmd.Flags().Float32P("threshold", "", 0.5, "similarity threshold")
+	memorySearchCmd.Flags().Bool("include-drafts", false, "include draft memories waiting for review")
+	memorySearchCmd.Flags().StringP("workspace", "w", "", "search the projects of this workspace (name or ID) if no project is given")
 
 	// Flags for list command
 	memoryListCmd.Flags().StringP("project", "p", "", "filter by project ID")
+	memoryListCmd.Flags().String("scope", "", "list the memories of a shared scope (global, team:<name>)")
 	memoryListCmd.Flags().StringP("type", "t", "", "filter by memory type")
 	memoryListCmd.Flags().IntP("limit", "l", 50, "maximum number of results")
+	memoryListCmd.Flags().StringArray("field", nil, "filter by field value as name=value (repeatable)")
+	memoryListCmd.Flags().String("source", "", "filter by write channel (cli, mcp, git, import)")
+	memoryListCmd.Flags().String("created-by", "", "filter by author")
+	memoryListCmd.Flags().String("client", "", "filter by MCP client name or name/version")
+	memoryListCmd.Flags().Bool("include-drafts", false, "include draft memories waiting for review")
+	memoryListCmd.Flags().StringP("workspace", "w", "", "list the projects of this workspace (name or ID) if no project is given")
+
+	// Flags for stats command
+	memoryStatsCmd.Flags().StringP("project", "p", "", "project ID")
+	memoryStatsCmd.Flags().Bool("unused", false, "list memories not retrieved recently")
+	memoryStatsCmd.Flags().Int("days", 30, "number of days without retrieval for --unused")
+
+	// Flags for types command
+	memoryTypesCmd.Flags().StringP("project", "p", "", "only show types available in this project")
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/tabwriter"
 	"time"
 
@@ -129,6 +130,7 @@ var projectGetCmd = &cobra.Command{
 		fmt.Printf("Description: %s\n", project.Description)
 		fmt.Printf("Created:     %s\n", project.CreatedAt.Format(time.RFC3339))
 		fmt.Printf("Updated:     %s\n", project.UpdatedAt.Format(time.RFC3339))
+		printProjectDetection(project)
 
 		// Get absolute path info
 		absPath, err := filepath.Abs(project.Path)
@@ -231,10 +233,11 @@ var projectUpdateCmd = &cobra.Command{
 		name, _ := cmd.Flags().GetString("name")
 		description, _ := cmd.Flags().GetString("description")
 		path, _ := cmd.Flags().GetString("path")
+		redetect, _ := cmd.Flags().GetBool("redetect")
 
 		// Check if any update flags were provided
-		if name == "" && description == "" && path == "" {
-			return fmt.Errorf("at least one field must be specified to update (--name, --description, or --path)")
+		if name == "" && description == "" && path == "" && !redetect {
+			return fmt.Errorf("at least one field must be specified to update (--name, --description, --path or --redetect)")
 		}
 
 		// Update fields if provided
@@ -267,6 +270,14 @@ var projectUpdateCmd = &cobra.Command{
 			return fmt.Errorf("failed to update project: %w", err)
 		}
 
+		// Detect languages, framework and dependencies again
+		if redetect {
+			project, err = services.ProjectService.RedetectProject(ctx, project.ID)
+			if err != nil {
+				return fmt.Errorf("failed to re-detect project: %w", err)
+			}
+		}
+
 		fmt.Printf("✅ Project '%s' updated successfully.\n", project.Name)
 
 		// Show updated information
@@ -275,22 +286,106 @@ var projectUpdateCmd = &cobra.Command{
 		fmt.Printf("Name:        %s\n", project.Name)
 		fmt.Printf("Path:        %s\n", project.Path)
 		fmt.Printf("Description: %s\n", project.Description)
+		if redetect {
+			printProjectDetection(project)
+		}
+
+		return nil
+	},
+}
+
+var projectRelocateCmd = &cobra.Command{
+	Use:   "relocate [project-id-or-path] [new-path]",
+	Short: "Update the path of a moved project",
+	Long: `Update the root path of a project after its repository was moved and write the
+project marker (.memory-bank/project.yaml) there. Without a new path, the current
+directory is used.
+
+Projects are usually found again without this: commands run in a subdirectory
+resolve the nearest project root above it, and a moved checkout containing the
+marker is re-associated with its project automatically.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+
+		newPath, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+		if len(args) > 1 {
+			newPath = args[1]
+		}
+
+		// Initialize services
+		services, err := GetServicesForCLI(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+
+		// Get project by ID, or by its old path
+		project, err := services.ProjectService.GetProject(ctx, domain.ProjectID(args[0]))
+		if err != nil {
+			project, err = services.ProjectService.GetProjectByPath(ctx, args[0])
+			if err != nil {
+				return fmt.Errorf("project not found: %s", args[0])
+			}
+		}
+		oldPath := project.Path
 
+		project, err = services.ProjectService.RelocateProject(ctx, project.ID, newPath)
+		if err != nil {
+			return fmt.Errorf("failed to relocate project: %w", err)
+		}
+
+		fmt.Printf("✅ Project '%s' relocated.\n", project.Name)
+		fmt.Printf("Old path: %s\n", oldPath)
+		fmt.Printf("New path: %s\n", project.Path)
 		return nil
 	},
 }
 
+// printProjectDetection prints the detected languages, framework and dependencies
+func printProjectDetection(project *domain.Project) {
+	fmt.Printf("Language:    %s\n", project.Language)
+	if project.Framework != "" {
+		fmt.Printf("Framework:   %s\n", project.Framework)
+	}
+	if len(project.Languages) > 0 {
+		shares := make([]string, len(project.Languages))
+		for i, share := range project.Languages {
+			shares[i] = fmt.Sprintf("%s %.1f%%", share.Language, share.Proportion*100)
+		}
+		fmt.Printf("Languages:   %s\n", strings.Join(shares, ", "))
+	}
+	if len(project.Dependencies) > 0 {
+		fmt.Printf("Dependencies (%d):\n", len(project.Dependencies))
+		for _, dependency := range project.Dependencies {
+			line := "  " + dependency.Name
+			if dependency.Version != "" {
+				line += " " + dependency.Version
+			}
+			line += " (" + dependency.Ecosystem
+			if dependency.Dev {
+				line += ", dev"
+			}
+			fmt.Println(line + ")")
+		}
+	}
+}
+
 func init() {
 	// Add flags to update command
 	projectUpdateCmd.Flags().String("name", "", "New project name")
 	projectUpdateCmd.Flags().String("description", "", "New project description")
 	projectUpdateCmd.Flags().String("path", "", "New project path")
+	projectUpdateCmd.Flags().Bool("redetect", false, "Detect languages, framework and dependencies again")
 
 	// Add subcommands to project command
 	projectCmd.AddCommand(projectListCmd)
 	projectCmd.AddCommand(projectGetCmd)
 	projectCmd.AddCommand(projectDeleteCmd)
 	projectCmd.AddCommand(projectUpdateCmd)
+	projectCmd.AddCommand(projectRelocateCmd)
 
 	// Add project command to root
 	rootCmd.AddCommand(projectCmd)
@@ -0,0 +1,202 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/spf13/cobra"
+)
+
+var memoryReviewCmd = &cobra.Command{
+	Use:   "review",
+	Short: "List draft memories waiting for review",
+	Long: `List the draft memories in the review queue, oldest first. With the review
+policy enabled, memories written through the configured sources (mcp by default)
+are created as drafts and are only returned by search and list on request.
+Use 'memory review approve', 'edit', 'reject' and 'merge' to review them.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectID, _ := cmd.Flags().GetString("project")
+
+		// Get services
+		services, err := GetServicesForCLI(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+
+		memories, err := services.MemoryService.ListReviewQueue(context.Background(), optionalProjectID(projectID))
+		if err != nil {
+			return fmt.Errorf("failed to list draft memories: %w", err)
+		}
+
+		if len(memories) == 0 {
+			fmt.Println("No memories to review")
+			return nil
+		}
+
+		fmt.Printf("Review queue (%d drafts):\n", len(memories))
+		for i, memory := range memories {
+			fmt.Printf("\n%d. %s\n", i+1, memory.Title)
+			fmt.Printf("   ID: %s\n", memory.ID)
+			fmt.Printf("   Type: %s, Project: %s\n", memory.Type, memory.ProjectID)
+			fmt.Printf("   Content: %s\n", truncateString(memory.Content, 200))
+			if len(memory.Tags) > 0 {
+				fmt.Printf("   Tags: %s\n", strings.Join(memory.Tags, ", "))
+			}
+			fmt.Printf("   Created: %s\n", memory.CreatedAt.Format("2006-01-02 15:04:05"))
+			if memory.Provenance != nil {
+				fmt.Printf("   Created by: %s\n", memory.Provenance)
+			}
+		}
+		return nil
+	},
+}
+
+var memoryReviewApproveCmd = &cobra.Command{
+	Use:   "approve [memory-id...]",
+	Short: "Approve draft memories",
+	Long:  `Take draft memories out of the review queue so they are used in search, context and the system prompt.`,
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get services
+		services, err := GetServicesForCLI(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+
+		ctx := context.Background()
+		for _, id := range args {
+			memory, err := services.MemoryService.ApproveMemory(ctx, domain.MemoryID(id))
+			if err != nil {
+				return fmt.Errorf("failed to approve memory %s: %w", id, err)
+			}
+			fmt.Printf("✓ Approved: %s (ID: %s)\n", memory.Title, memory.ID)
+		}
+		return nil
+	},
+}
+
+var memoryReviewEditCmd = &cobra.Command{
+	Use:   "edit [memory-id]",
+	Short: "Edit and approve a draft memory",
+	Long:  `Change the title, content, context or tags of a draft memory and approve it.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get services
+		services, err := GetServicesForCLI(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+
+		ctx := context.Background()
+		memory, err := services.MemoryService.GetMemory(ctx, domain.MemoryID(args[0]))
+		if err != nil {
+			return fmt.Errorf("failed to get memory: %w", err)
+		}
+		if !memory.IsDraft() {
+			return fmt.Errorf("memory %s is not a draft", memory.ID)
+		}
+
+		if cmd.Flags().Changed("title") {
+			memory.Title, _ = cmd.Flags().GetString("title")
+		}
+		if cmd.Flags().Changed("content") {
+			memory.Content, _ = cmd.Flags().GetString("content")
+		}
+		if cmd.Flags().Changed("context") {
+			memory.Context, _ = cmd.Flags().GetString("context")
+		}
+		if cmd.Flags().Changed("tags") {
+			tagsStr, _ := cmd.Flags().GetString("tags")
+			memory.Tags = make(domain.Tags, 0)
+			for _, tag := range strings.Split(tagsStr, ",") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					memory.Tags.Add(tag)
+				}
+			}
+		}
+		if strings.TrimSpace(memory.Title) == "" {
+			return fmt.Errorf("title cannot be empty")
+		}
+
+		if err := services.MemoryService.UpdateMemory(ctx, memory); err != nil {
+			return fmt.Errorf("failed to update memory: %w", err)
+		}
+		if memory, err = services.MemoryService.ApproveMemory(ctx, memory.ID); err != nil {
+			return fmt.Errorf("failed to approve memory: %w", err)
+		}
+
+		fmt.Printf("✓ Edited and approved: %s (ID: %s)\n", memory.Title, memory.ID)
+		return nil
+	},
+}
+
+var memoryReviewRejectCmd = &cobra.Command{
+	Use:   "reject [memory-id...]",
+	Short: "Reject and delete draft memories",
+	Long:  `Delete draft memories from the review queue. Approved memories cannot be rejected.`,
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get services
+		services, err := GetServicesForCLI(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+
+		ctx := context.Background()
+		for _, id := range args {
+			if err := services.MemoryService.RejectMemory(ctx, domain.MemoryID(id)); err != nil {
+				return fmt.Errorf("failed to reject memory %s: %w", id, err)
+			}
+			fmt.Printf("✓ Rejected: %s\n", id)
+		}
+		return nil
+	},
+}
+
+var memoryReviewMergeCmd = &cobra.Command{
+	Use:   "merge [draft-id] [target-id]",
+	Short: "Merge a draft into an existing memory",
+	Long: `Append the content and context of a draft memory to an existing memory of the
+same project, add the draft's tags and anchors, and delete the draft.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get services
+		services, err := GetServicesForCLI(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+
+		memory, err := services.MemoryService.MergeMemory(context.Background(), domain.MemoryID(args[0]), domain.MemoryID(args[1]))
+		if err != nil {
+			return fmt.Errorf("failed to merge memory: %w", err)
+		}
+
+		fmt.Printf("✓ Merged %s into: %s (ID: %s)\n", args[0], memory.Title, memory.ID)
+		return nil
+	},
+}
+
+// draftLabel marks draft memories in listings
+func draftLabel(memory *domain.Memory) string {
+	if memory.IsDraft() {
+		return " [draft]"
+	}
+	return ""
+}
+
+func init() {
+	memoryCmd.AddCommand(memoryReviewCmd)
+	memoryReviewCmd.AddCommand(memoryReviewApproveCmd)
+	memoryReviewCmd.AddCommand(memoryReviewEditCmd)
+	memoryReviewCmd.AddCommand(memoryReviewRejectCmd)
+	memoryReviewCmd.AddCommand(memoryReviewMergeCmd)
+
+	memoryReviewCmd.Flags().StringP("project", "p", "", "only show drafts of this project")
+
+	memoryReviewEditCmd.Flags().String("title", "", "new title")
+	memoryReviewEditCmd.Flags().String("content", "", "new content")
+	memoryReviewEditCmd.Flags().String("context", "", "new context")
+	memoryReviewEditCmd.Flags().String("tags", "", "comma-separated tags replacing the current tags")
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/spf13/cobra"
+)
+
+var scanSecretsCmd = &cobra.Command{
+	Use:   "scan-secrets",
+	Short: "Find and mask secrets in stored memories and sessions",
+	Long: `Scan stored memories and sessions for API keys, tokens, private keys, passwords
+and e-mail addresses using the rules of the redaction configuration. Only the kind
+and number of secrets are reported, never their values.
+
+With --fix the secrets are masked and the embeddings of affected memories are
+regenerated. Scanning works even if redaction on write is disabled.`,
+	Example: `  memory-bank scan-secrets
+  memory-bank scan-secrets --project my-project --fix`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectID, _ := cmd.Flags().GetString("project")
+		fix, _ := cmd.Flags().GetBool("fix")
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		// Get services
+		services, err := GetServicesForCLI(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+
+		req := ports.ScanSecretsRequest{Fix: fix}
+		if projectID != "" {
+			pid := domain.ProjectID(projectID)
+			req.ProjectID = &pid
+		}
+
+		result, err := services.SecretScanService.ScanSecrets(context.Background(), req)
+		if err != nil {
+			return fmt.Errorf("failed to scan for secrets: %w", err)
+		}
+
+		if asJSON {
+			output, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal scan result: %w", err)
+			}
+			fmt.Println(string(output))
+			return nil
+		}
+
+		fmt.Printf("Scanned %d memories and %d sessions\n", result.MemoriesScanned, result.SessionsScanned)
+		if len(result.Findings) == 0 {
+			fmt.Println("✓ No secrets found")
+			return nil
+		}
+
+		fmt.Printf("\nSecrets found in %d entries:\n", len(result.Findings))
+		for i, finding := range result.Findings {
+			fmt.Printf("\n%d. %s %s (%s)\n", i+1, finding.Kind, finding.ID, finding.ProjectID)
+			fmt.Printf("   Title: %s\n", truncateString(finding.Title, 80))
+			for _, redaction := range finding.Redactions {
+				fmt.Printf("   - %s: %d × %s\n", redaction.Field, redaction.Count, redaction.Rule)
+			}
+		}
+
+		if fix {
+			fmt.Printf("\n✓ Masked secrets in %d entries\n", result.Fixed)
+		} else {
+			fmt.Printf("\nRun with --fix to mask them (%s)\n", strings.Join(entryKinds(result.Findings), " and "))
+		}
+		return nil
+	},
+}
+
+// entryKinds describes the number of memories and sessions among the findings
+func entryKinds(findings []ports.SecretFinding) []string {
+	counts := make(map[string]int)
+	for _, finding := range findings {
+		counts[finding.Kind]++
+	}
+
+	var kinds []string
+	if n := counts["memory"]; n > 0 {
+		kinds = append(kinds, fmt.Sprintf("%d memories", n))
+	}
+	if n := counts["session"]; n > 0 {
+		kinds = append(kinds, fmt.Sprintf("%d sessions", n))
+	}
+	return kinds
+}
+
+func init() {
+	rootCmd.AddCommand(scanSecretsCmd)
+
+	scanSecretsCmd.Flags().StringP("project", "p", "", "only scan this project")
+	scanSecretsCmd.Flags().Bool("fix", false, "mask the secrets found")
+	scanSecretsCmd.Flags().Bool("json", false, "print the result as JSON")
+}
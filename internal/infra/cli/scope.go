@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/spf13/cobra"
+)
+
+var memoryPromoteCmd = &cobra.Command{
+	Use:   "promote [memory-id]",
+	Short: "Move or copy a memory into a shared scope",
+	Long: `Move a project memory into a shared scope so it is found in the searches of
+every project using the scope: the global scope is searched with all projects,
+team scopes with the projects listed in the scopes configuration. Use --copy to
+keep the project memory. Code anchors and the session are not carried over.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		scopeName, _ := cmd.Flags().GetString("scope")
+		copyMemory, _ := cmd.Flags().GetBool("copy")
+
+		scope, err := domain.ParseScope(scopeName)
+		if err != nil {
+			return err
+		}
+
+		// Get services
+		services, err := GetServicesForCLI(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+
+		memory, err := services.MemoryService.PromoteMemory(context.Background(), ports.PromoteMemoryRequest{
+			ID:    domain.MemoryID(args[0]),
+			Scope: scope,
+			Copy:  copyMemory,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to promote memory: %w", err)
+		}
+
+		if copyMemory {
+			fmt.Printf("✓ Copied to scope %s: %s (ID: %s)\n", scope.ScopeName(), memory.Title, memory.ID)
+		} else {
+			fmt.Printf("✓ Moved to scope %s: %s (ID: %s)\n", scope.ScopeName(), memory.Title, memory.ID)
+		}
+		return nil
+	},
+}
+
+// projectOrScope returns the --project flag, or the project ID of the shared scope
+// given with --scope
+func projectOrScope(cmd *cobra.Command) (string, error) {
+	projectID, _ := cmd.Flags().GetString("project")
+	scopeName, _ := cmd.Flags().GetString("scope")
+	if scopeName == "" {
+		return projectID, nil
+	}
+	if projectID != "" {
+		return "", fmt.Errorf("--project and --scope cannot be combined")
+	}
+	scope, err := domain.ParseScope(scopeName)
+	if err != nil {
+		return "", err
+	}
+	return string(scope), nil
+}
+
+func init() {
+	memoryCmd.AddCommand(memoryPromoteCmd)
+
+	memoryPromoteCmd.Flags().String("scope", "global", "shared scope: global or team:<name>")
+	memoryPromoteCmd.Flags().Bool("copy", false, "keep the project memory and create a copy in the scope")
+}
@@ -14,13 +14,34 @@ var searchCmd = &cobra.Command{
 	Use:   "search [query]",
 	Short: "Search across all memory entries",
 	Long: `Search across all memory entries using semantic search.
-This is a convenience command that searches all projects and memory types.`,
+This is a convenience command that searches all projects and memory types.
+
+The query may contain field operators alongside free text:
+  type:<type>        only memories of this type (repeat to allow several)
+  tag:<tag>          require a tag
+  -tag:<tag>         exclude memories with a tag
+  project:<id>       restrict to a project
+  after:<date>       created on or after the date (YYYY-MM-DD or RFC 3339)
+  before:<date>      created before the date
+  source:<source>    written through cli, mcp, git or import
+  created_by:<name>  created by this author
+  client:<name>      written by this MCP client
+  "exact phrase"     phrase that must appear verbatim
+
+Session tasks, progress entries and outcomes are searched as well and listed after the
+memories, unless --sessions=false or the query filters by memory attributes such as
+type or tags.
+
+Example:
+  memory-bank search 'type:decision tag:auth -tag:legacy after:2025-01-01 "exact phrase" jwt rotation'`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		query := args[0]
 		limit, _ := cmd.Flags().GetInt("limit")
 		threshold, _ := cmd.Flags().GetFloat32("threshold")
 		showContent, _ := cmd.Flags().GetBool("content")
+		includeDrafts, _ := cmd.Flags().GetBool("include-drafts")
+		includeSessions, _ := cmd.Flags().GetBool("sessions")
 
 		// Get services
 		services, err := GetServicesForCLI(cmd)
@@ -39,11 +60,17 @@ This is a convenience command that searches all projects and memory types.`,
 			fmt.Println("Including content in results")
 		}
 
-		// Create search request (no project filter for global search)
+		// Create search request (no project filter unless the query names one)
 		searchReq := ports.SemanticSearchRequest{
-			Query:     query,
-			Limit:     limit,
-			Threshold: threshold,
+			Limit:         limit,
+			Threshold:     threshold,
+			IncludeDrafts: includeDrafts,
+		}
+		if err := ports.ApplyQueryString(query, &searchReq); err != nil {
+			return err
+		}
+		if searchReq.ProjectIDs, err = workspaceProjectIDs(ctx, cmd, services); err != nil {
+			return err
 		}
 
 		// Search memories
@@ -52,12 +79,14 @@ This is a convenience command that searches all projects and memory types.`,
 			return fmt.Errorf("failed to search memories: %w", err)
 		}
 
+		recordSearchAccess(ctx, services, results)
+
 		fmt.Printf("\nGlobal Search Results (%d found):\n", len(results))
 		if len(results) == 0 {
 			fmt.Println("No memories found matching your query.")
 		} else {
 			for i, result := range results {
-				fmt.Printf("\n%d. %s (Score: %.3f)\n", i+1, result.Memory.Title, result.Similarity)
+				fmt.Printf("\n%d. %s%s (Score: %.3f)\n", i+1, result.Memory.Title, draftLabel(result.Memory), result.Similarity)
 				fmt.Printf("   Type: %s, Project: %s\n", result.Memory.Type, result.Memory.ProjectID)
 
 				if showContent {
@@ -70,6 +99,17 @@ This is a convenience command that searches all projects and memory types.`,
 					fmt.Printf("   Tags: %s\n", strings.Join(result.Memory.Tags, ", "))
 				}
 				fmt.Printf("   Created: %s\n", result.Memory.CreatedAt.Format("2006-01-02 15:04:05"))
+				printApplicability(result.Applicability)
+			}
+		}
+
+		if sessionReq, ok := searchReq.SessionSearch(); ok && includeSessions {
+			// Session hits are optional; the memory results have already been printed
+			sessionResults, err := services.SessionService.SearchSessions(ctx, sessionReq)
+			if err != nil {
+				services.Logger.WithError(err).Warn("Failed to search sessions")
+			} else {
+				printSessionSearchResults(sessionResults)
 			}
 		}
 
@@ -77,13 +117,37 @@ This is a convenience command that searches all projects and memory types.`,
 	},
 }
 
+// printSessionSearchResults prints the sessions found by a search with the part that
+// matched
+func printSessionSearchResults(results []ports.SessionSearchResult) {
+	if len(results) == 0 {
+		return
+	}
+	fmt.Printf("\nSession Results (%d found):\n", len(results))
+	for i, result := range results {
+		session := result.Session
+		fmt.Printf("\n%d. %s (Score: %.3f)\n", i+1, session.Name, result.Similarity)
+		fmt.Printf("   Session: %s, Project: %s, Status: %s\n", session.ID, session.ProjectID, session.Status)
+		switch {
+		case result.Entry != nil:
+			fmt.Printf("   Progress: %s\n", formatProgressEntry(*result.Entry))
+		case result.Hit == ports.SessionHitOutcome:
+			fmt.Printf("   Outcome: %s\n", truncateString(session.Outcome, 100))
+		default:
+			fmt.Printf("   Task: %s\n", truncateString(session.TaskDescription, 100))
+		}
+		fmt.Printf("   When: %s\n", result.Timestamp.Format("2006-01-02 15:04:05"))
+	}
+}
+
 // Advanced search commands
 
 var facetedSearchCmd = &cobra.Command{
 	Use:   "faceted [query]",
 	Short: "Advanced search with facets and filters",
 	Long: `Perform advanced search with faceted results and comprehensive filtering.
-Supports filtering by types, tags, sessions, content length, and more.`,
+Supports filtering by types, tags, sessions, content length, and more.
+The query accepts the same field operators as 'memory-bank search'.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		query := args[0]
@@ -153,6 +217,11 @@ Supports filtering by types, tags, sessions, content length, and more.`,
 			searchReq.Filters = filters
 		}
 
+		// Apply field operators from the query
+		if err := ports.ApplyQueryString(query, &searchReq); err != nil {
+			return err
+		}
+
 		// Set sort options
 		if sortBy != "" {
 			sortOption := &ports.SortOption{
@@ -168,6 +237,8 @@ Supports filtering by types, tags, sessions, content length, and more.`,
 			return fmt.Errorf("failed to perform faceted search: %w", err)
 		}
 
+		recordSearchAccess(ctx, services, response.Results)
+
 		// Display results
 		fmt.Printf("\nFaceted Search Results (%d found):\n", response.Total)
 		if len(response.Results) == 0 {
@@ -182,6 +253,7 @@ Supports filtering by types, tags, sessions, content length, and more.`,
 					fmt.Printf("   Tags: %s\n", strings.Join(result.Memory.Tags, ", "))
 				}
 				fmt.Printf("   Created: %s\n", result.Memory.CreatedAt.Format("2006-01-02 15:04:05"))
+				printApplicability(result.Applicability)
 			}
 		}
 
@@ -219,7 +291,8 @@ var enhancedSearchCmd = &cobra.Command{
 	Use:   "enhanced [query]",
 	Short: "Enhanced search with relevance scoring and highlights",
 	Long: `Perform enhanced search with improved relevance scoring, match reasons, and content highlights.
-Provides detailed insights into why each result was matched.`,
+Provides detailed insights into why each result was matched.
+The query accepts the same field operators as 'memory-bank search'.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		query := args[0]
@@ -228,6 +301,13 @@ Provides detailed insights into why each result was matched.`,
 		projectFlag, _ := cmd.Flags().GetString("project")
 		typeFlag, _ := cmd.Flags().GetString("type")
 		tagsFlag, _ := cmd.Flags().GetStringSlice("tags")
+		diversify, _ := cmd.Flags().GetBool("diversify")
+		lambda, _ := cmd.Flags().GetFloat64("lambda")
+		explain, _ := cmd.Flags().GetBool("explain")
+
+		if lambda <= 0 || lambda > 1 {
+			return fmt.Errorf("--lambda must be greater than 0 and at most 1")
+		}
 
 		// Get services
 		services, err := GetServicesForCLI(cmd)
@@ -241,9 +321,12 @@ Provides detailed insights into why each result was matched.`,
 
 		// Build search request
 		searchReq := ports.SemanticSearchRequest{
-			Query:     query,
-			Limit:     limit,
-			Threshold: threshold,
+			Query:           query,
+			Limit:           limit,
+			Threshold:       threshold,
+			Diversify:       diversify,
+			DiversityLambda: lambda,
+			Explain:         explain,
 		}
 
 		// Set project filter
@@ -263,12 +346,25 @@ Provides detailed insights into why each result was matched.`,
 			searchReq.Tags = domain.Tags(tagsFlag)
 		}
 
+		// Apply field operators from the query
+		if err := ports.ApplyQueryString(query, &searchReq); err != nil {
+			return err
+		}
+
 		// Perform enhanced search
 		results, err := services.MemoryService.SearchWithRelevanceScoring(ctx, searchReq)
 		if err != nil {
 			return fmt.Errorf("failed to perform enhanced search: %w", err)
 		}
 
+		accessedIDs := make([]domain.MemoryID, len(results))
+		for i, result := range results {
+			accessedIDs[i] = result.Memory.ID
+		}
+		if err := services.MemoryService.RecordAccess(ctx, accessedIDs); err != nil {
+			services.Logger.WithError(err).Warn("Failed to record memory access")
+		}
+
 		// Display results
 		fmt.Printf("\nEnhanced Search Results (%d found):\n", len(results))
 		if len(results) == 0 {
@@ -299,7 +395,13 @@ Provides detailed insights into why each result was matched.`,
 					}
 				}
 
+				// Show score breakdown
+				if result.ScoreBreakdown != nil {
+					printScoreBreakdown(result.ScoreBreakdown, diversify)
+				}
+
 				fmt.Printf("   Created: %s\n", result.Memory.CreatedAt.Format("2006-01-02 15:04:05"))
+				printApplicability(result.Applicability)
 			}
 		}
 
@@ -307,6 +409,20 @@ Provides detailed insights into why each result was matched.`,
 	},
 }
 
+// printScoreBreakdown prints the components of an enhanced search relevance score
+func printScoreBreakdown(b *ports.ScoreBreakdown, diversified bool) {
+	fmt.Printf("   Score Breakdown:\n")
+	fmt.Printf("     vector similarity: %.3f\n", b.VectorSimilarity)
+	fmt.Printf("     title boost:       %.3f\n", b.TitleBoost)
+	fmt.Printf("     phrase boost:      %.3f\n", b.PhraseBoost)
+	fmt.Printf("     tag boost:         %.3f\n", b.TagBoost)
+	fmt.Printf("     recency boost:     %.3f\n", b.RecencyBoost)
+	fmt.Printf("     type boost:        %.3f\n", b.TypeBoost)
+	if diversified {
+		fmt.Printf("     redundancy:        %.3f (MMR score %.3f)\n", b.DiversityPenalty, b.MMRScore)
+	}
+}
+
 var suggestionsCmd = &cobra.Command{
 	Use:   "suggestions [partial-query]",
 	Short: "Get search suggestions based on existing content",
@@ -365,6 +481,9 @@ func init() {
 	searchCmd.Flags().IntP("limit", "l", 10, "maximum number of results")
 	searchCmd.Flags().Float32P("threshold", "", 0.5, "similarity threshold")
 	searchCmd.Flags().Bool("content", false, "show content in results")
+	searchCmd.Flags().Bool("include-drafts", false, "include draft memories waiting for review")
+	searchCmd.Flags().StringP("workspace", "w", "", "only search the projects of this workspace (name or ID)")
+	searchCmd.Flags().Bool("sessions", true, "also search session tasks, progress entries and outcomes")
 
 	// Add advanced search commands
 	searchCmd.AddCommand(facetedSearchCmd)
@@ -390,6 +509,9 @@ func init() {
 	enhancedSearchCmd.Flags().StringP("project", "p", "", "filter by project ID")
 	enhancedSearchCmd.Flags().StringP("type", "t", "", "filter by memory type")
 	enhancedSearchCmd.Flags().StringSlice("tags", []string{}, "filter by tags")
+	enhancedSearchCmd.Flags().Bool("diversify", false, "re-rank results with maximal marginal relevance to reduce near-duplicates")
+	enhancedSearchCmd.Flags().Float64("lambda", ports.DefaultDiversityLambda, "relevance/diversity trade-off for --diversify (1 = pure relevance)")
+	enhancedSearchCmd.Flags().Bool("explain", false, "show a per-result score breakdown")
 
 	// Suggestions flags
 	suggestionsCmd.Flags().StringP("project", "p", "", "filter by project ID")
@@ -2,14 +2,21 @@ package cli
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"os"
+	"os/user"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/joern1811/memory-bank/internal/app"
+	"github.com/joern1811/memory-bank/internal/domain"
 	"github.com/joern1811/memory-bank/internal/infra/config"
 	"github.com/joern1811/memory-bank/internal/infra/database"
 	"github.com/joern1811/memory-bank/internal/infra/embedding"
+	"github.com/joern1811/memory-bank/internal/infra/encryption"
+	"github.com/joern1811/memory-bank/internal/infra/git"
 	"github.com/joern1811/memory-bank/internal/infra/vector"
 	"github.com/joern1811/memory-bank/internal/ports"
 	"github.com/sirupsen/logrus"
@@ -18,12 +25,17 @@ import (
 
 // ServiceContainer holds all application services
 type ServiceContainer struct {
-	MemoryService  *app.MemoryService
-	ProjectService *app.ProjectService
-	SessionService *app.SessionService
-	TaskService    ports.TaskService
-	Logger         *logrus.Logger
-	Config         *config.Config
+	MemoryService     *app.MemoryService
+	ProjectService    *app.ProjectService
+	SessionService    *app.SessionService
+	TaskService       ports.TaskService
+	ContextService    ports.ContextService
+	AnchorService     *app.AnchorService
+	TagService        *app.TagService
+	SecretScanService *app.SecretScanService
+	WorkspaceService  *app.WorkspaceService
+	Logger            *logrus.Logger
+	Config            *config.Config
 }
 
 // NewServiceContainer creates a new service container with all dependencies
@@ -98,6 +110,14 @@ func NewServiceContainerWithOptions(configPath string, quiet bool) (*ServiceCont
 	memoryRepo := database.NewSQLiteMemoryRepository(db, logger)
 	sessionRepo := database.NewSQLiteSessionRepository(db, logger)
 	projectRepo := database.NewSQLiteProjectRepository(db, logger)
+	if cfg.Encryption.Enabled {
+		keyring, err := keyringFromConfig(context.Background(), cfg.Encryption, db)
+		if err != nil {
+			return nil, err
+		}
+		memoryRepo.SetCipher(keyring)
+		sessionRepo.SetCipher(keyring)
+	}
 
 	// Initialize embedding provider using config
 	ollamaConfig := embedding.OllamaConfig{
@@ -127,24 +147,74 @@ func NewServiceContainerWithOptions(configPath string, quiet bool) (*ServiceCont
 	chromaStore := vector.NewChromaDBVectorStore(chromaConfig, logger)
 
 	var vectorStore ports.VectorStore = chromaStore
+	chromaAvailable := true
 	if err := chromaStore.HealthCheck(ctx); err != nil {
 		logger.Warn("ChromaDB is not available, falling back to mock vector store")
 		vectorStore = vector.NewMockVectorStore(logger)
+		chromaAvailable = false
 	}
 
 	// Initialize services
 	memoryService := app.NewMemoryService(memoryRepo, embeddingProvider, vectorStore, logger)
+	memoryService.SetEncrypted(cfg.Encryption.Enabled)
+	memoryService.SetRelevanceOptions(relevanceOptionsFromConfig(cfg.Search))
+	memoryService.SetTagNormalizer(tagNormalizerFromConfig(cfg.Tags))
+	memoryTypes, err := memoryTypeRegistryFromConfig(cfg.MemoryTypes)
+	if err != nil {
+		return nil, err
+	}
+	memoryService.SetMemoryTypes(memoryTypes)
+	redactor, err := redactorFromConfig(cfg.Redaction)
+	if err != nil {
+		return nil, err
+	}
+	autoTagger := app.NewAutoTagger(memoryRepo, memoryService.TagNormalizer(), autoTagOptionsFromConfig(cfg.Tags.Auto), logger)
+	memoryService.SetAutoTagger(autoTagger)
+	tagService := app.NewTagService(memoryService, memoryRepo, logger)
+	tagService.SetAutoTagger(autoTagger)
 	projectService := app.NewProjectService(projectRepo, logger)
 	sessionService := app.NewSessionService(sessionRepo, projectRepo, logger)
+	sourceControl := git.NewSourceControl(git.DefaultConfig(), logger)
+	if cfg.Redaction.Enabled {
+		memoryService.SetRedactor(redactor)
+		sessionService.SetRedactor(redactor)
+	}
+	provenance := provenanceFromConfig(cfg.Provenance, domain.ProvenanceSourceCLI)
+	memoryService.SetProvenance(provenance)
+	memoryService.SetSessionRepository(sessionRepo)
+	memoryService.SetProjectRepository(projectRepo)
+	sessionService.SetProvenance(provenance)
+	sessionService.SetMemoryService(memoryService)
+	sessionService.SetSourceControl(sourceControl)
+	sessionService.SetSummaryOptions(sessionSummaryOptionsFromConfig(cfg.Sessions))
+	sessionService.SetStalePolicy(stalePolicyFromConfig(cfg.Sessions))
+	if cfg.Sessions.SearchIndex {
+		sessionService.SetSearchIndex(embeddingProvider, sessionVectorStore(chromaConfig, chromaAvailable, logger))
+	}
+	reviewPolicy, err := reviewPolicyFromConfig(cfg.Review)
+	if err != nil {
+		return nil, err
+	}
+	memoryService.SetReviewPolicy(reviewPolicy)
+	scopes, err := scopesFromConfig(cfg.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	memoryService.SetScopes(scopes)
 	taskService := app.NewTaskService(memoryService, logger)
 
 	return &ServiceContainer{
-		MemoryService:  memoryService,
-		ProjectService: projectService,
-		SessionService: sessionService,
-		TaskService:    taskService,
-		Logger:         logger,
-		Config:         cfg,
+		MemoryService:     memoryService,
+		ProjectService:    projectService,
+		SessionService:    sessionService,
+		TaskService:       taskService,
+		ContextService:    app.NewContextService(memoryService, taskService, sessionService, logger),
+		AnchorService:     app.NewAnchorService(memoryService, memoryRepo, projectRepo, sourceControl, logger),
+		TagService:        tagService,
+		SecretScanService: app.NewSecretScanService(memoryRepo, sessionRepo, memoryService, redactor, logger),
+		WorkspaceService:  app.NewWorkspaceService(database.NewSQLiteWorkspaceRepository(db, logger), projectRepo, logger),
+		Logger:            logger,
+		Config:            cfg,
 	}, nil
 }
 
@@ -175,3 +245,231 @@ func GetServicesForCLI(cmd *cobra.Command) (*ServiceContainer, error) {
 		return NewServiceContainerWithOptions(configPath, true)
 	}
 }
+
+// relevanceOptionsFromConfig converts the search configuration into relevance scoring options
+func relevanceOptionsFromConfig(search config.Search) app.RelevanceOptions {
+	return app.RelevanceOptions{
+		RecencyWindow:        time.Duration(search.RecencyWindowDays) * 24 * time.Hour,
+		RecencyMaxBoost:      search.RecencyBoost,
+		PopularityMaxBoost:   search.PopularityBoost,
+		PopularitySaturation: search.PopularitySaturation,
+		MismatchWeight:       search.MismatchWeight,
+	}
+}
+
+// tagNormalizerFromConfig converts the tags configuration into a tag normalizer
+func tagNormalizerFromConfig(tags config.Tags) domain.TagNormalizer {
+	return domain.TagNormalizer{
+		Lowercase: tags.Lowercase,
+		Aliases:   tags.Aliases,
+	}
+}
+
+// autoTagOptionsFromConfig converts the auto-tag configuration into auto-tagger options
+func autoTagOptionsFromConfig(auto config.AutoTags) app.AutoTagOptions {
+	return app.AutoTagOptions{
+		Mode:     domain.AutoTagMode(auto.Mode),
+		MaxTags:  auto.MaxTags,
+		MinScore: auto.MinScore,
+	}
+}
+
+// memoryTypeRegistryFromConfig registers the user-defined memory types of the configuration
+func memoryTypeRegistryFromConfig(memoryTypes []config.MemoryType) (*domain.MemoryTypeRegistry, error) {
+	registry := domain.NewMemoryTypeRegistry()
+	for _, memoryType := range memoryTypes {
+		definition := domain.MemoryTypeDefinition{
+			Name:        domain.MemoryType(memoryType.Name),
+			Description: memoryType.Description,
+		}
+		if memoryType.Project != "" {
+			projectID := domain.ProjectID(memoryType.Project)
+			definition.ProjectID = &projectID
+		}
+		for _, field := range memoryType.Fields {
+			definition.Fields = append(definition.Fields, domain.FieldDefinition{
+				Name:        field.Name,
+				Type:        domain.FieldType(field.Type),
+				Description: field.Description,
+				Required:    field.Required,
+				Values:      field.Values,
+			})
+		}
+		if err := registry.Register(definition); err != nil {
+			return nil, fmt.Errorf("invalid memory type configuration: %w", err)
+		}
+	}
+	return registry, nil
+}
+
+// redactorFromConfig builds a redactor from the built-in rules that are not disabled
+// and the additional rules of the configuration
+func redactorFromConfig(redaction config.Redaction) (*domain.Redactor, error) {
+	disabled := make(map[string]bool, len(redaction.DisabledRules))
+	for _, name := range redaction.DisabledRules {
+		disabled[name] = true
+	}
+
+	var rules []domain.RedactionRule
+	for _, rule := range domain.DefaultRedactionRules() {
+		if disabled[rule.Name] {
+			delete(disabled, rule.Name)
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	for name := range disabled {
+		return nil, fmt.Errorf("invalid redaction configuration: unknown built-in rule %s", name)
+	}
+
+	for _, rule := range redaction.Rules {
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction configuration: rule %s: %w", rule.Name, err)
+		}
+		if rule.Group > pattern.NumSubexp() {
+			return nil, fmt.Errorf("invalid redaction configuration: rule %s has no group %d", rule.Name, rule.Group)
+		}
+		rules = append(rules, domain.RedactionRule{
+			Name:       rule.Name,
+			Pattern:    pattern,
+			Group:      rule.Group,
+			MinEntropy: rule.MinEntropy,
+		})
+	}
+
+	allowlist := make([]*regexp.Regexp, 0, len(redaction.Allowlist))
+	for _, value := range redaction.Allowlist {
+		pattern, err := regexp.Compile(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction configuration: allowlist pattern %q: %w", value, err)
+		}
+		allowlist = append(allowlist, pattern)
+	}
+
+	return domain.NewRedactor(rules, allowlist), nil
+}
+
+// keyringFromConfig loads the current and previous encryption keys of the configuration.
+// Keys from passphrases are derived with the parameters stored in the database.
+func keyringFromConfig(ctx context.Context, enc config.Encryption, db *sql.DB) (*encryption.Keyring, error) {
+	primary := keySourceFromConfig(enc.Key)
+	previous := make([]encryption.KeySource, len(enc.Previous))
+	needsKDF := primary.NeedsKDF()
+	for i, key := range enc.Previous {
+		previous[i] = keySourceFromConfig(key)
+		needsKDF = needsKDF || previous[i].NeedsKDF()
+	}
+
+	var params *encryption.KDFParams
+	if needsKDF {
+		var err error
+		if params, err = database.LoadKDFParams(ctx, db); err != nil {
+			return nil, err
+		}
+	}
+
+	keyring, err := encryption.LoadKeyring(primary, previous, params)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption configuration: %w", err)
+	}
+	return keyring, nil
+}
+
+// keySourceFromConfig converts a configured key into a key source
+func keySourceFromConfig(key config.EncryptionKey) encryption.KeySource {
+	return encryption.KeySource{
+		File:          key.File,
+		Env:           key.Env,
+		PassphraseEnv: key.PassphraseEnv,
+	}
+}
+
+// provenanceFromConfig returns the default provenance of writes through the given
+// channel, attributed to the configured author or else the OS user
+func provenanceFromConfig(cfg config.Provenance, source domain.ProvenanceSource) domain.Provenance {
+	author := strings.TrimSpace(cfg.Author)
+	if author == "" {
+		if current, err := user.Current(); err == nil {
+			author = current.Username
+		} else {
+			author = os.Getenv("USER")
+		}
+	}
+	return domain.Provenance{Author: author, Source: source}
+}
+
+// sessionSummaryOptionsFromConfig maps the sessions configuration to summary options
+func sessionSummaryOptionsFromConfig(cfg config.Sessions) app.SessionSummaryOptions {
+	return app.SessionSummaryOptions{
+		Enabled:          cfg.SummaryMemory,
+		DistillSolutions: cfg.DistillSolutions,
+	}
+}
+
+// stalePolicyFromConfig converts the session inactivity thresholds from the config
+func stalePolicyFromConfig(cfg config.Sessions) app.StaleSessionPolicy {
+	return app.StaleSessionPolicy{
+		StaleAfter: time.Duration(cfg.StaleAfterHours) * time.Hour,
+		PauseAfter: time.Duration(cfg.PauseAfterHours) * time.Hour,
+		AbortAfter: time.Duration(cfg.AbortAfterHours) * time.Hour,
+	}
+}
+
+// sessionCollectionSuffix is appended to the memory collection name to name the
+// collection of session search
+const sessionCollectionSuffix = "_sessions"
+
+// sessionVectorStore returns the vector store for session search: a collection next to
+// the memory collection, or a mock store if ChromaDB is not available
+func sessionVectorStore(chromaConfig vector.ChromaDBConfig, available bool, logger *logrus.Logger) ports.VectorStore {
+	if !available {
+		return vector.NewMockVectorStore(logger)
+	}
+	chromaConfig.Collection += sessionCollectionSuffix
+	return vector.NewChromaDBVectorStore(chromaConfig, logger)
+}
+
+// reviewPolicyFromConfig returns the review policy for new memories; it is empty
+// unless the review queue is enabled
+func reviewPolicyFromConfig(cfg config.Review) (domain.ReviewPolicy, error) {
+	if !cfg.Enabled {
+		return domain.ReviewPolicy{}, nil
+	}
+
+	policy := domain.ReviewPolicy{}
+	for _, value := range cfg.Sources {
+		source := domain.ProvenanceSource(strings.ToLower(strings.TrimSpace(value)))
+		if !source.IsValid() {
+			return domain.ReviewPolicy{}, fmt.Errorf("invalid review source %q, use cli, mcp, git or import", value)
+		}
+		policy.Sources = append(policy.Sources, source)
+	}
+	return policy, nil
+}
+
+// scopesFromConfig returns which shared scopes are searched with each project
+func scopesFromConfig(cfg config.Scopes) (domain.ScopeSettings, error) {
+	if cfg.Weight < 0 || cfg.Weight > 1 {
+		return domain.ScopeSettings{}, fmt.Errorf("invalid scope weight %v, use a value between 0 and 1", cfg.Weight)
+	}
+
+	settings := domain.ScopeSettings{Weight: cfg.Weight, Projects: make(map[domain.ProjectID][]domain.ProjectID)}
+	for _, name := range cfg.Include {
+		scope, err := domain.ParseScope(name)
+		if err != nil {
+			return domain.ScopeSettings{}, err
+		}
+		settings.Include = append(settings.Include, scope)
+	}
+	for projectID, names := range cfg.Projects {
+		for _, name := range names {
+			scope, err := domain.ParseScope(name)
+			if err != nil {
+				return domain.ScopeSettings{}, fmt.Errorf("project %s: %w", projectID, err)
+			}
+			settings.Projects[domain.ProjectID(projectID)] = append(settings.Projects[domain.ProjectID(projectID)], scope)
+		}
+	}
+	return settings, nil
+}
@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"context"
+	"encoding/base64"
+	"path/filepath"
+	"testing"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/infra/config"
+	"github.com/joern1811/memory-bank/internal/infra/database"
+	"github.com/joern1811/memory-bank/internal/infra/encryption"
+	"github.com/sirupsen/logrus"
+)
+
+func TestRedactorFromConfig(t *testing.T) {
+	redactor, err := redactorFromConfig(config.Redaction{
+		Enabled:       true,
+		DisabledRules: []string{"email"},
+		Allowlist:     []string{`^itk_0+$`},
+		Rules:         []config.RedactionRule{{Name: "internal_token", Pattern: `itk_[A-Za-z0-9]{8}`}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to build redactor: %v", err)
+	}
+
+	got, _ := redactor.Redact("mail jane@corp.io, token itk_a1B2c3D4, test token itk_00000000")
+	expected := "mail jane@corp.io, token [REDACTED:internal_token], test token itk_00000000"
+	if got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	invalid := []config.Redaction{
+		{DisabledRules: []string{"e-mail"}},
+		{Rules: []config.RedactionRule{{Name: "broken", Pattern: `(`}}},
+		{Rules: []config.RedactionRule{{Name: "group", Pattern: `key=\w+`, Group: 1}}},
+		{Allowlist: []string{`[`}},
+	}
+	for _, redaction := range invalid {
+		if _, err := redactorFromConfig(redaction); err == nil {
+			t.Errorf("Expected %+v to be rejected", redaction)
+		}
+	}
+}
+
+func TestReviewPolicyFromConfig(t *testing.T) {
+	policy, err := reviewPolicyFromConfig(config.Review{Enabled: true, Sources: []string{"MCP", " git "}})
+	if err != nil {
+		t.Fatalf("Failed to build review policy: %v", err)
+	}
+	if len(policy.Sources) != 2 || policy.Sources[0] != domain.ProvenanceSourceMCP || policy.Sources[1] != domain.ProvenanceSourceGit {
+		t.Errorf("Unexpected sources %v", policy.Sources)
+	}
+
+	if policy, _ := reviewPolicyFromConfig(config.Review{Sources: []string{"mcp"}}); len(policy.Sources) != 0 {
+		t.Errorf("Expected a disabled review queue to have no sources, got %v", policy.Sources)
+	}
+	if _, err := reviewPolicyFromConfig(config.Review{Enabled: true, Sources: []string{"agent"}}); err == nil {
+		t.Error("Expected an unknown source to be rejected")
+	}
+}
+
+func TestScopesFromConfig(t *testing.T) {
+	scopes, err := scopesFromConfig(config.Scopes{
+		Weight:   0.6,
+		Include:  []string{"global"},
+		Projects: map[string][]string{"api": {"team:backend"}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to build scopes: %v", err)
+	}
+	if scopes.Weight != 0.6 || len(scopes.Include) != 1 || scopes.Include[0] != domain.GlobalScope {
+		t.Errorf("Unexpected scopes %+v", scopes)
+	}
+	if projectScopes := scopes.Projects["api"]; len(projectScopes) != 1 || projectScopes[0] != domain.TeamScope("backend") {
+		t.Errorf("Unexpected project scopes %v", projectScopes)
+	}
+
+	if _, err := scopesFromConfig(config.Scopes{Weight: 1.5}); err == nil {
+		t.Error("Expected a weight above 1 to be rejected")
+	}
+	if _, err := scopesFromConfig(config.Scopes{Weight: 0.8, Projects: map[string][]string{"api": {"backend"}}}); err == nil {
+		t.Error("Expected an invalid scope to be rejected")
+	}
+}
+
+func TestKeyringFromConfig(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	db, err := database.NewSQLiteDatabase(filepath.Join(t.TempDir(), "test.db"), logger)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	oldKey, err := encryption.GenerateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	t.Setenv("TEST_MEMORY_BANK_PASSPHRASE", "correct horse battery staple")
+	t.Setenv("TEST_MEMORY_BANK_OLD_KEY", base64.StdEncoding.EncodeToString(oldKey))
+
+	ctx := context.Background()
+	enc := config.Encryption{
+		Enabled:  true,
+		Key:      config.EncryptionKey{PassphraseEnv: "TEST_MEMORY_BANK_PASSPHRASE"},
+		Previous: []config.EncryptionKey{{Env: "TEST_MEMORY_BANK_OLD_KEY"}},
+	}
+	keyring, err := keyringFromConfig(ctx, enc, db)
+	if err != nil {
+		t.Fatalf("Failed to load keyring: %v", err)
+	}
+	again, err := keyringFromConfig(ctx, enc, db)
+	if err != nil || again.PrimaryKeyID() != keyring.PrimaryKeyID() {
+		t.Errorf("Expected the passphrase to derive the same key with the stored salt, got %v", err)
+	}
+
+	oldKeyring, _ := encryption.NewKeyring(oldKey)
+	encrypted, _ := oldKeyring.Encrypt("incident notes")
+	if plain, err := keyring.Decrypt(encrypted); err != nil || plain != "incident notes" {
+		t.Errorf("Expected the previous key to decrypt, got %q, %v", plain, err)
+	}
+
+	enc.Key = config.EncryptionKey{Env: "TEST_MEMORY_BANK_UNSET"}
+	if _, err := keyringFromConfig(ctx, enc, db); err == nil {
+		t.Error("Expected a missing key to be rejected")
+	}
+}
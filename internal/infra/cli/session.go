@@ -2,6 +2,7 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -22,11 +23,18 @@ var sessionStartCmd = &cobra.Command{
 	Use:   "start [task-description]",
 	Short: "Start a new development session",
 	Long: `Start a new development session with a task description.
-The session will be created and set as active for the project.`,
+The session will be created and set as active for the project. Other active sessions
+of the project keep running, e.g. one per branch or agent; use --name to tell them apart.
+If the project is a git repository, the current branch and HEAD are recorded.
+
+Before starting, sessions of the project inactive for longer than sessions.pause_after_hours
+are paused and those inactive for longer than sessions.abort_after_hours aborted; open
+sessions inactive for longer than sessions.stale_after_hours are reported.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		taskDescription := args[0]
 		projectID, _ := cmd.Flags().GetString("project")
+		name, _ := cmd.Flags().GetString("name")
 
 		if taskDescription == "" {
 			return fmt.Errorf("task description is required")
@@ -49,6 +57,7 @@ The session will be created and set as active for the project.`,
 		// Create session request
 		req := ports.StartSessionRequest{
 			TaskDescription: taskDescription,
+			Name:            name,
 		}
 
 		// Set project ID if provided
@@ -69,6 +78,21 @@ The session will be created and set as active for the project.`,
 		fmt.Printf("✓ Development session started successfully (ID: %s)\n", session.ID)
 		fmt.Printf("  Status: %s\n", session.Status)
 		fmt.Printf("  Started: %s\n", session.StartTime.Format("2006-01-02 15:04:05"))
+		if session.Git != nil {
+			fmt.Printf("  Git: %s\n", formatGitStart(session.Git))
+		}
+
+		// Warn about sessions of the project left open
+		stale, err := services.SessionService.StaleSessions(ctx, &req.ProjectID)
+		if err != nil {
+			services.Logger.WithError(err).Warn("Failed to check for stale sessions")
+		}
+		if len(stale) > 0 {
+			fmt.Printf("\n⚠️  %d stale session(s) still open, complete them or run 'memory-bank session prune':\n", len(stale))
+			for _, idle := range stale {
+				fmt.Printf("  - %s (%s, %s, inactive for %s)\n", idle.Session.Name, idle.Session.ID, idle.Session.Status, domain.FormatIdleTime(idle.IdleFor))
+			}
+		}
 		return nil
 	},
 }
@@ -76,62 +100,116 @@ The session will be created and set as active for the project.`,
 var sessionLogCmd = &cobra.Command{
 	Use:   "log [message]",
 	Short: "Log progress to the active session",
-	Long:  `Log a progress message to the currently active development session.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Log a progress message to the currently active development session.
+If the project has several active sessions, choose one with --session.
+
+Entries are typed with --type and can refer to source files (--file, repeatable),
+a task or memory (--ref) and a git commit (--commit).`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		message := args[0]
-		projectPath, _ := cmd.Flags().GetString("project")
+		sessionRef, _ := cmd.Flags().GetString("session")
 		entryType, _ := cmd.Flags().GetString("type")
 
-		// Initialize services
-		services, err := NewServiceContainer()
+		// Get services
+		services, err := GetServicesForCLI(cmd)
 		if err != nil {
 			return fmt.Errorf("failed to initialize services: %w", err)
 		}
 
-		// Get project by path
-		var project *domain.Project
-		if projectPath != "" {
-			project, err = services.ProjectService.GetByPath(context.Background(), projectPath)
-			if err != nil {
-				return fmt.Errorf("failed to get project: %w", err)
-			}
-		} else {
-			// Try to detect project from current directory
-			currentDir, err := os.Getwd()
-			if err != nil {
-				return fmt.Errorf("failed to get current directory: %w", err)
-			}
-			project, err = services.ProjectService.GetByPath(context.Background(), currentDir)
-			if err != nil {
-				return fmt.Errorf("no project found. Run 'memory-bank init' first or specify --project")
-			}
+		// Find the session to log to
+		session, err := findSession(context.Background(), cmd, services, sessionRef, domain.SessionStatusActive)
+		if err != nil {
+			return err
+		}
+		if !session.IsActive() {
+			return fmt.Errorf("session %s is %s, only active sessions accept progress", session.ID, session.Status)
+		}
+
+		files, _ := cmd.Flags().GetStringArray("file")
+		ref, _ := cmd.Flags().GetString("ref")
+		commit, _ := cmd.Flags().GetString("commit")
+
+		// Log progress with type and metadata
+		entry, err := services.SessionService.LogProgress(context.Background(), ports.LogProgressRequest{
+			SessionID: session.ID,
+			Message:   message,
+			Type:      entryType,
+			Files:     files,
+			Ref:       ref,
+			Commit:    commit,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to log progress: %w", err)
 		}
 
-		// Get active session
-		session, err := services.SessionService.GetActiveSession(context.Background(), project.ID)
+		fmt.Printf("✓ Progress logged to session '%s': %s\n", session.Name, formatProgressEntry(*entry))
+		return nil
+	},
+}
+
+var sessionProgressCmd = &cobra.Command{
+	Use:   "progress [query]",
+	Short: "Filter and search progress entries across sessions",
+	Long: `List progress entries of all sessions, newest first. The optional query matches
+entries whose message contains all of its words; --type, --file, --ref and --commit
+filter by entry type and metadata.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectID, _ := cmd.Flags().GetString("project")
+		sessionID, _ := cmd.Flags().GetString("session")
+		types, _ := cmd.Flags().GetStringSlice("type")
+		file, _ := cmd.Flags().GetString("file")
+		ref, _ := cmd.Flags().GetString("ref")
+		commit, _ := cmd.Flags().GetString("commit")
+		since, _ := cmd.Flags().GetDuration("since")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		req := ports.SearchProgressRequest{
+			Types:  types,
+			File:   file,
+			Ref:    ref,
+			Commit: commit,
+			Limit:  limit,
+		}
+		if len(args) > 0 {
+			req.Query = args[0]
+		}
+		if projectID != "" {
+			pid := domain.ProjectID(projectID)
+			req.ProjectID = &pid
+		}
+		if sessionID != "" {
+			sid := domain.SessionID(sessionID)
+			req.SessionID = &sid
+		}
+		if since > 0 {
+			sinceTime := time.Now().Add(-since)
+			req.Since = &sinceTime
+		}
+
+		// Get services
+		services, err := GetServicesForCLI(cmd)
 		if err != nil {
-			return fmt.Errorf("no active session found. Start a session first with 'memory-bank session start'")
+			return fmt.Errorf("failed to initialize services: %w", err)
 		}
 
-		// Log progress with type
-		switch entryType {
-		case "milestone":
-			session.LogMilestone(message)
-		case "issue":
-			session.LogIssue(message)
-		case "solution":
-			session.LogSolution(message)
-		default:
-			session.LogInfo(message)
+		results, err := services.SessionService.SearchProgress(context.Background(), req)
+		if err != nil {
+			return fmt.Errorf("failed to search progress: %w", err)
 		}
 
-		// Update session
-		if err := services.SessionService.Update(context.Background(), session); err != nil {
-			return fmt.Errorf("failed to update session: %w", err)
+		if len(results) == 0 {
+			fmt.Println("No progress entries found.")
+			return nil
 		}
 
-		fmt.Printf("✓ Progress logged to session '%s': %s\n", session.Name, message)
+		fmt.Printf("Found %d progress entries:\n", len(results))
+		for _, result := range results {
+			fmt.Printf("\n%s  %s (%s)\n", result.Entry.Timestamp, result.SessionName, result.SessionID)
+			fmt.Printf("  %s\n", formatProgressEntry(result.Entry))
+			printProgressMetadata(result.Entry, "    ")
+		}
 		return nil
 	},
 }
@@ -140,15 +218,20 @@ var sessionCompleteCmd = &cobra.Command{
 	Use:   "complete [outcome]",
 	Short: "Complete current session",
 	Long: `Complete the currently active session with an optional outcome description.
-The session will be marked as completed and no longer active.`,
+The session will be marked as completed and no longer active. Paused sessions can be
+completed by ID or name with --session. In a git repository the HEAD at completion and
+the commits, changed files and line counts since the session started are recorded.
+
+Completing a session writes a session memory summarising its progress log, outcome
+and commits. With --distill, issue entries followed by a solution entry also become
+error_solution memories; the default is the sessions.distill_solutions setting.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		var outcome string
 		if len(args) > 0 {
 			outcome = args[0]
 		}
 
-		projectID, _ := cmd.Flags().GetString("project")
-		sessionID, _ := cmd.Flags().GetString("session")
+		sessionRef, _ := cmd.Flags().GetString("session")
 
 		// Get services
 		services, err := GetServicesForCLI(cmd)
@@ -158,26 +241,11 @@ The session will be marked as completed and no longer active.`,
 
 		ctx := context.Background()
 
-		var targetSessionID domain.SessionID
-
-		// If session ID provided, use it directly
-		if sessionID != "" {
-			targetSessionID = domain.SessionID(sessionID)
-		} else {
-			// Find active session for project
-			var pid domain.ProjectID
-			if projectID != "" {
-				pid = domain.ProjectID(projectID)
-			} else {
-				pid = domain.ProjectID("default")
-			}
-
-			activeSession, err := services.SessionService.GetActiveSession(ctx, pid)
-			if err != nil {
-				return fmt.Errorf("no active session found for project %s", pid)
-			}
-			targetSessionID = activeSession.ID
+		session, err := findSession(ctx, cmd, services, sessionRef, domain.SessionStatusActive)
+		if err != nil {
+			return err
 		}
+		targetSessionID := session.ID
 
 		fmt.Printf("Completing session %s\n", targetSessionID)
 		if outcome != "" {
@@ -185,12 +253,28 @@ The session will be marked as completed and no longer active.`,
 		}
 
 		// Complete session
-		err = services.SessionService.CompleteSession(ctx, targetSessionID, outcome)
+		req := ports.CompleteSessionRequest{SessionID: targetSessionID, Outcome: outcome}
+		if cmd.Flags().Changed("distill") {
+			distill, _ := cmd.Flags().GetBool("distill")
+			req.Distill = &distill
+		}
+		result, err := services.SessionService.CompleteSession(ctx, req)
 		if err != nil {
 			return fmt.Errorf("failed to complete session: %w", err)
 		}
 
 		fmt.Printf("✓ Session completed successfully\n")
+		fmt.Printf("  Active time: %s\n", result.Session.Duration().Truncate(time.Second))
+		if git := result.Session.Git; git != nil && git.EndCommit != "" {
+			fmt.Printf("  Git: %s, %d commits, %d files changed (+%d -%d)\n",
+				git.Range(), len(git.Commits), len(git.Files), git.Insertions, git.Deletions)
+		}
+		if result.Summary != nil {
+			fmt.Printf("  Summary memory: %s\n", result.Summary.ID)
+		}
+		for _, memory := range result.Distilled {
+			fmt.Printf("  Error solution: %s (ID: %s)\n", memory.Title, memory.ID)
+		}
 		return nil
 	},
 }
@@ -257,15 +341,13 @@ var sessionListCmd = &cobra.Command{
 			for i, session := range sessions {
 				fmt.Printf("\n%d. %s\n", i+1, session.TaskDescription)
 				fmt.Printf("   ID: %s\n", session.ID)
+				if session.Name != session.TaskDescription {
+					fmt.Printf("   Name: %s\n", session.Name)
+				}
 				fmt.Printf("   Project: %s, Status: %s\n", session.ProjectID, session.Status)
 				fmt.Printf("   Started: %s\n", session.StartTime.Format("2006-01-02 15:04:05"))
 
-				if session.EndTime != nil {
-					fmt.Printf("   Ended: %s\n", session.EndTime.Format("2006-01-02 15:04:05"))
-					fmt.Printf("   Duration: %s\n", session.Duration().String())
-				} else if session.IsActive() {
-					fmt.Printf("   Duration: %s (ongoing)\n", time.Since(session.StartTime).Truncate(time.Second).String())
-				}
+				printSessionDuration(session, "   ")
 
 				if session.Outcome != "" {
 					fmt.Printf("   Outcome: %s\n", truncateString(session.Outcome, 100))
@@ -275,7 +357,7 @@ var sessionListCmd = &cobra.Command{
 					fmt.Printf("   Progress entries: %d\n", len(session.Progress))
 					// Show last progress entry
 					lastEntry := session.Progress[len(session.Progress)-1]
-					fmt.Printf("   Latest: [%s] %s\n", lastEntry.Type, truncateString(lastEntry.Message, 80))
+					fmt.Printf("   Latest: %s %s\n", progressTypeLabel(lastEntry.Type), truncateString(lastEntry.Message, 80))
 				}
 			}
 		}
@@ -314,26 +396,35 @@ var sessionGetCmd = &cobra.Command{
 
 		fmt.Printf("\nSession Details:\n")
 		fmt.Printf("  ID: %s\n", session.ID)
+		if session.Name != session.TaskDescription {
+			fmt.Printf("  Name: %s\n", session.Name)
+		}
 		fmt.Printf("  Project: %s\n", session.ProjectID)
 		fmt.Printf("  Task: %s\n", session.TaskDescription)
 		fmt.Printf("  Status: %s\n", session.Status)
 		fmt.Printf("  Started: %s\n", session.StartTime.Format("2006-01-02 15:04:05"))
 
-		if session.EndTime != nil {
-			fmt.Printf("  Ended: %s\n", session.EndTime.Format("2006-01-02 15:04:05"))
-			fmt.Printf("  Duration: %s\n", session.Duration().String())
-		} else if session.IsActive() {
-			fmt.Printf("  Duration: %s (ongoing)\n", time.Since(session.StartTime).Truncate(time.Second).String())
-		}
+		printSessionDuration(session, "  ")
 
 		if session.Outcome != "" {
 			fmt.Printf("  Outcome: %s\n", session.Outcome)
 		}
+		if session.Provenance != nil {
+			fmt.Printf("  Started by: %s\n", session.Provenance)
+		}
+		if session.LastModifiedBy != nil {
+			fmt.Printf("  Last modified by: %s\n", session.LastModifiedBy)
+		}
+
+		if session.Git != nil {
+			printSessionGit(session.Git)
+		}
 
 		if len(session.Progress) > 0 {
 			fmt.Printf("\nProgress Log (%d entries):\n", len(session.Progress))
 			for i, entry := range session.Progress {
-				fmt.Printf("  %d. [%s] %s - %s\n", i+1, entry.Type, entry.Timestamp, entry.Message)
+				fmt.Printf("  %d. %s  %s\n", i+1, entry.Timestamp, formatProgressEntry(entry))
+				printProgressMetadata(entry, "       ")
 			}
 
 			// Show progress breakdown
@@ -365,15 +456,14 @@ var sessionAbortCmd = &cobra.Command{
 	Use:   "abort [session-id]",
 	Short: "Abort session",
 	Long: `Abort a session without completing it. The session will be marked as aborted.
-If no session ID is provided, the active session for the project will be aborted.`,
+The session can be given by ID or name. If none is provided, the active session for
+the project will be aborted.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		var sessionID string
+		var sessionRef string
 		if len(args) > 0 {
-			sessionID = args[0]
+			sessionRef = args[0]
 		}
 
-		projectID, _ := cmd.Flags().GetString("project")
-
 		// Get services
 		services, err := GetServicesForCLI(cmd)
 		if err != nil {
@@ -382,40 +472,347 @@ If no session ID is provided, the active session for the project will be aborted
 
 		ctx := context.Background()
 
-		var targetSessionID domain.SessionID
+		session, err := findSession(ctx, cmd, services, sessionRef, domain.SessionStatusActive)
+		if err != nil {
+			return err
+		}
+		targetSessionID := session.ID
 
-		// If session ID provided, use it directly
-		if sessionID != "" {
-			targetSessionID = domain.SessionID(sessionID)
-		} else {
-			// Find active session for project
-			var pid domain.ProjectID
-			if projectID != "" {
-				pid = domain.ProjectID(projectID)
+		fmt.Printf("Aborting session %s\n", targetSessionID)
+
+		// Abort session
+		err = services.SessionService.AbortSession(ctx, targetSessionID)
+		if err != nil {
+			return fmt.Errorf("failed to abort session: %w", err)
+		}
+
+		fmt.Printf("✓ Session aborted successfully\n")
+		return nil
+	},
+}
+
+var sessionPauseCmd = &cobra.Command{
+	Use:   "pause [session]",
+	Short: "Pause a session",
+	Long: `Pause an active session, given by ID or name. Paused time does not count towards the
+session's duration. If no session is provided, the active session for the project is paused.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return changeSessionState(cmd, args, domain.SessionStatusActive)
+	},
+}
+
+var sessionResumeCmd = &cobra.Command{
+	Use:   "resume [session]",
+	Short: "Resume a paused session",
+	Long: `Resume a paused session, given by ID or name. If no session is provided, the paused
+session for the project is resumed.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return changeSessionState(cmd, args, domain.SessionStatusPaused)
+	},
+}
+
+// changeSessionState pauses an active session or resumes a paused one
+func changeSessionState(cmd *cobra.Command, args []string, from domain.SessionStatus) error {
+	var sessionRef string
+	if len(args) > 0 {
+		sessionRef = args[0]
+	}
+
+	services, err := GetServicesForCLI(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to initialize services: %w", err)
+	}
+
+	ctx := context.Background()
+
+	session, err := findSession(ctx, cmd, services, sessionRef, from)
+	if err != nil {
+		return err
+	}
+
+	verb := "paused"
+	if from == domain.SessionStatusPaused {
+		verb = "resumed"
+		err = services.SessionService.ResumeSession(ctx, session.ID)
+	} else {
+		err = services.SessionService.PauseSession(ctx, session.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to change session %s: %w", session.ID, err)
+	}
+
+	updated, err := services.SessionService.GetSession(ctx, session.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	fmt.Printf("✓ Session '%s' %s (ID: %s)\n", updated.Name, verb, updated.ID)
+	fmt.Printf("  Active time: %s\n", updated.Duration().Truncate(time.Second))
+	return nil
+}
+
+// findSession returns the session given by ID or name, or the only session of the
+// project (--project, "default" if not set) with the given status
+func findSession(ctx context.Context, cmd *cobra.Command, services *ServiceContainer, ref string, status domain.SessionStatus) (*domain.Session, error) {
+	projectID, _ := cmd.Flags().GetString("project")
+	if projectID == "" {
+		projectID = "default"
+	}
+	return services.SessionService.FindSession(ctx, domain.ProjectID(projectID), ref, status)
+}
+
+// printSessionDuration prints the active time of a session, excluding pauses
+func printSessionDuration(session *domain.Session, indent string) {
+	switch {
+	case session.EndTime != nil:
+		fmt.Printf("%sEnded: %s\n", indent, session.EndTime.Format("2006-01-02 15:04:05"))
+		fmt.Printf("%sDuration: %s\n", indent, session.Duration().String())
+	case session.PausedAt != nil:
+		fmt.Printf("%sDuration: %s (paused since %s)\n", indent, session.Duration().Truncate(time.Second).String(), session.PausedAt.Format("2006-01-02 15:04:05"))
+	case session.IsActive():
+		fmt.Printf("%sDuration: %s (ongoing)\n", indent, session.Duration().Truncate(time.Second).String())
+	}
+}
+
+// formatGitStart renders the branch and commit a session started at
+func formatGitStart(git *domain.SessionGit) string {
+	if git.Branch == "" {
+		return domain.ShortCommitHash(git.StartCommit) + " (detached HEAD)"
+	}
+	return git.Branch + " @ " + domain.ShortCommitHash(git.StartCommit)
+}
+
+// printSessionGit prints the branch, commit range, commits and changed files of a session
+func printSessionGit(git *domain.SessionGit) {
+	fmt.Printf("\nGit:\n")
+	if git.Branch != "" {
+		fmt.Printf("  Branch: %s\n", git.Branch)
+	}
+	fmt.Printf("  Start commit: %s\n", git.StartCommit)
+	if git.EndCommit == "" {
+		return
+	}
+	fmt.Printf("  End commit: %s\n", git.EndCommit)
+	fmt.Printf("  Range: %s\n", git.Range())
+	fmt.Printf("  Changes: %d commits, %d files changed, +%d -%d\n",
+		len(git.Commits), len(git.Files), git.Insertions, git.Deletions)
+
+	if len(git.Commits) > 0 {
+		fmt.Printf("\n  Commits:\n")
+		for _, commit := range git.Commits {
+			fmt.Printf("    %s %s (%s, %s)\n", commit.ShortHash(), commit.Subject, commit.Author, commit.Date.Format("2006-01-02 15:04"))
+		}
+	}
+	if len(git.Files) > 0 {
+		fmt.Printf("\n  Files:\n")
+		for _, file := range git.Files {
+			path := file.Path
+			if file.OldPath != "" {
+				path = file.OldPath + " -> " + file.Path
+			}
+			if file.Binary {
+				fmt.Printf("    %-8s %s (binary)\n", file.Status, path)
 			} else {
-				pid = domain.ProjectID("default")
+				fmt.Printf("    %-8s %s (+%d -%d)\n", file.Status, path, file.Insertions, file.Deletions)
+			}
+		}
+	}
+}
+
+var sessionHandoffCmd = &cobra.Command{
+	Use:   "handoff",
+	Short: "Print a brief for continuing the session last worked on",
+	Long: `Print a brief of where the session of the project last worked on left off: its goal, latest
+progress, last milestones, issues without a solution entry, related open tasks and relevant
+decisions, patterns and error solutions. The output is markdown meant to be pasted into a
+new agent conversation.
+
+Without --project, the project containing the current directory is used, or the default
+project. --session picks another session by ID.`,
+	Example: `  memory-bank session handoff
+  memory-bank session handoff --project my-project --budget 1000 --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectID, _ := cmd.Flags().GetString("project")
+		sessionID, _ := cmd.Flags().GetString("session")
+		budget, _ := cmd.Flags().GetInt("budget")
+		limit, _ := cmd.Flags().GetInt("limit")
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		// Get services
+		services, err := GetServicesForCLI(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+
+		ctx := context.Background()
+
+		req := ports.HandoffRequest{
+			ProjectID:   domain.ProjectID("default"),
+			TokenBudget: budget,
+			Limit:       limit,
+		}
+		if projectID != "" {
+			req.ProjectID = domain.ProjectID(projectID)
+		} else if cwd, err := os.Getwd(); err == nil {
+			if project, err := services.ProjectService.GetProjectByPath(ctx, cwd); err == nil {
+				req.ProjectID = project.ID
 			}
+		}
+		if sessionID != "" {
+			sid := domain.SessionID(sessionID)
+			req.SessionID = &sid
+		}
 
-			activeSession, err := services.SessionService.GetActiveSession(ctx, pid)
+		brief, err := services.ContextService.BuildHandoff(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to build session handoff: %w", err)
+		}
+
+		if err := services.MemoryService.RecordAccess(ctx, brief.MemoryIDs()); err != nil {
+			services.Logger.WithError(err).Warn("Failed to record memory access")
+		}
+
+		if asJSON {
+			output, err := json.MarshalIndent(brief, "", "  ")
 			if err != nil {
-				return fmt.Errorf("no active session found for project %s", pid)
+				return fmt.Errorf("failed to marshal session handoff: %w", err)
 			}
-			targetSessionID = activeSession.ID
+			fmt.Println(string(output))
+			return nil
 		}
 
-		fmt.Printf("Aborting session %s\n", targetSessionID)
+		fmt.Print(brief.Text)
+		return nil
+	},
+}
 
-		// Abort session
-		err = services.SessionService.AbortSession(ctx, targetSessionID)
+var sessionPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Pause or abort inactive sessions",
+	Long: `Pause active sessions inactive for longer than --pause-after and abort active and paused
+sessions inactive for longer than --abort-after. The thresholds default to the
+sessions.pause_after_hours and sessions.abort_after_hours settings. A session's last
+activity is its latest progress entry, pause or resume, or its start. The reason is
+recorded in the progress log of each pruned session.
+
+Sessions are also pruned with the configured thresholds when a session is started and
+when the MCP server starts.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectFlag, _ := cmd.Flags().GetString("project")
+		pauseAfter, _ := cmd.Flags().GetDuration("pause-after")
+		abortAfter, _ := cmd.Flags().GetDuration("abort-after")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		// Get services
+		services, err := GetServicesForCLI(cmd)
 		if err != nil {
-			return fmt.Errorf("failed to abort session: %w", err)
+			return fmt.Errorf("failed to initialize services: %w", err)
 		}
 
-		fmt.Printf("✓ Session aborted successfully\n")
+		req := ports.PruneSessionsRequest{PauseAfter: pauseAfter, AbortAfter: abortAfter, DryRun: dryRun}
+		if projectFlag != "" {
+			pid := domain.ProjectID(projectFlag)
+			req.ProjectID = &pid
+		}
+
+		result, err := services.SessionService.PruneSessions(context.Background(), req)
+		if err != nil {
+			return fmt.Errorf("failed to prune sessions: %w", err)
+		}
+
+		if len(result.Paused) == 0 && len(result.Aborted) == 0 {
+			fmt.Println("No inactive sessions to prune.")
+			return nil
+		}
+
+		pausedVerb, abortedVerb := "Paused", "Aborted"
+		if dryRun {
+			pausedVerb, abortedVerb = "Would pause", "Would abort"
+		}
+		printPrunedSessions(pausedVerb, result.Paused)
+		printPrunedSessions(abortedVerb, result.Aborted)
 		return nil
 	},
 }
 
+// printPrunedSessions lists sessions paused or aborted for inactivity
+func printPrunedSessions(verb string, sessions []ports.StaleSession) {
+	if len(sessions) == 0 {
+		return
+	}
+	fmt.Printf("%s %d session(s):\n", verb, len(sessions))
+	for _, stale := range sessions {
+		fmt.Printf("  %s  %s (project %s, inactive for %s)\n",
+			stale.Session.ID, stale.Session.Name, stale.Session.ProjectID, domain.FormatIdleTime(stale.IdleFor))
+	}
+}
+
+var sessionReindexCmd = &cobra.Command{
+	Use:   "reindex",
+	Short: "Rebuild the search index of sessions",
+	Long: `Embed the task descriptions, progress entries and outcomes of all sessions, or of
+one project with --project, for 'memory-bank search' and memory_search. New entries are
+indexed as they are written; reindex covers sessions from before session search was
+enabled or while the embedding service was unavailable.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectFlag, _ := cmd.Flags().GetString("project")
+
+		// Get services
+		services, err := GetServicesForCLI(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+
+		var projectID *domain.ProjectID
+		if projectFlag != "" {
+			pid := domain.ProjectID(projectFlag)
+			projectID = &pid
+		}
+
+		indexed, err := services.SessionService.ReindexSessions(context.Background(), projectID)
+		if err != nil {
+			return fmt.Errorf("failed to reindex sessions: %w", err)
+		}
+
+		fmt.Printf("✓ Indexed %d session entries for search\n", indexed)
+		return nil
+	},
+}
+
+// progressTypeLabel returns the marker that sets a progress entry type apart
+func progressTypeLabel(entryType string) string {
+	switch entryType {
+	case domain.ProgressTypeMilestone:
+		return "🏁 MILESTONE"
+	case domain.ProgressTypeIssue:
+		return "⚠️  ISSUE"
+	case domain.ProgressTypeSolution:
+		return "✅ SOLUTION"
+	default:
+		return "•"
+	}
+}
+
+// formatProgressEntry renders a progress entry's message behind its type marker
+func formatProgressEntry(entry domain.ProgressEntry) string {
+	return progressTypeLabel(entry.Type) + " " + entry.Message
+}
+
+// printProgressMetadata prints the files, ref and commit of a progress entry, if any
+func printProgressMetadata(entry domain.ProgressEntry, indent string) {
+	if len(entry.Files) > 0 {
+		fmt.Printf("%sFiles: %s\n", indent, strings.Join(entry.Files, ", "))
+	}
+	if entry.Ref != "" {
+		fmt.Printf("%sRef: %s\n", indent, entry.Ref)
+	}
+	if entry.Commit != "" {
+		fmt.Printf("%sCommit: %s\n", indent, entry.Commit)
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(sessionCmd)
 
@@ -426,24 +823,65 @@ func init() {
 	sessionCmd.AddCommand(sessionListCmd)
 	sessionCmd.AddCommand(sessionGetCmd)
 	sessionCmd.AddCommand(sessionAbortCmd)
+	sessionCmd.AddCommand(sessionPauseCmd)
+	sessionCmd.AddCommand(sessionResumeCmd)
+	sessionCmd.AddCommand(sessionProgressCmd)
+	sessionCmd.AddCommand(sessionReindexCmd)
+	sessionCmd.AddCommand(sessionPruneCmd)
+	sessionCmd.AddCommand(sessionHandoffCmd)
 
 	// Flags for start command
 	sessionStartCmd.Flags().StringP("project", "p", "", "project ID")
+	sessionStartCmd.Flags().StringP("name", "n", "", "session name to tell parallel sessions apart, e.g. a branch (defaults to the task)")
 
 	// Flags for log command
 	sessionLogCmd.Flags().StringP("project", "p", "", "project ID (if no session ID provided)")
-	sessionLogCmd.Flags().StringP("session", "s", "", "specific session ID")
+	sessionLogCmd.Flags().StringP("session", "s", "", "specific session ID or name")
 	sessionLogCmd.Flags().StringP("type", "t", "info", "entry type (info, milestone, issue, solution)")
+	sessionLogCmd.Flags().StringArray("file", nil, "source file the entry refers to (repeatable)")
+	sessionLogCmd.Flags().String("ref", "", "task or memory ID the entry refers to")
+	sessionLogCmd.Flags().String("commit", "", "git commit the entry refers to")
+
+	// Flags for handoff command
+	sessionHandoffCmd.Flags().StringP("project", "p", "", "project ID (default: project of the current directory)")
+	sessionHandoffCmd.Flags().StringP("session", "s", "", "session ID (default: the session of the project last worked on)")
+	sessionHandoffCmd.Flags().Int("budget", ports.DefaultContextTokenBudget, "approximate size limit in tokens")
+	sessionHandoffCmd.Flags().IntP("limit", "l", ports.DefaultContextLimit, "maximum entries per section")
+	sessionHandoffCmd.Flags().Bool("json", false, "print the structured brief as JSON")
+
+	// Flags for prune command
+	sessionPruneCmd.Flags().StringP("project", "p", "", "only prune the sessions of this project")
+	sessionPruneCmd.Flags().Duration("pause-after", 0, "pause active sessions inactive this long, e.g. 48h (default: sessions.pause_after_hours)")
+	sessionPruneCmd.Flags().Duration("abort-after", 0, "abort open sessions inactive this long, e.g. 336h (default: sessions.abort_after_hours)")
+	sessionPruneCmd.Flags().Bool("dry-run", false, "list the sessions that would be pruned without changing them")
+
+	// Flags for reindex command
+	sessionReindexCmd.Flags().StringP("project", "p", "", "only reindex the sessions of this project")
+
+	// Flags for progress command
+	sessionProgressCmd.Flags().StringP("project", "p", "", "filter by project ID")
+	sessionProgressCmd.Flags().StringP("session", "s", "", "filter by session ID")
+	sessionProgressCmd.Flags().StringSliceP("type", "t", nil, "filter by entry types (info, milestone, issue, solution)")
+	sessionProgressCmd.Flags().String("file", "", "only entries referring to this file")
+	sessionProgressCmd.Flags().String("ref", "", "only entries referring to this task or memory ID")
+	sessionProgressCmd.Flags().String("commit", "", "only entries referring to a commit with this hash prefix")
+	sessionProgressCmd.Flags().Duration("since", 0, "only entries logged within this duration, e.g. 48h")
+	sessionProgressCmd.Flags().IntP("limit", "l", 50, "maximum number of results")
 
 	// Flags for complete command
 	sessionCompleteCmd.Flags().StringP("project", "p", "", "project ID (if no session ID provided)")
-	sessionCompleteCmd.Flags().StringP("session", "s", "", "specific session ID")
+	sessionCompleteCmd.Flags().StringP("session", "s", "", "specific session ID or name")
+	sessionCompleteCmd.Flags().Bool("distill", false, "turn issue entries followed by a solution into error_solution memories")
 
 	// Flags for list command
 	sessionListCmd.Flags().StringP("project", "p", "", "filter by project ID")
-	sessionListCmd.Flags().StringP("status", "", "", "filter by status (active, completed, aborted)")
+	sessionListCmd.Flags().StringP("status", "", "", "filter by status (active, paused, completed, aborted)")
 	sessionListCmd.Flags().IntP("limit", "l", 20, "maximum number of results")
 
 	// Flags for abort command
 	sessionAbortCmd.Flags().StringP("project", "p", "", "project ID (if no session ID provided)")
+
+	// Flags for pause and resume commands
+	sessionPauseCmd.Flags().StringP("project", "p", "", "project ID (if no session provided)")
+	sessionResumeCmd.Flags().StringP("project", "p", "", "project ID (if no session provided)")
 }
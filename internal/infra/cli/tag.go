@@ -0,0 +1,360 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/spf13/cobra"
+)
+
+var tagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Manage tags",
+	Long: `List, rename and merge tags across memories, and review tags added or
+suggested by the auto-tagger. Tags may be hierarchical, e.g. infra/db/sqlite;
+a tag filter also matches the tags below it.`,
+}
+
+var tagListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List tags with usage counts",
+	Long: `List tags with the number of memories using them. Hierarchical tags are
+shown as a tree; the count in brackets includes the tags below.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectID, _ := cmd.Flags().GetString("project")
+		prefix, _ := cmd.Flags().GetString("prefix")
+		sortBy, _ := cmd.Flags().GetString("sort")
+		origin, _ := cmd.Flags().GetString("origin")
+
+		if sortBy != "name" && sortBy != "count" {
+			return fmt.Errorf("invalid sort %q (expected name or count)", sortBy)
+		}
+		if origin != "" && origin != string(domain.TagOriginManual) && origin != string(domain.TagOriginAuto) {
+			return fmt.Errorf("invalid origin %q (expected manual or auto)", origin)
+		}
+
+		// Get services
+		services, err := GetServicesForCLI(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+
+		tags, err := services.TagService.ListTags(context.Background(), ports.ListTagsRequest{
+			ProjectID: optionalProjectID(projectID),
+			Prefix:    prefix,
+			Origin:    domain.TagOrigin(origin),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list tags: %w", err)
+		}
+
+		if len(tags) == 0 {
+			fmt.Println("No tags found")
+			return nil
+		}
+
+		if sortBy == "count" {
+			sort.SliceStable(tags, func(i, j int) bool {
+				return tags[i].Total > tags[j].Total
+			})
+			for _, tag := range tags {
+				fmt.Printf("%5d  %s\n", tag.Total, tag.Tag)
+			}
+			return nil
+		}
+
+		for _, tag := range tags {
+			depth := strings.Count(tag.Tag, domain.TagSeparator)
+			name := tag.Tag[strings.LastIndex(tag.Tag, domain.TagSeparator)+1:]
+			line := fmt.Sprintf("%s%s %d", strings.Repeat("  ", depth), name, tag.Count)
+			if tag.Total != tag.Count {
+				line += fmt.Sprintf(" (%d)", tag.Total)
+			}
+			fmt.Println(line)
+		}
+		return nil
+	},
+}
+
+var tagRenameCmd = &cobra.Command{
+	Use:   "rename [from] [to]",
+	Short: "Rename a tag",
+	Long: `Rename a tag in all memories. Tags below it keep their lower levels:
+renaming db to infra/db turns db/sqlite into infra/db/sqlite.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectID, _ := cmd.Flags().GetString("project")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		// Get services
+		services, err := GetServicesForCLI(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+
+		result, err := services.TagService.RenameTag(context.Background(), ports.RenameTagRequest{
+			ProjectID: optionalProjectID(projectID),
+			From:      args[0],
+			To:        args[1],
+			DryRun:    dryRun,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to rename tag: %w", err)
+		}
+
+		printTagChangeResult(result, fmt.Sprintf("renamed %s to %s", args[0], args[1]))
+		return nil
+	},
+}
+
+var tagMergeCmd = &cobra.Command{
+	Use:     "merge [source...] --into [target]",
+	Short:   "Merge tags into one",
+	Long:    `Replace each source tag, and the tags below it, with the target tag.`,
+	Example: `  memory-bank tag merge authn authentication --into auth`,
+	Args:    cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectID, _ := cmd.Flags().GetString("project")
+		target, _ := cmd.Flags().GetString("into")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		if target == "" {
+			return fmt.Errorf("--into is required")
+		}
+
+		// Get services
+		services, err := GetServicesForCLI(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+
+		result, err := services.TagService.MergeTags(context.Background(), ports.MergeTagsRequest{
+			ProjectID: optionalProjectID(projectID),
+			Sources:   args,
+			Target:    target,
+			DryRun:    dryRun,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to merge tags: %w", err)
+		}
+
+		printTagChangeResult(result, fmt.Sprintf("merged %s into %s", strings.Join(args, ", "), target))
+		return nil
+	},
+}
+
+var tagNormalizeCmd = &cobra.Command{
+	Use:   "normalize",
+	Short: "Apply tag normalization to existing memories",
+	Long: `Apply the configured tag normalization (tags.lowercase and tags.aliases) to
+the tags of existing memories. New tags are normalized when they are stored.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectID, _ := cmd.Flags().GetString("project")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		// Get services
+		services, err := GetServicesForCLI(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+
+		result, err := services.TagService.NormalizeTags(context.Background(), optionalProjectID(projectID), dryRun)
+		if err != nil {
+			return fmt.Errorf("failed to normalize tags: %w", err)
+		}
+
+		printTagChangeResult(result, "normalized tags")
+		return nil
+	},
+}
+
+var tagSuggestCmd = &cobra.Command{
+	Use:   "suggest [memory-id]",
+	Short: "Suggest tags for a memory",
+	Long: `Extract tags for a memory from known languages and frameworks, identifiers
+in code blocks and keywords that are distinctive within the project.
+With --apply the suggestions are added as auto tags.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apply, _ := cmd.Flags().GetBool("apply")
+
+		// Get services
+		services, err := GetServicesForCLI(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+
+		result, err := services.TagService.SuggestTags(context.Background(), domain.MemoryID(args[0]), apply)
+		if err != nil {
+			return fmt.Errorf("failed to suggest tags: %w", err)
+		}
+
+		if len(result.Suggestions) == 0 {
+			fmt.Println("No tags to suggest")
+			return nil
+		}
+
+		for _, suggestion := range result.Suggestions {
+			fmt.Printf("  %-30s %.2f  %s\n", suggestion.Tag, suggestion.Score, suggestion.Source)
+		}
+		if result.Applied {
+			fmt.Printf("✓ Added %d auto tags\n", len(result.Suggestions))
+		}
+		return nil
+	},
+}
+
+var tagReviewCmd = &cobra.Command{
+	Use:   "review",
+	Short: "List memories with unreviewed auto or suggested tags",
+	Long: `List memories whose auto tags or tag suggestions have not been accepted or
+rejected yet. Use 'tag accept' and 'tag reject' to review them.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectID, _ := cmd.Flags().GetString("project")
+
+		// Get services
+		services, err := GetServicesForCLI(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+
+		memories, err := services.TagService.ListPendingReview(context.Background(), optionalProjectID(projectID))
+		if err != nil {
+			return fmt.Errorf("failed to list memories pending tag review: %w", err)
+		}
+
+		if len(memories) == 0 {
+			fmt.Println("No tags to review")
+			return nil
+		}
+
+		for _, memory := range memories {
+			fmt.Printf("%s  %s\n", memory.ID, memory.Title)
+			if manual := memory.TagsWithOrigin(domain.TagOriginManual); len(manual) > 0 {
+				fmt.Printf("  manual:    %s\n", strings.Join(manual, ", "))
+			}
+			if len(memory.AutoTags) > 0 {
+				fmt.Printf("  auto:      %s\n", strings.Join(memory.AutoTags, ", "))
+			}
+			if len(memory.SuggestedTags) > 0 {
+				fmt.Printf("  suggested: %s\n", strings.Join(memory.SuggestedTags, ", "))
+			}
+		}
+		return nil
+	},
+}
+
+var tagAcceptCmd = &cobra.Command{
+	Use:   "accept [memory-id] [tag...]",
+	Short: "Accept auto or suggested tags",
+	Long:  `Turn auto and suggested tags of a memory into manual tags. Without tags all pending tags are accepted.`,
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get services
+		services, err := GetServicesForCLI(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+
+		result, err := services.TagService.AcceptTags(context.Background(), domain.MemoryID(args[0]), args[1:])
+		if err != nil {
+			return fmt.Errorf("failed to accept tags: %w", err)
+		}
+
+		printTagReviewResult(result, "Accepted")
+		return nil
+	},
+}
+
+var tagRejectCmd = &cobra.Command{
+	Use:   "reject [memory-id] [tag...]",
+	Short: "Reject auto or suggested tags",
+	Long:  `Remove auto and suggested tags from a memory. Without tags all pending tags are rejected. Manual tags are never removed.`,
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get services
+		services, err := GetServicesForCLI(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+
+		result, err := services.TagService.RejectTags(context.Background(), domain.MemoryID(args[0]), args[1:])
+		if err != nil {
+			return fmt.Errorf("failed to reject tags: %w", err)
+		}
+
+		printTagReviewResult(result, "Rejected")
+		return nil
+	},
+}
+
+// optionalProjectID converts an optional project flag, treating "" as all projects
+func optionalProjectID(projectID string) *domain.ProjectID {
+	if projectID == "" {
+		return nil
+	}
+	id := domain.ProjectID(projectID)
+	return &id
+}
+
+// printTagChangeResult reports the memories affected by a tag operation
+func printTagChangeResult(result *ports.TagChangeResult, action string) {
+	if result.DryRun {
+		fmt.Printf("Dry run (%s): %d memories would be updated\n", action, result.Updated)
+	} else {
+		fmt.Printf("✓ %s: %d memories updated\n", strings.ToUpper(action[:1])+action[1:], result.Updated)
+	}
+	for _, id := range result.MemoryIDs {
+		fmt.Printf("  %s\n", id)
+	}
+}
+
+// printTagReviewResult reports the outcome of accepting or rejecting tags
+func printTagReviewResult(result *ports.TagReviewResult, action string) {
+	if len(result.Changed) == 0 {
+		fmt.Println("No matching pending tags")
+	} else {
+		fmt.Printf("✓ %s: %s\n", action, strings.Join(result.Changed, ", "))
+	}
+	fmt.Printf("Tags: %s\n", strings.Join(result.Tags, ", "))
+	if len(result.Pending) > 0 {
+		fmt.Printf("Pending: %s\n", strings.Join(result.Pending, ", "))
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(tagCmd)
+
+	// Add subcommands
+	tagCmd.AddCommand(tagListCmd)
+	tagCmd.AddCommand(tagRenameCmd)
+	tagCmd.AddCommand(tagMergeCmd)
+	tagCmd.AddCommand(tagNormalizeCmd)
+	tagCmd.AddCommand(tagSuggestCmd)
+	tagCmd.AddCommand(tagReviewCmd)
+	tagCmd.AddCommand(tagAcceptCmd)
+	tagCmd.AddCommand(tagRejectCmd)
+
+	// Shared flags
+	for _, cmd := range []*cobra.Command{tagListCmd, tagRenameCmd, tagMergeCmd, tagNormalizeCmd, tagReviewCmd} {
+		cmd.Flags().StringP("project", "p", "", "project ID (default: all projects)")
+	}
+	for _, cmd := range []*cobra.Command{tagRenameCmd, tagMergeCmd, tagNormalizeCmd} {
+		cmd.Flags().Bool("dry-run", false, "show the affected memories without changing them")
+	}
+
+	// Flags for list command
+	tagListCmd.Flags().String("prefix", "", "only list this tag and the tags below it")
+	tagListCmd.Flags().String("sort", "name", "sort order: name (tree) or count")
+	tagListCmd.Flags().String("origin", "", "only count tags added by hand (manual) or by the auto-tagger (auto)")
+
+	// Flags for merge command
+	tagMergeCmd.Flags().String("into", "", "target tag")
+
+	// Flags for suggest command
+	tagSuggestCmd.Flags().Bool("apply", false, "add the suggestions to the memory as auto tags")
+}
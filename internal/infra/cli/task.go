@@ -534,6 +534,21 @@ func createTaskStatsCmd() *cobra.Command {
 				return err
 			}
 
+			// Statistics across the projects of a workspace
+			if workspace, _ := cmd.Flags().GetString("workspace"); workspace != "" && projectID == "" {
+				projectIDs, err := workspaceProjectIDs(ctx, cmd, services)
+				if err != nil {
+					return err
+				}
+				stats, err := services.TaskService.GetTaskStatisticsForProjects(ctx, projectIDs)
+				if err != nil {
+					return fmt.Errorf("failed to get task statistics: %w", err)
+				}
+				fmt.Printf("Task Statistics for Workspace: %s\n", workspace)
+				printTaskStatistics(stats)
+				return nil
+			}
+
 			// Get project ID if not provided
 			if projectID == "" {
 				wd, err := os.Getwd()
@@ -557,33 +572,7 @@ func createTaskStatsCmd() *cobra.Command {
 
 				// Display formatted statistics
 				fmt.Printf("Task Statistics for Project: %s\n", projectID)
-				fmt.Println(strings.Repeat("=", 50))
-				fmt.Printf("Total Tasks: %d\n", stats.TotalTasks)
-				fmt.Printf("Completed: %d\n", stats.CompletedTasks)
-				fmt.Printf("In Progress: %d\n", stats.InProgressTasks)
-				fmt.Printf("Todo: %d\n", stats.TodoTasks)
-				fmt.Printf("Blocked: %d\n", stats.BlockedTasks)
-				fmt.Printf("Overdue: %d\n", stats.OverdueTasks)
-				fmt.Printf("Completion Rate: %.1f%%\n", stats.CompletionRate)
-
-				if stats.TotalHours > 0 {
-					fmt.Printf("Total Hours: %d\n", stats.TotalHours)
-					fmt.Printf("Average Hours per Task: %.1f\n", stats.AverageHours)
-				}
-
-				if len(stats.TasksByPriority) > 0 {
-					fmt.Println("\nTasks by Priority:")
-					for priority, count := range stats.TasksByPriority {
-						fmt.Printf("  %s: %d\n", priority, count)
-					}
-				}
-
-				if len(stats.TasksByAssignee) > 0 {
-					fmt.Println("\nTasks by Assignee:")
-					for assignee, count := range stats.TasksByAssignee {
-						fmt.Printf("  %s: %d\n", assignee, count)
-					}
-				}
+				printTaskStatistics(stats)
 
 				return nil
 			}
@@ -610,6 +599,45 @@ func createTaskStatsCmd() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&projectID, "project-id", "", "Project ID (auto-detected if not provided)")
+	cmd.Flags().StringP("workspace", "w", "", "Aggregate the statistics of all projects of this workspace (name or ID)")
 
 	return cmd
 }
+
+// printTaskStatistics prints task statistics below the heading line
+func printTaskStatistics(stats *ports.TaskStatistics) {
+	fmt.Println(strings.Repeat("=", 50))
+	fmt.Printf("Total Tasks: %d\n", stats.TotalTasks)
+	fmt.Printf("Completed: %d\n", stats.CompletedTasks)
+	fmt.Printf("In Progress: %d\n", stats.InProgressTasks)
+	fmt.Printf("Todo: %d\n", stats.TodoTasks)
+	fmt.Printf("Blocked: %d\n", stats.BlockedTasks)
+	fmt.Printf("Overdue: %d\n", stats.OverdueTasks)
+	fmt.Printf("Completion Rate: %.1f%%\n", stats.CompletionRate)
+
+	if stats.TotalHours > 0 {
+		fmt.Printf("Total Hours: %d\n", stats.TotalHours)
+		fmt.Printf("Average Hours per Task: %.1f\n", stats.AverageHours)
+	}
+
+	if len(stats.TasksByPriority) > 0 {
+		fmt.Println("\nTasks by Priority:")
+		for priority, count := range stats.TasksByPriority {
+			fmt.Printf("  %s: %d\n", priority, count)
+		}
+	}
+
+	if len(stats.TasksByAssignee) > 0 {
+		fmt.Println("\nTasks by Assignee:")
+		for assignee, count := range stats.TasksByAssignee {
+			fmt.Printf("  %s: %d\n", assignee, count)
+		}
+	}
+
+	if len(stats.TasksByProject) > 0 {
+		fmt.Println("\nTasks by Project:")
+		for projectID, count := range stats.TasksByProject {
+			fmt.Printf("  %s: %d\n", projectID, count)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/spf13/cobra"
+)
+
+var workspaceCmd = &cobra.Command{
+	Use:   "workspace",
+	Short: "Group related projects into workspaces",
+	Long: `Manage workspaces grouping related projects, such as the services of a monorepo.
+Use --workspace on 'search', 'memory search', 'memory list' and 'task stats' to
+work across all projects of a workspace. Workspaces are referenced by name or ID.`,
+}
+
+var workspaceCreateCmd = &cobra.Command{
+	Use:   "create [name]",
+	Short: "Create a workspace",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		description, _ := cmd.Flags().GetString("description")
+
+		// Get services
+		services, err := GetServicesForCLI(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+
+		workspace, err := services.WorkspaceService.CreateWorkspace(context.Background(), ports.CreateWorkspaceRequest{
+			Name:        args[0],
+			Description: description,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create workspace: %w", err)
+		}
+
+		fmt.Printf("✓ Workspace created: %s (ID: %s)\n", workspace.Name, workspace.ID)
+		return nil
+	},
+}
+
+var workspaceListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List workspaces",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get services
+		services, err := GetServicesForCLI(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+
+		ctx := context.Background()
+		workspaces, err := services.WorkspaceService.ListWorkspaces(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list workspaces: %w", err)
+		}
+
+		if len(workspaces) == 0 {
+			fmt.Println("No workspaces found")
+			return nil
+		}
+
+		fmt.Printf("Workspaces (%d found):\n", len(workspaces))
+		for _, workspace := range workspaces {
+			projects, err := services.WorkspaceService.ListProjects(ctx, workspace.ID)
+			if err != nil {
+				return fmt.Errorf("failed to list workspace projects: %w", err)
+			}
+			fmt.Printf("\n%s (ID: %s)\n", workspace.Name, workspace.ID)
+			if workspace.Description != "" {
+				fmt.Printf("  Description: %s\n", workspace.Description)
+			}
+			fmt.Printf("  Projects: %d\n", len(projects))
+		}
+		return nil
+	},
+}
+
+var workspaceShowCmd = &cobra.Command{
+	Use:   "show [workspace]",
+	Short: "Show a workspace and its projects",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get services
+		services, err := GetServicesForCLI(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+
+		ctx := context.Background()
+		workspace, err := services.WorkspaceService.ResolveWorkspace(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		projects, err := services.WorkspaceService.ListProjects(ctx, workspace.ID)
+		if err != nil {
+			return fmt.Errorf("failed to list workspace projects: %w", err)
+		}
+
+		fmt.Printf("Workspace: %s\n", workspace.Name)
+		fmt.Printf("  ID: %s\n", workspace.ID)
+		if workspace.Description != "" {
+			fmt.Printf("  Description: %s\n", workspace.Description)
+		}
+		fmt.Printf("  Created: %s\n", workspace.CreatedAt.Format("2006-01-02 15:04:05"))
+		fmt.Printf("\nProjects (%d):\n", len(projects))
+		for _, project := range projects {
+			fmt.Printf("  %s (ID: %s) %s\n", project.Name, project.ID, project.Path)
+		}
+		return nil
+	},
+}
+
+var workspaceDeleteCmd = &cobra.Command{
+	Use:   "delete [workspace]",
+	Short: "Delete a workspace",
+	Long:  `Delete a workspace. Its projects are detached and keep their memories, sessions and tasks.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get services
+		services, err := GetServicesForCLI(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+
+		ctx := context.Background()
+		workspace, err := services.WorkspaceService.ResolveWorkspace(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		if err := services.WorkspaceService.DeleteWorkspace(ctx, workspace.ID); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Workspace deleted: %s\n", workspace.Name)
+		return nil
+	},
+}
+
+var workspaceAddCmd = &cobra.Command{
+	Use:   "add [workspace] [project-id...]",
+	Short: "Add projects to a workspace",
+	Long:  `Add projects to a workspace. A project belongs to at most one workspace and is moved from its previous one.`,
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get services
+		services, err := GetServicesForCLI(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+
+		ctx := context.Background()
+		workspace, err := services.WorkspaceService.ResolveWorkspace(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		for _, projectID := range args[1:] {
+			if err := services.WorkspaceService.AddProject(ctx, workspace.ID, domain.ProjectID(projectID)); err != nil {
+				return fmt.Errorf("failed to add project %s: %w", projectID, err)
+			}
+			fmt.Printf("✓ Added %s to workspace %s\n", projectID, workspace.Name)
+		}
+		return nil
+	},
+}
+
+var workspaceRemoveCmd = &cobra.Command{
+	Use:   "remove [project-id...]",
+	Short: "Remove projects from their workspace",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get services
+		services, err := GetServicesForCLI(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to initialize services: %w", err)
+		}
+
+		ctx := context.Background()
+		for _, projectID := range args {
+			if err := services.WorkspaceService.RemoveProject(ctx, domain.ProjectID(projectID)); err != nil {
+				return fmt.Errorf("failed to remove project %s: %w", projectID, err)
+			}
+			fmt.Printf("✓ Removed %s from its workspace\n", projectID)
+		}
+		return nil
+	},
+}
+
+// workspaceProjectIDs resolves the --workspace flag to the IDs of the workspace's
+// projects; it returns nil if the flag is not set
+func workspaceProjectIDs(ctx context.Context, cmd *cobra.Command, services *ServiceContainer) ([]domain.ProjectID, error) {
+	ref, _ := cmd.Flags().GetString("workspace")
+	if ref == "" {
+		return nil, nil
+	}
+
+	workspace, err := services.WorkspaceService.ResolveWorkspace(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return services.WorkspaceService.ProjectIDs(ctx, workspace.ID)
+}
+
+func init() {
+	rootCmd.AddCommand(workspaceCmd)
+	workspaceCmd.AddCommand(workspaceCreateCmd)
+	workspaceCmd.AddCommand(workspaceListCmd)
+	workspaceCmd.AddCommand(workspaceShowCmd)
+	workspaceCmd.AddCommand(workspaceDeleteCmd)
+	workspaceCmd.AddCommand(workspaceAddCmd)
+	workspaceCmd.AddCommand(workspaceRemoveCmd)
+
+	workspaceCreateCmd.Flags().StringP("description", "d", "", "workspace description")
+}
@@ -11,10 +11,21 @@ import (
 
 // Config holds the application configuration
 type Config struct {
-	Database Database `mapstructure:"database" yaml:"database" json:"database"`
-	Ollama   Ollama   `mapstructure:"ollama" yaml:"ollama" json:"ollama"`
-	ChromaDB ChromaDB `mapstructure:"chromadb" yaml:"chromadb" json:"chromadb"`
-	Logging  Logging  `mapstructure:"logging" yaml:"logging" json:"logging"`
+	Database   Database   `mapstructure:"database" yaml:"database" json:"database"`
+	Ollama     Ollama     `mapstructure:"ollama" yaml:"ollama" json:"ollama"`
+	ChromaDB   ChromaDB   `mapstructure:"chromadb" yaml:"chromadb" json:"chromadb"`
+	Logging    Logging    `mapstructure:"logging" yaml:"logging" json:"logging"`
+	Search     Search     `mapstructure:"search" yaml:"search" json:"search"`
+	MCP        MCP        `mapstructure:"mcp" yaml:"mcp" json:"mcp"`
+	Tags       Tags       `mapstructure:"tags" yaml:"tags" json:"tags"`
+	Redaction  Redaction  `mapstructure:"redaction" yaml:"redaction" json:"redaction"`
+	Encryption Encryption `mapstructure:"encryption" yaml:"encryption" json:"encryption"`
+	Provenance Provenance `mapstructure:"provenance" yaml:"provenance" json:"provenance"`
+	Review     Review     `mapstructure:"review" yaml:"review" json:"review"`
+	Scopes     Scopes     `mapstructure:"scopes" yaml:"scopes" json:"scopes"`
+	Sessions   Sessions   `mapstructure:"sessions" yaml:"sessions" json:"sessions"`
+
+	MemoryTypes []MemoryType `mapstructure:"memory_types" yaml:"memory_types" json:"memory_types"`
 }
 
 // Database configuration
@@ -46,6 +57,128 @@ type Logging struct {
 	Format string `mapstructure:"format" yaml:"format" json:"format"` // "json" or "text"
 }
 
+// Search configuration for usage- and dependency-aware relevance scoring
+type Search struct {
+	RecencyWindowDays    int     `mapstructure:"recency_window_days" yaml:"recency_window_days" json:"recency_window_days"`
+	RecencyBoost         float64 `mapstructure:"recency_boost" yaml:"recency_boost" json:"recency_boost"`
+	PopularityBoost      float64 `mapstructure:"popularity_boost" yaml:"popularity_boost" json:"popularity_boost"`
+	PopularitySaturation int     `mapstructure:"popularity_saturation" yaml:"popularity_saturation" json:"popularity_saturation"` // access count
+	MismatchWeight       float64 `mapstructure:"mismatch_weight" yaml:"mismatch_weight" json:"mismatch_weight"`                   // 0-1
+}
+
+// Provenance configuration
+type Provenance struct {
+	// Author is recorded as the creator of memories and sessions; defaults to the OS user
+	Author string `mapstructure:"author" yaml:"author" json:"author"`
+}
+
+// Review configuration for the queue of draft memories
+type Review struct {
+	Enabled bool `mapstructure:"enabled" yaml:"enabled" json:"enabled"`
+	// Sources lists the write channels whose new memories are created as drafts
+	Sources []string `mapstructure:"sources" yaml:"sources" json:"sources"`
+}
+
+// Sessions configuration for the memories written when a session is completed, for
+// session search and for the housekeeping of sessions left open
+type Sessions struct {
+	// SummaryMemory writes a session memory from the progress log, outcome and commits
+	SummaryMemory bool `mapstructure:"summary_memory" yaml:"summary_memory" json:"summary_memory"`
+	// DistillSolutions turns issue entries followed by a solution entry into
+	// error_solution memories
+	DistillSolutions bool `mapstructure:"distill_solutions" yaml:"distill_solutions" json:"distill_solutions"`
+	// SearchIndex embeds task descriptions, progress entries and outcomes so that
+	// searches also find sessions
+	SearchIndex bool `mapstructure:"search_index" yaml:"search_index" json:"search_index"`
+	// StaleAfterHours is the inactivity after which open sessions are reported as stale
+	StaleAfterHours int `mapstructure:"stale_after_hours" yaml:"stale_after_hours" json:"stale_after_hours"`
+	// PauseAfterHours is the inactivity after which active sessions are paused (0: never)
+	PauseAfterHours int `mapstructure:"pause_after_hours" yaml:"pause_after_hours" json:"pause_after_hours"`
+	// AbortAfterHours is the inactivity after which open sessions are aborted (0: never)
+	AbortAfterHours int `mapstructure:"abort_after_hours" yaml:"abort_after_hours" json:"abort_after_hours"`
+}
+
+// Scopes configuration for shared knowledge searched with every project
+type Scopes struct {
+	// Weight scales the similarity of shared memories in project searches (0-1)
+	Weight float64 `mapstructure:"weight" yaml:"weight" json:"weight"`
+	// Include lists the scopes searched with every project, e.g. global or team:backend
+	Include []string `mapstructure:"include" yaml:"include" json:"include"`
+	// Projects lists additional scopes per project ID
+	Projects map[string][]string `mapstructure:"projects" yaml:"projects" json:"projects"`
+}
+
+// MCP server configuration
+type MCP struct {
+	SystemPromptTokenBudget int `mapstructure:"system_prompt_token_budget" yaml:"system_prompt_token_budget" json:"system_prompt_token_budget"`
+}
+
+// Tags configuration for tag normalization
+type Tags struct {
+	Lowercase bool              `mapstructure:"lowercase" yaml:"lowercase" json:"lowercase"`
+	Aliases   map[string]string `mapstructure:"aliases" yaml:"aliases" json:"aliases"` // alias -> canonical tag
+	Auto      AutoTags          `mapstructure:"auto" yaml:"auto" json:"auto"`
+}
+
+// AutoTags configuration for automatic tag extraction on memory creation
+type AutoTags struct {
+	Mode     string  `mapstructure:"mode" yaml:"mode" json:"mode"` // off, suggest or apply
+	MaxTags  int     `mapstructure:"max_tags" yaml:"max_tags" json:"max_tags"`
+	MinScore float64 `mapstructure:"min_score" yaml:"min_score" json:"min_score"`
+}
+
+// Redaction configuration for masking secrets and personal data before storage
+type Redaction struct {
+	Enabled       bool            `mapstructure:"enabled" yaml:"enabled" json:"enabled"`
+	DisabledRules []string        `mapstructure:"disabled_rules" yaml:"disabled_rules" json:"disabled_rules"` // names of built-in rules to skip
+	Allowlist     []string        `mapstructure:"allowlist" yaml:"allowlist" json:"allowlist"`                // regular expressions of values to keep
+	Rules         []RedactionRule `mapstructure:"rules" yaml:"rules" json:"rules"`
+}
+
+// RedactionRule configuration for an additional redaction rule
+type RedactionRule struct {
+	Name       string  `mapstructure:"name" yaml:"name" json:"name"`
+	Pattern    string  `mapstructure:"pattern" yaml:"pattern" json:"pattern"`             // regular expression
+	Group      int     `mapstructure:"group" yaml:"group" json:"group"`                   // submatch to mask, 0 for the whole match
+	MinEntropy float64 `mapstructure:"min_entropy" yaml:"min_entropy" json:"min_entropy"` // bits per character, 0 disables the check
+}
+
+// Encryption configuration for field-level encryption of memories and sessions at rest
+type Encryption struct {
+	Enabled  bool            `mapstructure:"enabled" yaml:"enabled" json:"enabled"`
+	Key      EncryptionKey   `mapstructure:"key" yaml:"key" json:"key"`
+	Previous []EncryptionKey `mapstructure:"previous" yaml:"previous" json:"previous"` // keys before a rotation, only used for decryption
+}
+
+// EncryptionKey configuration for the source of an encryption key; exactly one field is set
+type EncryptionKey struct {
+	File          string `mapstructure:"file" yaml:"file" json:"file"`                               // file holding a base64 or hex encoded 32-byte key
+	Env           string `mapstructure:"env" yaml:"env" json:"env"`                                  // environment variable holding a base64 or hex encoded key
+	PassphraseEnv string `mapstructure:"passphrase_env" yaml:"passphrase_env" json:"passphrase_env"` // environment variable holding a passphrase
+}
+
+// Configured reports whether a source is set for the key
+func (k EncryptionKey) Configured() bool {
+	return k.File != "" || k.Env != "" || k.PassphraseEnv != ""
+}
+
+// MemoryType configuration for a user-defined memory type
+type MemoryType struct {
+	Name        string            `mapstructure:"name" yaml:"name" json:"name"`
+	Description string            `mapstructure:"description" yaml:"description" json:"description"`
+	Project     string            `mapstructure:"project" yaml:"project" json:"project"` // only available in this project if set
+	Fields      []MemoryTypeField `mapstructure:"fields" yaml:"fields" json:"fields"`
+}
+
+// MemoryTypeField configuration for a field of a user-defined memory type
+type MemoryTypeField struct {
+	Name        string   `mapstructure:"name" yaml:"name" json:"name"`
+	Type        string   `mapstructure:"type" yaml:"type" json:"type"` // string, number, integer, boolean, date, enum, list
+	Description string   `mapstructure:"description" yaml:"description" json:"description"`
+	Required    bool     `mapstructure:"required" yaml:"required" json:"required"`
+	Values      []string `mapstructure:"values" yaml:"values" json:"values"` // allowed values of an enum field
+}
+
 // LoadConfig loads configuration from file and environment variables
 func LoadConfig(configPath string) (*Config, error) {
 	// Set defaults
@@ -62,6 +195,29 @@ func LoadConfig(configPath string) (*Config, error) {
 	viper.SetDefault("chromadb.auto_start", false)
 	viper.SetDefault("logging.level", "info")
 	viper.SetDefault("logging.format", "json")
+	viper.SetDefault("search.recency_window_days", 90)
+	viper.SetDefault("search.recency_boost", 0.1)
+	viper.SetDefault("search.popularity_boost", 0.1)
+	viper.SetDefault("search.popularity_saturation", 20)
+	viper.SetDefault("search.mismatch_weight", 0.5)
+	viper.SetDefault("mcp.system_prompt_token_budget", 4000)
+	viper.SetDefault("provenance.author", "")
+	viper.SetDefault("review.enabled", false)
+	viper.SetDefault("review.sources", []string{"mcp"})
+	viper.SetDefault("scopes.weight", 0.8)
+	viper.SetDefault("scopes.include", []string{"global"})
+	viper.SetDefault("sessions.summary_memory", true)
+	viper.SetDefault("sessions.distill_solutions", false)
+	viper.SetDefault("sessions.search_index", true)
+	viper.SetDefault("sessions.stale_after_hours", 24)
+	viper.SetDefault("sessions.pause_after_hours", 72)
+	viper.SetDefault("sessions.abort_after_hours", 0)
+	viper.SetDefault("tags.lowercase", true)
+	viper.SetDefault("tags.auto.mode", "off")
+	viper.SetDefault("tags.auto.max_tags", 5)
+	viper.SetDefault("tags.auto.min_score", 0.3)
+	viper.SetDefault("redaction.enabled", true)
+	viper.SetDefault("encryption.enabled", false)
 
 	// Configure viper
 	viper.SetConfigType("yaml")
@@ -180,6 +336,78 @@ chromadb:
 logging:
   level: "info"    # debug, info, warn, error
   format: "json"   # json, text
+
+search:
+  recency_window_days: 90     # recently updated or accessed memories rank higher
+  recency_boost: 0.1          # 0 disables the recency component
+  popularity_boost: 0.1       # 0 disables the popularity component
+  popularity_saturation: 20   # access count at which popularity stops growing
+  mismatch_weight: 0.5        # similarity factor of memories for dependency versions the project no longer uses
+
+mcp:
+  system_prompt_token_budget: 4000   # approximate size limit of the system prompt resource
+
+provenance:
+  author: ""   # recorded as created_by on memories and sessions; defaults to the OS user
+
+review:
+  enabled: false    # create memories from the sources below as drafts for 'memory review'
+  sources: ["mcp"]  # write channels that need review: cli, mcp, git, import
+
+scopes:
+  weight: 0.8          # similarity factor of shared memories in project searches
+  include: ["global"]  # shared scopes searched with every project: global, team:<name>
+  projects:            # additional scopes per project ID
+    # 20240101120000-abcdefgh: ["team:backend"]
+
+sessions:
+  summary_memory: true       # write a session memory summarising the progress log on completion
+  distill_solutions: false   # also turn issue entries followed by a solution into error_solution memories
+  search_index: true         # embed tasks, progress entries and outcomes so that searches also find sessions
+  stale_after_hours: 24      # warn in session_start and the system prompt about sessions inactive this long (0: off)
+  pause_after_hours: 72      # pause active sessions inactive this long (0: never)
+  abort_after_hours: 0       # abort active and paused sessions inactive this long (0: never)
+
+tags:
+  lowercase: true   # store and match tags in lower case
+  aliases:          # alias: canonical tag, applied when tags are stored or filtered
+    # authn: auth
+    # db: infra/db
+  auto:
+    mode: "off"     # off, suggest (store for review) or apply (add as auto tags)
+    max_tags: 5     # maximum number of extracted tags per memory
+    min_score: 0.3  # minimum score (0-1) of an extracted tag
+
+redaction:
+  enabled: true      # mask API keys, tokens, private keys, passwords and e-mail addresses before storing
+  disabled_rules: [] # built-in rules to skip, e.g. [email]
+  allowlist: []      # regular expressions of values that are never masked, e.g. ["@example\\.com$"]
+  rules:             # additional rules
+    # - name: internal_token
+    #   pattern: "itk_[A-Za-z0-9]{32}"
+
+encryption:
+  enabled: false     # encrypt titles, content, context and session progress in the database (AES-GCM)
+  key:               # exactly one of:
+    # file: "/secure/memory-bank.key"         # base64 or hex encoded 32-byte key
+    # env: "MEMORY_BANK_KEY"                   # environment variable holding the key
+    # passphrase_env: "MEMORY_BANK_PASSPHRASE" # environment variable holding a passphrase (PBKDF2)
+  previous: []       # keys before a rotation; run "memory-bank encrypt --migrate" to re-encrypt
+
+# User-defined memory types with type-specific fields.
+# Field types: string, number, integer, boolean, date, enum (with values), list
+memory_types:
+  # - name: runbook
+  #   description: "Operational procedure for a service"
+  #   fields:
+  #     - name: service
+  #       type: string
+  #       required: true
+  #     - name: severity
+  #       type: enum
+  #       values: [low, medium, high]
+  # - name: api_contract
+  #   project: "my-project-id"   # only available in this project
 `
 
 	// Write configuration file
@@ -235,6 +463,87 @@ func (c *Config) ValidateConfig() error {
 		return fmt.Errorf("ChromaDB timeout must be positive")
 	}
 
+	// Validate search configuration
+	if c.Search.RecencyWindowDays < 0 {
+		return fmt.Errorf("search recency window cannot be negative")
+	}
+	if c.Search.RecencyBoost < 0 || c.Search.PopularityBoost < 0 {
+		return fmt.Errorf("search boosts cannot be negative")
+	}
+	if c.Search.PopularitySaturation < 0 {
+		return fmt.Errorf("search popularity saturation cannot be negative")
+	}
+
+	// Validate session configuration
+	if c.Sessions.StaleAfterHours < 0 || c.Sessions.PauseAfterHours < 0 || c.Sessions.AbortAfterHours < 0 {
+		return fmt.Errorf("session inactivity thresholds cannot be negative")
+	}
+
+	// Validate MCP configuration
+	if c.MCP.SystemPromptTokenBudget < 0 {
+		return fmt.Errorf("mcp system prompt token budget cannot be negative")
+	}
+
+	// Validate tags configuration
+	for alias, canonical := range c.Tags.Aliases {
+		if strings.TrimSpace(alias) == "" || strings.TrimSpace(canonical) == "" {
+			return fmt.Errorf("tag aliases cannot be empty")
+		}
+	}
+	validAutoTagModes := map[string]bool{
+		"off": true, "suggest": true, "apply": true,
+	}
+	if !validAutoTagModes[c.Tags.Auto.Mode] {
+		return fmt.Errorf("invalid auto-tag mode: %s (valid: off, suggest, apply)", c.Tags.Auto.Mode)
+	}
+	if c.Tags.Auto.MaxTags < 0 {
+		return fmt.Errorf("auto-tag max tags cannot be negative")
+	}
+	if c.Tags.Auto.MinScore < 0 || c.Tags.Auto.MinScore > 1 {
+		return fmt.Errorf("auto-tag min score must be between 0 and 1")
+	}
+
+	// Validate redaction configuration; patterns are compiled when the redactor is built
+	for _, rule := range c.Redaction.Rules {
+		if strings.TrimSpace(rule.Name) == "" || strings.TrimSpace(rule.Pattern) == "" {
+			return fmt.Errorf("redaction rules need a name and a pattern")
+		}
+		if rule.Group < 0 || rule.MinEntropy < 0 {
+			return fmt.Errorf("redaction rule %s: group and min entropy cannot be negative", rule.Name)
+		}
+	}
+
+	// Validate encryption configuration; keys are loaded when the database is opened
+	if c.Encryption.Enabled && !c.Encryption.Key.Configured() {
+		return fmt.Errorf("encryption is enabled but no key is configured")
+	}
+	for i, key := range append([]EncryptionKey{c.Encryption.Key}, c.Encryption.Previous...) {
+		if i == 0 && !key.Configured() {
+			continue
+		}
+		sources := 0
+		for _, source := range []string{key.File, key.Env, key.PassphraseEnv} {
+			if source != "" {
+				sources++
+			}
+		}
+		if sources != 1 {
+			return fmt.Errorf("encryption keys need exactly one of file, env or passphrase_env")
+		}
+	}
+
+	// Validate memory types; field definitions are checked when the types are registered
+	for _, memoryType := range c.MemoryTypes {
+		if strings.TrimSpace(memoryType.Name) == "" {
+			return fmt.Errorf("memory type name cannot be empty")
+		}
+		for _, field := range memoryType.Fields {
+			if strings.TrimSpace(field.Name) == "" || strings.TrimSpace(field.Type) == "" {
+				return fmt.Errorf("memory type %s: fields need a name and a type", memoryType.Name)
+			}
+		}
+	}
+
 	// Validate logging configuration
 	validLogLevels := map[string]bool{
 		"debug": true, "info": true, "warn": true, "error": true,
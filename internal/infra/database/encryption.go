@@ -0,0 +1,268 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/joern1811/memory-bank/internal/infra/encryption"
+)
+
+// FieldCipher encrypts and decrypts the text columns of memories and sessions
+type FieldCipher interface {
+	Encrypt(plaintext string) (string, error)
+	Decrypt(value string) (string, error)
+}
+
+// encryptedColumns lists the columns protected by field encryption per table. The
+// fields column of memories holds the values of user-defined types, the description
+// column of sessions the task, outcome and progress, git_state the commit messages
+// and changed files.
+var encryptedColumns = []struct {
+	table   string
+	columns []string
+}{
+	{table: "memories", columns: []string{"title", "content", "context", "fields"}},
+	{table: "sessions", columns: []string{"name", "description", "git_state"}},
+}
+
+// encryptField encrypts a column value. Empty values are stored as they are.
+func encryptField(c FieldCipher, value string) (string, error) {
+	if c == nil || value == "" {
+		return value, nil
+	}
+	encrypted, err := c.Encrypt(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt field: %w", err)
+	}
+	return encrypted, nil
+}
+
+// decryptField decrypts a column value. Plaintext values, e.g. from before encryption
+// was enabled, are returned as they are.
+func decryptField(c FieldCipher, value string) (string, error) {
+	if !encryption.IsEncrypted(value) {
+		return value, nil
+	}
+	if c == nil {
+		return "", fmt.Errorf("value is encrypted but encryption is not enabled")
+	}
+	decrypted, err := c.Decrypt(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt field: %w", err)
+	}
+	return decrypted, nil
+}
+
+// EncryptionStatus counts the non-empty values of a table's encrypted columns
+type EncryptionStatus struct {
+	Table     string         `json:"table"`
+	Values    int            `json:"values"`
+	Plaintext int            `json:"plaintext"`
+	ByKey     map[string]int `json:"by_key"` // encrypted values per key ID
+}
+
+// GetEncryptionStatus reports how many values of each table are stored as plaintext
+// and under which keys the others are encrypted
+func GetEncryptionStatus(ctx context.Context, db *sql.DB) ([]EncryptionStatus, error) {
+	var statuses []EncryptionStatus
+	for _, table := range encryptedColumns {
+		status := EncryptionStatus{Table: table.table, ByKey: make(map[string]int)}
+		err := scanEncryptedColumns(ctx, db, table.table, table.columns, func(_ string, values []string) error {
+			for _, value := range values {
+				if value == "" {
+					continue
+				}
+				status.Values++
+				if id, ok := encryption.ValueKeyID(value); ok {
+					status.ByKey[id]++
+				} else {
+					status.Plaintext++
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// RewriteEncryptedColumns applies rewrite to every non-empty value of the encrypted
+// columns in a single transaction and returns the number of rows changed. It is used
+// to encrypt, re-encrypt after a key rotation, or decrypt existing databases.
+func RewriteEncryptedColumns(ctx context.Context, db *sql.DB, rewrite func(value string) (string, error)) (int, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	changed := 0
+	for _, table := range encryptedColumns {
+		type update struct {
+			id     string
+			values []string
+		}
+		var updates []update
+
+		err := scanEncryptedColumns(ctx, tx, table.table, table.columns, func(id string, values []string) error {
+			rewritten := make([]string, len(values))
+			modified := false
+			for i, value := range values {
+				if value == "" {
+					continue
+				}
+				result, err := rewrite(value)
+				if err != nil {
+					return fmt.Errorf("%s %s: %w", strings.TrimSuffix(table.table, "s"), id, err)
+				}
+				rewritten[i] = result
+				modified = modified || result != value
+			}
+			if modified {
+				updates = append(updates, update{id: id, values: rewritten})
+			}
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		assignments := make([]string, len(table.columns))
+		for i, column := range table.columns {
+			assignments[i] = column + " = ?"
+		}
+		query := fmt.Sprintf("UPDATE %s SET %s WHERE id = ?", table.table, strings.Join(assignments, ", "))
+		for _, u := range updates {
+			args := make([]interface{}, 0, len(u.values)+1)
+			for _, value := range u.values {
+				args = append(args, value)
+			}
+			args = append(args, u.id)
+			if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+				return 0, fmt.Errorf("failed to update %s: %w", table.table, err)
+			}
+		}
+		changed += len(updates)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return changed, nil
+}
+
+// queryer is implemented by both *sql.DB and *sql.Tx
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// scanEncryptedColumns calls fn with the ID and the encrypted column values of every row
+// of a table. NULL values are passed as empty strings.
+func scanEncryptedColumns(ctx context.Context, q queryer, table string, columns []string, fn func(id string, values []string) error) error {
+	selects := make([]string, len(columns))
+	for i, column := range columns {
+		selects[i] = "COALESCE(" + column + ", '')"
+	}
+	rows, err := q.QueryContext(ctx, fmt.Sprintf("SELECT id, %s FROM %s", strings.Join(selects, ", "), table))
+	if err != nil {
+		return fmt.Errorf("failed to query %s: %w", table, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var id string
+		values := make([]string, len(columns))
+		dest := []interface{}{&id}
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return fmt.Errorf("failed to scan %s: %w", table, err)
+		}
+		if err := fn(id, values); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating %s: %w", table, err)
+	}
+	return nil
+}
+
+// LoadKDFParams returns the passphrase key derivation parameters of the database,
+// creating them with a random salt on first use
+func LoadKDFParams(ctx context.Context, db *sql.DB) (*encryption.KDFParams, error) {
+	params, err := readKDFParams(ctx, db)
+	if err != nil || params != nil {
+		return params, err
+	}
+
+	created, err := encryption.NewKDFParams()
+	if err != nil {
+		return nil, err
+	}
+	// INSERT OR IGNORE keeps the parameters of a concurrent first use
+	query := `INSERT OR IGNORE INTO encryption_params (name, value) VALUES (?, ?), (?, ?)`
+	if _, err := db.ExecContext(ctx, query,
+		"kdf_salt", base64.StdEncoding.EncodeToString(created.Salt),
+		"kdf_iterations", strconv.Itoa(created.Iterations),
+	); err != nil {
+		return nil, fmt.Errorf("failed to store key derivation parameters: %w", err)
+	}
+
+	params, err = readKDFParams(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	if params == nil {
+		return nil, fmt.Errorf("key derivation parameters are incomplete")
+	}
+	return params, nil
+}
+
+// readKDFParams reads the key derivation parameters, or nil if there are none yet
+func readKDFParams(ctx context.Context, db *sql.DB) (*encryption.KDFParams, error) {
+	rows, err := db.QueryContext(ctx, `SELECT name, value FROM encryption_params WHERE name IN ('kdf_salt', 'kdf_iterations')`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query key derivation parameters: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	values := make(map[string]string)
+	for rows.Next() {
+		var name, value string
+		if err := rows.Scan(&name, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan key derivation parameters: %w", err)
+		}
+		values[name] = value
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating key derivation parameters: %w", err)
+	}
+	if len(values) < 2 {
+		return nil, nil
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(values["kdf_salt"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid key derivation salt: %w", err)
+	}
+	iterations, err := strconv.Atoi(values["kdf_iterations"])
+	if err != nil || iterations <= 0 {
+		return nil, fmt.Errorf("invalid key derivation iteration count %q", values["kdf_iterations"])
+	}
+	return &encryption.KDFParams{Salt: salt, Iterations: iterations}, nil
+}
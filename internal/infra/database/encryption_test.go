@@ -0,0 +1,195 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/infra/encryption"
+)
+
+func newTestKeyring(t *testing.T, previous ...[]byte) (*encryption.Keyring, []byte) {
+	t.Helper()
+	key, err := encryption.GenerateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	keyring, err := encryption.NewKeyring(key, previous...)
+	if err != nil {
+		t.Fatalf("Failed to create keyring: %v", err)
+	}
+	return keyring, key
+}
+
+func TestEncryptedRepositories(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	keyring, _ := newTestKeyring(t)
+
+	memoryRepo := NewSQLiteMemoryRepository(db, setupTestLogger())
+	sessionRepo := NewSQLiteSessionRepository(db, setupTestLogger())
+
+	// Written before encryption was enabled
+	plainMemory := createTestMemory("proj_1", domain.MemoryTypeDecision)
+	if err := memoryRepo.Store(ctx, plainMemory); err != nil {
+		t.Fatalf("Failed to store memory: %v", err)
+	}
+
+	memoryRepo.SetCipher(keyring)
+	sessionRepo.SetCipher(keyring)
+
+	memory := createTestMemory("proj_1", domain.MemoryTypeErrorSolution)
+	memory.Title = "Database outage"
+	memory.Content = "Primary failed over after disk filled up"
+	memory.Fields = map[string]interface{}{"root_cause": "replica disk full"}
+	if err := memoryRepo.Store(ctx, memory); err != nil {
+		t.Fatalf("Failed to store memory: %v", err)
+	}
+	session := createTestSession("proj_1")
+	session.LogInfo("Rotated the replica credentials")
+	if err := sessionRepo.Store(ctx, session); err != nil {
+		t.Fatalf("Failed to store session: %v", err)
+	}
+
+	// The columns hold ciphertext
+	var title, content, fields, description string
+	if err := db.QueryRow(`SELECT title, content, fields FROM memories WHERE id = ?`, string(memory.ID)).Scan(&title, &content, &fields); err != nil {
+		t.Fatalf("Failed to read memory row: %v", err)
+	}
+	if err := db.QueryRow(`SELECT description FROM sessions WHERE id = ?`, string(session.ID)).Scan(&description); err != nil {
+		t.Fatalf("Failed to read session row: %v", err)
+	}
+	for _, value := range []string{title, content, fields, description} {
+		if !encryption.IsEncrypted(value) || strings.Contains(value, "replica") {
+			t.Errorf("Expected an encrypted column, got %q", value)
+		}
+	}
+
+	// The repositories return plaintext, including rows from before encryption
+	retrieved, err := memoryRepo.GetByID(ctx, memory.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve memory: %v", err)
+	}
+	assertMemoryEqual(t, memory, retrieved)
+	if retrieved.Fields["root_cause"] != "replica disk full" {
+		t.Errorf("Expected decrypted fields, got %v", retrieved.Fields)
+	}
+	if _, err := memoryRepo.GetByID(ctx, plainMemory.ID); err != nil {
+		t.Errorf("Expected plaintext rows to stay readable, got %v", err)
+	}
+	metadata, err := memoryRepo.GetMetadataByIDs(ctx, []domain.MemoryID{memory.ID})
+	if err != nil || len(metadata) != 1 || metadata[0].Title != "Database outage" {
+		t.Errorf("Expected decrypted metadata, got %+v, %v", metadata, err)
+	}
+	retrievedSession, err := sessionRepo.GetByID(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve session: %v", err)
+	}
+	progress := retrievedSession.Progress
+	if len(progress) != len(session.Progress) || progress[len(progress)-1].Message != "Rotated the replica credentials" {
+		t.Errorf("Expected decrypted progress, got %+v", retrievedSession.Progress)
+	}
+
+	// Without the key encrypted rows cannot be read
+	unkeyed := NewSQLiteMemoryRepository(db, setupTestLogger())
+	if _, err := unkeyed.GetByID(ctx, memory.ID); err == nil || !strings.Contains(err.Error(), "encryption is not enabled") {
+		t.Errorf("Expected a decryption error without a key, got %v", err)
+	}
+	otherKeyring, _ := newTestKeyring(t)
+	wrongKey := NewSQLiteSessionRepository(db, setupTestLogger())
+	wrongKey.SetCipher(otherKeyring)
+	if _, err := wrongKey.ListByProject(ctx, "proj_1"); err == nil {
+		t.Error("Expected listing to fail with the wrong key")
+	}
+}
+
+func TestRewriteEncryptedColumns(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	memoryRepo := NewSQLiteMemoryRepository(db, setupTestLogger())
+	sessionRepo := NewSQLiteSessionRepository(db, setupTestLogger())
+
+	memory := createTestMemory("proj_1", domain.MemoryTypeDecision)
+	memory.Context = ""
+	memory.Fields = map[string]interface{}{"status": "accepted"}
+	if err := memoryRepo.Store(ctx, memory); err != nil {
+		t.Fatalf("Failed to store memory: %v", err)
+	}
+	if err := sessionRepo.Store(ctx, createTestSession("proj_1")); err != nil {
+		t.Fatalf("Failed to store session: %v", err)
+	}
+
+	// Encrypt the existing plaintext
+	oldKeyring, oldKey := newTestKeyring(t)
+	changed, err := RewriteEncryptedColumns(ctx, db, oldKeyring.Reencrypt)
+	if err != nil || changed != 2 {
+		t.Fatalf("Expected two rows to be encrypted, got %d, %v", changed, err)
+	}
+	statuses, err := GetEncryptionStatus(ctx, db)
+	if err != nil {
+		t.Fatalf("Failed to get encryption status: %v", err)
+	}
+	for _, status := range statuses {
+		if status.Plaintext != 0 || status.ByKey[oldKeyring.PrimaryKeyID()] != status.Values {
+			t.Errorf("Expected all %s values under the old key, got %+v", status.Table, status)
+		}
+	}
+	if statuses[0].Values != 3 {
+		t.Errorf("Expected the empty context to be skipped, got %d memory values", statuses[0].Values)
+	}
+
+	// Rotate to a new key
+	newKeyring, _ := newTestKeyring(t, oldKey)
+	if changed, err := RewriteEncryptedColumns(ctx, db, newKeyring.Reencrypt); err != nil || changed != 2 {
+		t.Fatalf("Expected two rows to be re-encrypted, got %d, %v", changed, err)
+	}
+	if changed, _ := RewriteEncryptedColumns(ctx, db, newKeyring.Reencrypt); changed != 0 {
+		t.Errorf("Expected a second run to change nothing, got %d", changed)
+	}
+	memoryRepo.SetCipher(newKeyring)
+	if retrieved, err := memoryRepo.GetByID(ctx, memory.ID); err != nil || retrieved.Title != memory.Title {
+		t.Errorf("Expected the rotated memory to decrypt, got %+v, %v", retrieved, err)
+	}
+
+	// A failing rewrite leaves the database unchanged
+	if _, err := RewriteEncryptedColumns(ctx, db, oldKeyring.Decrypt); err == nil {
+		t.Error("Expected decrypting with the old key only to fail")
+	}
+
+	// Decrypt everything
+	if changed, err := RewriteEncryptedColumns(ctx, db, newKeyring.Decrypt); err != nil || changed != 2 {
+		t.Fatalf("Expected two rows to be decrypted, got %d, %v", changed, err)
+	}
+	unkeyed := NewSQLiteMemoryRepository(db, setupTestLogger())
+	if retrieved, err := unkeyed.GetByID(ctx, memory.ID); err != nil || retrieved.Content != memory.Content || retrieved.Fields["status"] != "accepted" {
+		t.Errorf("Expected plaintext after decryption, got %+v, %v", retrieved, err)
+	}
+}
+
+func TestLoadKDFParams(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	first, err := LoadKDFParams(ctx, db)
+	if err != nil {
+		t.Fatalf("Failed to load key derivation parameters: %v", err)
+	}
+	if len(first.Salt) == 0 || first.Iterations != encryption.DefaultKDFIterations {
+		t.Errorf("Unexpected parameters %+v", first)
+	}
+
+	second, err := LoadKDFParams(ctx, db)
+	if err != nil {
+		t.Fatalf("Failed to load key derivation parameters: %v", err)
+	}
+	if !bytes.Equal(first.Salt, second.Salt) {
+		t.Error("Expected the salt to be kept")
+	}
+}
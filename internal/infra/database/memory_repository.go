@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/joern1811/memory-bank/internal/domain"
 	"github.com/joern1811/memory-bank/internal/ports"
@@ -100,6 +101,7 @@ func initializeTables(db *sql.DB) error { //nolint:unused
 // SQLiteMemoryRepository implements MemoryRepository using SQLite
 type SQLiteMemoryRepository struct {
 	db     *sql.DB
+	cipher FieldCipher
 	logger *logrus.Logger
 }
 
@@ -111,6 +113,36 @@ func NewSQLiteMemoryRepository(db *sql.DB, logger *logrus.Logger) *SQLiteMemoryR
 	}
 }
 
+// SetCipher enables encryption of the title, content, context and fields columns.
+// Values stored as plaintext before remain readable.
+func (r *SQLiteMemoryRepository) SetCipher(cipher FieldCipher) {
+	r.cipher = cipher
+}
+
+// encryptText encrypts the title, content, context and type-specific fields of a
+// memory for storage. Fields are NULL if there are none.
+func (r *SQLiteMemoryRepository) encryptText(memory *domain.Memory) ([]interface{}, error) {
+	values := make([]interface{}, 0, 4)
+	for _, value := range []string{memory.Title, memory.Content, memory.Context} {
+		encrypted, err := encryptField(r.cipher, value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt memory %s: %w", memory.ID, err)
+		}
+		values = append(values, encrypted)
+	}
+
+	fieldsJSON, err := marshalFields(memory.Fields)
+	if err != nil {
+		return nil, err
+	}
+	if fieldsJSON != nil {
+		if fieldsJSON, err = encryptField(r.cipher, fieldsJSON.(string)); err != nil {
+			return nil, fmt.Errorf("failed to encrypt memory %s: %w", memory.ID, err)
+		}
+	}
+	return append(values, fieldsJSON), nil
+}
+
 // Store saves a memory to the database
 func (r *SQLiteMemoryRepository) Store(ctx context.Context, memory *domain.Memory) error {
 	r.logger.WithField("memory_id", memory.ID).Debug("Storing memory")
@@ -120,11 +152,53 @@ func (r *SQLiteMemoryRepository) Store(ctx context.Context, memory *domain.Memor
 		return fmt.Errorf("failed to marshal tags: %w", err)
 	}
 
+	anchorsJSON, err := marshalAnchors(memory.Anchors)
+	if err != nil {
+		return err
+	}
+
+	autoTagsJSON, err := marshalOptionalTags(memory.AutoTags)
+	if err != nil {
+		return err
+	}
+
+	suggestedTagsJSON, err := marshalOptionalTags(memory.SuggestedTags)
+	if err != nil {
+		return err
+	}
+
+	redactionsJSON, err := marshalRedactions(memory.Redactions)
+	if err != nil {
+		return err
+	}
+
+	provenanceJSON, err := marshalProvenance(memory.Provenance)
+	if err != nil {
+		return err
+	}
+
+	lastModifiedByJSON, err := marshalProvenance(memory.LastModifiedBy)
+	if err != nil {
+		return err
+	}
+
+	appliesToJSON, err := marshalAppliesTo(memory.AppliesTo)
+	if err != nil {
+		return err
+	}
+
+	text, err := r.encryptText(memory)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		INSERT INTO memories (
 			id, project_id, session_id, type, title, content, context, 
-			tags, created_at, updated_at, has_embedding
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			tags, created_at, updated_at, has_embedding, pinned, anchors,
+			auto_tags, suggested_tags, fields, redactions, provenance, last_modified_by,
+			review_status, reviewed_at, applies_to
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	var sessionID interface{}
@@ -137,13 +211,24 @@ func (r *SQLiteMemoryRepository) Store(ctx context.Context, memory *domain.Memor
 		string(memory.ProjectID),
 		sessionID,
 		string(memory.Type),
-		memory.Title,
-		memory.Content,
-		memory.Context,
+		text[0], // title
+		text[1], // content
+		text[2], // context
 		string(tagsJSON),
 		memory.CreatedAt,
 		memory.UpdatedAt,
 		memory.HasEmbedding,
+		memory.Pinned,
+		anchorsJSON,
+		autoTagsJSON,
+		suggestedTagsJSON,
+		text[3], // fields
+		redactionsJSON,
+		provenanceJSON,
+		lastModifiedByJSON,
+		reviewStatusValue(memory.ReviewStatus),
+		memory.ReviewedAt,
+		appliesToJSON,
 	)
 
 	if err != nil {
@@ -158,8 +243,7 @@ func (r *SQLiteMemoryRepository) GetByID(ctx context.Context, id domain.MemoryID
 	r.logger.WithField("memory_id", id).Debug("Getting memory by ID")
 
 	query := `
-		SELECT id, project_id, session_id, type, title, content, context, 
-		       tags, created_at, updated_at, has_embedding
+		SELECT ` + memoryColumns + `
 		FROM memories 
 		WHERE id = ?
 	`
@@ -177,10 +261,53 @@ func (r *SQLiteMemoryRepository) Update(ctx context.Context, memory *domain.Memo
 		return fmt.Errorf("failed to marshal tags: %w", err)
 	}
 
+	anchorsJSON, err := marshalAnchors(memory.Anchors)
+	if err != nil {
+		return err
+	}
+
+	autoTagsJSON, err := marshalOptionalTags(memory.AutoTags)
+	if err != nil {
+		return err
+	}
+
+	suggestedTagsJSON, err := marshalOptionalTags(memory.SuggestedTags)
+	if err != nil {
+		return err
+	}
+
+	redactionsJSON, err := marshalRedactions(memory.Redactions)
+	if err != nil {
+		return err
+	}
+
+	provenanceJSON, err := marshalProvenance(memory.Provenance)
+	if err != nil {
+		return err
+	}
+
+	lastModifiedByJSON, err := marshalProvenance(memory.LastModifiedBy)
+	if err != nil {
+		return err
+	}
+
+	appliesToJSON, err := marshalAppliesTo(memory.AppliesTo)
+	if err != nil {
+		return err
+	}
+
+	text, err := r.encryptText(memory)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		UPDATE memories 
 		SET project_id = ?, session_id = ?, type = ?, title = ?, content = ?, 
-		    context = ?, tags = ?, updated_at = ?, has_embedding = ?
+		    context = ?, tags = ?, updated_at = ?, has_embedding = ?, pinned = ?, anchors = ?,
+		    auto_tags = ?, suggested_tags = ?, fields = ?, redactions = ?,
+		    provenance = COALESCE(provenance, ?), last_modified_by = ?,
+		    review_status = ?, reviewed_at = ?, applies_to = ?
 		WHERE id = ?
 	`
 
@@ -193,12 +320,23 @@ func (r *SQLiteMemoryRepository) Update(ctx context.Context, memory *domain.Memo
 		string(memory.ProjectID),
 		sessionID,
 		string(memory.Type),
-		memory.Title,
-		memory.Content,
-		memory.Context,
+		text[0], // title
+		text[1], // content
+		text[2], // context
 		string(tagsJSON),
 		memory.UpdatedAt,
 		memory.HasEmbedding,
+		memory.Pinned,
+		anchorsJSON,
+		autoTagsJSON,
+		suggestedTagsJSON,
+		text[3], // fields
+		redactionsJSON,
+		provenanceJSON,
+		lastModifiedByJSON,
+		reviewStatusValue(memory.ReviewStatus),
+		memory.ReviewedAt,
+		appliesToJSON,
 		string(memory.ID),
 	)
 
@@ -246,8 +384,7 @@ func (r *SQLiteMemoryRepository) ListByProject(ctx context.Context, projectID do
 	r.logger.WithField("project_id", projectID).Debug("Listing memories by project")
 
 	query := `
-		SELECT id, project_id, session_id, type, title, content, context, 
-		       tags, created_at, updated_at, has_embedding
+		SELECT ` + memoryColumns + `
 		FROM memories 
 		WHERE project_id = ?
 		ORDER BY created_at DESC
@@ -274,8 +411,7 @@ func (r *SQLiteMemoryRepository) ListByType(ctx context.Context, projectID domai
 	}).Debug("Listing memories by type")
 
 	query := `
-		SELECT id, project_id, session_id, type, title, content, context, 
-		       tags, created_at, updated_at, has_embedding
+		SELECT ` + memoryColumns + `
 		FROM memories 
 		WHERE project_id = ? AND type = ?
 		ORDER BY created_at DESC
@@ -294,7 +430,7 @@ func (r *SQLiteMemoryRepository) ListByType(ctx context.Context, projectID domai
 	return r.scanMemories(rows)
 }
 
-// ListByTags retrieves memories that have all specified tags
+// ListByTags retrieves memories that have all specified tags or tags below them in the hierarchy
 func (r *SQLiteMemoryRepository) ListByTags(ctx context.Context, projectID domain.ProjectID, tags domain.Tags) ([]*domain.Memory, error) {
 	r.logger.WithFields(logrus.Fields{
 		"project_id": projectID,
@@ -304,8 +440,7 @@ func (r *SQLiteMemoryRepository) ListByTags(ctx context.Context, projectID domai
 	// For SQLite, we'll use JSON functions to query tags
 	// This is a simplified implementation - in production you might want a tags table
 	query := `
-		SELECT id, project_id, session_id, type, title, content, context, 
-		       tags, created_at, updated_at, has_embedding
+		SELECT ` + memoryColumns + `
 		FROM memories 
 		WHERE project_id = ?
 		ORDER BY created_at DESC
@@ -326,17 +461,10 @@ func (r *SQLiteMemoryRepository) ListByTags(ctx context.Context, projectID domai
 		return nil, err
 	}
 
-	// Filter memories that contain all required tags
+	// Filter memories that match all required tags; a tag also matches the tags below it
 	var filtered []*domain.Memory
 	for _, memory := range memories {
-		hasAllTags := true
-		for _, requiredTag := range tags {
-			if !memory.Tags.Contains(requiredTag) {
-				hasAllTags = false
-				break
-			}
-		}
-		if hasAllTags {
+		if memory.Tags.MatchesAll(tags) {
 			filtered = append(filtered, memory)
 		}
 	}
@@ -344,13 +472,176 @@ func (r *SQLiteMemoryRepository) ListByTags(ctx context.Context, projectID domai
 	return filtered, nil
 }
 
+// ListPinned retrieves all pinned memories for a project
+func (r *SQLiteMemoryRepository) ListPinned(ctx context.Context, projectID domain.ProjectID) ([]*domain.Memory, error) {
+	r.logger.WithField("project_id", projectID).Debug("Listing pinned memories")
+
+	query := `
+		SELECT ` + memoryColumns + `
+		FROM memories 
+		WHERE project_id = ? AND pinned = TRUE
+		ORDER BY updated_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, string(projectID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pinned memories: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			r.logger.WithError(err).Warn("Failed to close rows")
+		}
+	}()
+
+	return r.scanMemories(rows)
+}
+
+// ListAnchored retrieves all memories linked to source code, optionally limited to a project
+func (r *SQLiteMemoryRepository) ListAnchored(ctx context.Context, projectID *domain.ProjectID) ([]*domain.Memory, error) {
+	r.logger.WithField("project_id", projectID).Debug("Listing anchored memories")
+
+	query := `
+		SELECT ` + memoryColumns + `
+		FROM memories 
+		WHERE anchors IS NOT NULL`
+	var args []interface{}
+	if projectID != nil {
+		query += ` AND project_id = ?`
+		args = append(args, string(*projectID))
+	}
+	query += ` ORDER BY updated_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query anchored memories: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			r.logger.WithError(err).Warn("Failed to close rows")
+		}
+	}()
+
+	return r.scanMemories(rows)
+}
+
+// ListAll retrieves all memories, optionally limited to a project
+func (r *SQLiteMemoryRepository) ListAll(ctx context.Context, projectID *domain.ProjectID) ([]*domain.Memory, error) {
+	r.logger.WithField("project_id", projectID).Debug("Listing all memories")
+
+	query := `
+		SELECT ` + memoryColumns + `
+		FROM memories`
+	var args []interface{}
+	if projectID != nil {
+		query += ` WHERE project_id = ?`
+		args = append(args, string(*projectID))
+	}
+	query += ` ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query memories: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			r.logger.WithError(err).Warn("Failed to close rows")
+		}
+	}()
+
+	return r.scanMemories(rows)
+}
+
+// ListTagged retrieves all memories with at least one tag, optionally limited to a project
+func (r *SQLiteMemoryRepository) ListTagged(ctx context.Context, projectID *domain.ProjectID) ([]*domain.Memory, error) {
+	r.logger.WithField("project_id", projectID).Debug("Listing tagged memories")
+
+	query := `
+		SELECT ` + memoryColumns + `
+		FROM memories 
+		WHERE tags IS NOT NULL AND tags NOT IN ('', '[]', 'null')`
+	var args []interface{}
+	if projectID != nil {
+		query += ` AND project_id = ?`
+		args = append(args, string(*projectID))
+	}
+	query += ` ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tagged memories: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			r.logger.WithError(err).Warn("Failed to close rows")
+		}
+	}()
+
+	return r.scanMemories(rows)
+}
+
+// ListPendingTagReview retrieves memories with auto-applied or suggested tags, optionally limited to a project
+func (r *SQLiteMemoryRepository) ListPendingTagReview(ctx context.Context, projectID *domain.ProjectID) ([]*domain.Memory, error) {
+	r.logger.WithField("project_id", projectID).Debug("Listing memories with tags pending review")
+
+	query := `
+		SELECT ` + memoryColumns + `
+		FROM memories 
+		WHERE (auto_tags IS NOT NULL OR suggested_tags IS NOT NULL)`
+	var args []interface{}
+	if projectID != nil {
+		query += ` AND project_id = ?`
+		args = append(args, string(*projectID))
+	}
+	query += ` ORDER BY created_at DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query memories pending tag review: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			r.logger.WithError(err).Warn("Failed to close rows")
+		}
+	}()
+
+	return r.scanMemories(rows)
+}
+
+// ListDrafts retrieves the memories waiting in the review queue, oldest first,
+// optionally limited to a project
+func (r *SQLiteMemoryRepository) ListDrafts(ctx context.Context, projectID *domain.ProjectID) ([]*domain.Memory, error) {
+	r.logger.WithField("project_id", projectID).Debug("Listing draft memories")
+
+	query := `
+		SELECT ` + memoryColumns + `
+		FROM memories 
+		WHERE review_status = ?`
+	args := []interface{}{string(domain.ReviewStatusDraft)}
+	if projectID != nil {
+		query += ` AND project_id = ?`
+		args = append(args, string(*projectID))
+	}
+	query += ` ORDER BY created_at ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query draft memories: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			r.logger.WithError(err).Warn("Failed to close rows")
+		}
+	}()
+
+	return r.scanMemories(rows)
+}
+
 // ListBySession retrieves all memories for a session
 func (r *SQLiteMemoryRepository) ListBySession(ctx context.Context, sessionID domain.SessionID) ([]*domain.Memory, error) {
 	r.logger.WithField("session_id", sessionID).Debug("Listing memories by session")
 
 	query := `
-		SELECT id, project_id, session_id, type, title, content, context, 
-		       tags, created_at, updated_at, has_embedding
+		SELECT ` + memoryColumns + `
 		FROM memories 
 		WHERE session_id = ?
 		ORDER BY created_at DESC
@@ -369,11 +660,61 @@ func (r *SQLiteMemoryRepository) ListBySession(ctx context.Context, sessionID do
 	return r.scanMemories(rows)
 }
 
+// memoryColumns is the column list expected by scanMemory and scanMemories
+const memoryColumns = `id, project_id, session_id, type, title, content, context,
+		       tags, created_at, updated_at, has_embedding, access_count, last_accessed_at, pinned, anchors,
+		       auto_tags, suggested_tags, fields, redactions, provenance, last_modified_by,
+		       review_status, reviewed_at, applies_to`
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
 // scanMemory scans a single memory from a row
 func (r *SQLiteMemoryRepository) scanMemory(row *sql.Row) (*domain.Memory, error) {
+	memory, err := r.scanMemoryRow(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("memory not found")
+		}
+		return nil, fmt.Errorf("failed to scan memory: %w", err)
+	}
+	return memory, nil
+}
+
+// scanMemories scans multiple memories from rows
+func (r *SQLiteMemoryRepository) scanMemories(rows *sql.Rows) ([]*domain.Memory, error) {
+	var memories []*domain.Memory
+
+	for rows.Next() {
+		memory, err := r.scanMemoryRow(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan memory: %w", err)
+		}
+		memories = append(memories, memory)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return memories, nil
+}
+
+// scanMemoryRow scans the memoryColumns of the current row into a memory
+func (r *SQLiteMemoryRepository) scanMemoryRow(row rowScanner) (*domain.Memory, error) {
 	var memory domain.Memory
 	var sessionID sql.NullString
 	var tagsJSON string
+	var lastAccessedAt sql.NullTime
+	var anchorsJSON sql.NullString
+	var autoTagsJSON, suggestedTagsJSON sql.NullString
+	var fieldsJSON sql.NullString
+	var redactionsJSON sql.NullString
+	var provenanceJSON, lastModifiedByJSON sql.NullString
+	var reviewedAt sql.NullTime
+	var appliesToJSON sql.NullString
 
 	err := row.Scan(
 		&memory.ID,
@@ -387,13 +728,29 @@ func (r *SQLiteMemoryRepository) scanMemory(row *sql.Row) (*domain.Memory, error
 		&memory.CreatedAt,
 		&memory.UpdatedAt,
 		&memory.HasEmbedding,
+		&memory.AccessCount,
+		&lastAccessedAt,
+		&memory.Pinned,
+		&anchorsJSON,
+		&autoTagsJSON,
+		&suggestedTagsJSON,
+		&fieldsJSON,
+		&redactionsJSON,
+		&provenanceJSON,
+		&lastModifiedByJSON,
+		&memory.ReviewStatus,
+		&reviewedAt,
+		&appliesToJSON,
 	)
-
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("memory not found")
+		return nil, err
+	}
+
+	// Decrypt text columns
+	for _, field := range []*string{&memory.Title, &memory.Content, &memory.Context} {
+		if *field, err = decryptField(r.cipher, *field); err != nil {
+			return nil, fmt.Errorf("failed to decrypt memory %s: %w", memory.ID, err)
 		}
-		return nil, fmt.Errorf("failed to scan memory: %w", err)
 	}
 
 	// Handle nullable session ID
@@ -402,62 +759,176 @@ func (r *SQLiteMemoryRepository) scanMemory(row *sql.Row) (*domain.Memory, error
 		memory.SessionID = &sid
 	}
 
+	// Handle nullable access time
+	if lastAccessedAt.Valid {
+		accessed := lastAccessedAt.Time
+		memory.LastAccessedAt = &accessed
+	}
+
 	// Unmarshal tags
 	if err := json.Unmarshal([]byte(tagsJSON), &memory.Tags); err != nil {
 		r.logger.WithError(err).Warn("Failed to unmarshal tags, using empty tags")
 		memory.Tags = make(domain.Tags, 0)
 	}
 
-	return &memory, nil
-}
-
-// scanMemories scans multiple memories from rows
-func (r *SQLiteMemoryRepository) scanMemories(rows *sql.Rows) ([]*domain.Memory, error) {
-	var memories []*domain.Memory
-
-	for rows.Next() {
-		var memory domain.Memory
-		var sessionID sql.NullString
-		var tagsJSON string
+	// Unmarshal anchors
+	if anchorsJSON.Valid {
+		if err := json.Unmarshal([]byte(anchorsJSON.String), &memory.Anchors); err != nil {
+			r.logger.WithError(err).Warn("Failed to unmarshal anchors, ignoring them")
+			memory.Anchors = nil
+		}
+	}
 
-		err := rows.Scan(
-			&memory.ID,
-			&memory.ProjectID,
-			&sessionID,
-			&memory.Type,
-			&memory.Title,
-			&memory.Content,
-			&memory.Context,
-			&tagsJSON,
-			&memory.CreatedAt,
-			&memory.UpdatedAt,
-			&memory.HasEmbedding,
-		)
+	// Unmarshal tag origins
+	memory.AutoTags = r.unmarshalOptionalTags(autoTagsJSON)
+	memory.SuggestedTags = r.unmarshalOptionalTags(suggestedTagsJSON)
 
+	// Decrypt and unmarshal type-specific fields. Rewriting the encrypted columns
+	// stores missing fields as an empty string.
+	if fieldsJSON.Valid && fieldsJSON.String != "" {
+		fields, err := decryptField(r.cipher, fieldsJSON.String)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan memory: %w", err)
+			return nil, fmt.Errorf("failed to decrypt memory %s: %w", memory.ID, err)
 		}
+		if err := json.Unmarshal([]byte(fields), &memory.Fields); err != nil {
+			r.logger.WithError(err).Warn("Failed to unmarshal fields, ignoring them")
+			memory.Fields = nil
+		}
+	}
 
-		// Handle nullable session ID
-		if sessionID.Valid {
-			sid := domain.SessionID(sessionID.String)
-			memory.SessionID = &sid
+	// Unmarshal the redaction record
+	if redactionsJSON.Valid {
+		if err := json.Unmarshal([]byte(redactionsJSON.String), &memory.Redactions); err != nil {
+			r.logger.WithError(err).Warn("Failed to unmarshal redactions, ignoring them")
+			memory.Redactions = nil
 		}
+	}
+
+	// Unmarshal provenance
+	memory.Provenance = unmarshalProvenance(r.logger, provenanceJSON)
+	memory.LastModifiedBy = unmarshalProvenance(r.logger, lastModifiedByJSON)
 
-		// Unmarshal tags
-		if err := json.Unmarshal([]byte(tagsJSON), &memory.Tags); err != nil {
-			r.logger.WithError(err).Warn("Failed to unmarshal tags, using empty tags")
-			memory.Tags = make(domain.Tags, 0)
+	// Handle nullable review time
+	if reviewedAt.Valid {
+		reviewed := reviewedAt.Time
+		memory.ReviewedAt = &reviewed
+	}
+
+	// Unmarshal dependency constraints
+	if appliesToJSON.Valid {
+		if err := json.Unmarshal([]byte(appliesToJSON.String), &memory.AppliesTo); err != nil {
+			r.logger.WithError(err).Warn("Failed to unmarshal dependency constraints, ignoring them")
+			memory.AppliesTo = nil
 		}
+	}
 
-		memories = append(memories, &memory)
+	return &memory, nil
+}
+
+// reviewStatusValue returns the stored review status, treating an unset status as approved
+func reviewStatusValue(status domain.ReviewStatus) string {
+	if status == "" {
+		return string(domain.ReviewStatusApproved)
 	}
+	return string(status)
+}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating rows: %w", err)
+// marshalFields encodes type-specific fields as a JSON object, or NULL if there are none
+func marshalFields(fields map[string]interface{}) (interface{}, error) {
+	if len(fields) == 0 {
+		return nil, nil
 	}
+	fieldsJSON, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal fields: %w", err)
+	}
+	return string(fieldsJSON), nil
+}
 
-	return memories, nil
+// marshalRedactions encodes the redaction record as a JSON array, or NULL if nothing was redacted
+func marshalRedactions(redactions []domain.Redaction) (interface{}, error) {
+	if len(redactions) == 0 {
+		return nil, nil
+	}
+	redactionsJSON, err := json.Marshal(redactions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal redactions: %w", err)
+	}
+	return string(redactionsJSON), nil
+}
+
+// marshalAppliesTo encodes the dependency constraints as a JSON array, or NULL if there are none
+func marshalAppliesTo(constraints []domain.DependencyConstraint) (interface{}, error) {
+	if len(constraints) == 0 {
+		return nil, nil
+	}
+	appliesToJSON, err := json.Marshal(constraints)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal dependency constraints: %w", err)
+	}
+	return string(appliesToJSON), nil
+}
+
+// marshalProvenance encodes a provenance record as a JSON object, or NULL if there is none
+func marshalProvenance(provenance *domain.Provenance) (interface{}, error) {
+	if provenance == nil || provenance.IsEmpty() {
+		return nil, nil
+	}
+	provenanceJSON, err := json.Marshal(provenance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal provenance: %w", err)
+	}
+	return string(provenanceJSON), nil
+}
+
+// unmarshalProvenance decodes a provenance record, or returns nil for NULL and invalid values
+func unmarshalProvenance(logger *logrus.Logger, provenanceJSON sql.NullString) *domain.Provenance {
+	if !provenanceJSON.Valid {
+		return nil
+	}
+	var provenance domain.Provenance
+	if err := json.Unmarshal([]byte(provenanceJSON.String), &provenance); err != nil {
+		logger.WithError(err).Warn("Failed to unmarshal provenance, ignoring it")
+		return nil
+	}
+	return &provenance
+}
+
+// marshalOptionalTags encodes tags as a JSON array, or NULL if there are none
+func marshalOptionalTags(tags domain.Tags) (interface{}, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	tagsJSON, err := json.Marshal(tags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tags: %w", err)
+	}
+	return string(tagsJSON), nil
+}
+
+// unmarshalOptionalTags decodes a nullable JSON array of tags
+func (r *SQLiteMemoryRepository) unmarshalOptionalTags(tagsJSON sql.NullString) domain.Tags {
+	if !tagsJSON.Valid {
+		return nil
+	}
+	var tags domain.Tags
+	if err := json.Unmarshal([]byte(tagsJSON.String), &tags); err != nil {
+		r.logger.WithError(err).Warn("Failed to unmarshal tags, ignoring them")
+		return nil
+	}
+	return tags
+}
+
+// marshalAnchors encodes anchors as a JSON array, or NULL for memories without anchors
+func marshalAnchors(anchors []domain.CodeAnchor) (interface{}, error) {
+	if len(anchors) == 0 {
+		return nil, nil
+	}
+	anchorsJSON, err := json.Marshal(anchors)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal anchors: %w", err)
+	}
+	return string(anchorsJSON), nil
 }
 
 // GetByIDs retrieves multiple memories by their IDs in a single batch query
@@ -477,8 +948,7 @@ func (r *SQLiteMemoryRepository) GetByIDs(ctx context.Context, ids []domain.Memo
 	}
 
 	query := fmt.Sprintf(`
-		SELECT id, project_id, session_id, type, title, content, context, 
-		       tags, created_at, updated_at, has_embedding
+		SELECT `+memoryColumns+`
 		FROM memories 
 		WHERE id IN (%s)
 	`, strings.Join(placeholders, ","))
@@ -544,6 +1014,9 @@ func (r *SQLiteMemoryRepository) GetMetadataByIDs(ctx context.Context, ids []dom
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan metadata: %w", err)
 		}
+		if meta.Title, err = decryptField(r.cipher, meta.Title); err != nil {
+			return nil, fmt.Errorf("failed to decrypt memory %s: %w", meta.ID, err)
+		}
 
 		// Parse tags JSON
 		if err := json.Unmarshal([]byte(tagsJSON), &meta.Tags); err != nil {
@@ -562,6 +1035,68 @@ func (r *SQLiteMemoryRepository) GetMetadataByIDs(ctx context.Context, ids []dom
 	return metadata, nil
 }
 
+// RecordAccess increments the access counter and sets the last accessed time for the given memories
+func (r *SQLiteMemoryRepository) RecordAccess(ctx context.Context, ids []domain.MemoryID, accessedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	r.logger.WithField("batch_size", len(ids)).Debug("Recording memory access")
+
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, 0, len(ids)+1)
+	args = append(args, accessedAt)
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args = append(args, string(id))
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE memories
+		SET access_count = access_count + 1, last_accessed_at = ?
+		WHERE id IN (%s)
+	`, strings.Join(placeholders, ","))
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to record memory access: %w", err)
+	}
+
+	return nil
+}
+
+// ListUnused retrieves memories not accessed since the given time, optionally limited to a project.
+// Memories that were never accessed qualify once they were created before that time.
+func (r *SQLiteMemoryRepository) ListUnused(ctx context.Context, projectID *domain.ProjectID, since time.Time) ([]*domain.Memory, error) {
+	r.logger.WithFields(logrus.Fields{
+		"project_id": projectID,
+		"since":      since,
+	}).Debug("Listing unused memories")
+
+	query := `
+		SELECT ` + memoryColumns + `
+		FROM memories
+		WHERE COALESCE(last_accessed_at, created_at) < ?
+	`
+	args := []interface{}{since}
+	if projectID != nil {
+		query += " AND project_id = ?"
+		args = append(args, string(*projectID))
+	}
+	query += " ORDER BY COALESCE(last_accessed_at, created_at) ASC"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unused memories: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			r.logger.WithError(err).Warn("Failed to close rows")
+		}
+	}()
+
+	return r.scanMemories(rows)
+}
+
 // InitializeSchema creates the necessary tables
 func (r *SQLiteMemoryRepository) InitializeSchema(ctx context.Context) error {
 	r.logger.Info("Initializing memory database schema")
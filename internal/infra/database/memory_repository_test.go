@@ -412,3 +412,473 @@ func TestSQLiteMemoryRepository_SessionHandling(t *testing.T) {
 		t.Errorf("Expected SessionID %s, got %s", sessionID, *retrievedWithSession.SessionID)
 	}
 }
+
+func TestSQLiteMemoryRepository_RecordAccess(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewSQLiteMemoryRepository(db, setupTestLogger())
+	ctx := context.Background()
+
+	memory := createTestMemory("proj_1", domain.MemoryTypeDecision)
+	if err := repo.Store(ctx, memory); err != nil {
+		t.Fatalf("Failed to store memory: %v", err)
+	}
+
+	accessedAt := time.Now().Truncate(time.Second)
+	for i := 0; i < 2; i++ {
+		if err := repo.RecordAccess(ctx, []domain.MemoryID{memory.ID}, accessedAt); err != nil {
+			t.Fatalf("Failed to record access: %v", err)
+		}
+	}
+
+	retrieved, err := repo.GetByID(ctx, memory.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve memory: %v", err)
+	}
+	if retrieved.AccessCount != 2 {
+		t.Errorf("Expected access count 2, got %d", retrieved.AccessCount)
+	}
+	if retrieved.LastAccessedAt == nil || !retrieved.LastAccessedAt.Equal(accessedAt) {
+		t.Errorf("Expected last accessed at %v, got %v", accessedAt, retrieved.LastAccessedAt)
+	}
+
+	// Updating the memory must not reset usage tracking
+	retrieved.Title = "Updated"
+	if err := repo.Update(ctx, retrieved); err != nil {
+		t.Fatalf("Failed to update memory: %v", err)
+	}
+	updated, err := repo.GetByID(ctx, memory.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve memory: %v", err)
+	}
+	if updated.AccessCount != 2 {
+		t.Errorf("Expected access count to survive update, got %d", updated.AccessCount)
+	}
+}
+
+func TestSQLiteMemoryRepository_ListUnused(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewSQLiteMemoryRepository(db, setupTestLogger())
+	ctx := context.Background()
+
+	old := time.Now().Add(-60 * 24 * time.Hour)
+
+	neverUsed := createTestMemory("proj_1", domain.MemoryTypeDecision)
+	neverUsed.CreatedAt = old
+	recentlyUsed := createTestMemory("proj_1", domain.MemoryTypeDecision)
+	recentlyUsed.CreatedAt = old
+	otherProject := createTestMemory("proj_2", domain.MemoryTypeDecision)
+	otherProject.CreatedAt = old
+	fresh := createTestMemory("proj_1", domain.MemoryTypeDecision)
+
+	for _, m := range []*domain.Memory{neverUsed, recentlyUsed, otherProject, fresh} {
+		if err := repo.Store(ctx, m); err != nil {
+			t.Fatalf("Failed to store memory: %v", err)
+		}
+	}
+	if err := repo.RecordAccess(ctx, []domain.MemoryID{recentlyUsed.ID}, time.Now()); err != nil {
+		t.Fatalf("Failed to record access: %v", err)
+	}
+
+	since := time.Now().Add(-30 * 24 * time.Hour)
+	projectID := domain.ProjectID("proj_1")
+
+	unused, err := repo.ListUnused(ctx, &projectID, since)
+	if err != nil {
+		t.Fatalf("Failed to list unused memories: %v", err)
+	}
+	if len(unused) != 1 || unused[0].ID != neverUsed.ID {
+		t.Errorf("Expected only the never used memory of proj_1, got %d results", len(unused))
+	}
+
+	all, err := repo.ListUnused(ctx, nil, since)
+	if err != nil {
+		t.Fatalf("Failed to list unused memories: %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("Expected 2 unused memories across projects, got %d", len(all))
+	}
+}
+
+func TestSQLiteMemoryRepository_ListPinned(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewSQLiteMemoryRepository(db, setupTestLogger())
+	ctx := context.Background()
+
+	pinned := createTestMemory("proj_1", domain.MemoryTypeDecision)
+	pinned.SetPinned(true)
+	unpinned := createTestMemory("proj_1", domain.MemoryTypeDecision)
+	otherProject := createTestMemory("proj_2", domain.MemoryTypeDecision)
+	otherProject.SetPinned(true)
+
+	for _, m := range []*domain.Memory{pinned, unpinned, otherProject} {
+		if err := repo.Store(ctx, m); err != nil {
+			t.Fatalf("Failed to store memory: %v", err)
+		}
+	}
+
+	results, err := repo.ListPinned(ctx, domain.ProjectID("proj_1"))
+	if err != nil {
+		t.Fatalf("Failed to list pinned memories: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != pinned.ID || !results[0].Pinned {
+		t.Fatalf("Expected only the pinned memory of proj_1, got %d results", len(results))
+	}
+
+	// Unpinning through Update removes the memory from the list
+	results[0].SetPinned(false)
+	if err := repo.Update(ctx, results[0]); err != nil {
+		t.Fatalf("Failed to update memory: %v", err)
+	}
+	results, err = repo.ListPinned(ctx, domain.ProjectID("proj_1"))
+	if err != nil {
+		t.Fatalf("Failed to list pinned memories: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("Expected no pinned memories after unpinning, got %d", len(results))
+	}
+}
+
+func TestSQLiteMemoryRepository_Anchors(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewSQLiteMemoryRepository(db, setupTestLogger())
+	ctx := context.Background()
+
+	anchored := createTestMemory("proj_1", domain.MemoryTypeDecision)
+	anchored.AddAnchor(domain.CodeAnchor{Path: "internal/infra/vector/chromadb.go", StartLine: 10, EndLine: 20, Symbol: "Search", Commit: "abc123"})
+	plain := createTestMemory("proj_1", domain.MemoryTypeDecision)
+	otherProject := createTestMemory("proj_2", domain.MemoryTypeDecision)
+	otherProject.AddAnchor(domain.CodeAnchor{Path: "main.go"})
+
+	for _, m := range []*domain.Memory{anchored, plain, otherProject} {
+		if err := repo.Store(ctx, m); err != nil {
+			t.Fatalf("Failed to store memory: %v", err)
+		}
+	}
+
+	retrieved, err := repo.GetByID(ctx, anchored.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve memory: %v", err)
+	}
+	if len(retrieved.Anchors) != 1 || retrieved.Anchors[0] != anchored.Anchors[0] {
+		t.Errorf("Expected anchors to round-trip, got %+v", retrieved.Anchors)
+	}
+
+	projectID := domain.ProjectID("proj_1")
+	results, err := repo.ListAnchored(ctx, &projectID)
+	if err != nil {
+		t.Fatalf("Failed to list anchored memories: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != anchored.ID {
+		t.Errorf("Expected only the anchored memory of proj_1, got %d results", len(results))
+	}
+
+	// Removing all anchors stores NULL again
+	retrieved.Anchors = nil
+	if err := repo.Update(ctx, retrieved); err != nil {
+		t.Fatalf("Failed to update memory: %v", err)
+	}
+	all, err := repo.ListAnchored(ctx, nil)
+	if err != nil {
+		t.Fatalf("Failed to list anchored memories: %v", err)
+	}
+	if len(all) != 1 || all[0].ID != otherProject.ID {
+		t.Errorf("Expected only the proj_2 memory to remain anchored, got %d results", len(all))
+	}
+}
+
+func TestSQLiteMemoryRepository_HierarchicalTags(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewSQLiteMemoryRepository(db, setupTestLogger())
+	ctx := context.Background()
+
+	sqlite := createTestMemory("proj_1", domain.MemoryTypeDecision)
+	sqlite.Tags = domain.Tags{"infra/db/sqlite"}
+	cache := createTestMemory("proj_1", domain.MemoryTypeDecision)
+	cache.Tags = domain.Tags{"infra/cache"}
+	untagged := createTestMemory("proj_1", domain.MemoryTypeDecision)
+	untagged.Tags = domain.Tags{}
+
+	for _, m := range []*domain.Memory{sqlite, cache, untagged} {
+		if err := repo.Store(ctx, m); err != nil {
+			t.Fatalf("Failed to store memory: %v", err)
+		}
+	}
+
+	results, err := repo.ListByTags(ctx, "proj_1", domain.Tags{"infra/db"})
+	if err != nil {
+		t.Fatalf("Failed to list memories by tags: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != sqlite.ID {
+		t.Errorf("Expected infra/db to match infra/db/sqlite only, got %d results", len(results))
+	}
+
+	results, err = repo.ListByTags(ctx, "proj_1", domain.Tags{"infra"})
+	if err != nil {
+		t.Fatalf("Failed to list memories by tags: %v", err)
+	}
+	if len(results) != 2 {
+		t.Errorf("Expected infra to match both tagged memories, got %d", len(results))
+	}
+
+	tagged, err := repo.ListTagged(ctx, nil)
+	if err != nil {
+		t.Fatalf("Failed to list tagged memories: %v", err)
+	}
+	if len(tagged) != 2 {
+		t.Errorf("Expected 2 tagged memories, got %d", len(tagged))
+	}
+}
+
+func TestSQLiteMemoryRepository_TagOrigins(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewSQLiteMemoryRepository(db, setupTestLogger())
+	ctx := context.Background()
+
+	autoTagged := createTestMemory("proj_1", domain.MemoryTypeDecision)
+	autoTagged.ApplyAutoTags([]string{"sqlite"})
+	autoTagged.SuggestTags([]string{"migrations"})
+	plain := createTestMemory("proj_1", domain.MemoryTypeDecision)
+
+	for _, m := range []*domain.Memory{autoTagged, plain} {
+		if err := repo.Store(ctx, m); err != nil {
+			t.Fatalf("Failed to store memory: %v", err)
+		}
+	}
+
+	retrieved, err := repo.GetByID(ctx, autoTagged.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve memory: %v", err)
+	}
+	if retrieved.TagOrigin("sqlite") != domain.TagOriginAuto || retrieved.TagOrigin("test") != domain.TagOriginManual {
+		t.Errorf("Expected tag origins to round-trip, got auto tags %v", retrieved.AutoTags)
+	}
+	if len(retrieved.SuggestedTags) != 1 || retrieved.SuggestedTags[0] != "migrations" {
+		t.Errorf("Expected suggested tags to round-trip, got %v", retrieved.SuggestedTags)
+	}
+
+	projectID := domain.ProjectID("proj_1")
+	pending, err := repo.ListPendingTagReview(ctx, &projectID)
+	if err != nil {
+		t.Fatalf("Failed to list memories pending review: %v", err)
+	}
+	if len(pending) != 1 || pending[0].ID != autoTagged.ID {
+		t.Errorf("Expected only the auto-tagged memory, got %d results", len(pending))
+	}
+
+	// Reviewing all pending tags clears the columns
+	retrieved.AcceptTags()
+	if err := repo.Update(ctx, retrieved); err != nil {
+		t.Fatalf("Failed to update memory: %v", err)
+	}
+	pending, err = repo.ListPendingTagReview(ctx, nil)
+	if err != nil {
+		t.Fatalf("Failed to list memories pending review: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("Expected no memories pending review, got %d", len(pending))
+	}
+}
+
+func TestSQLiteMemoryRepository_Fields(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewSQLiteMemoryRepository(db, setupTestLogger())
+	ctx := context.Background()
+
+	memory := createTestMemory("proj_1", domain.MemoryType("incident"))
+	memory.Fields = map[string]interface{}{
+		"severity": "high",
+		"duration": int64(45),
+		"services": []string{"billing", "auth"},
+	}
+	if err := repo.Store(ctx, memory); err != nil {
+		t.Fatalf("Failed to store memory: %v", err)
+	}
+
+	retrieved, err := repo.GetByID(ctx, memory.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve memory: %v", err)
+	}
+	if retrieved.Fields["severity"] != "high" || !domain.FieldMatches(retrieved.Fields["services"], "auth") {
+		t.Errorf("Expected fields to round-trip, got %v", retrieved.Fields)
+	}
+	if domain.FormatFieldValue(retrieved.Fields["duration"]) != "45" {
+		t.Errorf("Expected integer field to round-trip, got %v", retrieved.Fields["duration"])
+	}
+
+	// Clearing the fields stores NULL
+	retrieved.Fields = nil
+	if err := repo.Update(ctx, retrieved); err != nil {
+		t.Fatalf("Failed to update memory: %v", err)
+	}
+	retrieved, err = repo.GetByID(ctx, memory.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve memory: %v", err)
+	}
+	if retrieved.Fields != nil {
+		t.Errorf("Expected no fields, got %v", retrieved.Fields)
+	}
+}
+
+func TestSQLiteMemoryRepository_Redactions(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewSQLiteMemoryRepository(db, setupTestLogger())
+	ctx := context.Background()
+
+	memory := createTestMemory("proj_1", domain.MemoryTypeCode)
+	if err := repo.Store(ctx, memory); err != nil {
+		t.Fatalf("Failed to store memory: %v", err)
+	}
+	retrieved, err := repo.GetByID(ctx, memory.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve memory: %v", err)
+	}
+	if retrieved.Redactions != nil {
+		t.Errorf("Expected no redactions, got %+v", retrieved.Redactions)
+	}
+
+	retrieved.Redactions = []domain.Redaction{{Field: "content", Rule: "github_token", Count: 2}}
+	if err := repo.Update(ctx, retrieved); err != nil {
+		t.Fatalf("Failed to update memory: %v", err)
+	}
+	retrieved, err = repo.GetByID(ctx, memory.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve memory: %v", err)
+	}
+	if len(retrieved.Redactions) != 1 || retrieved.Redactions[0].Count != 2 {
+		t.Errorf("Expected redactions to round-trip, got %+v", retrieved.Redactions)
+	}
+
+	all, err := repo.ListAll(ctx, nil)
+	if err != nil {
+		t.Fatalf("Failed to list memories: %v", err)
+	}
+	if len(all) != 1 {
+		t.Errorf("Expected one memory, got %d", len(all))
+	}
+}
+
+func TestSQLiteMemoryRepository_AppliesTo(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewSQLiteMemoryRepository(db, setupTestLogger())
+	ctx := context.Background()
+
+	memory := createTestMemory("proj_1", domain.MemoryTypeErrorSolution)
+	memory.AppliesTo = []domain.DependencyConstraint{{Name: "github.com/gin-gonic/gin", Ecosystem: "go", Versions: "^1.9"}}
+	if err := repo.Store(ctx, memory); err != nil {
+		t.Fatalf("Failed to store memory: %v", err)
+	}
+	retrieved, err := repo.GetByID(ctx, memory.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve memory: %v", err)
+	}
+	if len(retrieved.AppliesTo) != 1 || retrieved.AppliesTo[0] != memory.AppliesTo[0] {
+		t.Errorf("Expected dependency constraints to round-trip, got %+v", retrieved.AppliesTo)
+	}
+
+	retrieved.AppliesTo = nil
+	if err := repo.Update(ctx, retrieved); err != nil {
+		t.Fatalf("Failed to update memory: %v", err)
+	}
+	retrieved, err = repo.GetByID(ctx, memory.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve memory: %v", err)
+	}
+	if retrieved.AppliesTo != nil {
+		t.Errorf("Expected the constraints to be removed, got %+v", retrieved.AppliesTo)
+	}
+}
+
+func TestSQLiteMemoryRepository_Provenance(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewSQLiteMemoryRepository(db, setupTestLogger())
+	ctx := context.Background()
+
+	sessionID := domain.SessionID("sess_1")
+	memory := createTestMemory("proj_1", domain.MemoryTypeDecision)
+	memory.Provenance = &domain.Provenance{Author: "alice", Source: domain.ProvenanceSourceMCP, Client: "claude-code/1.0", SessionID: &sessionID}
+	if err := repo.Store(ctx, memory); err != nil {
+		t.Fatalf("Failed to store memory: %v", err)
+	}
+
+	// Updates record the last writer but never replace the creator
+	memory.Provenance = &domain.Provenance{Author: "mallory", Source: domain.ProvenanceSourceCLI}
+	memory.LastModifiedBy = &domain.Provenance{Author: "bob", Source: domain.ProvenanceSourceCLI}
+	if err := repo.Update(ctx, memory); err != nil {
+		t.Fatalf("Failed to update memory: %v", err)
+	}
+
+	retrieved, err := repo.GetByID(ctx, memory.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve memory: %v", err)
+	}
+	p := retrieved.Provenance
+	if p == nil || p.Author != "alice" || p.Client != "claude-code/1.0" || p.SessionID == nil || *p.SessionID != sessionID {
+		t.Errorf("Expected the creator to be kept, got %+v", p)
+	}
+	if retrieved.LastModifiedBy == nil || retrieved.LastModifiedBy.Author != "bob" {
+		t.Errorf("Expected the last writer, got %+v", retrieved.LastModifiedBy)
+	}
+}
+
+func TestSQLiteMemoryRepository_ReviewStatus(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewSQLiteMemoryRepository(db, setupTestLogger())
+	ctx := context.Background()
+	projectID := domain.ProjectID("proj_1")
+
+	approved := createTestMemory(projectID, domain.MemoryTypeDecision)
+	draft := createTestMemory(projectID, domain.MemoryTypePattern)
+	draft.MarkDraft()
+	otherDraft := createTestMemory("proj_2", domain.MemoryTypePattern)
+	otherDraft.MarkDraft()
+	for _, memory := range []*domain.Memory{approved, draft, otherDraft} {
+		if err := repo.Store(ctx, memory); err != nil {
+			t.Fatalf("Failed to store memory: %v", err)
+		}
+	}
+
+	drafts, err := repo.ListDrafts(ctx, &projectID)
+	if err != nil {
+		t.Fatalf("Failed to list drafts: %v", err)
+	}
+	if len(drafts) != 1 || drafts[0].ID != draft.ID || !drafts[0].IsDraft() {
+		t.Errorf("Expected the project's draft, got %+v", drafts)
+	}
+	if drafts, _ = repo.ListDrafts(ctx, nil); len(drafts) != 2 {
+		t.Errorf("Expected drafts of all projects, got %d", len(drafts))
+	}
+
+	draft.Approve(time.Now())
+	if err := repo.Update(ctx, draft); err != nil {
+		t.Fatalf("Failed to update memory: %v", err)
+	}
+	retrieved, err := repo.GetByID(ctx, draft.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve memory: %v", err)
+	}
+	if retrieved.ReviewStatus != domain.ReviewStatusApproved || retrieved.ReviewedAt == nil {
+		t.Errorf("Expected an approved memory with review time, got %q %v", retrieved.ReviewStatus, retrieved.ReviewedAt)
+	}
+}
@@ -307,5 +307,188 @@ func (m *Migrator) getAllMigrations() []Migration {
 			DROP INDEX IF EXISTS idx_sessions_started_at;
 			`,
 		},
+		{
+			Version: 3,
+			Name:    "add_memory_access_tracking",
+			Up: `
+			ALTER TABLE memories ADD COLUMN access_count INTEGER NOT NULL DEFAULT 0;
+			ALTER TABLE memories ADD COLUMN last_accessed_at DATETIME;
+			
+			CREATE INDEX IF NOT EXISTS idx_memories_last_accessed_at ON memories(last_accessed_at);
+			`,
+			Down: `
+			DROP INDEX IF EXISTS idx_memories_last_accessed_at;
+			
+			ALTER TABLE memories DROP COLUMN last_accessed_at;
+			ALTER TABLE memories DROP COLUMN access_count;
+			`,
+		},
+		{
+			Version: 4,
+			Name:    "add_memory_pinning",
+			Up: `
+			ALTER TABLE memories ADD COLUMN pinned BOOLEAN NOT NULL DEFAULT FALSE;
+			
+			CREATE INDEX IF NOT EXISTS idx_memories_project_pinned ON memories(project_id, pinned);
+			`,
+			Down: `
+			DROP INDEX IF EXISTS idx_memories_project_pinned;
+			
+			ALTER TABLE memories DROP COLUMN pinned;
+			`,
+		},
+		{
+			Version: 5,
+			Name:    "add_memory_anchors",
+			Up: `
+			ALTER TABLE memories ADD COLUMN anchors TEXT; -- JSON array of code anchors
+			`,
+			Down: `
+			ALTER TABLE memories DROP COLUMN anchors;
+			`,
+		},
+		{
+			Version: 6,
+			Name:    "add_memory_tag_origins",
+			Up: `
+			ALTER TABLE memories ADD COLUMN auto_tags TEXT; -- JSON array of tags added by the auto-tagger
+			ALTER TABLE memories ADD COLUMN suggested_tags TEXT; -- JSON array of tags suggested for review
+			`,
+			Down: `
+			ALTER TABLE memories DROP COLUMN suggested_tags;
+			ALTER TABLE memories DROP COLUMN auto_tags;
+			`,
+		},
+		{
+			Version: 7,
+			Name:    "add_memory_fields",
+			Up: `
+			ALTER TABLE memories ADD COLUMN fields TEXT; -- JSON object of type-specific fields
+			`,
+			Down: `
+			ALTER TABLE memories DROP COLUMN fields;
+			`,
+		},
+		{
+			Version: 8,
+			Name:    "add_memory_redactions",
+			Up: `
+			ALTER TABLE memories ADD COLUMN redactions TEXT; -- JSON array of masked secret kinds per field
+			`,
+			Down: `
+			ALTER TABLE memories DROP COLUMN redactions;
+			`,
+		},
+		{
+			Version: 9,
+			Name:    "add_encryption_params",
+			Up: `
+			CREATE TABLE IF NOT EXISTS encryption_params (
+				name TEXT PRIMARY KEY,
+				value TEXT NOT NULL
+			); -- e.g. the salt and iteration count of the passphrase key derivation
+			`,
+			Down: `
+			DROP TABLE IF EXISTS encryption_params;
+			`,
+		},
+		{
+			Version: 10,
+			Name:    "add_provenance",
+			Up: `
+			ALTER TABLE memories ADD COLUMN provenance TEXT; -- JSON object with author, source, client and session
+			ALTER TABLE memories ADD COLUMN last_modified_by TEXT;
+			ALTER TABLE sessions ADD COLUMN provenance TEXT;
+			ALTER TABLE sessions ADD COLUMN last_modified_by TEXT;
+			`,
+			Down: `
+			ALTER TABLE memories DROP COLUMN provenance;
+			ALTER TABLE memories DROP COLUMN last_modified_by;
+			ALTER TABLE sessions DROP COLUMN provenance;
+			ALTER TABLE sessions DROP COLUMN last_modified_by;
+			`,
+		},
+		{
+			Version: 11,
+			Name:    "add_review_status",
+			Up: `
+			ALTER TABLE memories ADD COLUMN review_status TEXT NOT NULL DEFAULT 'approved'; -- draft or approved
+			ALTER TABLE memories ADD COLUMN reviewed_at DATETIME;
+			CREATE INDEX IF NOT EXISTS idx_memories_review_status ON memories(review_status);
+			`,
+			Down: `
+			DROP INDEX IF EXISTS idx_memories_review_status;
+			ALTER TABLE memories DROP COLUMN review_status;
+			ALTER TABLE memories DROP COLUMN reviewed_at;
+			`,
+		},
+		{
+			Version: 12,
+			Name:    "add_workspaces",
+			Up: `
+			CREATE TABLE IF NOT EXISTS workspaces (
+				id TEXT PRIMARY KEY,
+				name TEXT UNIQUE NOT NULL,
+				description TEXT,
+				created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+				updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+			);
+			ALTER TABLE projects ADD COLUMN workspace_id TEXT; -- workspace grouping the project, if any
+			CREATE INDEX IF NOT EXISTS idx_projects_workspace_id ON projects(workspace_id);
+			`,
+			Down: `
+			DROP INDEX IF EXISTS idx_projects_workspace_id;
+			ALTER TABLE projects DROP COLUMN workspace_id;
+			DROP TABLE IF EXISTS workspaces;
+			`,
+		},
+		{
+			Version: 13,
+			Name:    "add_project_detection",
+			Up: `
+			ALTER TABLE projects ADD COLUMN language TEXT;
+			ALTER TABLE projects ADD COLUMN framework TEXT;
+			ALTER TABLE projects ADD COLUMN languages TEXT; -- JSON array of languages with their share of the source code
+			ALTER TABLE projects ADD COLUMN dependencies TEXT; -- JSON array of direct dependencies from the manifests
+			`,
+			Down: `
+			ALTER TABLE projects DROP COLUMN language;
+			ALTER TABLE projects DROP COLUMN framework;
+			ALTER TABLE projects DROP COLUMN languages;
+			ALTER TABLE projects DROP COLUMN dependencies;
+			`,
+		},
+		{
+			Version: 14,
+			Name:    "add_memory_applicability",
+			Up: `
+			ALTER TABLE memories ADD COLUMN applies_to TEXT; -- JSON array of dependency constraints
+			`,
+			Down: `
+			ALTER TABLE memories DROP COLUMN applies_to;
+			`,
+		},
+		{
+			Version: 15,
+			Name:    "add_session_pauses",
+			Up: `
+			ALTER TABLE sessions ADD COLUMN paused_at DATETIME; -- start of the current pause, if paused
+			ALTER TABLE sessions ADD COLUMN paused_duration INTEGER NOT NULL DEFAULT 0; -- nanoseconds spent in earlier pauses
+			`,
+			Down: `
+			ALTER TABLE sessions DROP COLUMN paused_at;
+			ALTER TABLE sessions DROP COLUMN paused_duration;
+			`,
+		},
+		{
+			Version: 16,
+			Name:    "add_session_git_state",
+			Up: `
+			ALTER TABLE sessions ADD COLUMN git_state TEXT; -- JSON: branch, start and end commit, commits and changed files
+			`,
+			Down: `
+			ALTER TABLE sessions DROP COLUMN git_state;
+			`,
+		},
 	}
 }
@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -26,18 +27,29 @@ func NewSQLiteProjectRepository(db *sql.DB, logger *logrus.Logger) *SQLiteProjec
 
 // Store stores a new project in the database
 func (r *SQLiteProjectRepository) Store(ctx context.Context, project *domain.Project) error {
+	languagesJSON, dependenciesJSON, err := marshalProjectDetection(project)
+	if err != nil {
+		return err
+	}
+
 	query := `
-		INSERT INTO projects (id, name, path, description, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?)
+		INSERT INTO projects (id, name, path, description, created_at, updated_at, workspace_id,
+			language, framework, languages, dependencies)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err = r.db.ExecContext(ctx, query,
 		project.ID,
 		project.Name,
 		project.Path,
 		project.Description,
 		project.CreatedAt,
 		project.UpdatedAt,
+		workspaceIDValue(project.WorkspaceID),
+		project.Language,
+		project.Framework,
+		languagesJSON,
+		dependenciesJSON,
 	)
 
 	if err != nil {
@@ -52,22 +64,14 @@ func (r *SQLiteProjectRepository) Store(ctx context.Context, project *domain.Pro
 // GetByID retrieves a project by its ID
 func (r *SQLiteProjectRepository) GetByID(ctx context.Context, id domain.ProjectID) (*domain.Project, error) {
 	query := `
-		SELECT id, name, path, description, created_at, updated_at
+		SELECT ` + projectColumns + `
 		FROM projects
 		WHERE id = ?
 	`
 
 	row := r.db.QueryRowContext(ctx, query, id)
 
-	project := &domain.Project{}
-	err := row.Scan(
-		&project.ID,
-		&project.Name,
-		&project.Path,
-		&project.Description,
-		&project.CreatedAt,
-		&project.UpdatedAt,
-	)
+	project, err := scanProjectRow(row)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -108,22 +112,14 @@ func (r *SQLiteProjectRepository) createDefaultProject(ctx context.Context, id d
 // GetByPath retrieves a project by its path
 func (r *SQLiteProjectRepository) GetByPath(ctx context.Context, path string) (*domain.Project, error) {
 	query := `
-		SELECT id, name, path, description, created_at, updated_at
+		SELECT ` + projectColumns + `
 		FROM projects
 		WHERE path = ?
 	`
 
 	row := r.db.QueryRowContext(ctx, query, path)
 
-	project := &domain.Project{}
-	err := row.Scan(
-		&project.ID,
-		&project.Name,
-		&project.Path,
-		&project.Description,
-		&project.CreatedAt,
-		&project.UpdatedAt,
-	)
+	project, err := scanProjectRow(row)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -141,9 +137,15 @@ func (r *SQLiteProjectRepository) GetByPath(ctx context.Context, path string) (*
 func (r *SQLiteProjectRepository) Update(ctx context.Context, project *domain.Project) error {
 	project.UpdatedAt = time.Now()
 
+	languagesJSON, dependenciesJSON, err := marshalProjectDetection(project)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		UPDATE projects
-		SET name = ?, path = ?, description = ?, updated_at = ?
+		SET name = ?, path = ?, description = ?, updated_at = ?, workspace_id = ?,
+			language = ?, framework = ?, languages = ?, dependencies = ?
 		WHERE id = ?
 	`
 
@@ -152,6 +154,11 @@ func (r *SQLiteProjectRepository) Update(ctx context.Context, project *domain.Pr
 		project.Path,
 		project.Description,
 		project.UpdatedAt,
+		workspaceIDValue(project.WorkspaceID),
+		project.Language,
+		project.Framework,
+		languagesJSON,
+		dependenciesJSON,
 		project.ID,
 	)
 
@@ -199,7 +206,7 @@ func (r *SQLiteProjectRepository) Delete(ctx context.Context, id domain.ProjectI
 // List retrieves all projects from the database
 func (r *SQLiteProjectRepository) List(ctx context.Context) ([]*domain.Project, error) {
 	query := `
-		SELECT id, name, path, description, created_at, updated_at
+		SELECT ` + projectColumns + `
 		FROM projects
 		ORDER BY created_at DESC
 	`
@@ -209,6 +216,39 @@ func (r *SQLiteProjectRepository) List(ctx context.Context) ([]*domain.Project,
 		r.logger.WithError(err).Error("Failed to list projects")
 		return nil, fmt.Errorf("failed to list projects: %w", err)
 	}
+
+	projects, err := r.scanProjects(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	r.logger.WithField("projects_count", len(projects)).Debug("Projects listed successfully")
+	return projects, nil
+}
+
+// ListByWorkspace retrieves the projects of a workspace, ordered by name
+func (r *SQLiteProjectRepository) ListByWorkspace(ctx context.Context, workspaceID domain.WorkspaceID) ([]*domain.Project, error) {
+	query := `
+		SELECT ` + projectColumns + `
+		FROM projects
+		WHERE workspace_id = ?
+		ORDER BY name
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, workspaceID)
+	if err != nil {
+		r.logger.WithError(err).WithField("workspace_id", workspaceID).Error("Failed to list workspace projects")
+		return nil, fmt.Errorf("failed to list workspace projects: %w", err)
+	}
+
+	return r.scanProjects(rows)
+}
+
+const projectColumns = `id, name, path, description, created_at, updated_at, workspace_id,
+	language, framework, languages, dependencies`
+
+// scanProjects scans all project rows and closes them
+func (r *SQLiteProjectRepository) scanProjects(rows *sql.Rows) ([]*domain.Project, error) {
 	defer func() {
 		if err := rows.Close(); err != nil {
 			r.logger.WithError(err).Warn("Failed to close rows")
@@ -218,16 +258,7 @@ func (r *SQLiteProjectRepository) List(ctx context.Context) ([]*domain.Project,
 	var projects []*domain.Project
 
 	for rows.Next() {
-		project := &domain.Project{}
-		err := rows.Scan(
-			&project.ID,
-			&project.Name,
-			&project.Path,
-			&project.Description,
-			&project.CreatedAt,
-			&project.UpdatedAt,
-		)
-
+		project, err := scanProjectRow(rows)
 		if err != nil {
 			r.logger.WithError(err).Error("Failed to scan project row")
 			continue
@@ -241,6 +272,74 @@ func (r *SQLiteProjectRepository) List(ctx context.Context) ([]*domain.Project,
 		return nil, fmt.Errorf("error iterating over project rows: %w", err)
 	}
 
-	r.logger.WithField("projects_count", len(projects)).Debug("Projects listed successfully")
 	return projects, nil
 }
+
+// scanProjectRow scans a row selected with projectColumns
+func scanProjectRow(row rowScanner) (*domain.Project, error) {
+	project := &domain.Project{}
+	var description, workspaceID, language, framework, languagesJSON, dependenciesJSON sql.NullString
+	err := row.Scan(
+		&project.ID,
+		&project.Name,
+		&project.Path,
+		&description,
+		&project.CreatedAt,
+		&project.UpdatedAt,
+		&workspaceID,
+		&language,
+		&framework,
+		&languagesJSON,
+		&dependenciesJSON,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	project.Description = description.String
+	if workspaceID.Valid && workspaceID.String != "" {
+		id := domain.WorkspaceID(workspaceID.String)
+		project.WorkspaceID = &id
+	}
+	project.Language = language.String
+	project.Framework = framework.String
+	if languagesJSON.Valid {
+		if err := json.Unmarshal([]byte(languagesJSON.String), &project.Languages); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal languages: %w", err)
+		}
+	}
+	if dependenciesJSON.Valid {
+		if err := json.Unmarshal([]byte(dependenciesJSON.String), &project.Dependencies); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal dependencies: %w", err)
+		}
+	}
+	return project, nil
+}
+
+// marshalProjectDetection encodes the detected languages and dependencies as JSON
+// arrays, or NULL if nothing was detected
+func marshalProjectDetection(project *domain.Project) (languages, dependencies interface{}, err error) {
+	if len(project.Languages) > 0 {
+		data, err := json.Marshal(project.Languages)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal languages: %w", err)
+		}
+		languages = string(data)
+	}
+	if len(project.Dependencies) > 0 {
+		data, err := json.Marshal(project.Dependencies)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal dependencies: %w", err)
+		}
+		dependencies = string(data)
+	}
+	return languages, dependencies, nil
+}
+
+// workspaceIDValue stores projects without workspace as NULL
+func workspaceIDValue(id *domain.WorkspaceID) interface{} {
+	if id == nil {
+		return nil
+	}
+	return string(*id)
+}
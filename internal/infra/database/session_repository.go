@@ -14,9 +14,11 @@ import (
 )
 
 // SQLiteSessionRepository implements the SessionRepository interface using SQLite
-// Maps to existing sessions table schema: (id, project_id, name, description, status, started_at, completed_at)
+// Maps to existing sessions table schema: (id, project_id, name, description, status, started_at, completed_at,
+// paused_at, paused_duration, git_state)
 type SQLiteSessionRepository struct {
 	db     *sql.DB
+	cipher FieldCipher
 	logger *logrus.Logger
 }
 
@@ -28,11 +30,136 @@ func NewSQLiteSessionRepository(db *sql.DB, logger *logrus.Logger) *SQLiteSessio
 	}
 }
 
+// SetCipher enables encryption of the name, description and git_state columns, which
+// hold the task, outcome, progress and commits. Values stored as plaintext before
+// remain readable.
+func (r *SQLiteSessionRepository) SetCipher(cipher FieldCipher) {
+	r.cipher = cipher
+}
+
+// encryptText encrypts the name and the built description of a session for storage
+func (r *SQLiteSessionRepository) encryptText(session *domain.Session) (string, string, error) {
+	name, err := encryptField(r.cipher, session.Name)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encrypt session %s: %w", session.ID, err)
+	}
+	description, err := encryptField(r.cipher, r.buildDescription(session))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encrypt session %s: %w", session.ID, err)
+	}
+	return name, description, nil
+}
+
+// encodeGitState marshals and encrypts the git state of a session; nil stays NULL
+func (r *SQLiteSessionRepository) encodeGitState(session *domain.Session) (*string, error) {
+	if session.Git == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(session.Git)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal git state of session %s: %w", session.ID, err)
+	}
+	value, err := encryptField(r.cipher, string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt session %s: %w", session.ID, err)
+	}
+	return &value, nil
+}
+
+// decodeGitState decrypts and unmarshals the git state of a scanned session
+func (r *SQLiteSessionRepository) decodeGitState(session *domain.Session, value sql.NullString) error {
+	if !value.Valid || value.String == "" {
+		return nil
+	}
+	data, err := decryptField(r.cipher, value.String)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt session %s: %w", session.ID, err)
+	}
+	var git domain.SessionGit
+	if err := json.Unmarshal([]byte(data), &git); err != nil {
+		r.logger.WithError(err).WithField("session_id", session.ID).Warn("Failed to parse session git state")
+		return nil
+	}
+	session.Git = &git
+	return nil
+}
+
+// decodeRow decrypts the name and description of a scanned session, parses the
+// task, outcome and progress from the description and decodes the provenance
+func (r *SQLiteSessionRepository) decodeRow(session *domain.Session, description string, provenance, lastModifiedBy sql.NullString) error {
+	var err error
+	if session.Name, err = decryptField(r.cipher, session.Name); err != nil {
+		return fmt.Errorf("failed to decrypt session %s: %w", session.ID, err)
+	}
+	if description, err = decryptField(r.cipher, description); err != nil {
+		return fmt.Errorf("failed to decrypt session %s: %w", session.ID, err)
+	}
+	r.parseDescription(description, session)
+	session.Provenance = unmarshalProvenance(r.logger, provenance)
+	session.LastModifiedBy = unmarshalProvenance(r.logger, lastModifiedBy)
+	return nil
+}
+
+const sessionColumns = `id, project_id, name, description, status, started_at, completed_at, provenance, last_modified_by,
+		       paused_at, paused_duration, git_state`
+
+// scanSessionRow scans a session selected with sessionColumns and computes its active time
+func (r *SQLiteSessionRepository) scanSessionRow(row rowScanner) (*domain.Session, error) {
+	session := &domain.Session{}
+	var description sql.NullString
+	var completedAt, pausedAt sql.NullTime
+	var provenance, lastModifiedBy, gitState sql.NullString
+	var pausedDuration int64
+
+	err := row.Scan(
+		&session.ID,
+		&session.ProjectID,
+		&session.Name, // name -> Name
+		&description,
+		&session.Status,
+		&session.StartTime, // started_at -> StartTime
+		&completedAt,
+		&provenance,
+		&lastModifiedBy,
+		&pausedAt,
+		&pausedDuration,
+		&gitState,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle completed_at -> EndTime
+	if completedAt.Valid {
+		session.EndTime = &completedAt.Time
+	}
+	if pausedAt.Valid {
+		session.PausedAt = &pausedAt.Time
+	}
+	session.PausedDuration = time.Duration(pausedDuration)
+
+	// Parse description to extract outcome and progress
+	if err := r.decodeRow(session, description.String, provenance, lastModifiedBy); err != nil {
+		return nil, err
+	}
+	if err := r.decodeGitState(session, gitState); err != nil {
+		return nil, err
+	}
+
+	// Set the active time of finished sessions
+	if session.EndTime != nil {
+		duration := session.CalculateDuration()
+		session.SessionDuration = &duration
+	}
+	return session, nil
+}
+
 // Store stores a new session in the database
 func (r *SQLiteSessionRepository) Store(ctx context.Context, session *domain.Session) error {
 	query := `
-		INSERT INTO sessions (id, project_id, name, description, status, started_at, completed_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO sessions (id, project_id, name, description, status, started_at, completed_at, provenance, last_modified_by,
+		                      paused_at, paused_duration, git_state)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	var completedAt *time.Time
@@ -41,23 +168,37 @@ func (r *SQLiteSessionRepository) Store(ctx context.Context, session *domain.Ses
 	}
 
 	// Build description with outcome and progress information
-	description := session.TaskDescription
-	if session.Outcome != "" {
-		description += " | Outcome: " + session.Outcome
+	name, description, err := r.encryptText(session)
+	if err != nil {
+		return err
 	}
-	if len(session.Progress) > 0 {
-		progressJSON, _ := json.Marshal(session.Progress)
-		description += " | Progress: " + string(progressJSON)
+
+	provenance, err := marshalProvenance(session.Provenance)
+	if err != nil {
+		return err
+	}
+	lastModifiedBy, err := marshalProvenance(session.LastModifiedBy)
+	if err != nil {
+		return err
+	}
+	gitState, err := r.encodeGitState(session)
+	if err != nil {
+		return err
 	}
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err = r.db.ExecContext(ctx, query,
 		session.ID,
 		session.ProjectID,
-		session.Name, // name
-		description,  // description = task + outcome + progress
+		name,        // name
+		description, // description = task + outcome + progress
 		session.Status,
 		session.StartTime, // started_at
 		completedAt,       // completed_at
+		provenance,
+		lastModifiedBy,
+		session.PausedAt,
+		int64(session.PausedDuration),
+		gitState,
 	)
 
 	if err != nil {
@@ -72,27 +213,12 @@ func (r *SQLiteSessionRepository) Store(ctx context.Context, session *domain.Ses
 // GetByID retrieves a session by its ID
 func (r *SQLiteSessionRepository) GetByID(ctx context.Context, id domain.SessionID) (*domain.Session, error) {
 	query := `
-		SELECT id, project_id, name, description, status, started_at, completed_at
+		SELECT ` + sessionColumns + `
 		FROM sessions
 		WHERE id = ?
 	`
 
-	row := r.db.QueryRowContext(ctx, query, id)
-
-	session := &domain.Session{}
-	var description sql.NullString
-	var completedAt sql.NullTime
-
-	err := row.Scan(
-		&session.ID,
-		&session.ProjectID,
-		&session.Name, // name -> Name
-		&description,
-		&session.Status,
-		&session.StartTime, // started_at -> StartTime
-		&completedAt,
-	)
-
+	session, err := r.scanSessionRow(r.db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("session not found: %s", id)
@@ -101,20 +227,6 @@ func (r *SQLiteSessionRepository) GetByID(ctx context.Context, id domain.Session
 		return nil, fmt.Errorf("failed to get session: %w", err)
 	}
 
-	// Handle completed_at -> EndTime
-	if completedAt.Valid {
-		session.EndTime = &completedAt.Time
-	}
-
-	// Parse description to extract outcome and progress
-	r.parseDescription(description.String, session)
-
-	// Calculate and set session duration if completed
-	if session.EndTime != nil {
-		duration := session.EndTime.Sub(session.StartTime)
-		session.SessionDuration = &duration
-	}
-
 	r.logger.WithField("session_id", id).Debug("Session retrieved successfully")
 	return session, nil
 }
@@ -167,7 +279,8 @@ func (r *SQLiteSessionRepository) buildDescription(session *domain.Session) stri
 func (r *SQLiteSessionRepository) Update(ctx context.Context, session *domain.Session) error {
 	query := `
 		UPDATE sessions
-		SET project_id = ?, name = ?, description = ?, status = ?, started_at = ?, completed_at = ?
+		SET project_id = ?, name = ?, description = ?, status = ?, started_at = ?, completed_at = ?,
+		    provenance = COALESCE(provenance, ?), last_modified_by = ?, paused_at = ?, paused_duration = ?, git_state = ?
 		WHERE id = ?
 	`
 
@@ -176,15 +289,36 @@ func (r *SQLiteSessionRepository) Update(ctx context.Context, session *domain.Se
 		completedAt = session.EndTime
 	}
 
-	description := r.buildDescription(session)
+	name, description, err := r.encryptText(session)
+	if err != nil {
+		return err
+	}
+
+	provenance, err := marshalProvenance(session.Provenance)
+	if err != nil {
+		return err
+	}
+	lastModifiedBy, err := marshalProvenance(session.LastModifiedBy)
+	if err != nil {
+		return err
+	}
+	gitState, err := r.encodeGitState(session)
+	if err != nil {
+		return err
+	}
 
 	result, err := r.db.ExecContext(ctx, query,
 		session.ProjectID,
-		session.Name, // Use Name field for the name column
+		name, // Use Name field for the name column
 		description,
 		session.Status,
 		session.StartTime,
 		completedAt,
+		provenance,
+		lastModifiedBy,
+		session.PausedAt,
+		int64(session.PausedDuration),
+		gitState,
 		session.ID,
 	)
 
@@ -232,7 +366,7 @@ func (r *SQLiteSessionRepository) Delete(ctx context.Context, id domain.SessionI
 // ListByProject retrieves all sessions for a specific project
 func (r *SQLiteSessionRepository) ListByProject(ctx context.Context, projectID domain.ProjectID) ([]*domain.Session, error) {
 	query := `
-		SELECT id, project_id, name, description, status, started_at, completed_at
+		SELECT ` + sessionColumns + `
 		FROM sessions
 		WHERE project_id = ?
 		ORDER BY started_at DESC
@@ -252,39 +386,11 @@ func (r *SQLiteSessionRepository) ListByProject(ctx context.Context, projectID d
 	var sessions []*domain.Session
 
 	for rows.Next() {
-		session := &domain.Session{}
-		var description sql.NullString
-		var completedAt sql.NullTime
-
-		err := rows.Scan(
-			&session.ID,
-			&session.ProjectID,
-			&session.Name, // Scan into Name field, not TaskDescription
-			&description,
-			&session.Status,
-			&session.StartTime,
-			&completedAt,
-		)
-
+		session, err := r.scanSessionRow(rows)
 		if err != nil {
 			r.logger.WithError(err).Error("Failed to scan session row")
-			continue
-		}
-
-		// Handle completed_at
-		if completedAt.Valid {
-			session.EndTime = &completedAt.Time
+			return nil, fmt.Errorf("failed to scan session: %w", err)
 		}
-
-		// Parse description
-		r.parseDescription(description.String, session)
-
-		// Calculate and set session duration if completed
-		if session.EndTime != nil {
-			duration := session.EndTime.Sub(session.StartTime)
-			session.SessionDuration = &duration
-		}
-
 		sessions = append(sessions, session)
 	}
 
@@ -304,29 +410,14 @@ func (r *SQLiteSessionRepository) ListByProject(ctx context.Context, projectID d
 // GetActiveSession retrieves the active session for a project
 func (r *SQLiteSessionRepository) GetActiveSession(ctx context.Context, projectID domain.ProjectID) (*domain.Session, error) {
 	query := `
-		SELECT id, project_id, name, description, status, started_at, completed_at
+		SELECT ` + sessionColumns + `
 		FROM sessions
 		WHERE project_id = ? AND status = ?
 		ORDER BY started_at DESC
 		LIMIT 1
 	`
 
-	row := r.db.QueryRowContext(ctx, query, projectID, domain.SessionStatusActive)
-
-	session := &domain.Session{}
-	var description sql.NullString
-	var completedAt sql.NullTime
-
-	err := row.Scan(
-		&session.ID,
-		&session.ProjectID,
-		&session.Name, // Scan into Name field, not TaskDescription
-		&description,
-		&session.Status,
-		&session.StartTime,
-		&completedAt,
-	)
-
+	session, err := r.scanSessionRow(r.db.QueryRowContext(ctx, query, projectID, domain.SessionStatusActive))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("no active session found for project: %s", projectID)
@@ -335,20 +426,6 @@ func (r *SQLiteSessionRepository) GetActiveSession(ctx context.Context, projectI
 		return nil, fmt.Errorf("failed to get active session: %w", err)
 	}
 
-	// Handle completed_at
-	if completedAt.Valid {
-		session.EndTime = &completedAt.Time
-	}
-
-	// Parse description
-	r.parseDescription(description.String, session)
-
-	// Calculate and set session duration if completed
-	if session.EndTime != nil {
-		duration := session.EndTime.Sub(session.StartTime)
-		session.SessionDuration = &duration
-	}
-
 	r.logger.WithFields(logrus.Fields{
 		"project_id": projectID,
 		"session_id": session.ID,
@@ -360,7 +437,7 @@ func (r *SQLiteSessionRepository) GetActiveSession(ctx context.Context, projectI
 // ListWithFilters retrieves sessions based on provided filters
 func (r *SQLiteSessionRepository) ListWithFilters(ctx context.Context, filters ports.SessionFilters) ([]*domain.Session, error) {
 	query := `
-		SELECT id, project_id, name, description, status, started_at, completed_at
+		SELECT ` + sessionColumns + `
 		FROM sessions
 		WHERE 1=1
 	`
@@ -399,39 +476,11 @@ func (r *SQLiteSessionRepository) ListWithFilters(ctx context.Context, filters p
 	var sessions []*domain.Session
 
 	for rows.Next() {
-		session := &domain.Session{}
-		var description sql.NullString
-		var completedAt sql.NullTime
-
-		err := rows.Scan(
-			&session.ID,
-			&session.ProjectID,
-			&session.Name, // Scan into Name field, not TaskDescription
-			&description,
-			&session.Status,
-			&session.StartTime,
-			&completedAt,
-		)
-
+		session, err := r.scanSessionRow(rows)
 		if err != nil {
 			r.logger.WithError(err).Error("Failed to scan session row")
-			continue
-		}
-
-		// Handle completed_at
-		if completedAt.Valid {
-			session.EndTime = &completedAt.Time
+			return nil, fmt.Errorf("failed to scan session: %w", err)
 		}
-
-		// Parse description
-		r.parseDescription(description.String, session)
-
-		// Calculate and set session duration if completed
-		if session.EndTime != nil {
-			duration := session.EndTime.Sub(session.StartTime)
-			session.SessionDuration = &duration
-		}
-
 		sessions = append(sessions, session)
 	}
 
@@ -543,3 +543,130 @@ func TestSQLiteSessionRepository_DurationHandling(t *testing.T) {
 		t.Errorf("Expected duration %v, got %v", expectedDuration, *retrievedCompleted.SessionDuration)
 	}
 }
+
+func TestSQLiteSessionRepository_PauseHandling(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewSQLiteSessionRepository(db, setupTestLogger())
+	ctx := context.Background()
+
+	session := createTestSession("proj_1")
+	session.StartTime = time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+	session.PausedDuration = 20 * time.Minute
+	if err := session.Pause(); err != nil {
+		t.Fatalf("Failed to pause session: %v", err)
+	}
+	if err := repo.Store(ctx, session); err != nil {
+		t.Fatalf("Failed to store session: %v", err)
+	}
+
+	retrieved, err := repo.GetByID(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve session: %v", err)
+	}
+	if retrieved.Status != domain.SessionStatusPaused {
+		t.Errorf("Expected status %s, got %s", domain.SessionStatusPaused, retrieved.Status)
+	}
+	if retrieved.PausedAt == nil || !retrieved.PausedAt.Equal(*session.PausedAt) {
+		t.Errorf("Expected PausedAt %v, got %v", session.PausedAt, retrieved.PausedAt)
+	}
+	if retrieved.PausedDuration != 20*time.Minute {
+		t.Errorf("Expected PausedDuration 20m, got %s", retrieved.PausedDuration)
+	}
+
+	// Completing closes the pause; the stored duration is the active time
+	retrieved.Complete("done")
+	if err := repo.Update(ctx, retrieved); err != nil {
+		t.Fatalf("Failed to update session: %v", err)
+	}
+	completed, err := repo.GetByID(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve completed session: %v", err)
+	}
+	if completed.PausedAt != nil {
+		t.Error("Expected PausedAt to be cleared")
+	}
+	expected := completed.EndTime.Sub(completed.StartTime) - completed.PausedDuration
+	if completed.SessionDuration == nil || *completed.SessionDuration != expected {
+		t.Errorf("Expected duration %v, got %v", expected, completed.SessionDuration)
+	}
+	if *completed.SessionDuration >= 2*time.Hour-19*time.Minute {
+		t.Errorf("Expected paused time to be excluded, got %v", *completed.SessionDuration)
+	}
+}
+
+func TestSQLiteSessionRepository_GitState(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewSQLiteSessionRepository(db, setupTestLogger())
+	ctx := context.Background()
+
+	session := createTestSession("proj_1")
+	if err := repo.Store(ctx, session); err != nil {
+		t.Fatalf("Failed to store session: %v", err)
+	}
+	retrieved, err := repo.GetByID(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve session: %v", err)
+	}
+	if retrieved.Git != nil {
+		t.Errorf("Expected no git state, got %+v", retrieved.Git)
+	}
+
+	retrieved.Git = &domain.SessionGit{
+		Branch:      "main",
+		StartCommit: "1111111aaaa",
+		EndCommit:   "2222222bbbb",
+		GitChanges: domain.GitChanges{
+			Commits:    []domain.GitCommit{{Hash: "2222222bbbb", Author: "dev", Subject: "Add cache"}},
+			Files:      []domain.GitFileChange{{Path: "vector_store.go", OldPath: "store.go", Status: domain.GitFileRenamed, Insertions: 3}},
+			Insertions: 3,
+		},
+	}
+	if err := repo.Update(ctx, retrieved); err != nil {
+		t.Fatalf("Failed to update session: %v", err)
+	}
+
+	updated, err := repo.GetByID(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("Failed to retrieve session: %v", err)
+	}
+	git := updated.Git
+	if git == nil || git.Branch != "main" || git.EndCommit != "2222222bbbb" || git.Insertions != 3 {
+		t.Fatalf("Expected git state to round-trip, got %+v", git)
+	}
+	if len(git.Commits) != 1 || git.Commits[0].Subject != "Add cache" {
+		t.Errorf("Expected commit, got %+v", git.Commits)
+	}
+	if len(git.Files) != 1 || git.Files[0].OldPath != "store.go" {
+		t.Errorf("Expected renamed file, got %+v", git.Files)
+	}
+}
+
+func TestSQLiteSessionRepository_Provenance(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := NewSQLiteSessionRepository(db, setupTestLogger())
+	ctx := context.Background()
+
+	session := createTestSession("proj_1")
+	session.Provenance = &domain.Provenance{Author: "alice", Source: domain.ProvenanceSourceCLI}
+	if err := repo.Store(ctx, session); err != nil {
+		t.Fatalf("Failed to store session: %v", err)
+	}
+	session.LastModifiedBy = &domain.Provenance{Source: domain.ProvenanceSourceMCP, Client: "cursor"}
+	if err := repo.Update(ctx, session); err != nil {
+		t.Fatalf("Failed to update session: %v", err)
+	}
+
+	active, err := repo.GetActiveSession(ctx, "proj_1")
+	if err != nil {
+		t.Fatalf("Failed to get active session: %v", err)
+	}
+	if active.Provenance == nil || active.Provenance.Author != "alice" || active.LastModifiedBy == nil || active.LastModifiedBy.Client != "cursor" {
+		t.Errorf("Expected provenance to round-trip, got %+v / %+v", active.Provenance, active.LastModifiedBy)
+	}
+}
@@ -0,0 +1,194 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// SQLiteWorkspaceRepository implements the WorkspaceRepository interface using SQLite
+type SQLiteWorkspaceRepository struct {
+	db     *sql.DB
+	logger *logrus.Logger
+}
+
+// NewSQLiteWorkspaceRepository creates a new SQLite workspace repository
+func NewSQLiteWorkspaceRepository(db *sql.DB, logger *logrus.Logger) *SQLiteWorkspaceRepository {
+	return &SQLiteWorkspaceRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+const workspaceColumns = `id, name, description, created_at, updated_at`
+
+// Store stores a new workspace in the database
+func (r *SQLiteWorkspaceRepository) Store(ctx context.Context, workspace *domain.Workspace) error {
+	query := `
+		INSERT INTO workspaces (id, name, description, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		workspace.ID,
+		workspace.Name,
+		workspace.Description,
+		workspace.CreatedAt,
+		workspace.UpdatedAt,
+	)
+	if err != nil {
+		r.logger.WithError(err).WithField("workspace_id", workspace.ID).Error("Failed to store workspace")
+		return fmt.Errorf("failed to store workspace: %w", err)
+	}
+
+	r.logger.WithField("workspace_id", workspace.ID).Debug("Workspace stored successfully")
+	return nil
+}
+
+// GetByID retrieves a workspace by its ID
+func (r *SQLiteWorkspaceRepository) GetByID(ctx context.Context, id domain.WorkspaceID) (*domain.Workspace, error) {
+	query := `SELECT ` + workspaceColumns + ` FROM workspaces WHERE id = ?`
+
+	workspace, err := scanWorkspaceRow(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("workspace not found: %s", id)
+		}
+		return nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+	return workspace, nil
+}
+
+// GetByName retrieves a workspace by its name
+func (r *SQLiteWorkspaceRepository) GetByName(ctx context.Context, name string) (*domain.Workspace, error) {
+	query := `SELECT ` + workspaceColumns + ` FROM workspaces WHERE name = ?`
+
+	workspace, err := scanWorkspaceRow(r.db.QueryRowContext(ctx, query, name))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("workspace not found: %s", name)
+		}
+		return nil, fmt.Errorf("failed to get workspace by name: %w", err)
+	}
+	return workspace, nil
+}
+
+// Update updates an existing workspace in the database
+func (r *SQLiteWorkspaceRepository) Update(ctx context.Context, workspace *domain.Workspace) error {
+	workspace.UpdatedAt = time.Now()
+
+	query := `
+		UPDATE workspaces
+		SET name = ?, description = ?, updated_at = ?
+		WHERE id = ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		workspace.Name,
+		workspace.Description,
+		workspace.UpdatedAt,
+		workspace.ID,
+	)
+	if err != nil {
+		r.logger.WithError(err).WithField("workspace_id", workspace.ID).Error("Failed to update workspace")
+		return fmt.Errorf("failed to update workspace: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("workspace not found: %s", workspace.ID)
+	}
+
+	return nil
+}
+
+// Delete deletes a workspace and detaches its projects in one transaction
+func (r *SQLiteWorkspaceRepository) Delete(ctx context.Context, id domain.WorkspaceID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err := tx.ExecContext(ctx, `UPDATE projects SET workspace_id = NULL WHERE workspace_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to detach workspace projects: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM workspaces WHERE id = ?`, id)
+	if err != nil {
+		r.logger.WithError(err).WithField("workspace_id", id).Error("Failed to delete workspace")
+		return fmt.Errorf("failed to delete workspace: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("workspace not found: %s", id)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.logger.WithField("workspace_id", id).Debug("Workspace deleted successfully")
+	return nil
+}
+
+// List retrieves all workspaces ordered by name
+func (r *SQLiteWorkspaceRepository) List(ctx context.Context) ([]*domain.Workspace, error) {
+	query := `SELECT ` + workspaceColumns + ` FROM workspaces ORDER BY name`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to list workspaces")
+		return nil, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			r.logger.WithError(err).Warn("Failed to close rows")
+		}
+	}()
+
+	var workspaces []*domain.Workspace
+	for rows.Next() {
+		workspace, err := scanWorkspaceRow(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan workspace: %w", err)
+		}
+		workspaces = append(workspaces, workspace)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over workspace rows: %w", err)
+	}
+
+	return workspaces, nil
+}
+
+// scanWorkspaceRow scans a row selected with workspaceColumns
+func scanWorkspaceRow(row rowScanner) (*domain.Workspace, error) {
+	workspace := &domain.Workspace{}
+	var description sql.NullString
+	if err := row.Scan(
+		&workspace.ID,
+		&workspace.Name,
+		&description,
+		&workspace.CreatedAt,
+		&workspace.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	workspace.Description = description.String
+	return workspace, nil
+}
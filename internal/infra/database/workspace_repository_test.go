@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+)
+
+func TestSQLiteWorkspaceRepository(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	logger := setupTestLogger()
+	workspaceRepo := NewSQLiteWorkspaceRepository(db, logger)
+	projectRepo := NewSQLiteProjectRepository(db, logger)
+	ctx := context.Background()
+
+	workspace := domain.NewWorkspace("platform", "Platform services")
+	if err := workspaceRepo.Store(ctx, workspace); err != nil {
+		t.Fatalf("Failed to store workspace: %v", err)
+	}
+	if err := workspaceRepo.Store(ctx, domain.NewWorkspace("platform", "")); err == nil {
+		t.Error("Expected duplicate workspace names to be rejected")
+	}
+
+	byName, err := workspaceRepo.GetByName(ctx, "platform")
+	if err != nil || byName.ID != workspace.ID || byName.Description != "Platform services" {
+		t.Fatalf("Failed to get workspace by name: %+v, %v", byName, err)
+	}
+
+	api := domain.NewProject("api", "/repo/api", "")
+	api.WorkspaceID = &workspace.ID
+	web := domain.NewProject("web", "/repo/web", "")
+	other := domain.NewProject("other", "/other", "")
+	for _, project := range []*domain.Project{web, api, other} {
+		if err := projectRepo.Store(ctx, project); err != nil {
+			t.Fatalf("Failed to store project: %v", err)
+		}
+	}
+	web.WorkspaceID = &workspace.ID
+	if err := projectRepo.Update(ctx, web); err != nil {
+		t.Fatalf("Failed to update project: %v", err)
+	}
+
+	projects, err := projectRepo.ListByWorkspace(ctx, workspace.ID)
+	if err != nil {
+		t.Fatalf("Failed to list workspace projects: %v", err)
+	}
+	if len(projects) != 2 || projects[0].ID != api.ID || projects[1].ID != web.ID {
+		t.Errorf("Expected api and web ordered by name, got %+v", projects)
+	}
+	if retrieved, _ := projectRepo.GetByID(ctx, other.ID); retrieved.WorkspaceID != nil {
+		t.Errorf("Expected a project without workspace, got %v", *retrieved.WorkspaceID)
+	}
+
+	if err := workspaceRepo.Delete(ctx, workspace.ID); err != nil {
+		t.Fatalf("Failed to delete workspace: %v", err)
+	}
+	if retrieved, _ := projectRepo.GetByID(ctx, api.ID); retrieved.WorkspaceID != nil {
+		t.Error("Expected projects to be detached from the deleted workspace")
+	}
+	if workspaces, _ := workspaceRepo.List(ctx); len(workspaces) != 0 {
+		t.Errorf("Expected no workspaces, got %d", len(workspaces))
+	}
+}
@@ -0,0 +1,36 @@
+package encryption
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+)
+
+// DefaultKDFIterations is the PBKDF2 iteration count for new databases
+const DefaultKDFIterations = 600000
+
+// KDFParams are the parameters for deriving a key from a passphrase. They are stored
+// in the database so that the same passphrase yields the same key on every machine.
+type KDFParams struct {
+	Salt       []byte
+	Iterations int
+}
+
+// NewKDFParams creates parameters with a random salt and the default iteration count
+func NewKDFParams() (*KDFParams, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	return &KDFParams{Salt: salt, Iterations: DefaultKDFIterations}, nil
+}
+
+// DeriveKey derives a key from a passphrase with PBKDF2-HMAC-SHA256
+func DeriveKey(passphrase string, params KDFParams) ([]byte, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, params.Salt, params.Iterations, KeySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	return key, nil
+}
@@ -0,0 +1,157 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// KeySize is the size of an AES-256 key in bytes
+const KeySize = 32
+
+// valuePrefix marks an encrypted value. The full format is
+// enc:v1:<key id>:<base64 of nonce and ciphertext>.
+const valuePrefix = "enc:v1:"
+
+// Keyring encrypts values with its primary key and decrypts values encrypted with
+// any of its keys, so that data written before a key rotation stays readable
+type Keyring struct {
+	primary string
+	keys    map[string]cipher.AEAD
+}
+
+// NewKeyring creates a keyring encrypting with the primary key. Previous keys are
+// only used for decryption.
+func NewKeyring(primary []byte, previous ...[]byte) (*Keyring, error) {
+	keyring := &Keyring{keys: make(map[string]cipher.AEAD)}
+
+	for i, key := range append([][]byte{primary}, previous...) {
+		if len(key) != KeySize {
+			return nil, fmt.Errorf("invalid key size %d, expected %d bytes", len(key), KeySize)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create cipher: %w", err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GCM: %w", err)
+		}
+
+		id := KeyID(key)
+		if i == 0 {
+			keyring.primary = id
+		}
+		keyring.keys[id] = aead
+	}
+
+	return keyring, nil
+}
+
+// PrimaryKeyID returns the ID of the key new values are encrypted with
+func (k *Keyring) PrimaryKeyID() string {
+	return k.primary
+}
+
+// Encrypt encrypts a value with the primary key
+func (k *Keyring) Encrypt(plaintext string) (string, error) {
+	aead := k.keys[k.primary]
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), nil)
+
+	return valuePrefix + k.primary + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts a value encrypted with one of the keyring's keys. Values that are
+// not encrypted are returned as they are.
+func (k *Keyring) Decrypt(value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+
+	id, payload, ok := strings.Cut(strings.TrimPrefix(value, valuePrefix), ":")
+	if !ok {
+		return "", fmt.Errorf("malformed encrypted value")
+	}
+	aead, ok := k.keys[id]
+	if !ok {
+		return "", fmt.Errorf("value is encrypted with unknown key %s", id)
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", fmt.Errorf("malformed encrypted value: %w", err)
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("malformed encrypted value: too short")
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value with key %s: %w", id, err)
+	}
+	return string(plaintext), nil
+}
+
+// Reencrypt returns a value encrypted with the primary key. Values already encrypted
+// with it are returned unchanged; plaintext values are encrypted.
+func (k *Keyring) Reencrypt(value string) (string, error) {
+	if id, ok := ValueKeyID(value); ok && id == k.primary {
+		return value, nil
+	}
+	plaintext, err := k.Decrypt(value)
+	if err != nil {
+		return "", err
+	}
+	return k.Encrypt(plaintext)
+}
+
+// IsEncrypted reports whether a value was produced by a keyring
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, valuePrefix)
+}
+
+// ValueKeyID returns the ID of the key an encrypted value was encrypted with
+func ValueKeyID(value string) (string, bool) {
+	if !IsEncrypted(value) {
+		return "", false
+	}
+	id, _, ok := strings.Cut(strings.TrimPrefix(value, valuePrefix), ":")
+	return id, ok
+}
+
+// KeyID returns a short identifier of a key. It is stored with every encrypted value
+// to select the key for decryption and does not reveal the key.
+func KeyID(key []byte) string {
+	sum := sha256.Sum256(append([]byte("memory-bank key id:"), key...))
+	return hex.EncodeToString(sum[:4])
+}
+
+// GenerateKey returns a new random key
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	return key, nil
+}
+
+// ParseKey decodes a base64 or hex encoded key
+func ParseKey(encoded string) ([]byte, error) {
+	encoded = strings.TrimSpace(encoded)
+	if key, err := base64.StdEncoding.DecodeString(encoded); err == nil && len(key) == KeySize {
+		return key, nil
+	}
+	if key, err := hex.DecodeString(encoded); err == nil && len(key) == KeySize {
+		return key, nil
+	}
+	return nil, fmt.Errorf("key must be %d bytes encoded as base64 or hex", KeySize)
+}
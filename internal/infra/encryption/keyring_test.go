@@ -0,0 +1,153 @@
+package encryption
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func mustKey(t *testing.T) []byte {
+	t.Helper()
+	key, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	return key
+}
+
+func TestKeyring_RoundTrip(t *testing.T) {
+	keyring, err := NewKeyring(mustKey(t))
+	if err != nil {
+		t.Fatalf("Failed to create keyring: %v", err)
+	}
+
+	encrypted, err := keyring.Encrypt("Use JWT for authentication")
+	if err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+	if !IsEncrypted(encrypted) || strings.Contains(encrypted, "JWT") {
+		t.Fatalf("Expected an encrypted value, got %q", encrypted)
+	}
+	if id, ok := ValueKeyID(encrypted); !ok || id != keyring.PrimaryKeyID() {
+		t.Errorf("Expected key ID %s, got %s", keyring.PrimaryKeyID(), id)
+	}
+
+	again, _ := keyring.Encrypt("Use JWT for authentication")
+	if again == encrypted {
+		t.Error("Expected a fresh nonce for every encryption")
+	}
+
+	decrypted, err := keyring.Decrypt(encrypted)
+	if err != nil || decrypted != "Use JWT for authentication" {
+		t.Errorf("Expected the plaintext back, got %q, %v", decrypted, err)
+	}
+
+	if plain, err := keyring.Decrypt("not encrypted"); err != nil || plain != "not encrypted" {
+		t.Errorf("Expected plaintext values to pass through, got %q, %v", plain, err)
+	}
+}
+
+func TestKeyring_WrongKeyAndTampering(t *testing.T) {
+	keyring, _ := NewKeyring(mustKey(t))
+	other, _ := NewKeyring(mustKey(t))
+
+	encrypted, _ := keyring.Encrypt("secret")
+	if _, err := other.Decrypt(encrypted); err == nil || !strings.Contains(err.Error(), "unknown key") {
+		t.Errorf("Expected an unknown key error, got %v", err)
+	}
+
+	tampered := encrypted[:len(encrypted)-4] + "AAA="
+	if _, err := keyring.Decrypt(tampered); err == nil {
+		t.Error("Expected tampered values to be rejected")
+	}
+}
+
+func TestKeyring_Rotation(t *testing.T) {
+	oldKey, newKey := mustKey(t), mustKey(t)
+	oldKeyring, _ := NewKeyring(oldKey)
+	rotated, err := NewKeyring(newKey, oldKey)
+	if err != nil {
+		t.Fatalf("Failed to create keyring: %v", err)
+	}
+
+	old, _ := oldKeyring.Encrypt("incident notes")
+	if plain, err := rotated.Decrypt(old); err != nil || plain != "incident notes" {
+		t.Fatalf("Expected previous keys to decrypt, got %q, %v", plain, err)
+	}
+
+	reencrypted, err := rotated.Reencrypt(old)
+	if err != nil {
+		t.Fatalf("Failed to re-encrypt: %v", err)
+	}
+	if id, _ := ValueKeyID(reencrypted); id != rotated.PrimaryKeyID() {
+		t.Errorf("Expected the value to use the new key, got %s", id)
+	}
+	if same, _ := rotated.Reencrypt(reencrypted); same != reencrypted {
+		t.Error("Expected values under the primary key to be kept")
+	}
+	if plain, _ := rotated.Reencrypt("plaintext"); !IsEncrypted(plain) {
+		t.Error("Expected plaintext values to be encrypted")
+	}
+}
+
+func TestNewKeyring_InvalidKey(t *testing.T) {
+	if _, err := NewKeyring([]byte("short")); err == nil {
+		t.Error("Expected short keys to be rejected")
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	key := mustKey(t)
+	for _, encoded := range []string{base64.StdEncoding.EncodeToString(key), hex.EncodeToString(key) + "\n"} {
+		parsed, err := ParseKey(encoded)
+		if err != nil || string(parsed) != string(key) {
+			t.Errorf("Failed to parse %q: %v", encoded, err)
+		}
+	}
+	if _, err := ParseKey("c2hvcnQ="); err == nil {
+		t.Error("Expected keys of the wrong size to be rejected")
+	}
+}
+
+func TestKeySource_Load(t *testing.T) {
+	key := mustKey(t)
+	file := filepath.Join(t.TempDir(), "key")
+	if err := os.WriteFile(file, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0600); err != nil {
+		t.Fatalf("Failed to write key file: %v", err)
+	}
+	t.Setenv("TEST_MEMORY_BANK_KEY", hex.EncodeToString(key))
+	t.Setenv("TEST_MEMORY_BANK_PASSPHRASE", "correct horse battery staple")
+
+	for _, source := range []KeySource{{File: file}, {Env: "TEST_MEMORY_BANK_KEY"}} {
+		loaded, err := source.Load(nil)
+		if err != nil || string(loaded) != string(key) {
+			t.Errorf("Failed to load %+v: %v", source, err)
+		}
+	}
+
+	params := &KDFParams{Salt: []byte("0123456789abcdef"), Iterations: 10}
+	passphrase := KeySource{PassphraseEnv: "TEST_MEMORY_BANK_PASSPHRASE"}
+	first, err := passphrase.Load(params)
+	if err != nil || len(first) != KeySize {
+		t.Fatalf("Failed to derive key: %v", err)
+	}
+	second, _ := passphrase.Load(params)
+	if string(first) != string(second) {
+		t.Error("Expected the same passphrase and salt to derive the same key")
+	}
+
+	invalid := []KeySource{
+		{},
+		{File: file, Env: "TEST_MEMORY_BANK_KEY"},
+		{Env: "TEST_MEMORY_BANK_UNSET"},
+		{PassphraseEnv: "TEST_MEMORY_BANK_PASSPHRASE"}, // no parameters
+	}
+	for _, source := range invalid {
+		if _, err := source.Load(nil); err == nil {
+			t.Errorf("Expected %+v to fail", source)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package encryption
+
+import (
+	"fmt"
+	"os"
+)
+
+// KeySource describes where a key comes from. Exactly one of the fields is set.
+type KeySource struct {
+	// File holds the key encoded as base64 or hex
+	File string
+	// Env names an environment variable holding the key encoded as base64 or hex
+	Env string
+	// PassphraseEnv names an environment variable holding a passphrase the key is derived from
+	PassphraseEnv string
+}
+
+// Validate checks that exactly one source is set
+func (s KeySource) Validate() error {
+	set := 0
+	for _, value := range []string{s.File, s.Env, s.PassphraseEnv} {
+		if value != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return fmt.Errorf("a key needs exactly one of file, env or passphrase_env")
+	}
+	return nil
+}
+
+// NeedsKDF reports whether the key is derived from a passphrase
+func (s KeySource) NeedsKDF() bool {
+	return s.PassphraseEnv != ""
+}
+
+// Load reads the key. Passphrases are derived with the given parameters.
+func (s KeySource) Load(params *KDFParams) ([]byte, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case s.File != "":
+		data, err := os.ReadFile(s.File)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key file: %w", err)
+		}
+		key, err := ParseKey(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("invalid key file %s: %w", s.File, err)
+		}
+		return key, nil
+
+	case s.Env != "":
+		value := os.Getenv(s.Env)
+		if value == "" {
+			return nil, fmt.Errorf("environment variable %s is not set", s.Env)
+		}
+		key, err := ParseKey(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key in %s: %w", s.Env, err)
+		}
+		return key, nil
+
+	default:
+		passphrase := os.Getenv(s.PassphraseEnv)
+		if passphrase == "" {
+			return nil, fmt.Errorf("environment variable %s is not set", s.PassphraseEnv)
+		}
+		if params == nil {
+			return nil, fmt.Errorf("key derivation parameters are required for a passphrase")
+		}
+		return DeriveKey(passphrase, *params)
+	}
+}
+
+// LoadKeyring loads the primary key and the previous keys into a keyring
+func LoadKeyring(primary KeySource, previous []KeySource, params *KDFParams) (*Keyring, error) {
+	primaryKey, err := primary.Load(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load encryption key: %w", err)
+	}
+
+	previousKeys := make([][]byte, 0, len(previous))
+	for i, source := range previous {
+		key, err := source.Load(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load previous encryption key %d: %w", i+1, err)
+		}
+		previousKeys = append(previousKeys, key)
+	}
+
+	return NewKeyring(primaryKey, previousKeys...)
+}
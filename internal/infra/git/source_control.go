@@ -0,0 +1,333 @@
+package git
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// SourceControl implements the SourceControl port using the git command line
+type SourceControl struct {
+	config Config
+	logger *logrus.Logger
+}
+
+// Config holds the thresholds used to decide whether anchored code changed heavily
+type Config struct {
+	HeavyChangeRatio    float64 `json:"heavy_change_ratio"`     // changed lines relative to the original size
+	MinHeavyChangeLines int     `json:"min_heavy_change_lines"` // small edits never count as heavy
+}
+
+// DefaultConfig returns the default change thresholds
+func DefaultConfig() Config {
+	return Config{
+		HeavyChangeRatio:    0.5,
+		MinHeavyChangeLines: 10,
+	}
+}
+
+// NewSourceControl creates a new git source control adapter
+func NewSourceControl(config Config, logger *logrus.Logger) *SourceControl {
+	if config.HeavyChangeRatio <= 0 {
+		config = DefaultConfig()
+	}
+	return &SourceControl{
+		config: config,
+		logger: logger,
+	}
+}
+
+// HeadCommit returns the current commit of the repository containing root
+func (g *SourceControl) HeadCommit(ctx context.Context, root string) (string, error) {
+	out, err := g.run(ctx, root, "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// CheckAnchor compares the anchored path in the working tree with the anchor's commit.
+// Renames and deletions are detected from the diff; a file counts as modified when the
+// lines added and removed exceed the configured share of its original size.
+func (g *SourceControl) CheckAnchor(ctx context.Context, root string, anchor domain.CodeAnchor) (domain.AnchorCheck, error) {
+	check := domain.AnchorCheck{Anchor: anchor, Status: domain.AnchorStatusCurrent}
+
+	_, statErr := os.Stat(filepath.Join(root, filepath.FromSlash(anchor.Path)))
+	exists := statErr == nil
+
+	if anchor.Commit == "" || !g.commitExists(ctx, root, anchor.Commit) {
+		if !exists {
+			check.Status = domain.AnchorStatusDeleted
+			check.Detail = "path no longer exists"
+			return check, nil
+		}
+		check.Status = domain.AnchorStatusUnknown
+		check.Detail = "no recorded commit to compare against"
+		return check, nil
+	}
+
+	if !exists {
+		check.Status = domain.AnchorStatusDeleted
+		check.Detail = "path no longer exists"
+
+		// A deleted file may have been renamed
+		nameStatus, err := g.run(ctx, root, "diff", "--relative", "--name-status", "-z", "-M", anchor.Commit, "--")
+		if err != nil {
+			return check, err
+		}
+		if newPath, ok := findRename(nameStatus, anchor.Path); ok {
+			check.Status = domain.AnchorStatusRenamed
+			check.NewPath = newPath
+			check.Detail = "renamed to " + newPath
+		}
+		return check, nil
+	}
+
+	numstat, err := g.run(ctx, root, "diff", "--relative", "--numstat", anchor.Commit, "--", anchor.Path)
+	if err != nil {
+		return check, err
+	}
+	check.ChangedLines = sumNumstat(numstat)
+	if check.ChangedLines == 0 {
+		return check, nil
+	}
+
+	originalLines, err := g.lineCount(ctx, root, anchor.Commit, anchor.Path)
+	if err != nil {
+		return check, err
+	}
+
+	if check.ChangedLines >= g.config.MinHeavyChangeLines &&
+		float64(check.ChangedLines) >= g.config.HeavyChangeRatio*float64(originalLines) {
+		check.Status = domain.AnchorStatusModified
+		check.Detail = fmt.Sprintf("%d of %d lines changed since %s", check.ChangedLines, originalLines, domain.ShortCommitHash(anchor.Commit))
+	}
+
+	return check, nil
+}
+
+// CurrentBranch returns the checked out branch, or "" if HEAD is detached
+func (g *SourceControl) CurrentBranch(ctx context.Context, root string) (string, error) {
+	out, err := g.run(ctx, root, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	branch := strings.TrimSpace(out)
+	if branch == "HEAD" {
+		return "", nil
+	}
+	return branch, nil
+}
+
+// Changes returns the commits in from..to, newest first, and the files changed
+// between the two commits with their line counts. Renames are detected.
+func (g *SourceControl) Changes(ctx context.Context, root, from, to string) (domain.GitChanges, error) {
+	var changes domain.GitChanges
+	if from == to {
+		return changes, nil
+	}
+
+	log, err := g.run(ctx, root, "log", "--format=%H%x1f%an%x1f%aI%x1f%s", from+".."+to)
+	if err != nil {
+		return changes, err
+	}
+	changes.Commits = parseLog(log)
+
+	nameStatus, err := g.run(ctx, root, "diff", "--name-status", "-z", "-M", from, to)
+	if err != nil {
+		return changes, err
+	}
+	numstat, err := g.run(ctx, root, "diff", "--numstat", "-z", "-M", from, to)
+	if err != nil {
+		return changes, err
+	}
+	changes.Files = parseNameStatus(nameStatus)
+	counts := parseNumstat(numstat)
+	for i, file := range changes.Files {
+		count, ok := counts[file.Path]
+		if !ok {
+			continue
+		}
+		changes.Files[i].Insertions = count.insertions
+		changes.Files[i].Deletions = count.deletions
+		changes.Files[i].Binary = count.binary
+		changes.Insertions += count.insertions
+		changes.Deletions += count.deletions
+	}
+	return changes, nil
+}
+
+// commitExists reports whether the commit is known to the repository
+func (g *SourceControl) commitExists(ctx context.Context, root, commit string) bool {
+	_, err := g.run(ctx, root, "cat-file", "-e", commit+"^{commit}")
+	return err == nil
+}
+
+// lineCount returns the number of lines of path (a file or directory) at the given commit
+func (g *SourceControl) lineCount(ctx context.Context, root, commit, path string) (int, error) {
+	out, err := g.run(ctx, root, "grep", "-I", "-c", "", commit, "--", path)
+	if err != nil {
+		// git grep exits with status 1 when nothing matches, e.g. for an empty file
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	total := 0
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.LastIndex(line, ":"); i >= 0 {
+			if n, err := strconv.Atoi(line[i+1:]); err == nil {
+				total += n
+			}
+		}
+	}
+	return total, nil
+}
+
+// run executes a git command in root and returns its standard output
+func (g *SourceControl) run(ctx context.Context, root string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", root}, args...)...)
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			g.logger.WithFields(logrus.Fields{
+				"args":   args,
+				"stderr": strings.TrimSpace(string(exitErr.Stderr)),
+			}).Debug("git command failed")
+			return "", fmt.Errorf("git %s failed: %w", args[0], err)
+		}
+		return "", fmt.Errorf("failed to run git: %w", err)
+	}
+	return string(out), nil
+}
+
+// findRename looks up the new name of path in `git diff --name-status -z -M` output
+func findRename(nameStatus, path string) (string, bool) {
+	for _, file := range parseNameStatus(nameStatus) {
+		if file.Status == domain.GitFileRenamed && file.OldPath == path {
+			return file.Path, true
+		}
+	}
+	return "", false
+}
+
+// parseLog parses `git log --format=%H%x1f%an%x1f%aI%x1f%s` output
+func parseLog(log string) []domain.GitCommit {
+	var commits []domain.GitCommit
+	scanner := bufio.NewScanner(strings.NewReader(log))
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "\x1f")
+		if len(fields) != 4 {
+			continue
+		}
+		date, _ := time.Parse(time.RFC3339, fields[2])
+		commits = append(commits, domain.GitCommit{
+			Hash:    fields[0],
+			Author:  fields[1],
+			Date:    date,
+			Subject: fields[3],
+		})
+	}
+	return commits
+}
+
+// parseNameStatus parses `git diff --name-status -z` output. Renames and copies are
+// followed by both the old and the new path.
+func parseNameStatus(out string) []domain.GitFileChange {
+	var files []domain.GitFileChange
+	fields := strings.Split(strings.TrimSuffix(out, "\x00"), "\x00")
+	for i := 0; i < len(fields); i++ {
+		status := fields[i]
+		if status == "" || i+1 >= len(fields) {
+			continue
+		}
+		file := domain.GitFileChange{Status: fileStatus(status[0])}
+		if (status[0] == 'R' || status[0] == 'C') && i+2 < len(fields) {
+			file.OldPath, file.Path = fields[i+1], fields[i+2]
+			i += 2
+		} else {
+			file.Path = fields[i+1]
+			i++
+		}
+		files = append(files, file)
+	}
+	return files
+}
+
+func fileStatus(code byte) string {
+	switch code {
+	case 'A':
+		return domain.GitFileAdded
+	case 'D':
+		return domain.GitFileDeleted
+	case 'R':
+		return domain.GitFileRenamed
+	case 'C':
+		return domain.GitFileCopied
+	default:
+		return domain.GitFileModified
+	}
+}
+
+// lineCounts are the lines added and removed in a file
+type lineCounts struct {
+	insertions, deletions int
+	binary                bool
+}
+
+// parseNumstat parses `git diff --numstat -z` output into line counts per path. For
+// renames and copies the counts line is followed by the old and the new path.
+func parseNumstat(out string) map[string]lineCounts {
+	counts := make(map[string]lineCounts)
+	fields := strings.Split(strings.TrimSuffix(out, "\x00"), "\x00")
+	for i := 0; i < len(fields); i++ {
+		parts := strings.SplitN(fields[i], "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		path := parts[2]
+		if path == "" && i+2 < len(fields) {
+			path = fields[i+2]
+			i += 2
+		}
+		// Binary files report "-" for both counts
+		var c lineCounts
+		c.binary = parts[0] == "-"
+		c.insertions, _ = strconv.Atoi(parts[0])
+		c.deletions, _ = strconv.Atoi(parts[1])
+		counts[path] = c
+	}
+	return counts
+}
+
+// sumNumstat adds up the added and removed lines in `git diff --numstat` output
+func sumNumstat(numstat string) int {
+	total := 0
+	scanner := bufio.NewScanner(strings.NewReader(numstat))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 {
+			continue
+		}
+		// Binary files report "-" for both counts
+		added, _ := strconv.Atoi(fields[0])
+		removed, _ := strconv.Atoi(fields[1])
+		total += added + removed
+	}
+	return total
+}
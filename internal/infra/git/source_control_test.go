@@ -0,0 +1,216 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/sirupsen/logrus"
+)
+
+// setupTestRepo creates a git repository with a committed file of 20 lines
+func setupTestRepo(t *testing.T) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	root := t.TempDir()
+	runGit(t, root, "init", "-q")
+	writeLines(t, filepath.Join(root, "store.go"), 20, "original")
+	writeLines(t, filepath.Join(root, "other.go"), 20, "other")
+	runGit(t, root, "add", ".")
+	runGit(t, root, "commit", "-q", "-m", "initial")
+	return root
+}
+
+func runGit(t *testing.T, root string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-C", root, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, out)
+	}
+}
+
+func writeLines(t *testing.T, path string, n int, text string) {
+	t.Helper()
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = text
+	}
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+}
+
+func setupSourceControl(t *testing.T) (*SourceControl, string, string) {
+	t.Helper()
+	root := setupTestRepo(t)
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	sc := NewSourceControl(DefaultConfig(), logger)
+
+	commit, err := sc.HeadCommit(context.Background(), root)
+	if err != nil {
+		t.Fatalf("Failed to get head commit: %v", err)
+	}
+	if len(commit) != 40 {
+		t.Fatalf("Expected a full commit hash, got %q", commit)
+	}
+	return sc, root, commit
+}
+
+func TestSourceControl_CheckAnchor_Unchanged(t *testing.T) {
+	sc, root, commit := setupSourceControl(t)
+
+	// A small edit does not make the anchor stale
+	writeLines(t, filepath.Join(root, "store.go"), 21, "original")
+
+	check, err := sc.CheckAnchor(context.Background(), root, domain.CodeAnchor{Path: "store.go", Commit: commit})
+	if err != nil {
+		t.Fatalf("Failed to check anchor: %v", err)
+	}
+	if check.Status != domain.AnchorStatusCurrent || check.ChangedLines != 1 {
+		t.Errorf("Expected current with 1 changed line, got %s with %d", check.Status, check.ChangedLines)
+	}
+}
+
+func TestSourceControl_CheckAnchor_HeavilyModified(t *testing.T) {
+	sc, root, commit := setupSourceControl(t)
+
+	writeLines(t, filepath.Join(root, "store.go"), 20, "rewritten")
+
+	check, err := sc.CheckAnchor(context.Background(), root, domain.CodeAnchor{Path: "store.go", Commit: commit})
+	if err != nil {
+		t.Fatalf("Failed to check anchor: %v", err)
+	}
+	if check.Status != domain.AnchorStatusModified || !check.PossiblyStale() {
+		t.Errorf("Expected modified, got %s (%d lines changed)", check.Status, check.ChangedLines)
+	}
+}
+
+func TestSourceControl_CheckAnchor_RenamedAndDeleted(t *testing.T) {
+	sc, root, commit := setupSourceControl(t)
+	ctx := context.Background()
+
+	runGit(t, root, "mv", "store.go", "vector_store.go")
+	if err := os.Remove(filepath.Join(root, "other.go")); err != nil {
+		t.Fatalf("Failed to remove file: %v", err)
+	}
+
+	renamed, err := sc.CheckAnchor(ctx, root, domain.CodeAnchor{Path: "store.go", Commit: commit})
+	if err != nil {
+		t.Fatalf("Failed to check anchor: %v", err)
+	}
+	if renamed.Status != domain.AnchorStatusRenamed || renamed.NewPath != "vector_store.go" {
+		t.Errorf("Expected rename to vector_store.go, got %s %q", renamed.Status, renamed.NewPath)
+	}
+
+	deleted, err := sc.CheckAnchor(ctx, root, domain.CodeAnchor{Path: "other.go", Commit: commit})
+	if err != nil {
+		t.Fatalf("Failed to check anchor: %v", err)
+	}
+	if deleted.Status != domain.AnchorStatusDeleted {
+		t.Errorf("Expected deleted, got %s", deleted.Status)
+	}
+}
+
+func TestSourceControl_CheckAnchor_RenamedWithSpecialCharacters(t *testing.T) {
+	sc, root, _ := setupSourceControl(t)
+	ctx := context.Background()
+
+	// Git quotes paths with spaces and non-ASCII characters unless -z is used
+	writeLines(t, filepath.Join(root, "my store.go"), 20, "spaced")
+	runGit(t, root, "add", ".")
+	runGit(t, root, "commit", "-q", "-m", "add spaced file")
+	commit, err := sc.HeadCommit(ctx, root)
+	if err != nil {
+		t.Fatalf("Failed to get head commit: %v", err)
+	}
+	runGit(t, root, "mv", "my store.go", "vector störe.go")
+
+	check, err := sc.CheckAnchor(ctx, root, domain.CodeAnchor{Path: "my store.go", Commit: commit})
+	if err != nil {
+		t.Fatalf("Failed to check anchor: %v", err)
+	}
+	if check.Status != domain.AnchorStatusRenamed || check.NewPath != "vector störe.go" {
+		t.Errorf("Expected rename to %q, got %s %q", "vector störe.go", check.Status, check.NewPath)
+	}
+}
+
+func TestSourceControl_CheckAnchor_WithoutCommit(t *testing.T) {
+	sc, root, _ := setupSourceControl(t)
+
+	check, err := sc.CheckAnchor(context.Background(), root, domain.CodeAnchor{Path: "store.go"})
+	if err != nil {
+		t.Fatalf("Failed to check anchor: %v", err)
+	}
+	if check.Status != domain.AnchorStatusUnknown || check.PossiblyStale() {
+		t.Errorf("Expected unknown status, got %s", check.Status)
+	}
+}
+
+func TestSourceControl_Changes(t *testing.T) {
+	sc, root, start := setupSourceControl(t)
+	ctx := context.Background()
+
+	runGit(t, root, "checkout", "-q", "-b", "feature/cache")
+	branch, err := sc.CurrentBranch(ctx, root)
+	if err != nil {
+		t.Fatalf("Failed to get branch: %v", err)
+	}
+	if branch != "feature/cache" {
+		t.Errorf("Expected branch feature/cache, got %q", branch)
+	}
+
+	writeLines(t, filepath.Join(root, "cache.go"), 5, "cache")
+	runGit(t, root, "add", ".")
+	runGit(t, root, "commit", "-q", "-m", "Add cache")
+	runGit(t, root, "mv", "store.go", "vector_store.go")
+	writeLines(t, filepath.Join(root, "other.go"), 18, "other")
+	runGit(t, root, "commit", "-q", "-a", "-m", "Rename store")
+	end, err := sc.HeadCommit(ctx, root)
+	if err != nil {
+		t.Fatalf("Failed to get head commit: %v", err)
+	}
+
+	changes, err := sc.Changes(ctx, root, start, end)
+	if err != nil {
+		t.Fatalf("Failed to get changes: %v", err)
+	}
+	if len(changes.Commits) != 2 || changes.Commits[0].Subject != "Rename store" || changes.Commits[1].Subject != "Add cache" {
+		t.Errorf("Expected both commits newest first, got %+v", changes.Commits)
+	}
+	if changes.Commits[0].Author != "test" || changes.Commits[0].Date.IsZero() {
+		t.Errorf("Expected author and date, got %+v", changes.Commits[0])
+	}
+
+	files := make(map[string]domain.GitFileChange)
+	for _, file := range changes.Files {
+		files[file.Path] = file
+	}
+	if file := files["cache.go"]; file.Status != domain.GitFileAdded || file.Insertions != 5 {
+		t.Errorf("Expected cache.go added with 5 lines, got %+v", file)
+	}
+	if file := files["vector_store.go"]; file.Status != domain.GitFileRenamed || file.OldPath != "store.go" {
+		t.Errorf("Expected store.go renamed to vector_store.go, got %+v", file)
+	}
+	if file := files["other.go"]; file.Status != domain.GitFileModified || file.Deletions != 2 {
+		t.Errorf("Expected other.go modified with 2 deletions, got %+v", file)
+	}
+	if changes.Insertions != 5 || changes.Deletions != 2 {
+		t.Errorf("Expected +5 -2 in total, got +%d -%d", changes.Insertions, changes.Deletions)
+	}
+
+	// Nothing changed without new commits
+	unchanged, err := sc.Changes(ctx, root, end, end)
+	if err != nil || len(unchanged.Commits) != 0 || len(unchanged.Files) != 0 {
+		t.Errorf("Expected no changes, got %+v (%v)", unchanged, err)
+	}
+}
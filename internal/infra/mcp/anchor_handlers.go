@@ -0,0 +1,165 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+// SetAnchorService enables code anchors on memories and the memory_for_file tool
+func (s *MemoryBankServer) SetAnchorService(anchorService ports.AnchorService) {
+	s.anchorService = anchorService
+}
+
+// parseAnchors parses anchors given as path[:start[-end]][#symbol]
+func parseAnchors(values []string) ([]domain.CodeAnchor, error) {
+	anchors := make([]domain.CodeAnchor, 0, len(values))
+	for _, value := range values {
+		anchor, err := domain.ParseCodeAnchor(value)
+		if err != nil {
+			return nil, err
+		}
+		anchors = append(anchors, anchor)
+	}
+	return anchors, nil
+}
+
+// MemoryForFileRequest represents a request for the memories anchored to a file
+type MemoryForFileRequest struct {
+	Path           string  `json:"path"`
+	ProjectID      *string `json:"project_id,omitempty"`
+	CheckStaleness *bool   `json:"check_staleness,omitempty"`
+}
+
+// MemoryForFileResponse lists the memories anchored to a file
+type MemoryForFileResponse struct {
+	ProjectID  string                 `json:"project_id"`
+	Path       string                 `json:"path"`
+	Memories   []ports.AnchoredMemory `json:"memories"`
+	Total      int                    `json:"total"`
+	StaleCount int                    `json:"stale_count"`
+}
+
+func (s *MemoryBankServer) handleMemoryForFile(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	s.logger.Debug("Handling memory/for_file request")
+
+	if s.anchorService == nil {
+		return nil, fmt.Errorf("anchor service is not available")
+	}
+
+	var req MemoryForFileRequest
+	if err := json.Unmarshal(params, &req); err != nil {
+		return nil, fmt.Errorf("invalid request parameters: %w", err)
+	}
+
+	if req.Path == "" {
+		return nil, fmt.Errorf("path is required")
+	}
+
+	var projectID domain.ProjectID
+	if req.ProjectID != nil && *req.ProjectID != "" {
+		projectID = domain.ProjectID(*req.ProjectID)
+	} else if project := s.currentProject(ctx); project != nil {
+		projectID = project.ID
+	} else {
+		return nil, fmt.Errorf("project_id is required outside a project directory")
+	}
+
+	checkStaleness := req.CheckStaleness == nil || *req.CheckStaleness
+
+	memories, err := s.anchorService.MemoriesForFile(ctx, projectID, req.Path, checkStaleness)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get memories for file")
+		return nil, fmt.Errorf("failed to get memories for file: %w", err)
+	}
+
+	response := MemoryForFileResponse{
+		ProjectID: string(projectID),
+		Path:      req.Path,
+		Memories:  memories,
+		Total:     len(memories),
+	}
+	ids := make([]domain.MemoryID, len(memories))
+	for i, memory := range memories {
+		ids[i] = memory.Memory.ID
+		if memory.PossiblyStale {
+			response.StaleCount++
+		}
+	}
+	if response.Memories == nil {
+		response.Memories = []ports.AnchoredMemory{}
+	}
+
+	s.recordAccess(ctx, ids)
+
+	s.logger.WithFields(logrus.Fields{
+		"path":        req.Path,
+		"total":       response.Total,
+		"stale_count": response.StaleCount,
+	}).Info("Memories for file retrieved successfully")
+	return response, nil
+}
+
+// AnchorMemoryRequest represents a request to add or remove anchors of a memory
+type AnchorMemoryRequest struct {
+	ID     string   `json:"id"`
+	Add    []string `json:"add,omitempty"`
+	Remove []string `json:"remove,omitempty"`
+}
+
+func (s *MemoryBankServer) handleAnchorMemory(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	s.logger.Debug("Handling memory/anchor request")
+
+	if s.anchorService == nil {
+		return nil, fmt.Errorf("anchor service is not available")
+	}
+
+	var req AnchorMemoryRequest
+	if err := json.Unmarshal(params, &req); err != nil {
+		return nil, fmt.Errorf("invalid request parameters: %w", err)
+	}
+
+	if req.ID == "" {
+		return nil, fmt.Errorf("id is required")
+	}
+	if len(req.Add) == 0 && len(req.Remove) == 0 {
+		return nil, fmt.Errorf("add or remove is required")
+	}
+
+	anchors, err := parseAnchors(req.Add)
+	if err != nil {
+		return nil, err
+	}
+
+	memoryID := domain.MemoryID(req.ID)
+	var memory *domain.Memory
+	if len(req.Remove) > 0 {
+		if memory, err = s.anchorService.RemoveAnchors(ctx, memoryID, req.Remove); err != nil {
+			return nil, fmt.Errorf("failed to remove anchors: %w", err)
+		}
+	}
+	if len(anchors) > 0 {
+		if memory, err = s.anchorService.AddAnchors(ctx, memoryID, anchors); err != nil {
+			return nil, fmt.Errorf("failed to add anchors: %w", err)
+		}
+	}
+
+	s.logger.WithField("memory_id", memoryID).Info("Memory anchors updated")
+	return map[string]interface{}{
+		"id":      string(memory.ID),
+		"anchors": memory.Anchors,
+	}, nil
+}
+
+func (s *MemoryBankServer) handleMemoryForFileTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return s.wrapHandler(ctx, request, s.handleMemoryForFile)
+}
+
+func (s *MemoryBankServer) handleAnchorMemoryTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return s.wrapHandler(ctx, request, s.handleAnchorMemory)
+}
@@ -0,0 +1,94 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/joern1811/memory-bank/internal/app"
+	"github.com/joern1811/memory-bank/internal/infra/database"
+	"github.com/joern1811/memory-bank/internal/infra/embedding"
+	"github.com/joern1811/memory-bank/internal/infra/vector"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+func TestMemoryForFileTool(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	db, err := database.NewSQLiteDatabase(":memory:", logger)
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+
+	memoryRepo := database.NewSQLiteMemoryRepository(db, logger)
+	projectRepo := database.NewSQLiteProjectRepository(db, logger)
+	memoryService := app.NewMemoryService(
+		memoryRepo,
+		embedding.NewMockEmbeddingProvider(768, logger),
+		vector.NewMockVectorStore(logger),
+		logger,
+	)
+	projectService := app.NewProjectService(projectRepo, logger)
+
+	ctx := context.Background()
+	project, err := projectService.InitializeProject(ctx, "/test/path", ports.InitializeProjectRequest{Name: "Test Project"})
+	if err != nil {
+		t.Fatalf("Failed to create test project: %v", err)
+	}
+
+	server := NewMemoryBankServer(memoryService, projectService, nil, nil, logger)
+	// Without source control anchors are stored without a commit and not checked
+	server.SetAnchorService(app.NewAnchorService(memoryService, memoryRepo, projectRepo, nil, logger))
+
+	create := mcp.CallToolRequest{}
+	create.Params.Arguments = map[string]interface{}{
+		"type":       "decision",
+		"title":      "Cosine distance for ChromaDB",
+		"content":    "The collection uses cosine distance",
+		"project_id": string(project.ID),
+		"anchors":    []interface{}{"internal/infra/vector/chromadb.go#ChromaDBVectorStore"},
+	}
+	result, err := server.handleCreateMemoryTool(ctx, create)
+	if err != nil || result.IsError {
+		t.Fatalf("Failed to create anchored memory: %v %+v", err, result)
+	}
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]interface{}{
+		"path":       "/test/path/internal/infra/vector/chromadb.go",
+		"project_id": string(project.ID),
+	}
+	result, err = server.handleMemoryForFileTool(ctx, request)
+	if err != nil {
+		t.Fatalf("Failed to call memory_for_file: %v", err)
+	}
+
+	var response MemoryForFileResponse
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Total != 1 || response.Memories[0].Memory.Title != "Cosine distance for ChromaDB" {
+		t.Errorf("Expected the anchored memory, got %+v", response)
+	}
+	if response.StaleCount != 0 {
+		t.Errorf("Expected no stale memories without source control, got %d", response.StaleCount)
+	}
+
+	request.Params.Arguments = map[string]interface{}{
+		"path":       "cmd/main.go",
+		"project_id": string(project.ID),
+	}
+	result, err = server.handleMemoryForFileTool(ctx, request)
+	if err != nil {
+		t.Fatalf("Failed to call memory_for_file: %v", err)
+	}
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Total != 0 {
+		t.Errorf("Expected no memories for an unrelated file, got %d", response.Total)
+	}
+}
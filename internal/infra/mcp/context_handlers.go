@@ -0,0 +1,235 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+// SetContextService enables the memory_context and session_handoff tools and the
+// session handoff resource
+func (s *MemoryBankServer) SetContextService(contextService ports.ContextService) {
+	s.contextService = contextService
+}
+
+// ContextRequest represents a request for a task-scoped context pack
+type ContextRequest struct {
+	Task        string   `json:"task"`
+	ProjectID   *string  `json:"project_id,omitempty"`
+	Files       []string `json:"files,omitempty"`
+	TokenBudget int      `json:"token_budget,omitempty"`
+	Limit       int      `json:"limit,omitempty"`
+	Threshold   float32  `json:"threshold,omitempty"`
+	Format      string   `json:"format,omitempty"` // "markdown" (default) or "json"
+}
+
+func (s *MemoryBankServer) handleContext(ctx context.Context, params json.RawMessage) (*ports.ContextPack, error) {
+	s.logger.Debug("Handling memory/context request")
+
+	if s.contextService == nil {
+		return nil, fmt.Errorf("context service is not available")
+	}
+
+	var req ContextRequest
+	if err := json.Unmarshal(params, &req); err != nil {
+		return nil, fmt.Errorf("invalid request parameters: %w", err)
+	}
+
+	if req.Task == "" {
+		return nil, fmt.Errorf("task is required")
+	}
+
+	packReq := ports.ContextPackRequest{
+		Task:        req.Task,
+		Files:       req.Files,
+		TokenBudget: req.TokenBudget,
+		Limit:       req.Limit,
+		Threshold:   req.Threshold,
+	}
+	if req.ProjectID != nil && *req.ProjectID != "" {
+		projectID := domain.ProjectID(*req.ProjectID)
+		packReq.ProjectID = &projectID
+	} else if project := s.currentProject(ctx); project != nil {
+		packReq.ProjectID = &project.ID
+	}
+
+	pack, err := s.contextService.BuildContextPack(ctx, packReq)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to build context pack")
+		return nil, fmt.Errorf("failed to build context pack: %w", err)
+	}
+
+	s.recordAccess(ctx, pack.MemoryIDs())
+
+	s.logger.WithField("estimated_tokens", pack.EstimatedTokens).Info("Context pack built successfully")
+	return pack, nil
+}
+
+// HandoffRequest represents a request for a session handoff brief
+type HandoffRequest struct {
+	ProjectID   *string `json:"project_id,omitempty"`
+	SessionID   *string `json:"session_id,omitempty"`
+	TokenBudget int     `json:"token_budget,omitempty"`
+	Limit       int     `json:"limit,omitempty"`
+	Format      string  `json:"format,omitempty"` // "markdown" (default) or "json"
+}
+
+func (s *MemoryBankServer) handleHandoff(ctx context.Context, params json.RawMessage) (*ports.HandoffBrief, error) {
+	s.logger.Debug("Handling session/handoff request")
+
+	if s.contextService == nil {
+		return nil, fmt.Errorf("context service is not available")
+	}
+
+	var req HandoffRequest
+	if err := json.Unmarshal(params, &req); err != nil {
+		return nil, fmt.Errorf("invalid request parameters: %w", err)
+	}
+
+	handoffReq := ports.HandoffRequest{
+		TokenBudget: req.TokenBudget,
+		Limit:       req.Limit,
+	}
+	if req.SessionID != nil && *req.SessionID != "" {
+		sessionID := domain.SessionID(*req.SessionID)
+		handoffReq.SessionID = &sessionID
+	}
+	switch {
+	case req.ProjectID != nil && *req.ProjectID != "":
+		handoffReq.ProjectID = domain.ProjectID(*req.ProjectID)
+	case handoffReq.SessionID == nil:
+		project := s.currentProject(ctx)
+		if project == nil {
+			return nil, fmt.Errorf("project_id is required outside a registered project")
+		}
+		handoffReq.ProjectID = project.ID
+	}
+
+	brief, err := s.contextService.BuildHandoff(ctx, handoffReq)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to build session handoff")
+		return nil, fmt.Errorf("failed to build session handoff: %w", err)
+	}
+
+	s.recordAccess(ctx, brief.MemoryIDs())
+
+	s.logger.WithFields(logrus.Fields{
+		"session_id":       brief.Session.ID,
+		"estimated_tokens": brief.EstimatedTokens,
+	}).Info("Session handoff built successfully")
+	return brief, nil
+}
+
+func (s *MemoryBankServer) handleHandoffTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	params, err := json.Marshal(request.Params.Arguments)
+	if err != nil {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{Type: "text", Text: fmt.Sprintf("Error marshaling arguments: %v", err)},
+			},
+		}, nil
+	}
+
+	brief, err := s.handleHandoff(ctx, params)
+	if err != nil {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{Type: "text", Text: fmt.Sprintf("Error: %v", err)},
+			},
+		}, nil
+	}
+
+	// The markdown rendering is meant to be pasted into a new conversation as is
+	text := brief.Text
+	if format, _ := request.GetArguments()["format"].(string); format == "json" {
+		briefJSON, err := json.Marshal(brief)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.TextContent{Type: "text", Text: fmt.Sprintf("Error marshaling result: %v", err)},
+				},
+			}, nil
+		}
+		text = string(briefJSON)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			mcp.TextContent{Type: "text", Text: text},
+		},
+	}, nil
+}
+
+// handleHandoffResource renders the handoff brief of the current project's session last worked on
+func (s *MemoryBankServer) handleHandoffResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	brief, err := s.handleHandoff(ctx, json.RawMessage("{}"))
+	if err != nil {
+		return nil, err
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      request.Params.URI,
+			MIMEType: "text/markdown",
+			Text:     brief.Text,
+		},
+	}, nil
+}
+
+// currentProject returns the project containing the server's working directory, if any
+func (s *MemoryBankServer) currentProject(ctx context.Context) *domain.Project {
+	currentDir, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+	project, err := s.projectService.GetProjectByPath(ctx, currentDir)
+	if err != nil {
+		return nil
+	}
+	return project
+}
+
+func (s *MemoryBankServer) handleContextTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	params, err := json.Marshal(request.Params.Arguments)
+	if err != nil {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{Type: "text", Text: fmt.Sprintf("Error marshaling arguments: %v", err)},
+			},
+		}, nil
+	}
+
+	pack, err := s.handleContext(ctx, params)
+	if err != nil {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.TextContent{Type: "text", Text: fmt.Sprintf("Error: %v", err)},
+			},
+		}, nil
+	}
+
+	// The markdown rendering is meant to be pasted into a prompt as is
+	text := pack.Text
+	if format, _ := request.GetArguments()["format"].(string); format == "json" {
+		packJSON, err := json.Marshal(pack)
+		if err != nil {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					mcp.TextContent{Type: "text", Text: fmt.Sprintf("Error marshaling result: %v", err)},
+				},
+			}, nil
+		}
+		text = string(packJSON)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			mcp.TextContent{Type: "text", Text: text},
+		},
+	}, nil
+}
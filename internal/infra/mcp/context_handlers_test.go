@@ -0,0 +1,171 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/joern1811/memory-bank/internal/app"
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/infra/database"
+	"github.com/joern1811/memory-bank/internal/infra/embedding"
+	"github.com/joern1811/memory-bank/internal/infra/vector"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+func TestContextTool(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	db, err := database.NewSQLiteDatabase(":memory:", logger)
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+
+	memoryService := app.NewMemoryService(
+		database.NewSQLiteMemoryRepository(db, logger),
+		embedding.NewMockEmbeddingProvider(768, logger),
+		vector.NewMockVectorStore(logger),
+		logger,
+	)
+	projectService := app.NewProjectService(database.NewSQLiteProjectRepository(db, logger), logger)
+	taskService := app.NewTaskService(memoryService, logger)
+
+	ctx := context.Background()
+	project, err := projectService.InitializeProject(ctx, "/test/path", ports.InitializeProjectRequest{Name: "Test Project"})
+	if err != nil {
+		t.Fatalf("Failed to create test project: %v", err)
+	}
+
+	memory, err := memoryService.CreateMemory(ctx, ports.CreateMemoryRequest{
+		ProjectID: project.ID,
+		Type:      domain.MemoryTypeDecision,
+		Title:     "Use JWT for authentication",
+		Content:   "Use JWT for authentication",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create memory: %v", err)
+	}
+
+	server := NewMemoryBankServer(memoryService, projectService, nil, taskService, logger)
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]interface{}{"task": "Use JWT for authentication"}
+
+	// Without a context service the tool reports an error
+	result, err := server.handleContextTool(ctx, request)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.HasPrefix(text, "Error:") {
+		t.Errorf("Expected an error without context service, got %q", text)
+	}
+
+	server.SetContextService(app.NewContextService(memoryService, taskService, nil, logger))
+
+	request.Params.Arguments = map[string]interface{}{
+		"task":       "Use JWT for authentication",
+		"project_id": string(project.ID),
+	}
+	result, err = server.handleContextTool(ctx, request)
+	if err != nil {
+		t.Fatalf("Failed to call memory_context: %v", err)
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	if !strings.HasPrefix(text, "# Context for: Use JWT for authentication") || !strings.Contains(text, "## Decisions") {
+		t.Errorf("Expected markdown context pack, got:\n%s", text)
+	}
+
+	// Retrieval through the context pack counts as an access
+	retrieved, err := memoryService.GetMemory(ctx, memory.ID)
+	if err != nil {
+		t.Fatalf("Failed to get memory: %v", err)
+	}
+	if retrieved.AccessCount != 1 {
+		t.Errorf("Expected access count 1, got %d", retrieved.AccessCount)
+	}
+
+	request.Params.Arguments = map[string]interface{}{
+		"task":       "Use JWT for authentication",
+		"project_id": string(project.ID),
+		"format":     "json",
+	}
+	result, err = server.handleContextTool(ctx, request)
+	if err != nil {
+		t.Fatalf("Failed to call memory_context: %v", err)
+	}
+	var pack ports.ContextPack
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &pack); err != nil {
+		t.Fatalf("Expected JSON context pack: %v", err)
+	}
+	if len(pack.Decisions) != 1 || pack.Decisions[0].Memory.ID != memory.ID {
+		t.Errorf("Expected the decision in the JSON pack, got %d decisions", len(pack.Decisions))
+	}
+}
+
+func TestHandoffTool(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	db, err := database.NewSQLiteDatabase(":memory:", logger)
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+
+	memoryService := app.NewMemoryService(
+		database.NewSQLiteMemoryRepository(db, logger),
+		embedding.NewMockEmbeddingProvider(768, logger),
+		vector.NewMockVectorStore(logger),
+		logger,
+	)
+	projectRepo := database.NewSQLiteProjectRepository(db, logger)
+	projectService := app.NewProjectService(projectRepo, logger)
+	sessionRepo := database.NewSQLiteSessionRepository(db, logger)
+	sessionService := app.NewSessionService(sessionRepo, projectRepo, logger)
+	taskService := app.NewTaskService(memoryService, logger)
+
+	ctx := context.Background()
+	project, err := projectService.InitializeProject(ctx, "/test/path", ports.InitializeProjectRequest{Name: "Test Project"})
+	if err != nil {
+		t.Fatalf("Failed to create test project: %v", err)
+	}
+
+	session := domain.NewSession(project.ID, "Auth refactor", "Refactor token validation")
+	session.LogIssue("Refresh tokens are not revoked")
+	if err := sessionRepo.Store(ctx, session); err != nil {
+		t.Fatalf("Failed to store session: %v", err)
+	}
+
+	server := NewMemoryBankServer(memoryService, projectService, sessionService, taskService, logger)
+	server.SetContextService(app.NewContextService(memoryService, taskService, sessionService, logger))
+
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]interface{}{"project_id": string(project.ID)}
+	result, err := server.handleHandoffTool(ctx, request)
+	if err != nil {
+		t.Fatalf("Failed to call session_handoff: %v", err)
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+	if !strings.HasPrefix(text, "# Handoff: Auth refactor") || !strings.Contains(text, "Refresh tokens are not revoked") {
+		t.Errorf("Expected markdown handoff brief, got:\n%s", text)
+	}
+
+	request.Params.Arguments = map[string]interface{}{
+		"session_id": string(session.ID),
+		"format":     "json",
+	}
+	result, err = server.handleHandoffTool(ctx, request)
+	if err != nil {
+		t.Fatalf("Failed to call session_handoff: %v", err)
+	}
+	var brief ports.HandoffBrief
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &brief); err != nil {
+		t.Fatalf("Expected JSON handoff brief: %v", err)
+	}
+	if brief.Session == nil || brief.Session.ID != session.ID || len(brief.OpenIssues) != 1 {
+		t.Errorf("Expected the session and its open issue in the JSON brief, got %+v", brief)
+	}
+}
@@ -0,0 +1,83 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ListMemoryTypesRequest represents a request to list the available memory types
+type ListMemoryTypesRequest struct {
+	ProjectID *string `json:"project_id,omitempty"`
+}
+
+// ListMemoryTypesResponse lists memory types with their fields
+type ListMemoryTypesResponse struct {
+	Types []domain.MemoryTypeDefinition `json:"types"`
+	Total int                           `json:"total"`
+}
+
+func (s *MemoryBankServer) handleListMemoryTypes(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	s.logger.Debug("Handling memory/types request")
+
+	var req ListMemoryTypesRequest
+	if err := json.Unmarshal(params, &req); err != nil {
+		return nil, fmt.Errorf("invalid request parameters: %w", err)
+	}
+
+	types := s.memoryService.ListMemoryTypes(ctx, optionalProjectID(req.ProjectID))
+
+	s.logger.WithField("count", len(types)).Info("Memory types listed successfully")
+	return ListMemoryTypesResponse{Types: types, Total: len(types)}, nil
+}
+
+// memoryTypeDescription describes the type parameter of memory_create, listing the
+// available types and the fields of user-defined types
+func (s *MemoryBankServer) memoryTypeDescription(ctx context.Context) string {
+	var builtin, custom []string
+	for _, def := range s.memoryService.ListMemoryTypes(ctx, nil) {
+		if def.Builtin {
+			builtin = append(builtin, string(def.Name))
+			continue
+		}
+
+		entry := string(def.Name)
+		if def.ProjectID != nil {
+			entry += fmt.Sprintf(" (project %s)", *def.ProjectID)
+		}
+		if len(def.Fields) > 0 {
+			fields := make([]string, len(def.Fields))
+			for i, field := range def.Fields {
+				fields[i] = describeField(field)
+			}
+			entry += " with fields " + strings.Join(fields, ", ")
+		}
+		custom = append(custom, entry)
+	}
+
+	description := "Memory type: " + strings.Join(builtin, ", ")
+	if len(custom) > 0 {
+		description += ". User-defined: " + strings.Join(custom, "; ") + ". Pass their fields in fields"
+	}
+	return description
+}
+
+// describeField renders a field definition as e.g. severity (enum: low|high, required)
+func describeField(field domain.FieldDefinition) string {
+	kind := string(field.Type)
+	if field.Type == domain.FieldTypeEnum {
+		kind += ": " + strings.Join(field.Values, "|")
+	}
+	if field.Required {
+		kind += ", required"
+	}
+	return fmt.Sprintf("%s (%s)", field.Name, kind)
+}
+
+func (s *MemoryBankServer) handleListMemoryTypesTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return s.wrapHandler(ctx, request, s.handleListMemoryTypes)
+}
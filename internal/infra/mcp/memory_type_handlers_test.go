@@ -0,0 +1,111 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/joern1811/memory-bank/internal/app"
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/infra/database"
+	"github.com/joern1811/memory-bank/internal/infra/embedding"
+	"github.com/joern1811/memory-bank/internal/infra/vector"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+func TestMemoryTypeTools(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	db, err := database.NewSQLiteDatabase(":memory:", logger)
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+
+	memoryService := app.NewMemoryService(
+		database.NewSQLiteMemoryRepository(db, logger),
+		embedding.NewMockEmbeddingProvider(768, logger),
+		vector.NewMockVectorStore(logger),
+		logger,
+	)
+	registry := domain.NewMemoryTypeRegistry()
+	if err := registry.Register(domain.MemoryTypeDefinition{
+		Name:        "runbook",
+		Description: "Operational runbook",
+		Fields: []domain.FieldDefinition{
+			{Name: "service", Type: domain.FieldTypeString, Required: true},
+			{Name: "severity", Type: domain.FieldTypeEnum, Values: []string{"low", "high"}},
+		},
+	}); err != nil {
+		t.Fatalf("Failed to register runbook: %v", err)
+	}
+	memoryService.SetMemoryTypes(registry)
+
+	server := NewMemoryBankServer(memoryService, app.NewProjectService(database.NewSQLiteProjectRepository(db, logger), logger), nil, nil, logger)
+	ctx := context.Background()
+
+	description := server.memoryTypeDescription(ctx)
+	if !strings.Contains(description, "runbook with fields service (string, required), severity (enum: low|high)") {
+		t.Errorf("Expected the type description to list runbook, got %q", description)
+	}
+
+	for _, service := range []string{"billing", "search"} {
+		create := mcp.CallToolRequest{}
+		create.Params.Arguments = map[string]interface{}{
+			"project_id": "proj",
+			"type":       "runbook",
+			"title":      "Restart " + service,
+			"content":    "Drain the queue, then restart.",
+			"fields":     map[string]interface{}{"service": service, "severity": "high"},
+		}
+		result, err := server.handleCreateMemoryTool(ctx, create)
+		if err != nil || result.IsError {
+			t.Fatalf("Failed to call memory_create: %v %+v", err, result)
+		}
+	}
+
+	invalid := mcp.CallToolRequest{}
+	invalid.Params.Arguments = map[string]interface{}{
+		"project_id": "proj",
+		"type":       "runbook",
+		"title":      "Missing service",
+		"content":    "No service given",
+		"fields":     map[string]interface{}{"severity": "low"},
+	}
+	result, err := server.handleCreateMemoryTool(ctx, invalid)
+	if err != nil || !strings.Contains(result.Content[0].(mcp.TextContent).Text, "field service is required") {
+		t.Errorf("Expected a runbook without service to be rejected, got %v %+v", err, result)
+	}
+
+	list := mcp.CallToolRequest{}
+	list.Params.Arguments = map[string]interface{}{
+		"project_id": "proj",
+		"fields":     map[string]interface{}{"service": "billing"},
+	}
+	result, err = server.handleListMemoriesTool(ctx, list)
+	if err != nil || result.IsError {
+		t.Fatalf("Failed to call memory_list: %v %+v", err, result)
+	}
+	var listed SearchMemoriesResponse
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &listed); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if listed.Total != 1 || listed.Results[0].Fields["service"] != "billing" {
+		t.Errorf("Expected the billing runbook, got %+v", listed.Results)
+	}
+
+	result, err = server.handleListMemoryTypesTool(ctx, mcp.CallToolRequest{})
+	if err != nil || result.IsError {
+		t.Fatalf("Failed to call memory_types: %v %+v", err, result)
+	}
+	var types ListMemoryTypesResponse
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &types); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	last := types.Types[len(types.Types)-1]
+	if types.Total != len(domain.BuiltinMemoryTypes())+1 || last.Name != "runbook" || len(last.Fields) != 2 {
+		t.Errorf("Expected the built-in types and runbook, got %+v", types)
+	}
+}
@@ -0,0 +1,27 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ClientProvenanceMiddleware records the MCP source and the client name and version
+// from the initialize handshake on every write made by a tool call
+func ClientProvenanceMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return next(withClientProvenance(ctx), request)
+	}
+}
+
+// withClientProvenance adds the provenance of the calling MCP client to the context
+func withClientProvenance(ctx context.Context) context.Context {
+	provenance := domain.Provenance{Source: domain.ProvenanceSourceMCP}
+	if session, ok := server.ClientSessionFromContext(ctx).(server.SessionWithClientInfo); ok {
+		info := session.GetClientInfo()
+		provenance.Client = domain.ClientName(info.Name, info.Version)
+	}
+	return domain.WithProvenance(ctx, provenance)
+}
@@ -0,0 +1,99 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/joern1811/memory-bank/internal/app"
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/infra/database"
+	"github.com/joern1811/memory-bank/internal/infra/embedding"
+	"github.com/joern1811/memory-bank/internal/infra/vector"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/sirupsen/logrus"
+)
+
+// clientInfoSession is a client session that completed the initialize handshake
+type clientInfoSession struct {
+	info mcp.Implementation
+}
+
+func (s *clientInfoSession) Initialize()       {}
+func (s *clientInfoSession) Initialized() bool { return true }
+func (s *clientInfoSession) SessionID() string { return "test-session" }
+func (s *clientInfoSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return make(chan mcp.JSONRPCNotification, 1)
+}
+func (s *clientInfoSession) GetClientInfo() mcp.Implementation     { return s.info }
+func (s *clientInfoSession) SetClientInfo(info mcp.Implementation) { s.info = info }
+
+func TestClientProvenanceMiddleware(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	db, err := database.NewSQLiteDatabase(":memory:", logger)
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+
+	memoryService := app.NewMemoryService(
+		database.NewSQLiteMemoryRepository(db, logger),
+		embedding.NewMockEmbeddingProvider(768, logger),
+		vector.NewMockVectorStore(logger),
+		logger,
+	)
+	memoryService.SetProvenance(domain.Provenance{Author: "alice", Source: domain.ProvenanceSourceCLI})
+	s := NewMemoryBankServer(memoryService, nil, nil, nil, logger)
+
+	ctx := context.Background()
+	if _, err := memoryService.CreateMemory(ctx, ports.CreateMemoryRequest{
+		ProjectID: "proj", Type: domain.MemoryTypeDecision, Title: "Manual decision", Content: "Written by hand",
+	}); err != nil {
+		t.Fatalf("Failed to create memory: %v", err)
+	}
+
+	session := &clientInfoSession{info: mcp.Implementation{Name: "claude-code", Version: "1.0.3"}}
+	sessionCtx := server.NewMCPServer("test", "1.0").WithContext(ctx, session)
+
+	create := mcp.CallToolRequest{}
+	create.Params.Arguments = map[string]interface{}{
+		"project_id": "proj",
+		"type":       "decision",
+		"title":      "Agent decision",
+		"content":    "Written by an agent",
+	}
+	result, err := ClientProvenanceMiddleware(s.handleCreateMemoryTool)(sessionCtx, create)
+	if err != nil || result.IsError {
+		t.Fatalf("Failed to call memory_create: %v %+v", err, result)
+	}
+
+	list := mcp.CallToolRequest{}
+	list.Params.Arguments = map[string]interface{}{"project_id": "proj", "client": "claude-code"}
+	result, err = s.handleListMemoriesTool(ctx, list)
+	if err != nil || result.IsError {
+		t.Fatalf("Failed to call memory_list: %v %+v", err, result)
+	}
+	var listed SearchMemoriesResponse
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &listed); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(listed.Results) != 1 {
+		t.Fatalf("Expected only the agent memory, got %+v", listed.Results)
+	}
+	p := listed.Results[0].Provenance
+	if p == nil || p.Author != "alice" || p.Source != domain.ProvenanceSourceMCP || p.Client != "claude-code/1.0.3" {
+		t.Errorf("Unexpected provenance %+v", p)
+	}
+
+	list.Params.Arguments = map[string]interface{}{"project_id": "proj", "source": "cli"}
+	result, _ = s.handleListMemoriesTool(ctx, list)
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &listed); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(listed.Results) != 1 || listed.Results[0].Title != "Manual decision" {
+		t.Errorf("Expected only the manual memory, got %+v", listed.Results)
+	}
+}
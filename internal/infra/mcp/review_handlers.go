@@ -0,0 +1,77 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ReviewQueueRequest represents a request to list draft memories
+type ReviewQueueRequest struct {
+	ProjectID *string `json:"project_id,omitempty"`
+	Limit     *int    `json:"limit,omitempty"`
+}
+
+// ReviewQueueResponse lists draft memories waiting for review. Total counts all
+// drafts, also those beyond the limit.
+type ReviewQueueResponse struct {
+	Memories []MemorySearchResult `json:"memories"`
+	Total    int                  `json:"total"`
+}
+
+// handleReviewQueue lists the review queue. Drafts are only reviewed through the
+// CLI so agents cannot approve their own memories.
+func (s *MemoryBankServer) handleReviewQueue(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	s.logger.Debug("Handling memory/review_queue request")
+
+	var req ReviewQueueRequest
+	if err := json.Unmarshal(params, &req); err != nil {
+		return nil, fmt.Errorf("invalid request parameters: %w", err)
+	}
+
+	limit := 50
+	if req.Limit != nil {
+		if *req.Limit <= 0 {
+			return nil, fmt.Errorf("limit must be positive")
+		}
+		limit = *req.Limit
+	}
+
+	drafts, err := s.memoryService.ListReviewQueue(ctx, optionalProjectID(req.ProjectID))
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to list review queue")
+		return nil, fmt.Errorf("failed to list review queue: %w", err)
+	}
+
+	response := ReviewQueueResponse{Memories: []MemorySearchResult{}, Total: len(drafts)}
+	for i, memory := range drafts {
+		if i >= limit {
+			break
+		}
+		response.Memories = append(response.Memories, MemorySearchResult{
+			ID:        string(memory.ID),
+			ProjectID: string(memory.ProjectID),
+			Type:      string(memory.Type),
+			Title:     memory.Title,
+			Content:   memory.Content,
+			Tags:      []string(memory.Tags),
+			Metadata:  map[string]interface{}{"context": memory.Context},
+			Fields:    memory.Fields,
+			CreatedAt: memory.CreatedAt,
+			UpdatedAt: memory.UpdatedAt,
+
+			Provenance:     memory.Provenance,
+			LastModifiedBy: memory.LastModifiedBy,
+			ReviewStatus:   memory.ReviewStatus,
+		})
+	}
+
+	s.logger.WithField("count", len(drafts)).Info("Review queue listed successfully")
+	return response, nil
+}
+
+func (s *MemoryBankServer) handleReviewQueueTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return s.wrapHandler(ctx, request, s.handleReviewQueue)
+}
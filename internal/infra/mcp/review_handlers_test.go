@@ -0,0 +1,87 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/joern1811/memory-bank/internal/app"
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/infra/database"
+	"github.com/joern1811/memory-bank/internal/infra/embedding"
+	"github.com/joern1811/memory-bank/internal/infra/vector"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+func TestReviewQueueTool(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	db, err := database.NewSQLiteDatabase(":memory:", logger)
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+
+	memoryService := app.NewMemoryService(
+		database.NewSQLiteMemoryRepository(db, logger),
+		embedding.NewMockEmbeddingProvider(768, logger),
+		vector.NewMockVectorStore(logger),
+		logger,
+	)
+	memoryService.SetReviewPolicy(domain.ReviewPolicy{Sources: []domain.ProvenanceSource{domain.ProvenanceSourceMCP}})
+	s := NewMemoryBankServer(memoryService, nil, nil, nil, logger)
+	ctx := context.Background()
+
+	for _, title := range []string{"First agent note", "Second agent note"} {
+		create := mcp.CallToolRequest{}
+		create.Params.Arguments = map[string]interface{}{
+			"project_id": "proj",
+			"type":       "pattern",
+			"title":      title,
+			"content":    "Written by an agent",
+		}
+		result, err := ClientProvenanceMiddleware(s.handleCreateMemoryTool)(ctx, create)
+		if err != nil || result.IsError {
+			t.Fatalf("Failed to call memory_create: %v %+v", err, result)
+		}
+	}
+
+	// Drafts are only listed on request
+	var listed SearchMemoriesResponse
+	list := mcp.CallToolRequest{}
+	list.Params.Arguments = map[string]interface{}{"project_id": "proj"}
+	result, _ := s.handleListMemoriesTool(ctx, list)
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &listed); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(listed.Results) != 0 {
+		t.Errorf("Expected drafts to be hidden, got %+v", listed.Results)
+	}
+
+	list.Params.Arguments = map[string]interface{}{"project_id": "proj", "include_drafts": true}
+	result, _ = s.handleListMemoriesTool(ctx, list)
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &listed); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(listed.Results) != 2 || listed.Results[0].ReviewStatus != domain.ReviewStatusDraft {
+		t.Errorf("Expected the drafts when requested, got %+v", listed.Results)
+	}
+
+	queue := mcp.CallToolRequest{}
+	queue.Params.Arguments = map[string]interface{}{"project_id": "proj", "limit": 1}
+	result, err = s.handleReviewQueueTool(ctx, queue)
+	if err != nil || result.IsError {
+		t.Fatalf("Failed to call memory_review_queue: %v %+v", err, result)
+	}
+	var drafts ReviewQueueResponse
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &drafts); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if drafts.Total != 2 || len(drafts.Memories) != 1 || drafts.Memories[0].Title != "First agent note" {
+		t.Errorf("Expected the oldest draft of two, got %+v", drafts)
+	}
+	if p := drafts.Memories[0].Provenance; p == nil || p.Source != domain.ProvenanceSourceMCP {
+		t.Errorf("Expected the draft's provenance, got %+v", p)
+	}
+}
@@ -24,7 +24,14 @@ type MemoryBankServer struct {
 	projectService ports.ProjectService
 	sessionService ports.SessionService
 	taskService    ports.TaskService
+	contextService ports.ContextService
+	anchorService  ports.AnchorService
+	tagService     ports.TagService
 	logger         *logrus.Logger
+
+	promptTokenBudget int
+
+	workspaceService ports.WorkspaceService
 }
 
 // NewMemoryBankServer creates a new MCP server instance
@@ -41,6 +48,8 @@ func NewMemoryBankServer(
 		sessionService: sessionService,
 		taskService:    taskService,
 		logger:         logger,
+
+		promptTokenBudget: DefaultSystemPromptTokenBudget,
 	}
 }
 
@@ -104,6 +113,16 @@ func (s *MemoryBankServer) RegisterMethods(mcpServer *server.MCPServer) {
 
 	mcpServer.AddResource(configTemplateResource, s.handleConfigTemplateResource)
 
+	// Register session handoff resource
+	handoffResource := mcp.NewResource(
+		"session://memory-bank/handoff",
+		"Memory Bank Session Handoff",
+		mcp.WithResourceDescription("Brief of where the session of the current project last worked on left off: goal, milestones, open issues, open tasks and relevant memories"),
+		mcp.WithMIMEType("text/markdown"),
+	)
+
+	mcpServer.AddResource(handoffResource, s.handleHandoffResource)
+
 	// Register debugging session starter prompt
 	debuggingSessionPrompt := mcp.NewPrompt(
 		"start-debugging-session",
@@ -197,21 +216,27 @@ func (s *MemoryBankServer) RegisterMethods(mcpServer *server.MCPServer) {
 	mcpServer.AddTool(mcp.NewTool("memory_create",
 		mcp.WithDescription("Create a new memory entry"),
 		mcp.WithString("project_id", mcp.Description("Project ID"), mcp.Required()),
-		mcp.WithString("type", mcp.Description("Memory type"), mcp.Required()),
+		mcp.WithString("type", mcp.Description(s.memoryTypeDescription(context.Background())), mcp.Required()),
 		mcp.WithString("title", mcp.Description("Memory title"), mcp.Required()),
 		mcp.WithString("content", mcp.Description("Memory content"), mcp.Required()),
 		mcp.WithArray("tags", mcp.Description("Memory tags")),
 		mcp.WithString("session_id", mcp.Description("Session ID")),
+		mcp.WithArray("anchors", mcp.Description("Source locations the memory describes, as path[:start[-end]][#symbol] relative to the project root")),
+		mcp.WithObject("fields", mcp.Description("Type-specific fields of user-defined memory types, see memory_types")),
+		mcp.WithArray("applies_to", mcp.Description("Dependencies and version ranges the memory is valid for, as name or name@range (e.g. github.com/gin-gonic/gin@^1.9); defaults to the project dependencies mentioned in the memory")),
 	), s.handleCreateMemoryTool)
 
 	mcpServer.AddTool(mcp.NewTool("memory_search",
 		mcp.WithDescription("Search memories semantically"),
-		mcp.WithString("query", mcp.Description("Search query"), mcp.Required()),
+		mcp.WithString("query", mcp.Description("Search query. Supports field operators: type:<type>, tag:<tag>, -tag:<tag>, field:<name>=<value>, project:<id>, after:<YYYY-MM-DD>, before:<YYYY-MM-DD>, source:<cli|mcp|git|import>, created_by:<author>, client:<name> and \"exact phrase\""), mcp.Required()),
 		mcp.WithString("project_id", mcp.Description("Project ID to filter by")),
+		mcp.WithString("workspace", mcp.Description("Search all projects of this workspace (name or ID) instead of a single project")),
 		mcp.WithString("type", mcp.Description("Memory type to filter by")),
-		mcp.WithArray("tags", mcp.Description("Tags to filter by")),
+		mcp.WithArray("tags", mcp.Description("Tags to filter by; a tag also matches the tags below it, e.g. infra/db matches infra/db/sqlite")),
 		mcp.WithNumber("limit", mcp.Description("Maximum number of results")),
 		mcp.WithNumber("threshold", mcp.Description("Similarity threshold")),
+		mcp.WithBoolean("include_drafts", mcp.Description("Also return draft memories waiting for review")),
+		mcp.WithBoolean("include_sessions", mcp.Description("Also search session tasks, progress entries and outcomes (default true); skipped when filtering by memory type or tags")),
 	), s.handleSearchMemoriesTool)
 
 	mcpServer.AddTool(mcp.NewTool("memory_get",
@@ -225,6 +250,8 @@ func (s *MemoryBankServer) RegisterMethods(mcpServer *server.MCPServer) {
 		mcp.WithString("title", mcp.Description("New title")),
 		mcp.WithString("content", mcp.Description("New content")),
 		mcp.WithArray("tags", mcp.Description("New tags")),
+		mcp.WithObject("fields", mcp.Description("Type-specific fields to set; null removes a field")),
+		mcp.WithArray("applies_to", mcp.Description("New dependency constraints as name or name@range; an empty list removes them")),
 	), s.handleUpdateMemoryTool)
 
 	mcpServer.AddTool(mcp.NewTool("memory_delete",
@@ -232,17 +259,112 @@ func (s *MemoryBankServer) RegisterMethods(mcpServer *server.MCPServer) {
 		mcp.WithString("id", mcp.Description("Memory ID"), mcp.Required()),
 	), s.handleDeleteMemoryTool)
 
+	mcpServer.AddTool(mcp.NewTool("memory_pin",
+		mcp.WithDescription("Pin or unpin a memory. Pinned memories are always included in the system prompt"),
+		mcp.WithString("id", mcp.Description("Memory ID"), mcp.Required()),
+		mcp.WithBoolean("pinned", mcp.Description("Whether the memory should be pinned (default: true)")),
+	), s.handlePinMemoryTool)
+
+	mcpServer.AddTool(mcp.NewTool("memory_context",
+		mcp.WithDescription("Get a token-budgeted context pack for a task: relevant decisions, patterns, error solutions, matching open tasks and the last session outcome, formatted for inclusion in a prompt"),
+		mcp.WithString("task", mcp.Description("Description of the task you are about to work on"), mcp.Required()),
+		mcp.WithString("project_id", mcp.Description("Project ID (default: project of the current directory)")),
+		mcp.WithArray("files", mcp.Description("File paths the task touches")),
+		mcp.WithNumber("token_budget", mcp.Description("Approximate size limit in tokens (default: 2000)")),
+		mcp.WithNumber("limit", mcp.Description("Maximum entries per section (default: 5)")),
+		mcp.WithNumber("threshold", mcp.Description("Minimum similarity of included memories (default: 0.3)")),
+		mcp.WithString("format", mcp.Description("Output format: markdown (default) or json")),
+	), s.handleContextTool)
+
+	mcpServer.AddTool(mcp.NewTool("memory_for_file",
+		mcp.WithDescription("List the memories anchored to a file or to a directory containing it, flagging memories whose code was renamed, deleted or heavily changed since"),
+		mcp.WithString("path", mcp.Description("File path, absolute or relative to the project root"), mcp.Required()),
+		mcp.WithString("project_id", mcp.Description("Project ID (default: project of the current directory)")),
+		mcp.WithBoolean("check_staleness", mcp.Description("Compare anchors with git history (default: true)")),
+	), s.handleMemoryForFileTool)
+
+	mcpServer.AddTool(mcp.NewTool("memory_anchor",
+		mcp.WithDescription("Link a memory to source files and symbols, or remove such links"),
+		mcp.WithString("id", mcp.Description("Memory ID"), mcp.Required()),
+		mcp.WithArray("add", mcp.Description("Anchors to add, as path[:start[-end]][#symbol] relative to the project root")),
+		mcp.WithArray("remove", mcp.Description("Paths whose anchors should be removed")),
+	), s.handleAnchorMemoryTool)
+
 	mcpServer.AddTool(mcp.NewTool("memory_list",
 		mcp.WithDescription("List memories with optional filters"),
 		mcp.WithString("project_id", mcp.Description("Project ID to filter by")),
+		mcp.WithString("workspace", mcp.Description("List the memories of all projects of this workspace (name or ID)")),
 		mcp.WithString("type", mcp.Description("Memory type to filter by")),
-		mcp.WithArray("tags", mcp.Description("Tags to filter by")),
+		mcp.WithArray("tags", mcp.Description("Tags to filter by; a tag also matches the tags below it, e.g. infra/db matches infra/db/sqlite")),
+		mcp.WithObject("fields", mcp.Description("Type-specific field values to filter by, e.g. {\"severity\": \"high\"}")),
+		mcp.WithString("source", mcp.Description("Only list memories written through this channel: cli, mcp, git or import")),
+		mcp.WithString("created_by", mcp.Description("Only list memories created by this author")),
+		mcp.WithString("client", mcp.Description("Only list memories written by this MCP client, by name or name/version")),
+		mcp.WithBoolean("include_drafts", mcp.Description("Also list draft memories waiting for review")),
 	), s.handleListMemoriesTool)
 
+	mcpServer.AddTool(mcp.NewTool("memory_review_queue",
+		mcp.WithDescription("List draft memories waiting for review, oldest first. Drafts are approved, edited, rejected or merged by a person with 'memory-bank memory review'"),
+		mcp.WithString("project_id", mcp.Description("Project ID to filter by (default: all projects)")),
+		mcp.WithNumber("limit", mcp.Description("Maximum number of drafts (default: 50)")),
+	), s.handleReviewQueueTool)
+
+	mcpServer.AddTool(mcp.NewTool("memory_types",
+		mcp.WithDescription("List the available memory types, including user-defined types and their fields"),
+		mcp.WithString("project_id", mcp.Description("Only include user-defined types available in this project")),
+	), s.handleListMemoryTypesTool)
+
+	// Register tag management operations
+	mcpServer.AddTool(mcp.NewTool("tag_list",
+		mcp.WithDescription("List tags with usage counts, including parents of hierarchical tags such as infra/db/sqlite"),
+		mcp.WithString("project_id", mcp.Description("Project ID to filter by (default: all projects)")),
+		mcp.WithString("prefix", mcp.Description("Only list this tag and the tags below it")),
+		mcp.WithString("origin", mcp.Description("Only count tags added by hand (manual) or by the auto-tagger (auto)")),
+	), s.handleListTagsTool)
+
+	mcpServer.AddTool(mcp.NewTool("tag_rename",
+		mcp.WithDescription("Rename a tag in all memories; tags below it keep their lower levels"),
+		mcp.WithString("from", mcp.Description("Tag to rename"), mcp.Required()),
+		mcp.WithString("to", mcp.Description("New tag name"), mcp.Required()),
+		mcp.WithString("project_id", mcp.Description("Only rename in this project (default: all projects)")),
+		mcp.WithBoolean("dry_run", mcp.Description("Report the affected memories without changing them")),
+	), s.handleRenameTagTool)
+
+	mcpServer.AddTool(mcp.NewTool("tag_merge",
+		mcp.WithDescription("Merge several tags into one, e.g. authn and authentication into auth"),
+		mcp.WithArray("sources", mcp.Description("Tags to merge"), mcp.Required()),
+		mcp.WithString("target", mcp.Description("Tag to merge into"), mcp.Required()),
+		mcp.WithString("project_id", mcp.Description("Only merge in this project (default: all projects)")),
+		mcp.WithBoolean("dry_run", mcp.Description("Report the affected memories without changing them")),
+	), s.handleMergeTagsTool)
+
+	mcpServer.AddTool(mcp.NewTool("tag_suggest",
+		mcp.WithDescription("Extract tags for a memory from known technologies, code identifiers and distinctive keywords"),
+		mcp.WithString("id", mcp.Description("Memory ID"), mcp.Required()),
+		mcp.WithBoolean("apply", mcp.Description("Add the suggestions to the memory as auto tags")),
+	), s.handleSuggestTagsTool)
+
+	mcpServer.AddTool(mcp.NewTool("tag_review",
+		mcp.WithDescription("List memories with auto or suggested tags that have not been accepted or rejected"),
+		mcp.WithString("project_id", mcp.Description("Project ID to filter by (default: all projects)")),
+	), s.handleTagReviewTool)
+
+	mcpServer.AddTool(mcp.NewTool("tag_accept",
+		mcp.WithDescription("Accept auto or suggested tags of a memory as manual tags"),
+		mcp.WithString("id", mcp.Description("Memory ID"), mcp.Required()),
+		mcp.WithArray("tags", mcp.Description("Tags to accept (default: all pending tags)")),
+	), s.handleAcceptTagsTool)
+
+	mcpServer.AddTool(mcp.NewTool("tag_reject",
+		mcp.WithDescription("Remove auto or suggested tags from a memory; manual tags are kept"),
+		mcp.WithString("id", mcp.Description("Memory ID"), mcp.Required()),
+		mcp.WithArray("tags", mcp.Description("Tags to reject (default: all pending tags)")),
+	), s.handleRejectTagsTool)
+
 	// Register advanced search operations
 	mcpServer.AddTool(mcp.NewTool("memory_faceted-search",
 		mcp.WithDescription("Advanced search with facets and filters"),
-		mcp.WithString("query", mcp.Description("Search query"), mcp.Required()),
+		mcp.WithString("query", mcp.Description("Search query. Supports field operators: type:<type>, tag:<tag>, -tag:<tag>, field:<name>=<value>, project:<id>, after:<YYYY-MM-DD>, before:<YYYY-MM-DD>, source:<cli|mcp|git|import>, created_by:<author>, client:<name> and \"exact phrase\""), mcp.Required()),
 		mcp.WithString("project_id", mcp.Description("Project ID to filter by")),
 		mcp.WithObject("filters", mcp.Description("Search filters")),
 		mcp.WithNumber("limit", mcp.Description("Maximum number of results")),
@@ -253,12 +375,15 @@ func (s *MemoryBankServer) RegisterMethods(mcpServer *server.MCPServer) {
 
 	mcpServer.AddTool(mcp.NewTool("memory_enhanced-search",
 		mcp.WithDescription("Enhanced search with relevance scoring and highlights"),
-		mcp.WithString("query", mcp.Description("Search query"), mcp.Required()),
+		mcp.WithString("query", mcp.Description("Search query. Supports field operators: type:<type>, tag:<tag>, -tag:<tag>, field:<name>=<value>, project:<id>, after:<YYYY-MM-DD>, before:<YYYY-MM-DD>, source:<cli|mcp|git|import>, created_by:<author>, client:<name> and \"exact phrase\""), mcp.Required()),
 		mcp.WithString("project_id", mcp.Description("Project ID to filter by")),
 		mcp.WithString("type", mcp.Description("Memory type to filter by")),
-		mcp.WithArray("tags", mcp.Description("Tags to filter by")),
+		mcp.WithArray("tags", mcp.Description("Tags to filter by; a tag also matches the tags below it, e.g. infra/db matches infra/db/sqlite")),
 		mcp.WithNumber("limit", mcp.Description("Maximum number of results")),
 		mcp.WithNumber("threshold", mcp.Description("Similarity threshold")),
+		mcp.WithBoolean("diversify", mcp.Description("Re-rank results with maximal marginal relevance to reduce near-duplicates")),
+		mcp.WithNumber("diversity_lambda", mcp.Description("Relevance/diversity trade-off between 0 and 1 (default 0.7, 1 = pure relevance)")),
+		mcp.WithBoolean("explain", mcp.Description("Include a per-result score breakdown")),
 	), s.handleEnhancedSearchTool)
 
 	mcpServer.AddTool(mcp.NewTool("memory_search-suggestions",
@@ -274,8 +399,13 @@ func (s *MemoryBankServer) RegisterMethods(mcpServer *server.MCPServer) {
 		mcp.WithString("name", mcp.Description("Project name"), mcp.Required()),
 		mcp.WithString("path", mcp.Description("Project path"), mcp.Required()),
 		mcp.WithString("description", mcp.Description("Project description")),
+		mcp.WithString("workspace", mcp.Description("Attach the project to this workspace (name or ID), creating it if needed")),
 	), s.handleInitProjectTool)
 
+	mcpServer.AddTool(mcp.NewTool("workspace_list",
+		mcp.WithDescription("List workspaces grouping related projects, with their project IDs"),
+	), s.handleListWorkspacesTool)
+
 	mcpServer.AddTool(mcp.NewTool("project_get",
 		mcp.WithDescription("Get project information"),
 		mcp.WithString("id", mcp.Description("Project ID")),
@@ -300,12 +430,13 @@ func (s *MemoryBankServer) RegisterMethods(mcpServer *server.MCPServer) {
 		mcp.WithString("name", mcp.Description("New project name")),
 		mcp.WithString("description", mcp.Description("New project description")),
 		mcp.WithString("new_path", mcp.Description("New project path")),
+		mcp.WithBoolean("redetect", mcp.Description("Detect languages, framework and dependencies again from the project files")),
 	), s.handleUpdateProjectTool)
 
 	// Register Session operations
 	mcpServer.AddTool(mcp.NewTool("session_start",
-		mcp.WithDescription("Start a new development session"),
-		mcp.WithString("title", mcp.Description("Session title"), mcp.Required()),
+		mcp.WithDescription("Start a new development session; other active sessions of the project keep running"),
+		mcp.WithString("title", mcp.Description("Session title, also used to find the session by name, e.g. a branch or agent"), mcp.Required()),
 		mcp.WithString("project_id", mcp.Description("Project ID"), mcp.Required()),
 		mcp.WithString("description", mcp.Description("Session description")),
 	), s.handleStartSessionTool)
@@ -313,22 +444,61 @@ func (s *MemoryBankServer) RegisterMethods(mcpServer *server.MCPServer) {
 	mcpServer.AddTool(mcp.NewTool("session_log",
 		mcp.WithDescription("Log progress to the active session"),
 		mcp.WithString("message", mcp.Description("Progress message"), mcp.Required()),
+		mcp.WithString("type", mcp.Description("Entry type: info (default), milestone, issue or solution")),
+		mcp.WithArray("files", mcp.Description("Source files the entry refers to")),
+		mcp.WithString("ref", mcp.Description("Task or memory ID the entry refers to")),
+		mcp.WithString("commit", mcp.Description("Git commit the entry refers to")),
 		mcp.WithString("project_id", mcp.Description("Project ID")),
-		mcp.WithString("session_id", mcp.Description("Session ID")),
+		mcp.WithString("session_id", mcp.Description("Session ID, or session name with project_id; required if the project has several active sessions")),
 	), s.handleLogSessionTool)
 
+	mcpServer.AddTool(mcp.NewTool("session_progress",
+		mcp.WithDescription("Filter and search progress entries across sessions, newest first"),
+		mcp.WithString("query", mcp.Description("Words that must all appear in the entry message")),
+		mcp.WithString("project_id", mcp.Description("Project ID to filter by")),
+		mcp.WithString("session_id", mcp.Description("Session ID to filter by")),
+		mcp.WithArray("types", mcp.Description("Entry types to include: info, milestone, issue, solution")),
+		mcp.WithString("file", mcp.Description("Only entries referring to this file")),
+		mcp.WithString("ref", mcp.Description("Only entries referring to this task or memory ID")),
+		mcp.WithString("commit", mcp.Description("Only entries referring to a commit with this hash prefix")),
+		mcp.WithString("since", mcp.Description("Only entries logged after this RFC3339 time")),
+		mcp.WithNumber("limit", mcp.Description("Maximum number of results (default 50)")),
+	), s.handleSessionProgressTool)
+
 	mcpServer.AddTool(mcp.NewTool("session_complete",
-		mcp.WithDescription("Complete a development session"),
+		mcp.WithDescription("Complete a development session and write a summary memory from its progress log"),
 		mcp.WithString("outcome", mcp.Description("Session outcome"), mcp.Required()),
 		mcp.WithString("project_id", mcp.Description("Project ID")),
-		mcp.WithString("session_id", mcp.Description("Session ID")),
+		mcp.WithString("session_id", mcp.Description("Session ID, or session name with project_id; required if the project has several active sessions")),
+		mcp.WithBoolean("distill", mcp.Description("Turn issue entries followed by a solution into error_solution memories (default from configuration)")),
 	), s.handleCompleteSessionTool)
 
+	mcpServer.AddTool(mcp.NewTool("session_pause",
+		mcp.WithDescription("Pause an active session; paused time does not count towards its duration"),
+		mcp.WithString("project_id", mcp.Description("Project ID")),
+		mcp.WithString("session_id", mcp.Description("Session ID, or session name with project_id")),
+	), s.handlePauseSessionTool)
+
+	mcpServer.AddTool(mcp.NewTool("session_resume",
+		mcp.WithDescription("Resume a paused session"),
+		mcp.WithString("project_id", mcp.Description("Project ID")),
+		mcp.WithString("session_id", mcp.Description("Session ID, or session name with project_id")),
+	), s.handleResumeSessionTool)
+
 	mcpServer.AddTool(mcp.NewTool("session_get",
 		mcp.WithDescription("Get session details"),
 		mcp.WithString("id", mcp.Description("Session ID"), mcp.Required()),
 	), s.handleGetSessionTool)
 
+	mcpServer.AddTool(mcp.NewTool("session_handoff",
+		mcp.WithDescription("Get a brief of where the session of a project last worked on left off: goal, last milestones, open issues, related open tasks and relevant memories, to continue the work in a new conversation"),
+		mcp.WithString("project_id", mcp.Description("Project ID (default: project of the current directory)")),
+		mcp.WithString("session_id", mcp.Description("Session to hand off (default: the session of the project last worked on)")),
+		mcp.WithNumber("token_budget", mcp.Description("Approximate size limit in tokens (default: 2000)")),
+		mcp.WithNumber("limit", mcp.Description("Maximum entries per section (default: 5)")),
+		mcp.WithString("format", mcp.Description("Output format: markdown (default) or json")),
+	), s.handleHandoffTool)
+
 	mcpServer.AddTool(mcp.NewTool("session_list",
 		mcp.WithDescription("List sessions with optional filters"),
 		mcp.WithString("project_id", mcp.Description("Project ID to filter by")),
@@ -337,7 +507,7 @@ func (s *MemoryBankServer) RegisterMethods(mcpServer *server.MCPServer) {
 	), s.handleListSessionsTool)
 
 	mcpServer.AddTool(mcp.NewTool("session_abort",
-		mcp.WithDescription("Abort active sessions"),
+		mcp.WithDescription("Abort active and paused sessions"),
 		mcp.WithString("project_id", mcp.Description("Project ID"), mcp.Required()),
 		mcp.WithString("session_id", mcp.Description("Specific session ID to abort")),
 	), s.handleAbortSessionTool)
@@ -392,7 +562,7 @@ func (s *MemoryBankServer) RegisterMethods(mcpServer *server.MCPServer) {
 		mcp.WithString("due_after", mcp.Description("Due after date (ISO 8601)")),
 		mcp.WithBoolean("is_overdue", mcp.Description("Filter overdue tasks")),
 		mcp.WithString("parent_task", mcp.Description("Parent task ID for subtasks")),
-		mcp.WithArray("tags", mcp.Description("Tags to filter by")),
+		mcp.WithArray("tags", mcp.Description("Tags to filter by; a tag also matches the tags below it, e.g. infra/db matches infra/db/sqlite")),
 		mcp.WithNumber("limit", mcp.Description("Maximum number of results")),
 		mcp.WithString("sort_by", mcp.Description("Sort by field (priority, due_date, created_at, updated_at, title)")),
 		mcp.WithString("sort_order", mcp.Description("Sort order (asc, desc)")),
@@ -423,8 +593,9 @@ func (s *MemoryBankServer) RegisterMethods(mcpServer *server.MCPServer) {
 	), s.handleRemoveSubtaskTool)
 
 	mcpServer.AddTool(mcp.NewTool("task_statistics",
-		mcp.WithDescription("Get task statistics for a project"),
-		mcp.WithString("project_id", mcp.Description("Project ID"), mcp.Required()),
+		mcp.WithDescription("Get task statistics for a project or for all projects of a workspace"),
+		mcp.WithString("project_id", mcp.Description("Project ID (required unless workspace is given)")),
+		mcp.WithString("workspace", mcp.Description("Aggregate the statistics of all projects of this workspace (name or ID)")),
 	), s.handleTaskStatisticsTool)
 
 	mcpServer.AddTool(mcp.NewTool("task_efficiency_report",
@@ -455,12 +626,23 @@ type CreateMemoryRequest struct {
 	Tags      []string               `json:"tags,omitempty"`
 	Metadata  map[string]interface{} `json:"metadata,omitempty"`
 	SessionID *string                `json:"session_id,omitempty"`
+	Anchors   []string               `json:"anchors,omitempty"`
+	Fields    map[string]interface{} `json:"fields,omitempty"`
+	AppliesTo []string               `json:"applies_to,omitempty"`
 }
 
 // CreateMemoryResponse represents the response from creating a memory
 type CreateMemoryResponse struct {
-	ID        string    `json:"id"`
-	CreatedAt time.Time `json:"created_at"`
+	ID        string              `json:"id"`
+	CreatedAt time.Time           `json:"created_at"`
+	Anchors   []domain.CodeAnchor `json:"anchors,omitempty"`
+	// Tags added or suggested by the auto-tagger, if enabled
+	AutoTags      domain.Tags `json:"auto_tags,omitempty"`
+	SuggestedTags domain.Tags `json:"suggested_tags,omitempty"`
+	// Kinds of secrets masked before the memory was stored
+	Redactions []domain.Redaction `json:"redactions,omitempty"`
+	// Dependencies the memory is valid for, given or taken from the project's manifests
+	AppliesTo []domain.DependencyConstraint `json:"applies_to,omitempty"`
 }
 
 // Tool handlers that wrap the existing handlers to match MCP tool interface
@@ -537,6 +719,18 @@ func (s *MemoryBankServer) handleCreateMemory(ctx context.Context, params json.R
 		return nil, fmt.Errorf("content is required")
 	}
 
+	anchors, err := parseAnchors(req.Anchors)
+	if err != nil {
+		return nil, err
+	}
+	if len(anchors) > 0 && s.anchorService == nil {
+		return nil, fmt.Errorf("anchors are not supported by this server")
+	}
+	appliesTo, err := parseDependencyConstraints(req.AppliesTo)
+	if err != nil {
+		return nil, err
+	}
+
 	// Convert to domain types
 	projectID := domain.ProjectID(req.ProjectID)
 	memoryType := domain.MemoryType(req.Type)
@@ -557,6 +751,8 @@ func (s *MemoryBankServer) handleCreateMemory(ctx context.Context, params json.R
 		Content:   req.Content,
 		Context:   "", // Could be extracted from metadata
 		Tags:      tags,
+		Fields:    req.Fields,
+		AppliesTo: appliesTo,
 	}
 
 	memory, err := s.memoryService.CreateMemory(ctx, createReq)
@@ -565,9 +761,23 @@ func (s *MemoryBankServer) handleCreateMemory(ctx context.Context, params json.R
 		return nil, fmt.Errorf("failed to create memory: %w", err)
 	}
 
+	if len(anchors) > 0 {
+		memory, err = s.anchorService.AddAnchors(ctx, memory.ID, anchors)
+		if err != nil {
+			s.logger.WithError(err).Error("Failed to anchor memory")
+			return nil, fmt.Errorf("memory created but anchoring failed: %w", err)
+		}
+	}
+
 	response := CreateMemoryResponse{
 		ID:        string(memory.ID),
 		CreatedAt: memory.CreatedAt,
+		Anchors:   memory.Anchors,
+
+		AutoTags:      memory.AutoTags,
+		SuggestedTags: memory.SuggestedTags,
+		Redactions:    memory.Redactions,
+		AppliesTo:     memory.AppliesTo,
 	}
 
 	s.logger.WithFields(logrus.Fields{
@@ -579,6 +789,19 @@ func (s *MemoryBankServer) handleCreateMemory(ctx context.Context, params json.R
 	return response, nil
 }
 
+// parseDependencyConstraints parses dependency constraints given as name or name@range
+func parseDependencyConstraints(values []string) ([]domain.DependencyConstraint, error) {
+	constraints := make([]domain.DependencyConstraint, 0, len(values))
+	for _, value := range values {
+		constraint, err := domain.ParseDependencyConstraint(value)
+		if err != nil {
+			return nil, err
+		}
+		constraints = append(constraints, constraint)
+	}
+	return constraints, nil
+}
+
 // SearchMemoriesRequest represents a request to search memories
 type SearchMemoriesRequest struct {
 	Query     string   `json:"query"`
@@ -587,12 +810,33 @@ type SearchMemoriesRequest struct {
 	Tags      []string `json:"tags,omitempty"`
 	Limit     *int     `json:"limit,omitempty"`
 	Threshold *float32 `json:"threshold,omitempty"`
+
+	IncludeDrafts   bool   `json:"include_drafts,omitempty"`
+	Workspace       string `json:"workspace,omitempty"`
+	IncludeSessions *bool  `json:"include_sessions,omitempty"`
 }
 
 // SearchMemoriesResponse represents the response from searching memories
 type SearchMemoriesResponse struct {
 	Results []MemorySearchResult `json:"results"`
 	Total   int                  `json:"total"`
+
+	// Sessions whose task, progress entries or outcome match the query
+	Sessions []SessionSearchHit `json:"sessions,omitempty"`
+}
+
+// SessionSearchHit is a session matching a search with the part that matched
+type SessionSearchHit struct {
+	SessionID   string                 `json:"session_id"`
+	SessionName string                 `json:"session_name"`
+	ProjectID   string                 `json:"project_id"`
+	Task        string                 `json:"task"`
+	Status      string                 `json:"status"`
+	Hit         string                 `json:"hit"` // task, progress or outcome
+	Entry       map[string]interface{} `json:"entry,omitempty"`
+	Outcome     string                 `json:"outcome,omitempty"`
+	Timestamp   time.Time              `json:"timestamp"`
+	Similarity  float32                `json:"similarity"`
 }
 
 // MemorySearchResult represents a single search result
@@ -607,6 +851,26 @@ type MemorySearchResult struct {
 	Similarity float32                `json:"similarity"`
 	CreatedAt  time.Time              `json:"created_at"`
 	UpdatedAt  time.Time              `json:"updated_at"`
+
+	AccessCount    int                 `json:"access_count"`
+	LastAccessedAt *time.Time          `json:"last_accessed_at,omitempty"`
+	Pinned         bool                `json:"pinned"`
+	Anchors        []domain.CodeAnchor `json:"anchors,omitempty"`
+
+	// Fields holds the type-specific fields of user-defined memory types
+	Fields map[string]interface{} `json:"fields,omitempty"`
+
+	// Provenance records who created the memory and through which channel
+	Provenance     *domain.Provenance `json:"provenance,omitempty"`
+	LastModifiedBy *domain.Provenance `json:"last_modified_by,omitempty"`
+
+	// ReviewStatus is draft while the memory waits in the review queue
+	ReviewStatus domain.ReviewStatus `json:"review_status,omitempty"`
+
+	// AppliesTo lists the dependencies the memory is valid for; Applicability tells
+	// search results whether the project still satisfies them
+	AppliesTo     []domain.DependencyConstraint `json:"applies_to,omitempty"`
+	Applicability *domain.Applicability         `json:"applicability,omitempty"`
 }
 
 func (s *MemoryBankServer) handleSearchMemories(ctx context.Context, params json.RawMessage) (interface{}, error) {
@@ -648,12 +912,23 @@ func (s *MemoryBankServer) handleSearchMemories(ctx context.Context, params json
 
 	// Perform search
 	searchQuery := ports.SemanticSearchRequest{
-		Query:     req.Query,
 		ProjectID: filters.ProjectID,
 		Type:      filters.Type,
 		Tags:      filters.Tags,
 		Limit:     limit,
 		Threshold: threshold,
+
+		IncludeDrafts: req.IncludeDrafts,
+	}
+	if searchQuery.ProjectID == nil {
+		projectIDs, err := s.workspaceProjectIDs(ctx, req.Workspace)
+		if err != nil {
+			return nil, err
+		}
+		searchQuery.ProjectIDs = projectIDs
+	}
+	if err := ports.ApplyQueryString(req.Query, &searchQuery); err != nil {
+		return nil, err
 	}
 
 	searchResults, err := s.memoryService.SearchMemories(ctx, searchQuery)
@@ -673,16 +948,32 @@ func (s *MemoryBankServer) handleSearchMemories(ctx context.Context, params json
 			Content:    result.Memory.Content,
 			Tags:       []string(result.Memory.Tags),
 			Metadata:   map[string]interface{}{"context": result.Memory.Context}, // Use context as metadata
+			Fields:     result.Memory.Fields,
 			Similarity: float32(result.Similarity),
 			CreatedAt:  result.Memory.CreatedAt,
 			UpdatedAt:  result.Memory.UpdatedAt,
+
+			Provenance:     result.Memory.Provenance,
+			LastModifiedBy: result.Memory.LastModifiedBy,
+			ReviewStatus:   result.Memory.ReviewStatus,
+
+			AccessCount:    result.Memory.AccessCount,
+			LastAccessedAt: result.Memory.LastAccessedAt,
+
+			AppliesTo:     result.Memory.AppliesTo,
+			Applicability: result.Applicability,
 		}
 	}
 
+	s.recordAccess(ctx, searchResultIDs(searchResults))
+
 	response := SearchMemoriesResponse{
 		Results: results,
 		Total:   len(results),
 	}
+	if req.IncludeSessions == nil || *req.IncludeSessions {
+		response.Sessions = s.searchSessions(ctx, searchQuery)
+	}
 
 	s.logger.WithFields(logrus.Fields{
 		"query":         req.Query,
@@ -692,6 +983,42 @@ func (s *MemoryBankServer) handleSearchMemories(ctx context.Context, params json
 	return response, nil
 }
 
+// searchSessions returns the sessions matching a memory search. Failures are logged so
+// that the memory results are still returned.
+func (s *MemoryBankServer) searchSessions(ctx context.Context, query ports.SemanticSearchRequest) []SessionSearchHit {
+	if s.sessionService == nil {
+		return nil
+	}
+	sessionQuery, ok := query.SessionSearch()
+	if !ok {
+		return nil
+	}
+	results, err := s.sessionService.SearchSessions(ctx, sessionQuery)
+	if err != nil {
+		s.logger.WithError(err).Warn("Failed to search sessions")
+		return nil
+	}
+
+	hits := make([]SessionSearchHit, len(results))
+	for i, result := range results {
+		hits[i] = SessionSearchHit{
+			SessionID:   string(result.Session.ID),
+			SessionName: result.Session.Name,
+			ProjectID:   string(result.Session.ProjectID),
+			Task:        result.Session.TaskDescription,
+			Status:      string(result.Session.Status),
+			Hit:         result.Hit,
+			Outcome:     result.Session.Outcome,
+			Timestamp:   result.Timestamp,
+			Similarity:  float32(result.Similarity),
+		}
+		if result.Entry != nil {
+			hits[i].Entry = progressEntryMap(*result.Entry)
+		}
+	}
+	return hits
+}
+
 // GetMemoryRequest represents a request to get a specific memory
 type GetMemoryRequest struct {
 	ID string `json:"id"`
@@ -724,10 +1051,24 @@ func (s *MemoryBankServer) handleGetMemory(ctx context.Context, params json.RawM
 		Content:   memory.Content,
 		Tags:      []string(memory.Tags),
 		Metadata:  map[string]interface{}{"context": memory.Context},
+		Fields:    memory.Fields,
 		CreatedAt: memory.CreatedAt,
 		UpdatedAt: memory.UpdatedAt,
+
+		Provenance:     memory.Provenance,
+		LastModifiedBy: memory.LastModifiedBy,
+		ReviewStatus:   memory.ReviewStatus,
+
+		AccessCount:    memory.AccessCount,
+		LastAccessedAt: memory.LastAccessedAt,
+		Pinned:         memory.Pinned,
+		Anchors:        memory.Anchors,
+
+		AppliesTo: memory.AppliesTo,
 	}
 
+	s.recordAccess(ctx, []domain.MemoryID{memory.ID})
+
 	s.logger.WithField("memory_id", memoryID).Info("Memory retrieved successfully")
 	return result, nil
 }
@@ -739,6 +1080,9 @@ type UpdateMemoryRequest struct {
 	Content  *string                `json:"content,omitempty"`
 	Tags     []string               `json:"tags,omitempty"`
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Fields   map[string]interface{} `json:"fields,omitempty"` // merged into the existing fields, null removes a field
+
+	AppliesTo *[]string `json:"applies_to,omitempty"` // replaces the dependency constraints, empty removes them
 }
 
 func (s *MemoryBankServer) handleUpdateMemory(ctx context.Context, params json.RawMessage) (interface{}, error) {
@@ -776,6 +1120,25 @@ func (s *MemoryBankServer) handleUpdateMemory(ctx context.Context, params json.R
 			memory.Context = context
 		}
 	}
+	if len(req.Fields) > 0 {
+		if memory.Fields == nil {
+			memory.Fields = make(map[string]interface{})
+		}
+		for name, value := range req.Fields {
+			if value == nil {
+				delete(memory.Fields, name)
+			} else {
+				memory.Fields[name] = value
+			}
+		}
+	}
+	if req.AppliesTo != nil {
+		appliesTo, err := parseDependencyConstraints(*req.AppliesTo)
+		if err != nil {
+			return nil, err
+		}
+		memory.AppliesTo = appliesTo
+	}
 
 	// Update memory
 	err = s.memoryService.UpdateMemory(ctx, memory)
@@ -792,8 +1155,15 @@ func (s *MemoryBankServer) handleUpdateMemory(ctx context.Context, params json.R
 		Content:   memory.Content,
 		Tags:      []string(memory.Tags),
 		Metadata:  map[string]interface{}{"context": memory.Context},
+		Fields:    memory.Fields,
 		CreatedAt: memory.CreatedAt,
 		UpdatedAt: memory.UpdatedAt,
+
+		Provenance:     memory.Provenance,
+		LastModifiedBy: memory.LastModifiedBy,
+		ReviewStatus:   memory.ReviewStatus,
+
+		AppliesTo: memory.AppliesTo,
 	}
 
 	s.logger.WithField("memory_id", memoryID).Info("Memory updated successfully")
@@ -828,11 +1198,60 @@ func (s *MemoryBankServer) handleDeleteMemory(ctx context.Context, params json.R
 	return map[string]interface{}{"success": true}, nil
 }
 
+// PinMemoryRequest represents a request to pin or unpin a memory
+type PinMemoryRequest struct {
+	ID     string `json:"id"`
+	Pinned *bool  `json:"pinned,omitempty"`
+}
+
+func (s *MemoryBankServer) handlePinMemory(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	s.logger.Debug("Handling memory/pin request")
+
+	var req PinMemoryRequest
+	if err := json.Unmarshal(params, &req); err != nil {
+		return nil, fmt.Errorf("invalid request parameters: %w", err)
+	}
+
+	if req.ID == "" {
+		return nil, fmt.Errorf("id is required")
+	}
+
+	pinned := true
+	if req.Pinned != nil {
+		pinned = *req.Pinned
+	}
+
+	memoryID := domain.MemoryID(req.ID)
+	memory, err := s.memoryService.SetPinned(ctx, memoryID, pinned)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to pin memory")
+		return nil, fmt.Errorf("failed to pin memory: %w", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"memory_id": memoryID,
+		"pinned":    pinned,
+	}).Info("Memory pin state updated")
+
+	return map[string]interface{}{
+		"id":     string(memory.ID),
+		"title":  memory.Title,
+		"pinned": memory.Pinned,
+	}, nil
+}
+
 // ListMemoriesRequest represents a request to list memories
 type ListMemoriesRequest struct {
-	ProjectID *string  `json:"project_id,omitempty"`
-	Type      *string  `json:"type,omitempty"`
-	Tags      []string `json:"tags,omitempty"`
+	ProjectID *string           `json:"project_id,omitempty"`
+	Type      *string           `json:"type,omitempty"`
+	Tags      []string          `json:"tags,omitempty"`
+	Fields    map[string]string `json:"fields,omitempty"`
+	Source    string            `json:"source,omitempty"`
+	CreatedBy string            `json:"created_by,omitempty"`
+	Client    string            `json:"client,omitempty"`
+
+	IncludeDrafts bool   `json:"include_drafts,omitempty"`
+	Workspace     string `json:"workspace,omitempty"`
 }
 
 func (s *MemoryBankServer) handleListMemories(ctx context.Context, params json.RawMessage) (interface{}, error) {
@@ -865,6 +1284,28 @@ func (s *MemoryBankServer) handleListMemories(ctx context.Context, params json.R
 		Tags:      filters.Tags,
 		Limit:     1000, // Large limit to get all results
 		Threshold: 0.0,  // No threshold filtering
+
+		IncludeDrafts: req.IncludeDrafts,
+	}
+	if searchQuery.ProjectID == nil {
+		projectIDs, err := s.workspaceProjectIDs(ctx, req.Workspace)
+		if err != nil {
+			return nil, err
+		}
+		searchQuery.ProjectIDs = projectIDs
+	}
+	if len(req.Fields) > 0 {
+		searchQuery.Filters = &ports.SearchFilters{Fields: req.Fields}
+	}
+	if req.Source != "" || req.CreatedBy != "" || req.Client != "" {
+		source := domain.ProvenanceSource(strings.ToLower(req.Source))
+		if source != "" && !source.IsValid() {
+			return nil, fmt.Errorf("invalid source %q, use cli, mcp, git or import", req.Source)
+		}
+		if searchQuery.Filters == nil {
+			searchQuery.Filters = &ports.SearchFilters{}
+		}
+		searchQuery.Filters.Provenance = &domain.ProvenanceFilter{Author: req.CreatedBy, Source: source, Client: req.Client}
 	}
 
 	searchResults, err := s.memoryService.SearchMemories(ctx, searchQuery)
@@ -884,8 +1325,13 @@ func (s *MemoryBankServer) handleListMemories(ctx context.Context, params json.R
 			Content:   result.Memory.Content,
 			Tags:      []string(result.Memory.Tags),
 			Metadata:  map[string]interface{}{"context": result.Memory.Context},
+			Fields:    result.Memory.Fields,
 			CreatedAt: result.Memory.CreatedAt,
 			UpdatedAt: result.Memory.UpdatedAt,
+
+			Provenance:     result.Memory.Provenance,
+			LastModifiedBy: result.Memory.LastModifiedBy,
+			ReviewStatus:   result.Memory.ReviewStatus,
 		}
 	}
 
@@ -903,6 +1349,7 @@ type InitProjectRequest struct {
 	Name        string `json:"name"`
 	Path        string `json:"path"`
 	Description string `json:"description,omitempty"`
+	Workspace   string `json:"workspace,omitempty"`
 }
 
 // InitProjectResponse represents the response from initializing a project
@@ -911,6 +1358,28 @@ type InitProjectResponse struct {
 	Name      string    `json:"name"`
 	Path      string    `json:"path"`
 	CreatedAt time.Time `json:"created_at"`
+
+	WorkspaceID string `json:"workspace_id,omitempty"`
+
+	ProjectDetection
+}
+
+// ProjectDetection holds the languages, framework and dependencies detected for a project
+type ProjectDetection struct {
+	Language     string                 `json:"language,omitempty"`
+	Framework    string                 `json:"framework,omitempty"`
+	Languages    []domain.LanguageShare `json:"languages,omitempty"`
+	Dependencies []domain.Dependency    `json:"dependencies,omitempty"`
+}
+
+// projectDetection returns the detection results of a project
+func projectDetection(project *domain.Project) ProjectDetection {
+	return ProjectDetection{
+		Language:     project.Language,
+		Framework:    project.Framework,
+		Languages:    project.Languages,
+		Dependencies: project.Dependencies,
+	}
 }
 
 func (s *MemoryBankServer) handleInitProject(ctx context.Context, params json.RawMessage) (interface{}, error) {
@@ -947,6 +1416,17 @@ func (s *MemoryBankServer) handleInitProject(ctx context.Context, params json.Ra
 		CreatedAt: project.CreatedAt,
 	}
 
+	if req.Workspace != "" {
+		if s.workspaceService == nil {
+			return nil, fmt.Errorf("workspace service is not available")
+		}
+		workspace, err := s.workspaceService.AttachProject(ctx, req.Workspace, project.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add project to workspace: %w", err)
+		}
+		response.WorkspaceID = string(workspace.ID)
+	}
+
 	s.logger.WithFields(logrus.Fields{
 		"project_id": project.ID,
 		"name":       project.Name,
@@ -992,6 +1472,8 @@ func (s *MemoryBankServer) handleGetProject(ctx context.Context, params json.Raw
 		Name:      project.Name,
 		Path:      project.Path,
 		CreatedAt: project.CreatedAt,
+
+		ProjectDetection: projectDetection(project),
 	}
 
 	s.logger.WithField("project_id", project.ID).Info("Project retrieved successfully")
@@ -1073,6 +1555,7 @@ type UpdateProjectRequest struct {
 	Name        *string `json:"name,omitempty"`
 	Description *string `json:"description,omitempty"`
 	NewPath     *string `json:"new_path,omitempty"`
+	Redetect    bool    `json:"redetect,omitempty"`
 }
 
 // UpdateProjectResponse represents the response from updating a project
@@ -1082,6 +1565,8 @@ type UpdateProjectResponse struct {
 	Path        string `json:"path"`
 	Description string `json:"description"`
 	Message     string `json:"message"`
+
+	ProjectDetection
 }
 
 func (s *MemoryBankServer) handleUpdateProject(ctx context.Context, params json.RawMessage) (interface{}, error) {
@@ -1111,8 +1596,8 @@ func (s *MemoryBankServer) handleUpdateProject(ctx context.Context, params json.
 	}
 
 	// Check if any update fields were provided
-	if req.Name == nil && req.Description == nil && req.NewPath == nil {
-		return nil, fmt.Errorf("at least one field must be specified to update (name, description, or new_path)")
+	if req.Name == nil && req.Description == nil && req.NewPath == nil && !req.Redetect {
+		return nil, fmt.Errorf("at least one field must be specified to update (name, description, new_path or redetect)")
 	}
 
 	// Track what changed for logging
@@ -1133,15 +1618,25 @@ func (s *MemoryBankServer) handleUpdateProject(ctx context.Context, params json.
 	}
 
 	// Only update if there are actual changes
-	if len(changes) == 0 {
+	if len(changes) == 0 && !req.Redetect {
 		return nil, fmt.Errorf("no changes detected")
 	}
 
 	// Update the project
-	err = s.projectService.UpdateProject(ctx, project)
-	if err != nil {
-		s.logger.WithError(err).WithField("project_id", project.ID).Error("Failed to update project")
-		return nil, fmt.Errorf("failed to update project: %w", err)
+	if len(changes) > 0 {
+		err = s.projectService.UpdateProject(ctx, project)
+		if err != nil {
+			s.logger.WithError(err).WithField("project_id", project.ID).Error("Failed to update project")
+			return nil, fmt.Errorf("failed to update project: %w", err)
+		}
+	}
+	if req.Redetect {
+		project, err = s.projectService.RedetectProject(ctx, project.ID)
+		if err != nil {
+			s.logger.WithError(err).WithField("project_id", project.ID).Error("Failed to re-detect project")
+			return nil, fmt.Errorf("failed to re-detect project: %w", err)
+		}
+		changes = append(changes, "detection")
 	}
 
 	response := UpdateProjectResponse{
@@ -1150,6 +1645,8 @@ func (s *MemoryBankServer) handleUpdateProject(ctx context.Context, params json.
 		Path:        project.Path,
 		Description: project.Description,
 		Message:     fmt.Sprintf("Project '%s' updated successfully (%s)", project.Name, strings.Join(changes, ", ")),
+
+		ProjectDetection: projectDetection(project),
 	}
 
 	s.logger.WithFields(logrus.Fields{
@@ -1203,6 +1700,22 @@ type StartSessionResponse struct {
 	Description string    `json:"description"`
 	Status      string    `json:"status"`
 	CreatedAt   time.Time `json:"created_at"`
+
+	// Git is the branch and HEAD the session started at, if the project is a git repository
+	Git *domain.SessionGit `json:"git,omitempty"`
+
+	// Warning and StaleSessions report sessions of the project left open
+	Warning       string             `json:"warning,omitempty"`
+	StaleSessions []StaleSessionInfo `json:"stale_sessions,omitempty"`
+}
+
+// StaleSessionInfo describes an open session that has been inactive for a long time
+type StaleSessionInfo struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Status       string    `json:"status"`
+	LastActivity time.Time `json:"last_activity"`
+	IdleFor      string    `json:"idle_for"`
 }
 
 func (s *MemoryBankServer) handleStartSession(ctx context.Context, params json.RawMessage) (interface{}, error) {
@@ -1229,6 +1742,7 @@ func (s *MemoryBankServer) handleStartSession(ctx context.Context, params json.R
 	serviceReq := ports.StartSessionRequest{
 		ProjectID:       domain.ProjectID(req.ProjectID),
 		TaskDescription: description,
+		Name:            req.Title,
 	}
 
 	// Start session
@@ -1245,6 +1759,25 @@ func (s *MemoryBankServer) handleStartSession(ctx context.Context, params json.R
 		Description: description,
 		Status:      string(session.Status),
 		CreatedAt:   session.StartTime,
+		Git:         session.Git,
+	}
+
+	// Warn about sessions of the project left open
+	stale, err := s.sessionService.StaleSessions(ctx, &session.ProjectID)
+	if err != nil {
+		s.logger.WithError(err).Warn("Failed to check for stale sessions")
+	}
+	for _, idle := range stale {
+		response.StaleSessions = append(response.StaleSessions, StaleSessionInfo{
+			ID:           string(idle.Session.ID),
+			Name:         idle.Session.Name,
+			Status:       string(idle.Session.Status),
+			LastActivity: idle.Session.LastActivity(),
+			IdleFor:      domain.FormatIdleTime(idle.IdleFor),
+		})
+	}
+	if len(stale) > 0 {
+		response.Warning = fmt.Sprintf("%d stale session(s) of this project are still open; complete or abort them with session_complete or session_abort", len(stale))
 	}
 
 	s.logger.WithFields(logrus.Fields{
@@ -1256,14 +1789,20 @@ func (s *MemoryBankServer) handleStartSession(ctx context.Context, params json.R
 }
 
 type LogSessionRequest struct {
-	Message   string  `json:"message"`
-	ProjectID *string `json:"project_id,omitempty"`
-	SessionID *string `json:"session_id,omitempty"`
+	Message   string   `json:"message"`
+	Type      string   `json:"type,omitempty"`
+	Files     []string `json:"files,omitempty"`
+	Ref       string   `json:"ref,omitempty"`
+	Commit    string   `json:"commit,omitempty"`
+	ProjectID *string  `json:"project_id,omitempty"`
+	SessionID *string  `json:"session_id,omitempty"`
 }
 
 type LogSessionResponse struct {
 	Success   bool   `json:"success"`
 	SessionID string `json:"session_id"`
+	Type      string `json:"type"`
+	Timestamp string `json:"timestamp"`
 }
 
 func (s *MemoryBankServer) handleLogSession(ctx context.Context, params json.RawMessage) (interface{}, error) {
@@ -1279,23 +1818,21 @@ func (s *MemoryBankServer) handleLogSession(ctx context.Context, params json.Raw
 	}
 
 	// Determine session ID
-	var sessionID domain.SessionID
-	if req.SessionID != nil {
-		sessionID = domain.SessionID(*req.SessionID)
-	} else if req.ProjectID != nil {
-		// Find active session for project
-		projectID := domain.ProjectID(*req.ProjectID)
-		activeSession, err := s.sessionService.GetActiveSession(ctx, projectID)
-		if err != nil {
-			return nil, fmt.Errorf("no active session found for project: %w", err)
-		}
-		sessionID = activeSession.ID
-	} else {
-		return nil, fmt.Errorf("either session_id or project_id is required")
+	sessionID, err := s.resolveSessionID(ctx, req.SessionID, req.ProjectID, domain.SessionStatusActive)
+	if err != nil {
+		return nil, err
 	}
 
 	// Log progress
-	if err := s.sessionService.LogProgress(ctx, sessionID, req.Message); err != nil {
+	entry, err := s.sessionService.LogProgress(ctx, ports.LogProgressRequest{
+		SessionID: sessionID,
+		Message:   req.Message,
+		Type:      req.Type,
+		Files:     req.Files,
+		Ref:       req.Ref,
+		Commit:    req.Commit,
+	})
+	if err != nil {
 		s.logger.WithError(err).Error("Failed to log session progress")
 		return nil, fmt.Errorf("failed to log session progress: %w", err)
 	}
@@ -1303,20 +1840,126 @@ func (s *MemoryBankServer) handleLogSession(ctx context.Context, params json.Raw
 	response := LogSessionResponse{
 		Success:   true,
 		SessionID: string(sessionID),
+		Type:      entry.Type,
+		Timestamp: entry.Timestamp,
 	}
 
-	s.logger.WithFields(logrus.Fields{
-		"session_id": sessionID,
-		"message":    req.Message,
-	}).Info("Session progress logged")
+	s.logger.WithField("session_id", sessionID).Info("Session progress logged")
 
 	return response, nil
 }
 
+// SessionProgressRequest filters progress entries across sessions
+type SessionProgressRequest struct {
+	Query     string   `json:"query,omitempty"`
+	ProjectID *string  `json:"project_id,omitempty"`
+	SessionID *string  `json:"session_id,omitempty"`
+	Types     []string `json:"types,omitempty"`
+	File      string   `json:"file,omitempty"`
+	Ref       string   `json:"ref,omitempty"`
+	Commit    string   `json:"commit,omitempty"`
+	Since     string   `json:"since,omitempty"`
+	Limit     *int     `json:"limit,omitempty"`
+}
+
+// SessionProgressResult is a progress entry with the session it was logged to
+type SessionProgressResult struct {
+	SessionID   string                 `json:"session_id"`
+	SessionName string                 `json:"session_name"`
+	ProjectID   string                 `json:"project_id"`
+	Entry       map[string]interface{} `json:"entry"`
+}
+
+// SessionProgressResponse lists the matching progress entries, newest first
+type SessionProgressResponse struct {
+	Results []SessionProgressResult `json:"results"`
+	Total   int                     `json:"total"`
+}
+
+func (s *MemoryBankServer) handleSessionProgress(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	s.logger.Debug("Handling session/progress request")
+
+	var req SessionProgressRequest
+	if err := json.Unmarshal(params, &req); err != nil {
+		return nil, fmt.Errorf("invalid request parameters: %w", err)
+	}
+
+	search := ports.SearchProgressRequest{
+		Types:  req.Types,
+		Query:  req.Query,
+		File:   req.File,
+		Ref:    req.Ref,
+		Commit: req.Commit,
+	}
+	if req.ProjectID != nil {
+		projectID := domain.ProjectID(*req.ProjectID)
+		search.ProjectID = &projectID
+	}
+	if req.SessionID != nil {
+		sessionID := domain.SessionID(*req.SessionID)
+		search.SessionID = &sessionID
+	}
+	if req.Since != "" {
+		since, err := time.Parse(time.RFC3339, req.Since)
+		if err != nil {
+			return nil, fmt.Errorf("invalid since time, expected RFC3339: %w", err)
+		}
+		search.Since = &since
+	}
+	if req.Limit != nil {
+		search.Limit = *req.Limit
+	}
+
+	results, err := s.sessionService.SearchProgress(ctx, search)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to search session progress")
+		return nil, fmt.Errorf("failed to search session progress: %w", err)
+	}
+
+	response := SessionProgressResponse{
+		Results: make([]SessionProgressResult, len(results)),
+		Total:   len(results),
+	}
+	for i, result := range results {
+		response.Results[i] = SessionProgressResult{
+			SessionID:   string(result.SessionID),
+			SessionName: result.SessionName,
+			ProjectID:   string(result.ProjectID),
+			Entry:       progressEntryMap(result.Entry),
+		}
+	}
+
+	return response, nil
+}
+
+func (s *MemoryBankServer) handleSessionProgressTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return s.wrapHandler(ctx, request, s.handleSessionProgress)
+}
+
+// progressEntryMap converts a progress entry to its response format
+func progressEntryMap(entry domain.ProgressEntry) map[string]interface{} {
+	m := map[string]interface{}{
+		"timestamp": entry.Timestamp,
+		"type":      entry.Type,
+		"content":   entry.Message,
+	}
+	if len(entry.Files) > 0 {
+		m["files"] = entry.Files
+	}
+	if entry.Ref != "" {
+		m["ref"] = entry.Ref
+	}
+	if entry.Commit != "" {
+		m["commit"] = entry.Commit
+	}
+	return m
+}
+
 type CompleteSessionRequest struct {
 	Outcome   string  `json:"outcome"`
 	ProjectID *string `json:"project_id,omitempty"`
 	SessionID *string `json:"session_id,omitempty"`
+	Distill   *bool   `json:"distill,omitempty"`
 }
 
 type CompleteSessionResponse struct {
@@ -1324,6 +1967,13 @@ type CompleteSessionResponse struct {
 	SessionID string    `json:"session_id"`
 	Duration  string    `json:"duration"`
 	EndedAt   time.Time `json:"ended_at"`
+
+	// Memories written from the session
+	SummaryMemoryID    string   `json:"summary_memory_id,omitempty"`
+	DistilledMemoryIDs []string `json:"distilled_memory_ids,omitempty"`
+
+	// Git is the commit range, commits and changed files the session covered
+	Git *domain.SessionGit `json:"git,omitempty"`
 }
 
 func (s *MemoryBankServer) handleCompleteSession(ctx context.Context, params json.RawMessage) (interface{}, error) {
@@ -1339,43 +1989,36 @@ func (s *MemoryBankServer) handleCompleteSession(ctx context.Context, params jso
 	}
 
 	// Determine session ID
-	var sessionID domain.SessionID
-	if req.SessionID != nil {
-		sessionID = domain.SessionID(*req.SessionID)
-	} else if req.ProjectID != nil {
-		// Find active session for project
-		projectID := domain.ProjectID(*req.ProjectID)
-		activeSession, err := s.sessionService.GetActiveSession(ctx, projectID)
-		if err != nil {
-			return nil, fmt.Errorf("no active session found for project: %w", err)
-		}
-		sessionID = activeSession.ID
-	} else {
-		return nil, fmt.Errorf("either session_id or project_id is required")
+	sessionID, err := s.resolveSessionID(ctx, req.SessionID, req.ProjectID, domain.SessionStatusActive)
+	if err != nil {
+		return nil, err
 	}
 
 	// Complete session
-	if err := s.sessionService.CompleteSession(ctx, sessionID, req.Outcome); err != nil {
+	result, err := s.sessionService.CompleteSession(ctx, ports.CompleteSessionRequest{
+		SessionID: sessionID,
+		Outcome:   req.Outcome,
+		Distill:   req.Distill,
+	})
+	if err != nil {
 		s.logger.WithError(err).Error("Failed to complete session")
 		return nil, fmt.Errorf("failed to complete session: %w", err)
 	}
+	session := result.Session
 
-	// Get updated session for response
-	session, err := s.sessionService.GetSession(ctx, sessionID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve updated session: %w", err)
-	}
-
-	var duration string
-	if session.EndTime != nil {
-		duration = session.Duration().String()
-	}
-
+	duration := session.Duration().String()
 	response := CompleteSessionResponse{
 		Success:   true,
 		SessionID: string(sessionID),
 		Duration:  duration,
 		EndedAt:   *session.EndTime,
+		Git:       session.Git,
+	}
+	if result.Summary != nil {
+		response.SummaryMemoryID = string(result.Summary.ID)
+	}
+	for _, memory := range result.Distilled {
+		response.DistilledMemoryIDs = append(response.DistilledMemoryIDs, string(memory.ID))
 	}
 
 	s.logger.WithFields(logrus.Fields{
@@ -1387,6 +2030,88 @@ func (s *MemoryBankServer) handleCompleteSession(ctx context.Context, params jso
 	return response, nil
 }
 
+// resolveSessionID returns the given session ID, or with a project the session given
+// by ID or name, or the project's only session with the given status
+func (s *MemoryBankServer) resolveSessionID(ctx context.Context, sessionID, projectID *string, status domain.SessionStatus) (domain.SessionID, error) {
+	if projectID == nil {
+		if sessionID == nil {
+			return "", fmt.Errorf("either session_id or project_id is required")
+		}
+		return domain.SessionID(*sessionID), nil
+	}
+
+	ref := ""
+	if sessionID != nil {
+		ref = *sessionID
+	}
+	session, err := s.sessionService.FindSession(ctx, domain.ProjectID(*projectID), ref, status)
+	if err != nil {
+		return "", err
+	}
+	return session.ID, nil
+}
+
+// SessionStateRequest identifies the session to pause or resume
+type SessionStateRequest struct {
+	ProjectID *string `json:"project_id,omitempty"`
+	SessionID *string `json:"session_id,omitempty"`
+}
+
+// SessionStateResponse reports a session's state after pausing or resuming it
+type SessionStateResponse struct {
+	Success   bool       `json:"success"`
+	SessionID string     `json:"session_id"`
+	Status    string     `json:"status"`
+	Duration  string     `json:"duration"` // active time, excluding pauses
+	PausedAt  *time.Time `json:"paused_at,omitempty"`
+}
+
+func (s *MemoryBankServer) handlePauseSession(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	return s.changeSessionState(ctx, params, domain.SessionStatusActive)
+}
+
+func (s *MemoryBankServer) handleResumeSession(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	return s.changeSessionState(ctx, params, domain.SessionStatusPaused)
+}
+
+// changeSessionState pauses an active session or resumes a paused one
+func (s *MemoryBankServer) changeSessionState(ctx context.Context, params json.RawMessage, from domain.SessionStatus) (interface{}, error) {
+	s.logger.WithField("from", from).Debug("Handling session state change request")
+
+	var req SessionStateRequest
+	if err := json.Unmarshal(params, &req); err != nil {
+		return nil, fmt.Errorf("invalid request parameters: %w", err)
+	}
+
+	sessionID, err := s.resolveSessionID(ctx, req.SessionID, req.ProjectID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	if from == domain.SessionStatusPaused {
+		err = s.sessionService.ResumeSession(ctx, sessionID)
+	} else {
+		err = s.sessionService.PauseSession(ctx, sessionID)
+	}
+	if err != nil {
+		s.logger.WithError(err).WithField("session_id", sessionID).Error("Failed to change session state")
+		return nil, fmt.Errorf("failed to change session state: %w", err)
+	}
+
+	session, err := s.sessionService.GetSession(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve updated session: %w", err)
+	}
+
+	return SessionStateResponse{
+		Success:   true,
+		SessionID: string(session.ID),
+		Status:    string(session.Status),
+		Duration:  session.Duration().String(),
+		PausedAt:  session.PausedAt,
+	}, nil
+}
+
 type GetSessionRequest struct {
 	ID string `json:"id"`
 }
@@ -1402,6 +2127,16 @@ type GetSessionResponse struct {
 	UpdatedAt   time.Time                `json:"updated_at"`
 	EndedAt     *time.Time               `json:"ended_at,omitempty"`
 	Duration    *string                  `json:"duration,omitempty"`
+
+	// Name tells parallel sessions apart; Duration is the active time, excluding pauses
+	Name     string     `json:"name"`
+	PausedAt *time.Time `json:"paused_at,omitempty"`
+
+	Provenance     *domain.Provenance `json:"provenance,omitempty"`
+	LastModifiedBy *domain.Provenance `json:"last_modified_by,omitempty"`
+
+	// Git is the branch and commits the session covered, if its project is a git repository
+	Git *domain.SessionGit `json:"git,omitempty"`
 }
 
 func (s *MemoryBankServer) handleGetSession(ctx context.Context, params json.RawMessage) (interface{}, error) {
@@ -1432,23 +2167,25 @@ func (s *MemoryBankServer) handleGetSession(ctx context.Context, params json.Raw
 		Progress:    make([]map[string]interface{}, len(session.Progress)),
 		CreatedAt:   session.StartTime,
 		UpdatedAt:   session.StartTime, // Using StartTime as fallback
+		Name:        session.Name,
+		PausedAt:    session.PausedAt,
+
+		Provenance:     session.Provenance,
+		LastModifiedBy: session.LastModifiedBy,
+		Git:            session.Git,
 	}
 
 	// Convert progress entries
 	for i, entry := range session.Progress {
-		response.Progress[i] = map[string]interface{}{
-			"timestamp": entry.Timestamp,
-			"type":      entry.Type,
-			"content":   entry.Message,
-		}
+		response.Progress[i] = progressEntryMap(entry)
 	}
 
 	// Add optional fields
 	if session.EndTime != nil {
 		response.EndedAt = session.EndTime
-		duration := session.Duration().String()
-		response.Duration = &duration
 	}
+	duration := session.Duration().String()
+	response.Duration = &duration
 
 	s.logger.WithField("session_id", sessionID).Info("Session retrieved successfully")
 
@@ -1569,7 +2306,6 @@ func (s *MemoryBankServer) handleFacetedSearch(ctx context.Context, params json.
 
 	// Build search request
 	searchReq := ports.FacetedSearchRequest{
-		Query:         req.Query,
 		Limit:         limit,
 		Threshold:     threshold,
 		IncludeFacets: includeFacets,
@@ -1623,6 +2359,11 @@ func (s *MemoryBankServer) handleFacetedSearch(ctx context.Context, params json.
 		searchReq.Filters = filters
 	}
 
+	// Apply field operators from the query
+	if err := ports.ApplyQueryString(req.Query, &searchReq); err != nil {
+		return nil, err
+	}
+
 	// Convert sort option
 	if req.SortBy != nil {
 		sortOption := &ports.SortOption{
@@ -1665,12 +2406,22 @@ func (s *MemoryBankServer) handleFacetedSearch(ctx context.Context, params json.
 			Content:    result.Memory.Content,
 			Tags:       []string(result.Memory.Tags),
 			Metadata:   map[string]interface{}{"context": result.Memory.Context},
+			Fields:     result.Memory.Fields,
 			Similarity: float32(result.Similarity),
 			CreatedAt:  result.Memory.CreatedAt,
 			UpdatedAt:  result.Memory.UpdatedAt,
+
+			Provenance:     result.Memory.Provenance,
+			LastModifiedBy: result.Memory.LastModifiedBy,
+			ReviewStatus:   result.Memory.ReviewStatus,
+
+			AccessCount:    result.Memory.AccessCount,
+			LastAccessedAt: result.Memory.LastAccessedAt,
 		}
 	}
 
+	s.recordAccess(ctx, searchResultIDs(searchResponse.Results))
+
 	response := FacetedSearchResponse{
 		Results: results,
 		Total:   searchResponse.Total,
@@ -1736,12 +2487,15 @@ func (s *MemoryBankServer) handleFacetedSearch(ctx context.Context, params json.
 
 // EnhancedSearchRequest represents a request for enhanced search with relevance scoring
 type EnhancedSearchRequest struct {
-	Query     string   `json:"query"`
-	ProjectID *string  `json:"project_id,omitempty"`
-	Type      *string  `json:"type,omitempty"`
-	Tags      []string `json:"tags,omitempty"`
-	Limit     *int     `json:"limit,omitempty"`
-	Threshold *float32 `json:"threshold,omitempty"`
+	Query           string   `json:"query"`
+	ProjectID       *string  `json:"project_id,omitempty"`
+	Type            *string  `json:"type,omitempty"`
+	Tags            []string `json:"tags,omitempty"`
+	Limit           *int     `json:"limit,omitempty"`
+	Threshold       *float32 `json:"threshold,omitempty"`
+	Diversify       bool     `json:"diversify,omitempty"`
+	DiversityLambda *float64 `json:"diversity_lambda,omitempty"`
+	Explain         bool     `json:"explain,omitempty"`
 }
 
 // EnhancedSearchResponse represents enhanced search results
@@ -1763,8 +2517,12 @@ type EnhancedMemorySearchResult struct {
 	RelevanceScore float64                `json:"relevance_score"`
 	MatchReasons   []string               `json:"match_reasons"`
 	Highlights     []string               `json:"highlights"`
+	ScoreBreakdown *ports.ScoreBreakdown  `json:"score_breakdown,omitempty"`
+	Fields         map[string]interface{} `json:"fields,omitempty"`
 	CreatedAt      time.Time              `json:"created_at"`
 	UpdatedAt      time.Time              `json:"updated_at"`
+
+	Provenance *domain.Provenance `json:"provenance,omitempty"`
 }
 
 func (s *MemoryBankServer) handleEnhancedSearch(ctx context.Context, params json.RawMessage) (interface{}, error) {
@@ -1792,12 +2550,19 @@ func (s *MemoryBankServer) handleEnhancedSearch(ctx context.Context, params json
 
 	// Build search request
 	searchQuery := ports.SemanticSearchRequest{
-		Query:     req.Query,
 		Limit:     limit,
 		Threshold: threshold,
+		Diversify: req.Diversify,
+		Explain:   req.Explain,
 	}
 
 	// Set optional fields
+	if req.DiversityLambda != nil {
+		if *req.DiversityLambda <= 0 || *req.DiversityLambda > 1 {
+			return nil, fmt.Errorf("diversity_lambda must be greater than 0 and at most 1")
+		}
+		searchQuery.DiversityLambda = *req.DiversityLambda
+	}
 	if req.ProjectID != nil {
 		projectID := domain.ProjectID(*req.ProjectID)
 		searchQuery.ProjectID = &projectID
@@ -1810,6 +2575,11 @@ func (s *MemoryBankServer) handleEnhancedSearch(ctx context.Context, params json
 		searchQuery.Tags = domain.Tags(req.Tags)
 	}
 
+	// Apply field operators from the query
+	if err := ports.ApplyQueryString(req.Query, &searchQuery); err != nil {
+		return nil, err
+	}
+
 	// Perform enhanced search
 	searchResults, err := s.memoryService.SearchWithRelevanceScoring(ctx, searchQuery)
 	if err != nil {
@@ -1832,11 +2602,21 @@ func (s *MemoryBankServer) handleEnhancedSearch(ctx context.Context, params json
 			RelevanceScore: result.RelevanceScore,
 			MatchReasons:   result.MatchReasons,
 			Highlights:     result.Highlights,
+			ScoreBreakdown: result.ScoreBreakdown,
+			Fields:         result.Memory.Fields,
 			CreatedAt:      result.Memory.CreatedAt,
 			UpdatedAt:      result.Memory.UpdatedAt,
+
+			Provenance: result.Memory.Provenance,
 		}
 	}
 
+	accessedIDs := make([]domain.MemoryID, len(searchResults))
+	for i, result := range searchResults {
+		accessedIDs[i] = result.Memory.ID
+	}
+	s.recordAccess(ctx, accessedIDs)
+
 	response := EnhancedSearchResponse{
 		Results: results,
 		Total:   len(results),
@@ -1927,148 +2707,21 @@ func (s *MemoryBankServer) handleSystemPromptResource(ctx context.Context, reque
 	return []mcp.ResourceContents{textContent}, nil
 }
 
-// generateSystemPrompt creates a dynamic system prompt with current context
-func (s *MemoryBankServer) generateSystemPrompt(ctx context.Context) (string, error) {
-	// Get current project information and existing memories for context
-	projects, err := s.projectService.ListProjects(ctx)
-	if err != nil {
-		s.logger.WithError(err).Warn("Failed to load projects for system prompt")
-		projects = []*domain.Project{} // Continue with empty projects
+// recordAccess updates usage tracking for memories returned to the client.
+// Failures are logged but never fail the request.
+func (s *MemoryBankServer) recordAccess(ctx context.Context, ids []domain.MemoryID) {
+	if err := s.memoryService.RecordAccess(ctx, ids); err != nil {
+		s.logger.WithError(err).Warn("Failed to record memory access")
 	}
+}
 
-	// Build contextual information
-	var contextInfo strings.Builder
-	contextInfo.WriteString("# Memory Bank Integration Context\n\n")
-
-	if len(projects) > 0 {
-		contextInfo.WriteString("## Current Projects\n")
-		for _, project := range projects {
-			contextInfo.WriteString(fmt.Sprintf("- **%s** (%s): %s\n",
-				project.Name,
-				string(project.ID),
-				project.Description))
-		}
-		contextInfo.WriteString("\n")
-	}
-
-	// Get recent memories for each project to provide context
-	memoryContext := s.buildMemoryContext(ctx, projects)
-	if memoryContext != "" {
-		contextInfo.WriteString("## Available Memory Types\n")
-		contextInfo.WriteString(memoryContext)
-		contextInfo.WriteString("\n")
-	}
-
-	// Generate the complete system prompt
-	systemPrompt := fmt.Sprintf(`# Memory Bank - MCP Integration System Prompt
-
-You are working with Memory Bank, a semantic memory management system that helps store and retrieve development knowledge. Use this system to maintain context across development sessions and build institutional knowledge.
-
-%s## How to Use Memory Bank Effectively
-
-### When to Store Memories
-- **Decisions**: Store architectural decisions with rationale and alternatives considered
-- **Patterns**: Save code patterns, design patterns, and best practices you discover
-- **Error Solutions**: Document errors encountered and their solutions for future reference
-- **Code Snippets**: Store reusable code examples with context
-- **Documentation**: Keep project-specific documentation and notes
-- **Session Progress**: Track development session progress and outcomes
-
-### Memory Creation Best Practices
-- **Use descriptive titles**: Clear, searchable titles help retrieval
-- **Add relevant tags**: Use consistent tagging for better organization (e.g., "auth", "api", "frontend")
-- **Include context**: Add enough context so the memory is useful later
-- **Structure content**: Use markdown formatting for readability
-- **Reference related memories**: Link to related decisions or patterns when relevant
-
-### Effective Search Strategies
-- **Semantic search**: Use natural language queries to find related content
-- **Faceted search**: Filter by type, tags, or project for precise results
-- **Enhanced search**: Get relevance scoring and match explanations
-- **Use search suggestions**: Get intelligent suggestions based on existing content
-
-### Memory Types Guide
-- **Decision**: type "decision" - For architectural and design decisions
-- **Pattern**: type "pattern" - For reusable code or design patterns  
-- **ErrorSolution**: type "error_solution" - For documented error fixes
-- **Code**: type "code" - For code snippets and examples
-- **Documentation**: type "documentation" - For project documentation
-
-### Available MCP Methods
-- memory/create: Create new memory entries
-- memory/search: Semantic search across memories
-- memory/faceted-search: Advanced search with filters and facets
-- memory/enhanced-search: Search with relevance scoring and highlights
-- memory/search-suggestions: Get intelligent search suggestions
-- memory/get: Retrieve specific memory by ID
-- memory/update: Update existing memories
-- memory/delete: Remove memories
-- memory/list: List memories with optional filters
-- project/init: Initialize new project
-- project/get: Get project information
-- project/list: List all projects
-- session/start: Start development session
-- session/log: Log session progress
-- session/complete: Complete session with outcome
-
-### Integration Tips
-1. **Start sessions**: Use session/start when beginning major development work
-2. **Document decisions**: Store important decisions as they're made
-3. **Search before implementing**: Check existing patterns and solutions first
-4. **Tag consistently**: Use consistent tags across related memories
-5. **Update memories**: Keep memories current as code evolves
-6. **Complete sessions**: Document outcomes when finishing work
-
-### Example Usage Patterns
-- Before starting a new feature, search for related patterns: memory/search "authentication patterns"
-- When encountering an error, check for solutions: memory/search "JWT token validation error"
-- After making a decision, document it: memory/create with type "decision"
-- Store useful code patterns: memory/create with type "pattern"
-- Track progress on complex tasks: session/start, session/log, session/complete
-
-Remember: The more you use Memory Bank consistently, the more valuable it becomes as your development knowledge base grows and evolves.
-`, contextInfo.String())
-
-	return systemPrompt, nil
-}
-
-// buildMemoryContext analyzes existing memories to provide context about available content
-func (s *MemoryBankServer) buildMemoryContext(ctx context.Context, projects []*domain.Project) string {
-	var context strings.Builder
-
-	// Get sample of memories from each project to understand what's available
-	for _, project := range projects {
-		// Search for recent memories in this project
-		searchReq := ports.SemanticSearchRequest{
-			Query:     "", // Empty query to get all memories
-			ProjectID: &project.ID,
-			Limit:     10,
-			Threshold: 0.0,
-		}
-
-		memories, err := s.memoryService.SearchMemories(ctx, searchReq)
-		if err != nil {
-			s.logger.WithError(err).Debug("Failed to load memories for context")
-			continue
-		}
-
-		if len(memories) > 0 {
-			context.WriteString(fmt.Sprintf("### %s Project Memories (%d total)\n", project.Name, len(memories)))
-
-			// Group by type to show what kinds of memories exist
-			typeCount := make(map[domain.MemoryType]int)
-			for _, memory := range memories {
-				typeCount[memory.Memory.Type]++
-			}
-
-			for memType, count := range typeCount {
-				context.WriteString(fmt.Sprintf("- %s: %d entries\n", string(memType), count))
-			}
-			context.WriteString("\n")
-		}
+// searchResultIDs returns the memory IDs of search results
+func searchResultIDs(results []ports.MemorySearchResult) []domain.MemoryID {
+	ids := make([]domain.MemoryID, len(results))
+	for i, result := range results {
+		ids[i] = result.Memory.ID
 	}
-
-	return context.String()
+	return ids
 }
 
 // Additional tool wrapper functions
@@ -2155,6 +2808,10 @@ func (s *MemoryBankServer) handleDeleteMemoryTool(ctx context.Context, request m
 	return s.wrapHandler(ctx, request, s.handleDeleteMemory)
 }
 
+func (s *MemoryBankServer) handlePinMemoryTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return s.wrapHandler(ctx, request, s.handlePinMemory)
+}
+
 func (s *MemoryBankServer) handleListMemoriesTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return s.wrapHandler(ctx, request, s.handleListMemories)
 }
@@ -2203,6 +2860,14 @@ func (s *MemoryBankServer) handleCompleteSessionTool(ctx context.Context, reques
 	return s.wrapHandler(ctx, request, s.handleCompleteSession)
 }
 
+func (s *MemoryBankServer) handlePauseSessionTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return s.wrapHandler(ctx, request, s.handlePauseSession)
+}
+
+func (s *MemoryBankServer) handleResumeSessionTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return s.wrapHandler(ctx, request, s.handleResumeSession)
+}
+
 func (s *MemoryBankServer) handleGetSessionTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return s.wrapHandler(ctx, request, s.handleGetSession)
 }
@@ -2268,23 +2933,21 @@ func (s *MemoryBankServer) handleListSessions(ctx context.Context, params json.R
 			Progress:    make([]map[string]interface{}, len(session.Progress)),
 			CreatedAt:   session.StartTime,
 			UpdatedAt:   session.StartTime,
+			Name:        session.Name,
+			PausedAt:    session.PausedAt,
 		}
 
 		// Convert progress entries
 		for j, entry := range session.Progress {
-			sessionResponse.Progress[j] = map[string]interface{}{
-				"timestamp": entry.Timestamp,
-				"type":      entry.Type,
-				"content":   entry.Message,
-			}
+			sessionResponse.Progress[j] = progressEntryMap(entry)
 		}
 
 		// Add optional fields
 		if session.EndTime != nil {
 			sessionResponse.EndedAt = session.EndTime
-			duration := session.Duration().String()
-			sessionResponse.Duration = &duration
 		}
+		duration := session.Duration().String()
+		sessionResponse.Duration = &duration
 
 		sessionResponses[i] = sessionResponse
 	}
@@ -1,9 +1,18 @@
 package mcp
 
 import (
+	"context"
+	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/joern1811/memory-bank/internal/app"
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/infra/database"
+	"github.com/joern1811/memory-bank/internal/infra/embedding"
+	"github.com/joern1811/memory-bank/internal/infra/vector"
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/sirupsen/logrus"
 )
 
@@ -106,3 +115,183 @@ func mustParseTime(timeStr string) time.Time {
 	}
 	return t
 }
+
+func TestMemoryTools_AppliesTo(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	db, err := database.NewSQLiteDatabase(":memory:", logger)
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	memoryService := app.NewMemoryService(
+		database.NewSQLiteMemoryRepository(db, logger),
+		embedding.NewMockEmbeddingProvider(768, logger),
+		vector.NewMockVectorStore(logger),
+		logger,
+	)
+	server := NewMemoryBankServer(memoryService, nil, nil, nil, logger)
+	ctx := context.Background()
+
+	arguments := map[string]interface{}{
+		"project_id": "api", "type": "error_solution", "title": "ChromaDB 404", "content": "Use the v2 API",
+		"applies_to": []interface{}{"github.com/amikos-tech/chroma-go@^0.1"},
+	}
+	create := mcp.CallToolRequest{}
+	create.Params.Arguments = arguments
+	result, err := server.handleCreateMemoryTool(ctx, create)
+	if err != nil || result.IsError {
+		t.Fatalf("Failed to call memory_create: %v %+v", err, result)
+	}
+	var created CreateMemoryResponse
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &created); err != nil {
+		t.Fatalf("Failed to parse response: %v: %s", err, result.Content[0].(mcp.TextContent).Text)
+	}
+	if len(created.AppliesTo) != 1 || created.AppliesTo[0].Versions != "^0.1" {
+		t.Errorf("Expected the given constraint, got %+v", created.AppliesTo)
+	}
+
+	arguments["applies_to"] = []interface{}{"chroma-go@newest"}
+	if result, _ := server.handleCreateMemoryTool(ctx, create); !strings.HasPrefix(result.Content[0].(mcp.TextContent).Text, "Error") {
+		t.Errorf("Expected an invalid range to be rejected, got %+v", result)
+	}
+
+	update := mcp.CallToolRequest{}
+	update.Params.Arguments = map[string]interface{}{"id": created.ID, "applies_to": []interface{}{}}
+	result, err = server.handleUpdateMemoryTool(ctx, update)
+	if err != nil || result.IsError {
+		t.Fatalf("Failed to call memory_update: %v %+v", err, result)
+	}
+	memory, err := memoryService.GetMemory(ctx, domain.MemoryID(created.ID))
+	if err != nil {
+		t.Fatalf("Failed to get memory: %v", err)
+	}
+	if len(memory.AppliesTo) != 0 {
+		t.Errorf("Expected an empty list to remove the constraints, got %+v", memory.AppliesTo)
+	}
+}
+
+func TestSessionTools_ParallelSessions(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	db, err := database.NewSQLiteDatabase(":memory:", logger)
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	projectRepo := database.NewSQLiteProjectRepository(db, logger)
+	project := domain.NewProject("api", "/tmp/api", "")
+	if err := projectRepo.Store(context.Background(), project); err != nil {
+		t.Fatalf("Failed to store project: %v", err)
+	}
+	sessionService := app.NewSessionService(database.NewSQLiteSessionRepository(db, logger), projectRepo, logger)
+	server := NewMemoryBankServer(nil, nil, sessionService, nil, logger)
+	ctx := context.Background()
+
+	call := func(handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), arguments map[string]interface{}) string {
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = arguments
+		result, err := handler(ctx, request)
+		if err != nil {
+			t.Fatalf("Failed to call tool: %v", err)
+		}
+		return result.Content[0].(mcp.TextContent).Text
+	}
+
+	var first, second StartSessionResponse
+	for name, response := range map[string]*StartSessionResponse{"main": &first, "feature/login": &second} {
+		text := call(server.handleStartSessionTool, map[string]interface{}{"project_id": string(project.ID), "title": name})
+		if err := json.Unmarshal([]byte(text), response); err != nil {
+			t.Fatalf("Failed to parse response: %v: %s", err, text)
+		}
+	}
+
+	// Both sessions stay active, so the project alone is ambiguous
+	text := call(server.handleLogSessionTool, map[string]interface{}{"project_id": string(project.ID), "message": "progress"})
+	if !strings.Contains(text, "specify one of") {
+		t.Errorf("Expected an ambiguity error, got %s", text)
+	}
+
+	text = call(server.handlePauseSessionTool, map[string]interface{}{"project_id": string(project.ID), "session_id": "main"})
+	var paused SessionStateResponse
+	if err := json.Unmarshal([]byte(text), &paused); err != nil {
+		t.Fatalf("Failed to parse response: %v: %s", err, text)
+	}
+	if paused.SessionID != first.ID || paused.Status != string(domain.SessionStatusPaused) || paused.PausedAt == nil {
+		t.Errorf("Expected session %s to be paused, got %+v", first.ID, paused)
+	}
+
+	// With one session paused, the other one is the only active session
+	text = call(server.handleLogSessionTool, map[string]interface{}{"project_id": string(project.ID), "message": "progress"})
+	if !strings.Contains(text, second.ID) {
+		t.Errorf("Expected progress to be logged to %s, got %s", second.ID, text)
+	}
+
+	text = call(server.handleResumeSessionTool, map[string]interface{}{"project_id": string(project.ID)})
+	var resumed SessionStateResponse
+	if err := json.Unmarshal([]byte(text), &resumed); err != nil {
+		t.Fatalf("Failed to parse response: %v: %s", err, text)
+	}
+	if resumed.SessionID != first.ID || resumed.Status != string(domain.SessionStatusActive) || resumed.PausedAt != nil {
+		t.Errorf("Expected session %s to be resumed, got %+v", first.ID, resumed)
+	}
+}
+
+func TestSessionTools_TypedProgress(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	db, err := database.NewSQLiteDatabase(":memory:", logger)
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	projectRepo := database.NewSQLiteProjectRepository(db, logger)
+	project := domain.NewProject("api", "/tmp/api", "")
+	if err := projectRepo.Store(context.Background(), project); err != nil {
+		t.Fatalf("Failed to store project: %v", err)
+	}
+	sessionService := app.NewSessionService(database.NewSQLiteSessionRepository(db, logger), projectRepo, logger)
+	server := NewMemoryBankServer(nil, nil, sessionService, nil, logger)
+	ctx := context.Background()
+
+	call := func(handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), arguments map[string]interface{}) string {
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = arguments
+		result, err := handler(ctx, request)
+		if err != nil {
+			t.Fatalf("Failed to call tool: %v", err)
+		}
+		return result.Content[0].(mcp.TextContent).Text
+	}
+
+	call(server.handleStartSessionTool, map[string]interface{}{"project_id": string(project.ID), "title": "Fix login"})
+
+	text := call(server.handleLogSessionTool, map[string]interface{}{
+		"project_id": string(project.ID),
+		"message":    "Token refresh races with logout",
+		"type":       "issue",
+		"files":      []interface{}{"internal/auth/token.go"},
+		"ref":        "task-42",
+	})
+	var logged LogSessionResponse
+	if err := json.Unmarshal([]byte(text), &logged); err != nil {
+		t.Fatalf("Failed to parse response: %v: %s", err, text)
+	}
+	if logged.Type != "issue" {
+		t.Errorf("Expected an issue entry, got %s", text)
+	}
+
+	text = call(server.handleLogSessionTool, map[string]interface{}{"project_id": string(project.ID), "message": "x", "type": "bug"})
+	if !strings.Contains(text, "invalid progress type") {
+		t.Errorf("Expected an invalid type error, got %s", text)
+	}
+
+	text = call(server.handleSessionProgressTool, map[string]interface{}{"file": "token.go", "types": []interface{}{"issue"}})
+	var progress SessionProgressResponse
+	if err := json.Unmarshal([]byte(text), &progress); err != nil {
+		t.Fatalf("Failed to parse response: %v: %s", err, text)
+	}
+	if progress.Total != 1 || progress.Results[0].Entry["ref"] != "task-42" {
+		t.Errorf("Expected the issue entry with its ref, got %s", text)
+	}
+}
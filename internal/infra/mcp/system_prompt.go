@@ -0,0 +1,305 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+)
+
+// DefaultSystemPromptTokenBudget is the default size limit of the system prompt resource
+const DefaultSystemPromptTokenBudget = 4000
+
+const systemPromptHeader = `# Memory Bank - MCP Integration System Prompt
+
+You are working with Memory Bank, a semantic memory management system that helps store and retrieve development knowledge. Use this system to maintain context across development sessions and build institutional knowledge.
+
+`
+
+const systemPromptGuide = `## How to Use Memory Bank Effectively
+
+### When to Store Memories
+- **Decisions**: Store architectural decisions with rationale and alternatives considered
+- **Patterns**: Save code patterns, design patterns, and best practices you discover
+- **Error Solutions**: Document errors encountered and their solutions for future reference
+- **Code Snippets**: Store reusable code examples with context
+- **Documentation**: Keep project-specific documentation and notes
+- **Session Progress**: Track development session progress and outcomes
+
+### Memory Creation Best Practices
+- **Use descriptive titles**: Clear, searchable titles help retrieval
+- **Add relevant tags**: Use consistent tagging for better organization (e.g., "auth", "api", "frontend")
+- **Include context**: Add enough context so the memory is useful later
+- **Structure content**: Use markdown formatting for readability
+- **Reference related memories**: Link to related decisions or patterns when relevant
+
+### Effective Search Strategies
+- **Semantic search**: Use natural language queries to find related content
+- **Faceted search**: Filter by type, tags, or project for precise results
+- **Enhanced search**: Get relevance scoring and match explanations
+- **Use search suggestions**: Get intelligent suggestions based on existing content
+
+### Memory Types Guide
+- **Decision**: type "decision" - For architectural and design decisions
+- **Pattern**: type "pattern" - For reusable code or design patterns  
+- **ErrorSolution**: type "error_solution" - For documented error fixes
+- **Code**: type "code" - For code snippets and examples
+- **Documentation**: type "documentation" - For project documentation
+
+### Available MCP Methods
+- memory/create: Create new memory entries
+- memory/search: Semantic search across memories
+- memory/faceted-search: Advanced search with filters and facets
+- memory/enhanced-search: Search with relevance scoring and highlights
+- memory/search-suggestions: Get intelligent search suggestions
+- memory/get: Retrieve specific memory by ID
+- memory/update: Update existing memories
+- memory/delete: Remove memories
+- memory/list: List memories with optional filters
+- memory/pin: Pin a memory so it is always included in this prompt
+- project/init: Initialize new project
+- project/get: Get project information
+- project/list: List all projects
+- session/start: Start development session
+- session/log: Log session progress
+- session/complete: Complete session with outcome
+
+### Integration Tips
+1. **Start sessions**: Use session/start when beginning major development work
+2. **Document decisions**: Store important decisions as they're made
+3. **Search before implementing**: Check existing patterns and solutions first
+4. **Tag consistently**: Use consistent tags across related memories
+5. **Update memories**: Keep memories current as code evolves
+6. **Complete sessions**: Document outcomes when finishing work
+
+### Example Usage Patterns
+- Before starting a new feature, search for related patterns: memory/search "authentication patterns"
+- When encountering an error, check for solutions: memory/search "JWT token validation error"
+- After making a decision, document it: memory/create with type "decision"
+- Store useful code patterns: memory/create with type "pattern"
+- Track progress on complex tasks: session/start, session/log, session/complete
+
+Remember: The more you use Memory Bank consistently, the more valuable it becomes as your development knowledge base grows and evolves.
+`
+
+// SetSystemPromptTokenBudget limits the size of the system prompt resource
+func (s *MemoryBankServer) SetSystemPromptTokenBudget(tokens int) {
+	s.promptTokenBudget = tokens
+}
+
+// generateSystemPrompt creates a dynamic system prompt with current context.
+// Context is added in priority order: pinned memories, active and stale sessions, overdue tasks,
+// the project list, memory statistics and finally the usage guide.
+func (s *MemoryBankServer) generateSystemPrompt(ctx context.Context) (string, error) {
+	// Get current project information and existing memories for context
+	projects, err := s.projectService.ListProjects(ctx)
+	if err != nil {
+		s.logger.WithError(err).Warn("Failed to load projects for system prompt")
+		projects = []*domain.Project{} // Continue with empty projects
+	}
+
+	focus := s.focusProjects(ctx, projects)
+
+	sections := []domain.PromptSection{
+		{Heading: "# Memory Bank Integration Context\n\n## Pinned Memories", Items: s.pinnedMemoryItems(ctx, focus)},
+		{Heading: "## Active Sessions", Items: s.activeSessionItems(ctx, focus)},
+		{Heading: "## Stale Sessions", Items: s.staleSessionItems(ctx, focus)},
+		{Heading: "## Overdue Tasks", Items: s.overdueTaskItems(ctx, focus)},
+		{Heading: "## Current Projects", Items: projectItems(projects)},
+		{Heading: "## User-Defined Memory Types", Items: s.customMemoryTypeItems(ctx)},
+	}
+
+	if memoryContext := s.buildMemoryContext(ctx, projects); memoryContext != "" {
+		sections = append(sections, domain.PromptSection{Heading: "## Available Memory Types", Items: []string{memoryContext}})
+	}
+	sections = append(sections, domain.PromptSection{Items: []string{systemPromptGuide}})
+
+	budget := s.promptTokenBudget
+	if budget <= 0 {
+		budget = DefaultSystemPromptTokenBudget
+	}
+
+	return systemPromptHeader + domain.RenderPromptSections(sections, budget-domain.EstimateTokens(systemPromptHeader)), nil
+}
+
+// customMemoryTypeItems describes the user-defined memory types and their fields
+func (s *MemoryBankServer) customMemoryTypeItems(ctx context.Context) []string {
+	var items []string
+	for _, def := range s.memoryService.ListMemoryTypes(ctx, nil) {
+		if def.Builtin {
+			continue
+		}
+		item := fmt.Sprintf("- **%s**", def.Name)
+		if def.Description != "" {
+			item += ": " + def.Description
+		}
+		if def.ProjectID != nil {
+			item += fmt.Sprintf(" (project %s)", *def.ProjectID)
+		}
+		for _, field := range def.Fields {
+			item += "\n  - " + describeField(field)
+		}
+		items = append(items, item+"\n")
+	}
+	return items
+}
+
+// focusProjects returns the project for the server's working directory, or all projects if none matches
+func (s *MemoryBankServer) focusProjects(ctx context.Context, projects []*domain.Project) []*domain.Project {
+	if project := s.currentProject(ctx); project != nil {
+		return []*domain.Project{project}
+	}
+	return projects
+}
+
+// pinnedMemoryItems lists the full pinned memories of the given projects
+func (s *MemoryBankServer) pinnedMemoryItems(ctx context.Context, projects []*domain.Project) []string {
+	var items []string
+	for _, project := range projects {
+		pinned, err := s.memoryService.ListPinnedMemories(ctx, project.ID)
+		if err != nil {
+			s.logger.WithError(err).WithField("project_id", project.ID).Warn("Failed to load pinned memories for system prompt")
+			continue
+		}
+		for _, memory := range pinned {
+			items = append(items, fmt.Sprintf("### %s (%s, %s)\n%s\n", memory.Title, memory.Type, project.Name, memory.Content))
+		}
+	}
+	return items
+}
+
+// activeSessionItems describes the active sessions of each given project, including
+// parallel sessions
+func (s *MemoryBankServer) activeSessionItems(ctx context.Context, projects []*domain.Project) []string {
+	if s.sessionService == nil {
+		return nil
+	}
+
+	status := domain.SessionStatusActive
+	var items []string
+	for _, project := range projects {
+		sessions, err := s.sessionService.ListSessions(ctx, ports.SessionFilters{ProjectID: &project.ID, Status: &status})
+		if err != nil {
+			s.logger.WithError(err).WithField("project_id", project.ID).Warn("Failed to load active sessions for system prompt")
+			continue
+		}
+
+		for _, session := range sessions {
+			item := fmt.Sprintf("- **%s** (%s, started %s)", session.Name, project.Name, session.StartTime.Format("2006-01-02 15:04"))
+			if session.TaskDescription != "" {
+				item += ": " + session.TaskDescription
+			}
+			if n := len(session.Progress); n > 0 {
+				item += fmt.Sprintf("\n  Latest progress: %s", session.Progress[n-1].Message)
+			}
+			items = append(items, item+"\n")
+		}
+	}
+	return items
+}
+
+// staleSessionItems warns about open sessions of the given projects that have been
+// inactive for a long time
+func (s *MemoryBankServer) staleSessionItems(ctx context.Context, projects []*domain.Project) []string {
+	if s.sessionService == nil {
+		return nil
+	}
+
+	var items []string
+	for _, project := range projects {
+		stale, err := s.sessionService.StaleSessions(ctx, &project.ID)
+		if err != nil {
+			s.logger.WithError(err).WithField("project_id", project.ID).Warn("Failed to load stale sessions for system prompt")
+			continue
+		}
+		for _, idle := range stale {
+			items = append(items, fmt.Sprintf("- **%s** (%s, %s, inactive for %s, ID %s): complete it with session_complete or abort it with session_abort\n",
+				idle.Session.Name, project.Name, idle.Session.Status, domain.FormatIdleTime(idle.IdleFor), idle.Session.ID))
+		}
+	}
+	return items
+}
+
+// overdueTaskItems lists overdue tasks of the given projects
+func (s *MemoryBankServer) overdueTaskItems(ctx context.Context, projects []*domain.Project) []string {
+	if s.taskService == nil {
+		return nil
+	}
+
+	overdue := true
+	var items []string
+	for _, project := range projects {
+		projectID := project.ID
+		tasks, err := s.taskService.ListTasks(ctx, ports.TaskFilters{
+			ProjectID: &projectID,
+			IsOverdue: &overdue,
+			SortBy:    "due_date",
+			SortOrder: "asc",
+		})
+		if err != nil {
+			s.logger.WithError(err).WithField("project_id", project.ID).Warn("Failed to load overdue tasks for system prompt")
+			continue
+		}
+		for _, task := range tasks {
+			due := ""
+			if task.DueDate != nil {
+				due = ", due " + task.DueDate.Format("2006-01-02")
+			}
+			items = append(items, fmt.Sprintf("- %s (%s, %s%s)\n", task.Title, project.Name, task.Priority, due))
+		}
+	}
+	return items
+}
+
+// projectItems lists all known projects
+func projectItems(projects []*domain.Project) []string {
+	items := make([]string, 0, len(projects))
+	for _, project := range projects {
+		items = append(items, fmt.Sprintf("- **%s** (%s): %s\n",
+			project.Name,
+			string(project.ID),
+			project.Description))
+	}
+	return items
+}
+
+// buildMemoryContext analyzes existing memories to provide context about available content
+func (s *MemoryBankServer) buildMemoryContext(ctx context.Context, projects []*domain.Project) string {
+	var context strings.Builder
+
+	// Get sample of memories from each project to understand what's available
+	for _, project := range projects {
+		// Search for recent memories in this project
+		searchReq := ports.SemanticSearchRequest{
+			Query:     "", // Empty query to get all memories
+			ProjectID: &project.ID,
+			Limit:     10,
+			Threshold: 0.0,
+		}
+
+		memories, err := s.memoryService.SearchMemories(ctx, searchReq)
+		if err != nil {
+			s.logger.WithError(err).Debug("Failed to load memories for context")
+			continue
+		}
+
+		if len(memories) > 0 {
+			context.WriteString(fmt.Sprintf("### %s Project Memories (%d total)\n", project.Name, len(memories)))
+
+			// Group by type to show what kinds of memories exist
+			typeCount := make(map[domain.MemoryType]int)
+			for _, memory := range memories {
+				typeCount[memory.Memory.Type]++
+			}
+
+			for memType, count := range typeCount {
+				context.WriteString(fmt.Sprintf("- %s: %d entries\n", string(memType), count))
+			}
+			context.WriteString("\n")
+		}
+	}
+
+	return context.String()
+}
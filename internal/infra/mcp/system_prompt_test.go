@@ -0,0 +1,169 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joern1811/memory-bank/internal/app"
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/infra/database"
+	"github.com/joern1811/memory-bank/internal/infra/embedding"
+	"github.com/joern1811/memory-bank/internal/infra/vector"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/sirupsen/logrus"
+)
+
+func TestGenerateSystemPrompt_PinnedMemoriesFirst(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	db, err := database.NewSQLiteDatabase(":memory:", logger)
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+
+	memoryService := app.NewMemoryService(
+		database.NewSQLiteMemoryRepository(db, logger),
+		embedding.NewMockEmbeddingProvider(768, logger),
+		vector.NewMockVectorStore(logger),
+		logger,
+	)
+	projectService := app.NewProjectService(database.NewSQLiteProjectRepository(db, logger), logger)
+
+	ctx := context.Background()
+	project, err := projectService.InitializeProject(ctx, "/test/path", ports.InitializeProjectRequest{Name: "Test Project"})
+	if err != nil {
+		t.Fatalf("Failed to create test project: %v", err)
+	}
+
+	memory, err := memoryService.CreateMemory(ctx, ports.CreateMemoryRequest{
+		ProjectID: project.ID,
+		Type:      domain.MemoryTypeDecision,
+		Title:     "Always use UTC timestamps",
+		Content:   "All timestamps are stored in UTC.",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create memory: %v", err)
+	}
+	if _, err := memoryService.SetPinned(ctx, memory.ID, true); err != nil {
+		t.Fatalf("Failed to pin memory: %v", err)
+	}
+
+	server := NewMemoryBankServer(memoryService, projectService, nil, nil, logger)
+	server.SetSystemPromptTokenBudget(200)
+
+	prompt, err := server.generateSystemPrompt(ctx)
+	if err != nil {
+		t.Fatalf("Failed to generate system prompt: %v", err)
+	}
+
+	if !strings.Contains(prompt, "Always use UTC timestamps") || !strings.Contains(prompt, "All timestamps are stored in UTC.") {
+		t.Errorf("Expected pinned memory in system prompt, got:\n%s", prompt)
+	}
+	if strings.Contains(prompt, "grows and evolves") {
+		t.Error("Expected the usage guide to be cut under a small budget")
+	}
+	if !strings.Contains(prompt, "to fit the token budget") {
+		t.Error("Expected a truncation note")
+	}
+}
+
+func TestGenerateSystemPrompt_WarnsAboutStaleSessions(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	db, err := database.NewSQLiteDatabase(":memory:", logger)
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+
+	memoryService := app.NewMemoryService(
+		database.NewSQLiteMemoryRepository(db, logger),
+		embedding.NewMockEmbeddingProvider(768, logger),
+		vector.NewMockVectorStore(logger),
+		logger,
+	)
+	projectRepo := database.NewSQLiteProjectRepository(db, logger)
+	sessionRepo := database.NewSQLiteSessionRepository(db, logger)
+	projectService := app.NewProjectService(projectRepo, logger)
+	sessionService := app.NewSessionService(sessionRepo, projectRepo, logger)
+
+	ctx := context.Background()
+	project, err := projectService.InitializeProject(ctx, "/test/path", ports.InitializeProjectRequest{Name: "Test Project"})
+	if err != nil {
+		t.Fatalf("Failed to create test project: %v", err)
+	}
+
+	forgotten := domain.NewSession(project.ID, "Forgotten refactoring", "Refactor the cache")
+	forgotten.StartTime = time.Now().Add(-50 * time.Hour)
+	if err := sessionRepo.Store(ctx, forgotten); err != nil {
+		t.Fatalf("Failed to store session: %v", err)
+	}
+	current := domain.NewSession(project.ID, "Current work", "Fix login")
+	if err := sessionRepo.Store(ctx, current); err != nil {
+		t.Fatalf("Failed to store session: %v", err)
+	}
+
+	server := NewMemoryBankServer(memoryService, projectService, sessionService, nil, logger)
+	prompt, err := server.generateSystemPrompt(ctx)
+	if err != nil {
+		t.Fatalf("Failed to generate system prompt: %v", err)
+	}
+
+	if !strings.Contains(prompt, "## Stale Sessions") || !strings.Contains(prompt, "**Forgotten refactoring** (Test Project, active, inactive for 2d 2h") {
+		t.Errorf("Expected a stale session warning, got:\n%s", prompt)
+	}
+	if strings.Contains(prompt, "**Current work** (Test Project, active, inactive") {
+		t.Error("Expected the current session not to be reported as stale")
+	}
+}
+
+func TestGenerateSystemPrompt_ListsParallelActiveSessions(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	db, err := database.NewSQLiteDatabase(":memory:", logger)
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+
+	memoryService := app.NewMemoryService(
+		database.NewSQLiteMemoryRepository(db, logger),
+		embedding.NewMockEmbeddingProvider(768, logger),
+		vector.NewMockVectorStore(logger),
+		logger,
+	)
+	projectRepo := database.NewSQLiteProjectRepository(db, logger)
+	sessionRepo := database.NewSQLiteSessionRepository(db, logger)
+	projectService := app.NewProjectService(projectRepo, logger)
+	sessionService := app.NewSessionService(sessionRepo, projectRepo, logger)
+
+	ctx := context.Background()
+	project, err := projectService.InitializeProject(ctx, "/test/path", ports.InitializeProjectRequest{Name: "Test Project"})
+	if err != nil {
+		t.Fatalf("Failed to create test project: %v", err)
+	}
+
+	cache := domain.NewSession(project.ID, "feature/cache", "Add cache")
+	cache.StartTime = time.Now().Add(-time.Hour)
+	login := domain.NewSession(project.ID, "fix/login", "Fix login")
+	for _, session := range []*domain.Session{cache, login} {
+		if err := sessionRepo.Store(ctx, session); err != nil {
+			t.Fatalf("Failed to store session: %v", err)
+		}
+	}
+
+	server := NewMemoryBankServer(memoryService, projectService, sessionService, nil, logger)
+	prompt, err := server.generateSystemPrompt(ctx)
+	if err != nil {
+		t.Fatalf("Failed to generate system prompt: %v", err)
+	}
+
+	for _, want := range []string{"**feature/cache** (Test Project", "**fix/login** (Test Project"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("Expected active session %q in system prompt, got:\n%s", want, prompt)
+		}
+	}
+}
@@ -0,0 +1,321 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+// SetTagService enables the tag management tools
+func (s *MemoryBankServer) SetTagService(tagService ports.TagService) {
+	s.tagService = tagService
+}
+
+// ListTagsRequest represents a request to list tags with usage counts
+type ListTagsRequest struct {
+	ProjectID *string `json:"project_id,omitempty"`
+	Prefix    string  `json:"prefix,omitempty"`
+	Origin    string  `json:"origin,omitempty"`
+}
+
+// ListTagsResponse lists tags with usage counts
+type ListTagsResponse struct {
+	Tags  []domain.TagCount `json:"tags"`
+	Total int               `json:"total"`
+}
+
+// RenameTagRequest represents a request to rename a tag
+type RenameTagRequest struct {
+	From      string  `json:"from"`
+	To        string  `json:"to"`
+	ProjectID *string `json:"project_id,omitempty"`
+	DryRun    bool    `json:"dry_run,omitempty"`
+}
+
+// MergeTagsRequest represents a request to merge tags
+type MergeTagsRequest struct {
+	Sources   []string `json:"sources"`
+	Target    string   `json:"target"`
+	ProjectID *string  `json:"project_id,omitempty"`
+	DryRun    bool     `json:"dry_run,omitempty"`
+}
+
+// SuggestTagsRequest represents a request to extract tags for a memory
+type SuggestTagsRequest struct {
+	ID    string `json:"id"`
+	Apply bool   `json:"apply,omitempty"`
+}
+
+// TagReviewRequest represents a request to list memories with unreviewed tags
+type TagReviewRequest struct {
+	ProjectID *string `json:"project_id,omitempty"`
+}
+
+// TagReviewItem is a memory with its unreviewed tags
+type TagReviewItem struct {
+	ID            string      `json:"id"`
+	Title         string      `json:"title"`
+	Type          string      `json:"type"`
+	Tags          domain.Tags `json:"tags"`
+	AutoTags      domain.Tags `json:"auto_tags,omitempty"`
+	SuggestedTags domain.Tags `json:"suggested_tags,omitempty"`
+}
+
+// TagReviewResponse lists memories with unreviewed tags
+type TagReviewResponse struct {
+	Memories []TagReviewItem `json:"memories"`
+	Total    int             `json:"total"`
+}
+
+// ReviewTagsRequest represents a request to accept or reject pending tags of a memory
+type ReviewTagsRequest struct {
+	ID   string   `json:"id"`
+	Tags []string `json:"tags,omitempty"`
+}
+
+func (s *MemoryBankServer) handleListTags(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	s.logger.Debug("Handling tag/list request")
+
+	if s.tagService == nil {
+		return nil, fmt.Errorf("tag service is not available")
+	}
+
+	var req ListTagsRequest
+	if err := json.Unmarshal(params, &req); err != nil {
+		return nil, fmt.Errorf("invalid request parameters: %w", err)
+	}
+
+	origin := domain.TagOrigin(req.Origin)
+	if origin != "" && origin != domain.TagOriginManual && origin != domain.TagOriginAuto {
+		return nil, fmt.Errorf("invalid origin: %s (expected manual or auto)", req.Origin)
+	}
+
+	tags, err := s.tagService.ListTags(ctx, ports.ListTagsRequest{
+		ProjectID: optionalProjectID(req.ProjectID),
+		Prefix:    req.Prefix,
+		Origin:    origin,
+	})
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to list tags")
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	s.logger.WithField("count", len(tags)).Info("Tags listed successfully")
+	return ListTagsResponse{Tags: tags, Total: len(tags)}, nil
+}
+
+func (s *MemoryBankServer) handleRenameTag(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	s.logger.Debug("Handling tag/rename request")
+
+	if s.tagService == nil {
+		return nil, fmt.Errorf("tag service is not available")
+	}
+
+	var req RenameTagRequest
+	if err := json.Unmarshal(params, &req); err != nil {
+		return nil, fmt.Errorf("invalid request parameters: %w", err)
+	}
+
+	if req.From == "" || req.To == "" {
+		return nil, fmt.Errorf("from and to are required")
+	}
+
+	result, err := s.tagService.RenameTag(ctx, ports.RenameTagRequest{
+		ProjectID: optionalProjectID(req.ProjectID),
+		From:      req.From,
+		To:        req.To,
+		DryRun:    req.DryRun,
+	})
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to rename tag")
+		return nil, fmt.Errorf("failed to rename tag: %w", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"from":    req.From,
+		"to":      req.To,
+		"updated": result.Updated,
+	}).Info("Tag renamed successfully")
+	return result, nil
+}
+
+func (s *MemoryBankServer) handleMergeTags(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	s.logger.Debug("Handling tag/merge request")
+
+	if s.tagService == nil {
+		return nil, fmt.Errorf("tag service is not available")
+	}
+
+	var req MergeTagsRequest
+	if err := json.Unmarshal(params, &req); err != nil {
+		return nil, fmt.Errorf("invalid request parameters: %w", err)
+	}
+
+	if len(req.Sources) == 0 || req.Target == "" {
+		return nil, fmt.Errorf("sources and target are required")
+	}
+
+	result, err := s.tagService.MergeTags(ctx, ports.MergeTagsRequest{
+		ProjectID: optionalProjectID(req.ProjectID),
+		Sources:   req.Sources,
+		Target:    req.Target,
+		DryRun:    req.DryRun,
+	})
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to merge tags")
+		return nil, fmt.Errorf("failed to merge tags: %w", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"sources": req.Sources,
+		"target":  req.Target,
+		"updated": result.Updated,
+	}).Info("Tags merged successfully")
+	return result, nil
+}
+
+func (s *MemoryBankServer) handleSuggestTags(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	s.logger.Debug("Handling tag/suggest request")
+
+	if s.tagService == nil {
+		return nil, fmt.Errorf("tag service is not available")
+	}
+
+	var req SuggestTagsRequest
+	if err := json.Unmarshal(params, &req); err != nil {
+		return nil, fmt.Errorf("invalid request parameters: %w", err)
+	}
+
+	if req.ID == "" {
+		return nil, fmt.Errorf("id is required")
+	}
+
+	result, err := s.tagService.SuggestTags(ctx, domain.MemoryID(req.ID), req.Apply)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to suggest tags")
+		return nil, fmt.Errorf("failed to suggest tags: %w", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"memory_id":   req.ID,
+		"suggestions": len(result.Suggestions),
+		"applied":     result.Applied,
+	}).Info("Tags suggested successfully")
+	return result, nil
+}
+
+func (s *MemoryBankServer) handleTagReview(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	s.logger.Debug("Handling tag/review request")
+
+	if s.tagService == nil {
+		return nil, fmt.Errorf("tag service is not available")
+	}
+
+	var req TagReviewRequest
+	if err := json.Unmarshal(params, &req); err != nil {
+		return nil, fmt.Errorf("invalid request parameters: %w", err)
+	}
+
+	memories, err := s.tagService.ListPendingReview(ctx, optionalProjectID(req.ProjectID))
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to list memories pending tag review")
+		return nil, fmt.Errorf("failed to list memories pending tag review: %w", err)
+	}
+
+	items := make([]TagReviewItem, 0, len(memories))
+	for _, memory := range memories {
+		items = append(items, TagReviewItem{
+			ID:            string(memory.ID),
+			Title:         memory.Title,
+			Type:          string(memory.Type),
+			Tags:          memory.Tags,
+			AutoTags:      memory.AutoTags,
+			SuggestedTags: memory.SuggestedTags,
+		})
+	}
+
+	s.logger.WithField("count", len(items)).Info("Tag review queue listed successfully")
+	return TagReviewResponse{Memories: items, Total: len(items)}, nil
+}
+
+func (s *MemoryBankServer) handleAcceptTags(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	if s.tagService == nil {
+		return nil, fmt.Errorf("tag service is not available")
+	}
+	return s.handleReviewTags(ctx, params, "accept", s.tagService.AcceptTags)
+}
+
+func (s *MemoryBankServer) handleRejectTags(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	if s.tagService == nil {
+		return nil, fmt.Errorf("tag service is not available")
+	}
+	return s.handleReviewTags(ctx, params, "reject", s.tagService.RejectTags)
+}
+
+func (s *MemoryBankServer) handleReviewTags(ctx context.Context, params json.RawMessage, action string,
+	review func(context.Context, domain.MemoryID, []string) (*ports.TagReviewResult, error)) (interface{}, error) {
+	s.logger.Debugf("Handling tag/%s request", action)
+
+	var req ReviewTagsRequest
+	if err := json.Unmarshal(params, &req); err != nil {
+		return nil, fmt.Errorf("invalid request parameters: %w", err)
+	}
+
+	if req.ID == "" {
+		return nil, fmt.Errorf("id is required")
+	}
+
+	result, err := review(ctx, domain.MemoryID(req.ID), req.Tags)
+	if err != nil {
+		s.logger.WithError(err).Errorf("Failed to %s tags", action)
+		return nil, fmt.Errorf("failed to %s tags: %w", action, err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"memory_id": req.ID,
+		"tags":      result.Changed,
+	}).Infof("Tags %sed successfully", action)
+	return result, nil
+}
+
+// optionalProjectID converts an optional project ID parameter, treating "" as unset
+func optionalProjectID(projectID *string) *domain.ProjectID {
+	if projectID == nil || *projectID == "" {
+		return nil
+	}
+	id := domain.ProjectID(*projectID)
+	return &id
+}
+
+func (s *MemoryBankServer) handleListTagsTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return s.wrapHandler(ctx, request, s.handleListTags)
+}
+
+func (s *MemoryBankServer) handleRenameTagTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return s.wrapHandler(ctx, request, s.handleRenameTag)
+}
+
+func (s *MemoryBankServer) handleMergeTagsTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return s.wrapHandler(ctx, request, s.handleMergeTags)
+}
+
+func (s *MemoryBankServer) handleSuggestTagsTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return s.wrapHandler(ctx, request, s.handleSuggestTags)
+}
+
+func (s *MemoryBankServer) handleTagReviewTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return s.wrapHandler(ctx, request, s.handleTagReview)
+}
+
+func (s *MemoryBankServer) handleAcceptTagsTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return s.wrapHandler(ctx, request, s.handleAcceptTags)
+}
+
+func (s *MemoryBankServer) handleRejectTagsTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return s.wrapHandler(ctx, request, s.handleRejectTags)
+}
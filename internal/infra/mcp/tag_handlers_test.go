@@ -0,0 +1,179 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/joern1811/memory-bank/internal/app"
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/infra/database"
+	"github.com/joern1811/memory-bank/internal/infra/embedding"
+	"github.com/joern1811/memory-bank/internal/infra/vector"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+func TestTagTools(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	db, err := database.NewSQLiteDatabase(":memory:", logger)
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+
+	memoryRepo := database.NewSQLiteMemoryRepository(db, logger)
+	memoryService := app.NewMemoryService(
+		memoryRepo,
+		embedding.NewMockEmbeddingProvider(768, logger),
+		vector.NewMockVectorStore(logger),
+		logger,
+	)
+	projectService := app.NewProjectService(database.NewSQLiteProjectRepository(db, logger), logger)
+
+	ctx := context.Background()
+	for _, tags := range []domain.Tags{{"authn", "api"}, {"Authentication"}, {"auth"}} {
+		if _, err := memoryService.CreateMemory(ctx, ports.CreateMemoryRequest{
+			ProjectID: "proj",
+			Type:      domain.MemoryTypeDecision,
+			Title:     "Authentication decision",
+			Content:   "content",
+			Tags:      tags,
+		}); err != nil {
+			t.Fatalf("Failed to create memory: %v", err)
+		}
+	}
+
+	server := NewMemoryBankServer(memoryService, projectService, nil, nil, logger)
+	server.SetTagService(app.NewTagService(memoryService, memoryRepo, logger))
+
+	merge := mcp.CallToolRequest{}
+	merge.Params.Arguments = map[string]interface{}{
+		"sources": []interface{}{"authn", "authentication"},
+		"target":  "auth",
+	}
+	result, err := server.handleMergeTagsTool(ctx, merge)
+	if err != nil || result.IsError {
+		t.Fatalf("Failed to call tag_merge: %v %+v", err, result)
+	}
+	var changed ports.TagChangeResult
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &changed); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if changed.Updated != 2 {
+		t.Errorf("Expected 2 updated memories, got %d", changed.Updated)
+	}
+
+	list := mcp.CallToolRequest{}
+	list.Params.Arguments = map[string]interface{}{"project_id": "proj"}
+	result, err = server.handleListTagsTool(ctx, list)
+	if err != nil || result.IsError {
+		t.Fatalf("Failed to call tag_list: %v %+v", err, result)
+	}
+	var response ListTagsResponse
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if response.Total != 2 || response.Tags[1].Tag != "auth" || response.Tags[1].Count != 3 {
+		t.Errorf("Expected api and auth (3), got %+v", response.Tags)
+	}
+}
+
+func TestTagReviewTools(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	db, err := database.NewSQLiteDatabase(":memory:", logger)
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+
+	memoryRepo := database.NewSQLiteMemoryRepository(db, logger)
+	memoryService := app.NewMemoryService(
+		memoryRepo,
+		embedding.NewMockEmbeddingProvider(768, logger),
+		vector.NewMockVectorStore(logger),
+		logger,
+	)
+	options := app.DefaultAutoTagOptions()
+	options.Mode = domain.AutoTagModeSuggest
+	tagger := app.NewAutoTagger(memoryRepo, memoryService.TagNormalizer(), options, logger)
+	memoryService.SetAutoTagger(tagger)
+	tagService := app.NewTagService(memoryService, memoryRepo, logger)
+	tagService.SetAutoTagger(tagger)
+
+	server := NewMemoryBankServer(memoryService, app.NewProjectService(database.NewSQLiteProjectRepository(db, logger), logger), nil, nil, logger)
+	server.SetTagService(tagService)
+
+	ctx := context.Background()
+	create := mcp.CallToolRequest{}
+	create.Params.Arguments = map[string]interface{}{
+		"project_id": "proj",
+		"type":       "decision",
+		"title":      "Use PostgreSQL for the event store",
+		"content":    "We store events in PostgreSQL and publish them to Kafka.",
+		"tags":       []interface{}{"storage"},
+	}
+	result, err := server.handleCreateMemoryTool(ctx, create)
+	if err != nil || result.IsError {
+		t.Fatalf("Failed to call memory_create: %v %+v", err, result)
+	}
+	var created CreateMemoryResponse
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &created); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if !created.SuggestedTags.Contains("postgresql") || !created.SuggestedTags.Contains("kafka") || len(created.AutoTags) != 0 {
+		t.Fatalf("Expected technology suggestions only, got auto %v suggested %v", created.AutoTags, created.SuggestedTags)
+	}
+
+	review := mcp.CallToolRequest{}
+	review.Params.Arguments = map[string]interface{}{"project_id": "proj"}
+	result, err = server.handleTagReviewTool(ctx, review)
+	if err != nil || result.IsError {
+		t.Fatalf("Failed to call tag_review: %v %+v", err, result)
+	}
+	var queue TagReviewResponse
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &queue); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if queue.Total != 1 || queue.Memories[0].ID != created.ID {
+		t.Fatalf("Expected the new memory in the review queue, got %+v", queue)
+	}
+
+	accept := mcp.CallToolRequest{}
+	accept.Params.Arguments = map[string]interface{}{"id": created.ID, "tags": []interface{}{"PostgreSQL"}}
+	result, err = server.handleAcceptTagsTool(ctx, accept)
+	if err != nil || result.IsError {
+		t.Fatalf("Failed to call tag_accept: %v %+v", err, result)
+	}
+
+	reject := mcp.CallToolRequest{}
+	reject.Params.Arguments = map[string]interface{}{"id": created.ID}
+	result, err = server.handleRejectTagsTool(ctx, reject)
+	if err != nil || result.IsError {
+		t.Fatalf("Failed to call tag_reject: %v %+v", err, result)
+	}
+	var rejected ports.TagReviewResult
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &rejected); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if !rejected.Tags.Contains("storage") || !rejected.Tags.Contains("postgresql") || rejected.Tags.Contains("kafka") || len(rejected.Pending) != 0 {
+		t.Errorf("Expected manual and accepted tags only, got %+v", rejected)
+	}
+
+	list := mcp.CallToolRequest{}
+	list.Params.Arguments = map[string]interface{}{"origin": "auto"}
+	result, err = server.handleListTagsTool(ctx, list)
+	if err != nil || result.IsError {
+		t.Fatalf("Failed to call tag_list: %v %+v", err, result)
+	}
+	var autoTags ListTagsResponse
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &autoTags); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if autoTags.Total != 0 {
+		t.Errorf("Expected no auto tags after review, got %+v", autoTags.Tags)
+	}
+}
@@ -526,15 +526,25 @@ func (s *MemoryBankServer) handleTaskStatisticsTool(ctx context.Context, request
 	// Use TaskService for full functionality
 	if s.taskService != nil {
 		var projectID domain.ProjectID
+		workspace, _ := argsMap["workspace"].(string)
 		if pid, ok := argsMap["project_id"].(string); ok && pid != "" {
 			projectID = domain.ProjectID(pid)
-		} else {
+		} else if workspace == "" {
 			return &mcp.CallToolResult{
-				Content: []mcp.Content{mcp.TextContent{Type: "text", Text: "project_id is required for task statistics"}},
+				Content: []mcp.Content{mcp.TextContent{Type: "text", Text: "project_id or workspace is required for task statistics"}},
 			}, nil
 		}
 
-		stats, err := s.taskService.GetTaskStatistics(ctx, projectID)
+		var stats *ports.TaskStatistics
+		var err error
+		if projectID == "" {
+			var projectIDs []domain.ProjectID
+			if projectIDs, err = s.workspaceProjectIDs(ctx, workspace); err == nil {
+				stats, err = s.taskService.GetTaskStatisticsForProjects(ctx, projectIDs)
+			}
+		} else {
+			stats, err = s.taskService.GetTaskStatistics(ctx, projectID)
+		}
 		if err != nil {
 			return &mcp.CallToolResult{
 				Content: []mcp.Content{mcp.TextContent{Type: "text", Text: "Failed to get task statistics: " + err.Error()}},
@@ -0,0 +1,87 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// SetWorkspaceService enables workspace-wide search, listing and task statistics
+func (s *MemoryBankServer) SetWorkspaceService(workspaceService ports.WorkspaceService) {
+	s.workspaceService = workspaceService
+}
+
+// WorkspaceInfo describes a workspace and its projects
+type WorkspaceInfo struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	ProjectIDs  []string `json:"project_ids"`
+}
+
+// ListWorkspacesResponse lists workspaces with their projects
+type ListWorkspacesResponse struct {
+	Workspaces []WorkspaceInfo `json:"workspaces"`
+	Total      int             `json:"total"`
+}
+
+func (s *MemoryBankServer) handleListWorkspaces(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	s.logger.Debug("Handling workspace/list request")
+
+	if s.workspaceService == nil {
+		return nil, fmt.Errorf("workspace service is not available")
+	}
+
+	workspaces, err := s.workspaceService.ListWorkspaces(ctx)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to list workspaces")
+		return nil, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+
+	response := ListWorkspacesResponse{Workspaces: make([]WorkspaceInfo, len(workspaces))}
+	for i, workspace := range workspaces {
+		projects, err := s.workspaceService.ListProjects(ctx, workspace.ID)
+		if err != nil {
+			return nil, err
+		}
+		projectIDs := make([]string, len(projects))
+		for j, project := range projects {
+			projectIDs[j] = string(project.ID)
+		}
+		response.Workspaces[i] = WorkspaceInfo{
+			ID:          string(workspace.ID),
+			Name:        workspace.Name,
+			Description: workspace.Description,
+			ProjectIDs:  projectIDs,
+		}
+	}
+	response.Total = len(response.Workspaces)
+
+	s.logger.WithField("count", response.Total).Info("Workspaces listed successfully")
+	return response, nil
+}
+
+// workspaceProjectIDs resolves a workspace name or ID to the IDs of its projects;
+// it returns nil if no workspace is given
+func (s *MemoryBankServer) workspaceProjectIDs(ctx context.Context, ref string) ([]domain.ProjectID, error) {
+	if ref == "" {
+		return nil, nil
+	}
+	if s.workspaceService == nil {
+		return nil, fmt.Errorf("workspace service is not available")
+	}
+
+	workspace, err := s.workspaceService.ResolveWorkspace(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	return s.workspaceService.ProjectIDs(ctx, workspace.ID)
+}
+
+func (s *MemoryBankServer) handleListWorkspacesTool(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return s.wrapHandler(ctx, request, s.handleListWorkspaces)
+}
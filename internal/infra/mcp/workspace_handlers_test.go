@@ -0,0 +1,111 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/joern1811/memory-bank/internal/app"
+	"github.com/joern1811/memory-bank/internal/domain"
+	"github.com/joern1811/memory-bank/internal/infra/database"
+	"github.com/joern1811/memory-bank/internal/infra/embedding"
+	"github.com/joern1811/memory-bank/internal/infra/vector"
+	"github.com/joern1811/memory-bank/internal/ports"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+func TestWorkspaceTools(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	db, err := database.NewSQLiteDatabase(":memory:", logger)
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+
+	memoryService := app.NewMemoryService(
+		database.NewSQLiteMemoryRepository(db, logger),
+		embedding.NewMockEmbeddingProvider(768, logger),
+		vector.NewMockVectorStore(logger),
+		logger,
+	)
+	projectRepo := database.NewSQLiteProjectRepository(db, logger)
+	server := NewMemoryBankServer(memoryService, app.NewProjectService(projectRepo, logger), nil, app.NewTaskService(memoryService, logger), logger)
+	server.SetWorkspaceService(app.NewWorkspaceService(database.NewSQLiteWorkspaceRepository(db, logger), projectRepo, logger))
+
+	ctx := context.Background()
+	var projectIDs []string
+	for _, name := range []string{"api", "web"} {
+		initProject := mcp.CallToolRequest{}
+		initProject.Params.Arguments = map[string]interface{}{"name": name, "path": "/repo/" + name, "workspace": "platform"}
+		result, err := server.handleInitProjectTool(ctx, initProject)
+		if err != nil || result.IsError {
+			t.Fatalf("Failed to call project_init: %v %+v", err, result)
+		}
+		var project InitProjectResponse
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &project); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		if project.WorkspaceID == "" {
+			t.Fatalf("Expected the project to be attached to a workspace, got %+v", project)
+		}
+		projectIDs = append(projectIDs, project.ID)
+	}
+
+	for _, projectID := range append(projectIDs, "other") {
+		if _, err := memoryService.CreateMemory(ctx, ports.CreateMemoryRequest{
+			ProjectID: domain.ProjectID(projectID),
+			Type:      domain.MemoryTypeDecision,
+			Title:     "Decision in " + projectID,
+			Content:   "content",
+		}); err != nil {
+			t.Fatalf("Failed to create memory: %v", err)
+		}
+	}
+
+	result, err := server.handleListWorkspacesTool(ctx, mcp.CallToolRequest{})
+	if err != nil || result.IsError {
+		t.Fatalf("Failed to call workspace_list: %v %+v", err, result)
+	}
+	var workspaces ListWorkspacesResponse
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &workspaces); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if workspaces.Total != 1 || workspaces.Workspaces[0].Name != "platform" || len(workspaces.Workspaces[0].ProjectIDs) != 2 {
+		t.Fatalf("Expected one workspace with two projects, got %+v", workspaces)
+	}
+
+	list := mcp.CallToolRequest{}
+	list.Params.Arguments = map[string]interface{}{"workspace": "platform"}
+	result, err = server.handleListMemoriesTool(ctx, list)
+	if err != nil || result.IsError {
+		t.Fatalf("Failed to call memory_list: %v %+v", err, result)
+	}
+	var memories SearchMemoriesResponse
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &memories); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if memories.Total != 2 {
+		t.Errorf("Expected the memories of both workspace projects, got %+v", memories.Results)
+	}
+	for _, memory := range memories.Results {
+		if memory.ProjectID == "other" {
+			t.Errorf("Expected memories outside the workspace to be excluded, got %s", memory.Title)
+		}
+	}
+
+	stats := mcp.CallToolRequest{}
+	stats.Params.Arguments = map[string]interface{}{"workspace": "platform"}
+	result, err = server.handleTaskStatisticsTool(ctx, stats)
+	if err != nil || result.IsError {
+		t.Fatalf("Failed to call task_statistics: %v %+v", err, result)
+	}
+	var statistics ports.TaskStatistics
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &statistics); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(statistics.TasksByProject) != 2 {
+		t.Errorf("Expected statistics for both workspace projects, got %+v", statistics.TasksByProject)
+	}
+}
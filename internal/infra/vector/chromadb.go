@@ -10,6 +10,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -213,6 +214,18 @@ type chromaDBQueryResponse struct {
 	Documents [][]string                 `json:"documents"`
 }
 
+// chromaDBGetRequest represents a get-by-ID request to ChromaDB
+type chromaDBGetRequest struct {
+	IDs     []string `json:"ids"`
+	Include []string `json:"include,omitempty"`
+}
+
+// chromaDBGetResponse represents a get-by-ID response from ChromaDB
+type chromaDBGetResponse struct {
+	IDs        []string    `json:"ids"`
+	Embeddings [][]float32 `json:"embeddings"`
+}
+
 // chromaDBCollection represents a collection in ChromaDB
 type chromaDBCollection struct {
 	Name     string                 `json:"name"`
@@ -514,7 +527,7 @@ func (c *ChromaDBVectorStore) Update(ctx context.Context, id string, vector doma
 	doc := chromaDBDocument{
 		IDs:        []string{id},
 		Embeddings: [][]float32{vector},
-		Metadatas:  []map[string]interface{}{metadata},
+		Metadatas:  []map[string]interface{}{c.normalizeMetadata(metadata)},
 	}
 
 	jsonBody, err := json.Marshal(doc)
@@ -557,11 +570,17 @@ func (c *ChromaDBVectorStore) Update(ctx context.Context, id string, vector doma
 
 // Search performs vector similarity search
 func (c *ChromaDBVectorStore) Search(ctx context.Context, vector domain.EmbeddingVector, limit int, threshold float32) ([]ports.SearchResult, error) {
+	return c.SearchWhere(ctx, vector, limit, threshold, nil)
+}
+
+// SearchWhere performs vector similarity search on the entries matching a metadata filter
+func (c *ChromaDBVectorStore) SearchWhere(ctx context.Context, vector domain.EmbeddingVector, limit int, threshold float32, where map[string][]string) ([]ports.SearchResult, error) {
 	c.logger.WithFields(logrus.Fields{
 		"collection":    c.collection,
 		"vector_length": len(vector),
 		"limit":         limit,
 		"threshold":     threshold,
+		"where":         where,
 	}).Debug("Searching vectors in ChromaDB")
 
 	// Prepare query request
@@ -569,6 +588,7 @@ func (c *ChromaDBVectorStore) Search(ctx context.Context, vector domain.Embeddin
 		QueryEmbeddings: [][]float32{vector},
 		NResults:        limit,
 		Include:         []string{"metadatas", "distances"},
+		Where:           chromaWhere(where),
 	}
 
 	jsonBody, err := json.Marshal(queryReq)
@@ -645,11 +665,104 @@ func (c *ChromaDBVectorStore) Search(ctx context.Context, vector domain.Embeddin
 	return results, nil
 }
 
+// chromaWhere translates a metadata filter into a ChromaDB where clause. Keys without
+// values are ignored.
+func chromaWhere(where map[string][]string) map[string]interface{} {
+	keys := make([]string, 0, len(where))
+	for key, values := range where {
+		if len(values) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	clauses := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		clauses = append(clauses, map[string]interface{}{key: map[string]interface{}{"$in": where[key]}})
+	}
+	switch len(clauses) {
+	case 0:
+		return nil
+	case 1:
+		return clauses[0].(map[string]interface{})
+	default:
+		return map[string]interface{}{"$and": clauses}
+	}
+}
+
 // SearchByText performs text-based search (not implemented as it requires embedding generation)
 func (c *ChromaDBVectorStore) SearchByText(ctx context.Context, text string, limit int, threshold float32) ([]ports.SearchResult, error) {
 	return nil, fmt.Errorf("SearchByText not implemented - use Search with pre-generated embeddings")
 }
 
+// GetVectors retrieves the stored embeddings for the given IDs
+func (c *ChromaDBVectorStore) GetVectors(ctx context.Context, ids []string) (map[string]domain.EmbeddingVector, error) {
+	vectors := make(map[string]domain.EmbeddingVector)
+	if len(ids) == 0 {
+		return vectors, nil
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"collection": c.collection,
+		"batch_size": len(ids),
+	}).Debug("Getting vectors from ChromaDB")
+
+	jsonBody, err := json.Marshal(chromaDBGetRequest{
+		IDs:     ids,
+		Include: []string{"embeddings"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal get request: %w", err)
+	}
+
+	// Create HTTP request
+	url, err := c.buildCollectionOperationURL(ctx, "get")
+	if err != nil {
+		return nil, fmt.Errorf("failed to build collection URL: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	// Execute request
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			c.logger.WithError(err).Warn("Failed to close response body")
+		}
+	}()
+
+	// Read response
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("chromadb API error (status %d): %s", resp.StatusCode, string(body))
+	}
+
+	var getResp chromaDBGetResponse
+	if err := json.Unmarshal(body, &getResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	for i, id := range getResp.IDs {
+		if i < len(getResp.Embeddings) {
+			vectors[id] = domain.EmbeddingVector(getResp.Embeddings[i])
+		}
+	}
+
+	c.logger.WithField("vectors_count", len(vectors)).Debug("Vectors retrieved from ChromaDB")
+	return vectors, nil
+}
+
 // CreateCollection creates a new collection in ChromaDB
 func (c *ChromaDBVectorStore) CreateCollection(ctx context.Context, name string) error {
 	c.logger.WithField("collection", name).Debug("Creating collection in ChromaDB")
@@ -1068,6 +1181,11 @@ func (m *MockVectorStore) Update(ctx context.Context, id string, vector domain.E
 
 // Search performs mock vector similarity search using simple dot product
 func (m *MockVectorStore) Search(ctx context.Context, vector domain.EmbeddingVector, limit int, threshold float32) ([]ports.SearchResult, error) {
+	return m.SearchWhere(ctx, vector, limit, threshold, nil)
+}
+
+// SearchWhere performs mock vector similarity search on the entries matching a metadata filter
+func (m *MockVectorStore) SearchWhere(ctx context.Context, vector domain.EmbeddingVector, limit int, threshold float32, where map[string][]string) ([]ports.SearchResult, error) {
 	m.logger.WithFields(logrus.Fields{
 		"vector_length": len(vector),
 		"limit":         limit,
@@ -1078,6 +1196,9 @@ func (m *MockVectorStore) Search(ctx context.Context, vector domain.EmbeddingVec
 
 	// Calculate similarity with all stored vectors using dot product
 	for id, entry := range m.vectors {
+		if !metadataMatches(entry.Metadata, where) {
+			continue
+		}
 		similarity := calculateDotProduct(vector, entry.Vector)
 
 		if similarity.IsRelevant(threshold) {
@@ -1106,11 +1227,43 @@ func (m *MockVectorStore) Search(ctx context.Context, vector domain.EmbeddingVec
 	return results, nil
 }
 
+// metadataMatches reports whether metadata matches a filter. Keys without values are ignored.
+func metadataMatches(metadata map[string]interface{}, where map[string][]string) bool {
+	for key, values := range where {
+		if len(values) == 0 {
+			continue
+		}
+		value := fmt.Sprint(metadata[key])
+		matched := false
+		for _, allowed := range values {
+			if value == allowed {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
 // SearchByText is not implemented in the mock store
 func (m *MockVectorStore) SearchByText(ctx context.Context, text string, limit int, threshold float32) ([]ports.SearchResult, error) {
 	return nil, fmt.Errorf("SearchByText not implemented in mock store")
 }
 
+// GetVectors returns the stored vectors for the given IDs
+func (m *MockVectorStore) GetVectors(ctx context.Context, ids []string) (map[string]domain.EmbeddingVector, error) {
+	vectors := make(map[string]domain.EmbeddingVector, len(ids))
+	for _, id := range ids {
+		if entry, exists := m.vectors[id]; exists {
+			vectors[id] = entry.Vector
+		}
+	}
+	return vectors, nil
+}
+
 // CreateCollection creates a mock collection
 func (m *MockVectorStore) CreateCollection(ctx context.Context, name string) error {
 	m.logger.WithField("collection", name).Debug("Creating mock collection")
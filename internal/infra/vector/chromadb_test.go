@@ -263,6 +263,79 @@ func TestChromaDBVectorStore_Search(t *testing.T) {
 	}
 }
 
+func TestChromaWhere(t *testing.T) {
+	if where := chromaWhere(nil); where != nil {
+		t.Errorf("Expected no where clause without filters, got %v", where)
+	}
+
+	where, _ := json.Marshal(chromaWhere(map[string][]string{"project_id": {"proj_1"}}))
+	if string(where) != `{"project_id":{"$in":["proj_1"]}}` {
+		t.Errorf("Unexpected where clause: %s", where)
+	}
+
+	where, _ = json.Marshal(chromaWhere(map[string][]string{"project_id": {"proj_1", "proj_2"}, "hit": {"outcome"}, "type": nil}))
+	if string(where) != `{"$and":[{"hit":{"$in":["outcome"]}},{"project_id":{"$in":["proj_1","proj_2"]}}]}` {
+		t.Errorf("Unexpected where clause: %s", where)
+	}
+}
+
+func TestChromaDBVectorStore_GetVectors(t *testing.T) {
+	mockCollections := []chromaDBCollection{
+		{Name: "test_collection", ID: "test_col_id"},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/tenants/default_tenant/databases/default_database/collections":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(mockCollections)
+
+		case "/api/v2/tenants/default_tenant/databases/default_database/collections/test_col_id/get":
+			var getReq chromaDBGetRequest
+			if err := json.NewDecoder(r.Body).Decode(&getReq); err != nil {
+				t.Errorf("Failed to decode request body: %v", err)
+			}
+			if len(getReq.IDs) != 2 {
+				t.Errorf("Expected 2 IDs, got %d", len(getReq.IDs))
+			}
+			if len(getReq.Include) != 1 || getReq.Include[0] != "embeddings" {
+				t.Errorf("Expected embeddings to be included, got %v", getReq.Include)
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(chromaDBGetResponse{
+				IDs:        []string{"id1"},
+				Embeddings: [][]float32{{0.1, 0.2, 0.3}},
+			})
+
+		default:
+			t.Errorf("Unexpected request path: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	config := ChromaDBConfig{
+		BaseURL:    server.URL,
+		Collection: "test_collection",
+		Tenant:     "default_tenant",
+		Database:   "default_database",
+	}
+	store := NewChromaDBVectorStore(config, setupTestLogger())
+
+	vectors, err := store.GetVectors(context.Background(), []string{"id1", "missing"})
+	if err != nil {
+		t.Fatalf("GetVectors failed: %v", err)
+	}
+
+	if len(vectors) != 1 {
+		t.Errorf("Expected 1 vector, got %d", len(vectors))
+	}
+	if len(vectors["id1"]) != 3 {
+		t.Errorf("Expected vector of length 3 for id1, got %d", len(vectors["id1"]))
+	}
+}
+
 func TestChromaDBVectorStore_Search_WithThreshold(t *testing.T) {
 	// Mock collections response for getCollectionID
 	mockCollections := []chromaDBCollection{
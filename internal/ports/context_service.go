@@ -0,0 +1,93 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+)
+
+// ContextService assembles task-scoped context for inclusion in an agent prompt
+type ContextService interface {
+	BuildContextPack(ctx context.Context, req ContextPackRequest) (*ContextPack, error)
+	BuildHandoff(ctx context.Context, req HandoffRequest) (*HandoffBrief, error)
+}
+
+// Context pack defaults
+const (
+	DefaultContextTokenBudget = 2000
+	DefaultContextLimit       = 5
+	DefaultContextThreshold   = 0.3
+)
+
+// ContextPackRequest describes the task an agent is about to work on
+type ContextPackRequest struct {
+	ProjectID   *domain.ProjectID `json:"project_id,omitempty"`
+	Task        string            `json:"task"`
+	Files       []string          `json:"files,omitempty"`        // paths the task touches, used to sharpen the search
+	TokenBudget int               `json:"token_budget,omitempty"` // approximate size limit of the rendered text
+	Limit       int               `json:"limit,omitempty"`        // maximum entries per section
+	Threshold   float32           `json:"threshold,omitempty"`    // minimum similarity of included memories
+}
+
+// ContextPack is a deduplicated bundle of the knowledge relevant to a task
+type ContextPack struct {
+	ProjectID      *domain.ProjectID    `json:"project_id,omitempty"`
+	Task           string               `json:"task"`
+	Decisions      []MemorySearchResult `json:"decisions"`
+	Patterns       []MemorySearchResult `json:"patterns"`
+	ErrorSolutions []MemorySearchResult `json:"error_solutions"`
+	OpenTasks      []*domain.Task       `json:"open_tasks"`
+	LastSession    *domain.Session      `json:"last_session,omitempty"`
+
+	Text            string `json:"text"` // markdown rendering, ready to include in a prompt
+	EstimatedTokens int    `json:"estimated_tokens"`
+}
+
+// MemoryIDs returns the IDs of all memories included in the pack
+func (p *ContextPack) MemoryIDs() []domain.MemoryID {
+	var ids []domain.MemoryID
+	for _, group := range [][]MemorySearchResult{p.Decisions, p.Patterns, p.ErrorSolutions} {
+		for _, result := range group {
+			ids = append(ids, result.Memory.ID)
+		}
+	}
+	for _, task := range p.OpenTasks {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
+
+// HandoffRequest selects the session to hand off to a new agent conversation
+type HandoffRequest struct {
+	ProjectID domain.ProjectID `json:"project_id"`
+	// SessionID picks a session; the session of the project last worked on is used if nil
+	SessionID   *domain.SessionID `json:"session_id,omitempty"`
+	TokenBudget int               `json:"token_budget,omitempty"` // approximate size limit of the rendered text
+	Limit       int               `json:"limit,omitempty"`        // maximum entries per section
+}
+
+// HandoffBrief summarises where a session left off: its goal, latest milestones,
+// unresolved issues, related open tasks and relevant memories
+type HandoffBrief struct {
+	ProjectID  domain.ProjectID       `json:"project_id"`
+	Session    *domain.Session        `json:"session"`
+	Milestones []domain.ProgressEntry `json:"milestones"`
+	OpenIssues []domain.ProgressEntry `json:"open_issues"`
+	OpenTasks  []*domain.Task         `json:"open_tasks"`
+	Memories   []MemorySearchResult   `json:"memories"`
+
+	Text            string `json:"text"` // markdown rendering, ready to paste into a new conversation
+	EstimatedTokens int    `json:"estimated_tokens"`
+}
+
+// MemoryIDs returns the IDs of all memories and tasks included in the brief
+func (b *HandoffBrief) MemoryIDs() []domain.MemoryID {
+	var ids []domain.MemoryID
+	for _, result := range b.Memories {
+		ids = append(ids, result.Memory.ID)
+	}
+	for _, task := range b.OpenTasks {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
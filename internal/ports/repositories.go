@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/joern1811/memory-bank/internal/domain"
 )
@@ -19,13 +20,23 @@ type MemoryRepository interface {
 	GetMetadataByIDs(ctx context.Context, ids []domain.MemoryID) ([]*MemoryMetadata, error)
 
 	// Query operations
+	ListAll(ctx context.Context, projectID *domain.ProjectID) ([]*domain.Memory, error)
 	ListByProject(ctx context.Context, projectID domain.ProjectID) ([]*domain.Memory, error)
 	ListByType(ctx context.Context, projectID domain.ProjectID, memoryType domain.MemoryType) ([]*domain.Memory, error)
 	ListByTags(ctx context.Context, projectID domain.ProjectID, tags domain.Tags) ([]*domain.Memory, error)
+	ListPinned(ctx context.Context, projectID domain.ProjectID) ([]*domain.Memory, error)
+	ListAnchored(ctx context.Context, projectID *domain.ProjectID) ([]*domain.Memory, error)
+	ListTagged(ctx context.Context, projectID *domain.ProjectID) ([]*domain.Memory, error)
+	ListPendingTagReview(ctx context.Context, projectID *domain.ProjectID) ([]*domain.Memory, error)
+	ListDrafts(ctx context.Context, projectID *domain.ProjectID) ([]*domain.Memory, error)
 
 	// Session-related operations
 	ListBySession(ctx context.Context, sessionID domain.SessionID) ([]*domain.Memory, error)
 
+	// Usage tracking
+	RecordAccess(ctx context.Context, ids []domain.MemoryID, accessedAt time.Time) error
+	ListUnused(ctx context.Context, projectID *domain.ProjectID, since time.Time) ([]*domain.Memory, error)
+
 	// Cleanup operations
 	ResetEmbeddingFlags(ctx context.Context, projectID string) error
 }
@@ -48,6 +59,17 @@ type ProjectRepository interface {
 	Update(ctx context.Context, project *domain.Project) error
 	Delete(ctx context.Context, id domain.ProjectID) error
 	List(ctx context.Context) ([]*domain.Project, error)
+	ListByWorkspace(ctx context.Context, workspaceID domain.WorkspaceID) ([]*domain.Project, error)
+}
+
+// WorkspaceRepository defines the interface for workspace storage
+type WorkspaceRepository interface {
+	Store(ctx context.Context, workspace *domain.Workspace) error
+	GetByID(ctx context.Context, id domain.WorkspaceID) (*domain.Workspace, error)
+	GetByName(ctx context.Context, name string) (*domain.Workspace, error)
+	Update(ctx context.Context, workspace *domain.Workspace) error
+	Delete(ctx context.Context, id domain.WorkspaceID) error
+	List(ctx context.Context) ([]*domain.Workspace, error)
 }
 
 // SessionRepository defines the interface for session storage
@@ -84,14 +106,34 @@ type VectorStore interface {
 
 	// Search operations
 	Search(ctx context.Context, vector domain.EmbeddingVector, limit int, threshold float32) ([]SearchResult, error)
+	// SearchWhere is Search restricted to entries whose metadata value for each key of
+	// where is one of the given values
+	SearchWhere(ctx context.Context, vector domain.EmbeddingVector, limit int, threshold float32, where map[string][]string) ([]SearchResult, error)
 	SearchByText(ctx context.Context, text string, limit int, threshold float32) ([]SearchResult, error)
 
+	// Retrieval operations
+	GetVectors(ctx context.Context, ids []string) (map[string]domain.EmbeddingVector, error)
+
 	// Management operations
 	CreateCollection(ctx context.Context, name string) error
 	DeleteCollection(ctx context.Context, name string) error
 	ListCollections(ctx context.Context) ([]string, error)
 }
 
+// SourceControl defines the interface for inspecting the version history of a project
+type SourceControl interface {
+	// HeadCommit returns the current commit of the repository containing root
+	HeadCommit(ctx context.Context, root string) (string, error)
+	// CheckAnchor reports how the anchored path changed since the anchor's commit.
+	// Anchor paths are relative to root.
+	CheckAnchor(ctx context.Context, root string, anchor domain.CodeAnchor) (domain.AnchorCheck, error)
+	// CurrentBranch returns the checked out branch, or "" if HEAD is detached
+	CurrentBranch(ctx context.Context, root string) (string, error)
+	// Changes returns the commits in from..to, newest first, and the files changed
+	// between the two commits
+	Changes(ctx context.Context, root, from, to string) (domain.GitChanges, error)
+}
+
 // BatchStoreItem represents an item for batch storage operations
 type BatchStoreItem struct {
 	ID       string                 `json:"id"`
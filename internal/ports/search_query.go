@@ -0,0 +1,122 @@
+package ports
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/joern1811/memory-bank/internal/domain"
+)
+
+// ErrEmptySearchText is returned when a parsed query consists of filters only
+var ErrEmptySearchText = errors.New("search query needs free text or a quoted phrase in addition to filters")
+
+// SearchQueryTarget is a search request a parsed query can be applied to
+type SearchQueryTarget interface {
+	ApplySearchQuery(q *domain.SearchQuery) error
+}
+
+// ApplyQueryString parses a query string with field operators and applies it to a search request
+func ApplyQueryString(raw string, req SearchQueryTarget) error {
+	parsed, err := domain.ParseSearchQuery(raw)
+	if err != nil {
+		return err
+	}
+	if err := req.ApplySearchQuery(parsed); err != nil {
+		return fmt.Errorf("invalid search query: %w", err)
+	}
+	return nil
+}
+
+// ApplySearchQuery replaces the request's query text with the parsed free text and adds
+// the parsed filters. It fails if the query names a different project than the request.
+func (r *SemanticSearchRequest) ApplySearchQuery(q *domain.SearchQuery) error {
+	return applySearchQuery(q, &r.ProjectID, &r.Query, &r.Filters)
+}
+
+// ApplySearchQuery replaces the request's query text with the parsed free text and adds
+// the parsed filters. It fails if the query names a different project than the request.
+func (r *FacetedSearchRequest) ApplySearchQuery(q *domain.SearchQuery) error {
+	return applySearchQuery(q, &r.ProjectID, &r.Query, &r.Filters)
+}
+
+// applySearchQuery applies a parsed query to the project, query text and filters of a
+// search request
+func applySearchQuery(q *domain.SearchQuery, projectID **domain.ProjectID, query *string, filters **SearchFilters) error {
+	merged, err := mergeQueryProject(*projectID, q.ProjectID)
+	if err != nil {
+		return err
+	}
+	if q.SearchText() == "" {
+		return ErrEmptySearchText
+	}
+	*projectID = merged
+	*query = q.SearchText()
+
+	if q.HasFilters() {
+		if *filters == nil {
+			*filters = &SearchFilters{}
+		}
+		(*filters).MergeSearchQuery(q)
+	}
+	return nil
+}
+
+// MergeSearchQuery adds the filters of a parsed query to the existing filters.
+// Query time bounds take precedence over existing ones.
+func (f *SearchFilters) MergeSearchQuery(q *domain.SearchQuery) {
+	f.Types = append(f.Types, q.Types...)
+	for _, tag := range q.Tags {
+		f.Tags.Add(tag)
+	}
+	for _, tag := range q.ExcludeTags {
+		f.ExcludeTags.Add(tag)
+	}
+	for name, value := range q.Fields {
+		if f.Fields == nil {
+			f.Fields = make(map[string]string)
+		}
+		f.Fields[name] = value
+	}
+	f.Phrases = append(f.Phrases, q.Phrases...)
+	if q.Provenance != nil {
+		merged := domain.ProvenanceFilter{}
+		if f.Provenance != nil {
+			merged = *f.Provenance
+		}
+		if q.Provenance.Author != "" {
+			merged.Author = q.Provenance.Author
+		}
+		if q.Provenance.Source != "" {
+			merged.Source = q.Provenance.Source
+		}
+		if q.Provenance.Client != "" {
+			merged.Client = q.Provenance.Client
+		}
+		f.Provenance = &merged
+	}
+
+	if q.After != nil || q.Before != nil {
+		if f.TimeFilter == nil {
+			f.TimeFilter = &TimeFilter{}
+		}
+		if q.After != nil {
+			after := q.After.Format(time.RFC3339)
+			f.TimeFilter.After = &after
+		}
+		if q.Before != nil {
+			before := q.Before.Format(time.RFC3339)
+			f.TimeFilter.Before = &before
+		}
+	}
+}
+
+func mergeQueryProject(current, fromQuery *domain.ProjectID) (*domain.ProjectID, error) {
+	if fromQuery == nil {
+		return current, nil
+	}
+	if current != nil && *current != *fromQuery {
+		return nil, fmt.Errorf("query project %q conflicts with project %q", *fromQuery, *current)
+	}
+	return fromQuery, nil
+}
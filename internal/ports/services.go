@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/joern1811/memory-bank/internal/domain"
 )
@@ -32,6 +33,26 @@ type MemoryService interface {
 	// Cleanup operations
 	RegenerateEmbedding(ctx context.Context, memoryID domain.MemoryID) error
 	CleanupEmbeddings(ctx context.Context, projectID domain.ProjectID) (*CleanupResult, error)
+
+	// Pinning
+	SetPinned(ctx context.Context, id domain.MemoryID, pinned bool) (*domain.Memory, error)
+	ListPinnedMemories(ctx context.Context, projectID domain.ProjectID) ([]*domain.Memory, error)
+
+	// Usage tracking
+	RecordAccess(ctx context.Context, ids []domain.MemoryID) error
+	ListUnusedMemories(ctx context.Context, projectID *domain.ProjectID, unusedFor time.Duration) ([]*domain.Memory, error)
+
+	// Memory types, including user-defined types available in the project (all projects if nil)
+	ListMemoryTypes(ctx context.Context, projectID *domain.ProjectID) []domain.MemoryTypeDefinition
+
+	// Review of draft memories, e.g. those created by agents
+	ListReviewQueue(ctx context.Context, projectID *domain.ProjectID) ([]*domain.Memory, error)
+	ApproveMemory(ctx context.Context, id domain.MemoryID) (*domain.Memory, error)
+	RejectMemory(ctx context.Context, id domain.MemoryID) error
+	MergeMemory(ctx context.Context, draftID, targetID domain.MemoryID) (*domain.Memory, error)
+
+	// Shared knowledge scopes
+	PromoteMemory(ctx context.Context, req PromoteMemoryRequest) (*domain.Memory, error)
 }
 
 // ProjectService defines the primary port for project operations
@@ -45,6 +66,8 @@ type ProjectService interface {
 
 	// Project initialization
 	InitializeProject(ctx context.Context, path string, req InitializeProjectRequest) (*domain.Project, error)
+	RelocateProject(ctx context.Context, id domain.ProjectID, path string) (*domain.Project, error)
+	RedetectProject(ctx context.Context, id domain.ProjectID) (*domain.Project, error)
 }
 
 // SessionService defines the primary port for session operations
@@ -52,11 +75,63 @@ type SessionService interface {
 	StartSession(ctx context.Context, req StartSessionRequest) (*domain.Session, error)
 	GetSession(ctx context.Context, id domain.SessionID) (*domain.Session, error)
 	GetActiveSession(ctx context.Context, projectID domain.ProjectID) (*domain.Session, error)
-	LogProgress(ctx context.Context, sessionID domain.SessionID, entry string) error
-	CompleteSession(ctx context.Context, sessionID domain.SessionID, outcome string) error
+	LogProgress(ctx context.Context, req LogProgressRequest) (*domain.ProgressEntry, error)
+	SearchProgress(ctx context.Context, req SearchProgressRequest) ([]ProgressSearchResult, error)
+	SearchSessions(ctx context.Context, req SessionSearchRequest) ([]SessionSearchResult, error)
+	ReindexSessions(ctx context.Context, projectID *domain.ProjectID) (int, error)
+	CompleteSession(ctx context.Context, req CompleteSessionRequest) (*CompleteSessionResult, error)
 	AbortSession(ctx context.Context, sessionID domain.SessionID) error
+	PauseSession(ctx context.Context, sessionID domain.SessionID) error
+	ResumeSession(ctx context.Context, sessionID domain.SessionID) error
+	FindSession(ctx context.Context, projectID domain.ProjectID, ref string, status domain.SessionStatus) (*domain.Session, error)
 	ListSessions(ctx context.Context, filters SessionFilters) ([]*domain.Session, error)
 	AbortActiveSessionsForProject(ctx context.Context, projectID domain.ProjectID) ([]domain.SessionID, error)
+
+	// Housekeeping of sessions left open
+	StaleSessions(ctx context.Context, projectID *domain.ProjectID) ([]StaleSession, error)
+	PruneSessions(ctx context.Context, req PruneSessionsRequest) (*PruneSessionsResult, error)
+}
+
+// AnchorService defines the primary port for linking memories to source code
+type AnchorService interface {
+	AddAnchors(ctx context.Context, id domain.MemoryID, anchors []domain.CodeAnchor) (*domain.Memory, error)
+	RemoveAnchors(ctx context.Context, id domain.MemoryID, paths []string) (*domain.Memory, error)
+	MemoriesForFile(ctx context.Context, projectID domain.ProjectID, path string, checkStaleness bool) ([]AnchoredMemory, error)
+	CheckAnchors(ctx context.Context, projectID domain.ProjectID) ([]AnchoredMemory, error)
+}
+
+// TagService defines the primary port for managing tags across memories
+type TagService interface {
+	ListTags(ctx context.Context, req ListTagsRequest) ([]domain.TagCount, error)
+	RenameTag(ctx context.Context, req RenameTagRequest) (*TagChangeResult, error)
+	MergeTags(ctx context.Context, req MergeTagsRequest) (*TagChangeResult, error)
+	NormalizeTags(ctx context.Context, projectID *domain.ProjectID, dryRun bool) (*TagChangeResult, error)
+
+	// Auto-tagging and review of auto and suggested tags
+	SuggestTags(ctx context.Context, id domain.MemoryID, apply bool) (*TagSuggestionResult, error)
+	ListPendingReview(ctx context.Context, projectID *domain.ProjectID) ([]*domain.Memory, error)
+	AcceptTags(ctx context.Context, id domain.MemoryID, tags []string) (*TagReviewResult, error)
+	RejectTags(ctx context.Context, id domain.MemoryID, tags []string) (*TagReviewResult, error)
+}
+
+// WorkspaceService defines the primary port for grouping related projects into workspaces
+type WorkspaceService interface {
+	CreateWorkspace(ctx context.Context, req CreateWorkspaceRequest) (*domain.Workspace, error)
+	ResolveWorkspace(ctx context.Context, idOrName string) (*domain.Workspace, error)
+	ListWorkspaces(ctx context.Context) ([]*domain.Workspace, error)
+	DeleteWorkspace(ctx context.Context, id domain.WorkspaceID) error
+
+	// Workspace membership; a project belongs to at most one workspace
+	AddProject(ctx context.Context, id domain.WorkspaceID, projectID domain.ProjectID) error
+	AttachProject(ctx context.Context, idOrName string, projectID domain.ProjectID) (*domain.Workspace, error)
+	RemoveProject(ctx context.Context, projectID domain.ProjectID) error
+	ListProjects(ctx context.Context, id domain.WorkspaceID) ([]*domain.Project, error)
+	ProjectIDs(ctx context.Context, id domain.WorkspaceID) ([]domain.ProjectID, error)
+}
+
+// SecretScanService defines the primary port for auditing stored data for secrets
+type SecretScanService interface {
+	ScanSecrets(ctx context.Context, req ScanSecretsRequest) (*ScanSecretsResult, error)
 }
 
 // Requests and Responses
@@ -70,6 +145,21 @@ type CreateMemoryRequest struct {
 	Content   string            `json:"content"`
 	Context   string            `json:"context"`
 	Tags      domain.Tags       `json:"tags,omitempty"`
+
+	// Fields holds the type-specific fields of user-defined memory types
+	Fields map[string]interface{} `json:"fields,omitempty"`
+
+	// AppliesTo lists the dependencies the memory is valid for. If empty, the project
+	// dependencies mentioned in the memory are used with their current version range.
+	AppliesTo []domain.DependencyConstraint `json:"applies_to,omitempty"`
+}
+
+// PromoteMemoryRequest represents a request to move or copy a project memory into a
+// shared scope such as domain.GlobalScope
+type PromoteMemoryRequest struct {
+	ID    domain.MemoryID  `json:"id"`
+	Scope domain.ProjectID `json:"scope"`
+	Copy  bool             `json:"copy,omitempty"` // keep the project memory and create a copy in the scope
 }
 
 // CreateDecisionRequest represents a request to create a decision memory
@@ -107,6 +197,12 @@ type CreateProjectRequest struct {
 	Framework   string `json:"framework,omitempty"`
 }
 
+// CreateWorkspaceRequest represents a request to create a workspace
+type CreateWorkspaceRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
 // InitializeProjectRequest represents a request to initialize a project
 type InitializeProjectRequest struct {
 	Name              string            `json:"name"`
@@ -122,6 +218,108 @@ type InitializeProjectRequest struct {
 type StartSessionRequest struct {
 	ProjectID       domain.ProjectID `json:"project_id"`
 	TaskDescription string           `json:"task_description"`
+	// Name tells parallel sessions of a project apart, e.g. a branch or agent name;
+	// defaults to the task description
+	Name string `json:"name,omitempty"`
+}
+
+// LogProgressRequest represents a request to log a progress entry to a session
+type LogProgressRequest struct {
+	SessionID domain.SessionID `json:"session_id"`
+	Message   string           `json:"message"`
+	Type      string           `json:"type,omitempty"` // info if empty
+	Files     []string         `json:"files,omitempty"`
+	Ref       string           `json:"ref,omitempty"`
+	Commit    string           `json:"commit,omitempty"`
+}
+
+// SearchProgressRequest filters progress entries across sessions. Query matches
+// entries whose message contains all of its words, ignoring case.
+type SearchProgressRequest struct {
+	ProjectID *domain.ProjectID `json:"project_id,omitempty"`
+	SessionID *domain.SessionID `json:"session_id,omitempty"`
+	Types     []string          `json:"types,omitempty"`
+	Query     string            `json:"query,omitempty"`
+	File      string            `json:"file,omitempty"`   // entries referencing a path ending in File
+	Ref       string            `json:"ref,omitempty"`    // exact match
+	Commit    string            `json:"commit,omitempty"` // commit hash prefix
+	Since     *time.Time        `json:"since,omitempty"`
+	Limit     int               `json:"limit"`
+}
+
+// ProgressSearchResult is a progress entry with the session it was logged to
+type ProgressSearchResult struct {
+	SessionID   domain.SessionID     `json:"session_id"`
+	SessionName string               `json:"session_name"`
+	ProjectID   domain.ProjectID     `json:"project_id"`
+	Entry       domain.ProgressEntry `json:"entry"`
+}
+
+// Parts of a session that session search matches
+const (
+	SessionHitTask     = "task"
+	SessionHitProgress = "progress"
+	SessionHitOutcome  = "outcome"
+)
+
+// SessionSearchRequest represents a semantic search over the task descriptions,
+// progress entries and outcomes of sessions
+type SessionSearchRequest struct {
+	Query      string             `json:"query"`
+	ProjectID  *domain.ProjectID  `json:"project_id,omitempty"`
+	ProjectIDs []domain.ProjectID `json:"project_ids,omitempty"` // any of these projects if ProjectID is nil
+	Limit      int                `json:"limit"`
+	Threshold  float32            `json:"threshold"`
+	TimeFilter *TimeFilter        `json:"time_filter,omitempty"` // matched against the hit's timestamp
+}
+
+// SessionSearchResult is a session matching a search with the part that matched
+type SessionSearchResult struct {
+	Session *domain.Session `json:"session"`
+	Hit     string          `json:"hit"` // task, progress or outcome
+	// Entry is the matching progress entry of progress hits
+	Entry      *domain.ProgressEntry `json:"entry,omitempty"`
+	Timestamp  time.Time             `json:"timestamp"`
+	Similarity domain.Similarity     `json:"similarity"`
+}
+
+// CompleteSessionRequest represents a request to complete a session
+type CompleteSessionRequest struct {
+	SessionID domain.SessionID `json:"session_id"`
+	Outcome   string           `json:"outcome"`
+	// Distill turns issue entries followed by a solution entry into error_solution
+	// memories; the configured default applies if nil
+	Distill *bool `json:"distill,omitempty"`
+}
+
+// CompleteSessionResult is a completed session with the memories written from it,
+// all linked to the session
+type CompleteSessionResult struct {
+	Session   *domain.Session  `json:"session"`
+	Summary   *domain.Memory   `json:"summary,omitempty"`
+	Distilled []*domain.Memory `json:"distilled,omitempty"`
+}
+
+// StaleSession is an open session and how long it has been inactive
+type StaleSession struct {
+	Session *domain.Session `json:"session"`
+	IdleFor time.Duration   `json:"idle_for"`
+}
+
+// PruneSessionsRequest represents a request to pause or abort inactive sessions.
+// Thresholds that are zero fall back to the configured ones.
+type PruneSessionsRequest struct {
+	ProjectID  *domain.ProjectID `json:"project_id,omitempty"`
+	PauseAfter time.Duration     `json:"pause_after,omitempty"`
+	AbortAfter time.Duration     `json:"abort_after,omitempty"`
+	// DryRun reports the sessions that would be paused or aborted without changing them
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// PruneSessionsResult lists the sessions paused and aborted for inactivity
+type PruneSessionsResult struct {
+	Paused  []StaleSession `json:"paused,omitempty"`
+	Aborted []StaleSession `json:"aborted,omitempty"`
 }
 
 // SessionFilters represents filters for listing sessions
@@ -131,10 +329,66 @@ type SessionFilters struct {
 	Limit     int                   `json:"limit"`
 }
 
+// ListTagsRequest filters the tags counted by ListTags
+type ListTagsRequest struct {
+	ProjectID *domain.ProjectID `json:"project_id,omitempty"` // all projects if nil
+	Prefix    string            `json:"prefix,omitempty"`     // only this tag and the tags below it
+	Origin    domain.TagOrigin  `json:"origin,omitempty"`     // only manual or auto tags, all if empty
+}
+
+// RenameTagRequest renames a tag and the tags below it, e.g. "db" to "infra/db"
+type RenameTagRequest struct {
+	ProjectID *domain.ProjectID `json:"project_id,omitempty"` // all projects if nil
+	From      string            `json:"from"`
+	To        string            `json:"to"`
+	DryRun    bool              `json:"dry_run,omitempty"`
+}
+
+// MergeTagsRequest replaces several tags with a single target tag
+type MergeTagsRequest struct {
+	ProjectID *domain.ProjectID `json:"project_id,omitempty"` // all projects if nil
+	Sources   []string          `json:"sources"`
+	Target    string            `json:"target"`
+	DryRun    bool              `json:"dry_run,omitempty"`
+}
+
+// TagChangeResult reports the memories changed by a tag operation
+type TagChangeResult struct {
+	Updated   int               `json:"updated"`
+	MemoryIDs []domain.MemoryID `json:"memory_ids"`
+	DryRun    bool              `json:"dry_run,omitempty"`
+}
+
+// TagSuggestionResult holds the tags the auto-tagger extracted for a memory
+type TagSuggestionResult struct {
+	MemoryID    domain.MemoryID        `json:"memory_id"`
+	Suggestions []domain.TagSuggestion `json:"suggestions"`
+	Applied     bool                   `json:"applied"` // whether the suggestions were added as auto tags
+}
+
+// TagReviewResult reports the outcome of accepting or rejecting pending tags
+type TagReviewResult struct {
+	MemoryID domain.MemoryID `json:"memory_id"`
+	Changed  domain.Tags     `json:"changed"` // the accepted or rejected tags
+	Tags     domain.Tags     `json:"tags"`
+	Pending  domain.Tags     `json:"pending"`
+}
+
+// AnchoredMemory is a memory linked to source code together with the state of its anchors
+type AnchoredMemory struct {
+	Memory        *domain.Memory       `json:"memory"`
+	Checks        []domain.AnchorCheck `json:"checks,omitempty"`
+	PossiblyStale bool                 `json:"possibly_stale"`
+}
+
 // MemorySearchResult represents a memory with similarity score
 type MemorySearchResult struct {
 	Memory     *domain.Memory    `json:"memory"`
 	Similarity domain.Similarity `json:"similarity"`
+
+	// Applicability tells whether the memory's dependency constraints match the
+	// project's current dependencies; nil if the memory has none
+	Applicability *domain.Applicability `json:"applicability,omitempty"`
 }
 
 // SemanticSearchRequest represents a semantic search request
@@ -146,14 +400,63 @@ type SemanticSearchRequest struct {
 	Limit      int                `json:"limit"`
 	Threshold  float32            `json:"threshold"`
 	TimeFilter *TimeFilter        `json:"time_filter,omitempty"`
+	Filters    *SearchFilters     `json:"filters,omitempty"` // additional filters, e.g. from a parsed query
+
+	// ProjectIDs restricts the search to any of these projects, e.g. of a workspace,
+	// when ProjectID is nil
+	ProjectIDs []domain.ProjectID `json:"project_ids,omitempty"`
+
+	// Re-ranking options (only honoured by SearchWithRelevanceScoring)
+	Diversify       bool    `json:"diversify,omitempty"`        // apply maximal marginal relevance re-ranking
+	DiversityLambda float64 `json:"diversity_lambda,omitempty"` // 0..1, 1 = pure relevance, 0 = pure diversity
+	Explain         bool    `json:"explain,omitempty"`          // include a per-result score breakdown
+
+	// IncludeDrafts also returns memories waiting in the review queue
+	IncludeDrafts bool `json:"include_drafts,omitempty"`
+}
+
+// SessionSearch returns the session search matching a memory search. It reports false
+// if the search filters by memory attributes such as type or tags, which sessions lack.
+func (r SemanticSearchRequest) SessionSearch() (SessionSearchRequest, bool) {
+	if r.Type != nil || len(r.Tags) > 0 {
+		return SessionSearchRequest{}, false
+	}
+	timeFilter := r.TimeFilter
+	if f := r.Filters; f != nil {
+		if len(f.Types) > 0 || len(f.Tags) > 0 || len(f.ExcludeTags) > 0 || len(f.Fields) > 0 ||
+			len(f.Phrases) > 0 || len(f.SessionIDs) > 0 || f.HasContent || f.MinLength != nil ||
+			f.MaxLength != nil || f.Provenance != nil {
+			return SessionSearchRequest{}, false
+		}
+		if f.TimeFilter != nil {
+			timeFilter = f.TimeFilter
+		}
+	}
+	return SessionSearchRequest{
+		Query:      r.Query,
+		ProjectID:  r.ProjectID,
+		ProjectIDs: r.ProjectIDs,
+		Limit:      r.Limit,
+		Threshold:  r.Threshold,
+		TimeFilter: timeFilter,
+	}, true
 }
 
+// DefaultDiversityLambda balances relevance and novelty when no lambda is given
+const DefaultDiversityLambda = 0.7
+
 // ListMemoriesRequest represents a request to list memories
 type ListMemoriesRequest struct {
-	ProjectID *domain.ProjectID  `json:"project_id,omitempty"`
-	Type      *domain.MemoryType `json:"type,omitempty"`
-	Tags      domain.Tags        `json:"tags,omitempty"`
-	Limit     int                `json:"limit"`
+	ProjectID  *domain.ProjectID        `json:"project_id,omitempty"`
+	Type       *domain.MemoryType       `json:"type,omitempty"`
+	Tags       domain.Tags              `json:"tags,omitempty"`
+	Fields     map[string]string        `json:"fields,omitempty"` // type-specific field values, see domain.FieldMatches
+	Provenance *domain.ProvenanceFilter `json:"provenance,omitempty"`
+	ProjectIDs []domain.ProjectID       `json:"project_ids,omitempty"` // any of these projects; used when ProjectID is nil
+	Limit      int                      `json:"limit"`
+
+	// IncludeDrafts also returns memories waiting in the review queue
+	IncludeDrafts bool `json:"include_drafts,omitempty"`
 }
 
 // FacetedSearchRequest represents an advanced search with faceting
@@ -165,17 +468,27 @@ type FacetedSearchRequest struct {
 	Threshold     float32           `json:"threshold"`
 	IncludeFacets bool              `json:"include_facets"`
 	SortBy        *SortOption       `json:"sort_by,omitempty"`
+
+	// ProjectIDs restricts the search to any of these projects when ProjectID is nil
+	ProjectIDs []domain.ProjectID `json:"project_ids,omitempty"`
+
+	// IncludeDrafts also returns memories waiting in the review queue
+	IncludeDrafts bool `json:"include_drafts,omitempty"`
 }
 
 // SearchFilters represents comprehensive search filters
 type SearchFilters struct {
-	Types      []domain.MemoryType `json:"types,omitempty"`
-	Tags       domain.Tags         `json:"tags,omitempty"`
-	SessionIDs []domain.SessionID  `json:"session_ids,omitempty"`
-	TimeFilter *TimeFilter         `json:"time_filter,omitempty"`
-	HasContent bool                `json:"has_content,omitempty"`
-	MinLength  *int                `json:"min_length,omitempty"`
-	MaxLength  *int                `json:"max_length,omitempty"`
+	Types       []domain.MemoryType      `json:"types,omitempty"`
+	Tags        domain.Tags              `json:"tags,omitempty"`
+	ExcludeTags domain.Tags              `json:"exclude_tags,omitempty"`
+	Fields      map[string]string        `json:"fields,omitempty"`  // type-specific field values, see domain.FieldMatches
+	Phrases     []string                 `json:"phrases,omitempty"` // case-insensitive, matched against title, content and context
+	SessionIDs  []domain.SessionID       `json:"session_ids,omitempty"`
+	TimeFilter  *TimeFilter              `json:"time_filter,omitempty"`
+	HasContent  bool                     `json:"has_content,omitempty"`
+	MinLength   *int                     `json:"min_length,omitempty"`
+	MaxLength   *int                     `json:"max_length,omitempty"`
+	Provenance  *domain.ProvenanceFilter `json:"provenance,omitempty"`
 }
 
 // SortOption represents sorting options for search results
@@ -256,6 +569,29 @@ type EnhancedMemorySearchResult struct {
 	RelevanceScore float64           `json:"relevance_score"`
 	MatchReasons   []string          `json:"match_reasons"`
 	Highlights     []string          `json:"highlights"`
+	ScoreBreakdown *ScoreBreakdown   `json:"score_breakdown,omitempty"`
+
+	Applicability *domain.Applicability `json:"applicability,omitempty"`
+}
+
+// ScoreBreakdown explains how a relevance score was composed
+type ScoreBreakdown struct {
+	VectorSimilarity float64 `json:"vector_similarity"`
+	TitleBoost       float64 `json:"title_boost"`
+	PhraseBoost      float64 `json:"phrase_boost"`
+	TagBoost         float64 `json:"tag_boost"`
+	RecencyBoost     float64 `json:"recency_boost"`
+	TypeBoost        float64 `json:"type_boost"`
+	PopularityBoost  float64 `json:"popularity_boost"`
+	// DiversityPenalty is the maximum similarity to an already selected result
+	// when MMR re-ranking is enabled; it does not change RelevanceScore.
+	DiversityPenalty float64 `json:"diversity_penalty,omitempty"`
+	MMRScore         float64 `json:"mmr_score,omitempty"`
+}
+
+// Total returns the sum of all score components
+func (b ScoreBreakdown) Total() float64 {
+	return b.VectorSimilarity + b.TitleBoost + b.PhraseBoost + b.TagBoost + b.RecencyBoost + b.TypeBoost + b.PopularityBoost
 }
 
 // SearchSuggestion represents a search suggestion
@@ -274,3 +610,27 @@ type CleanupResult struct {
 	Errors              int      `json:"errors"`
 	ErrorMessages       []string `json:"error_messages,omitempty"`
 }
+
+// ScanSecretsRequest selects the data audited by ScanSecrets
+type ScanSecretsRequest struct {
+	ProjectID *domain.ProjectID `json:"project_id,omitempty"` // all projects if nil
+	Fix       bool              `json:"fix"`                  // mask the secrets found and re-embed affected memories
+}
+
+// SecretFinding lists the secrets detected in a memory or session
+type SecretFinding struct {
+	Kind       string             `json:"kind"` // "memory" or "session"
+	ID         string             `json:"id"`
+	ProjectID  domain.ProjectID   `json:"project_id"`
+	Title      string             `json:"title"`
+	Redactions []domain.Redaction `json:"redactions"`
+	Fixed      bool               `json:"fixed"`
+}
+
+// ScanSecretsResult reports the outcome of a secret scan
+type ScanSecretsResult struct {
+	MemoriesScanned int             `json:"memories_scanned"`
+	SessionsScanned int             `json:"sessions_scanned"`
+	Findings        []SecretFinding `json:"findings"`
+	Fixed           int             `json:"fixed"`
+}
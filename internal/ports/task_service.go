@@ -40,6 +40,7 @@ type TaskService interface {
 
 	// Task analytics
 	GetTaskStatistics(ctx context.Context, projectID domain.ProjectID) (*TaskStatistics, error)
+	GetTaskStatisticsForProjects(ctx context.Context, projectIDs []domain.ProjectID) (*TaskStatistics, error)
 	GetTaskEfficiencyReport(ctx context.Context, projectID domain.ProjectID) (*EfficiencyReport, error)
 }
 
@@ -75,6 +76,7 @@ type UpdateTaskRequest struct {
 // TaskFilters represents filters for task queries
 type TaskFilters struct {
 	ProjectID     *domain.ProjectID
+	ProjectIDs    []domain.ProjectID // any of these projects, used when ProjectID is nil
 	Status        *domain.TaskStatus
 	Priority      *domain.Priority
 	Assignee      *string
@@ -106,6 +108,9 @@ type TaskStatistics struct {
 	AverageHours    float64                 `json:"average_hours"`
 	TotalHours      int                     `json:"total_hours"`
 	CompletionRate  float64                 `json:"completion_rate"`
+
+	// TasksByProject counts the tasks per project of statistics over several projects
+	TasksByProject map[domain.ProjectID]int `json:"tasks_by_project,omitempty"`
 }
 
 // EfficiencyReport represents efficiency metrics for tasks